@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"strings"
+
+	_ "github.com/df-mc/dragonfly/server/block"
+	_ "github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world/anvil"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+)
+
+func main() {
+	in := flag.String("in", "", "directory of the Java Edition world to convert")
+	out := flag.String("out", "", "directory to write the converted world to")
+	workers := flag.Int("workers", 0, "number of region files converted at the same time (defaults to the number of CPUs)")
+	flag.Parse()
+
+	if *in == "" || *out == "" {
+		log.Fatalln("Must pass both -in and -out.")
+	}
+	db, err := mcdb.Open(*out)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	report, err := anvil.Config{Workers: *workers}.Convert(*in, db)
+	if closeErr := db.Close(); closeErr != nil {
+		log.Println(closeErr)
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	log.Printf("Converted %v chunks, skipped %v chunks.\n", report.Chunks, report.SkippedChunks)
+	logUnmapped("Unmapped block states", report.UnmappedBlocks)
+	logUnmapped("Unmapped biomes", report.UnmappedBiomes)
+	logUnmapped("Unmapped items", report.UnmappedItems)
+	logUnmapped("Unsupported block entities", report.UnsupportedBlockEntities)
+}
+
+// logUnmapped logs the values passed under a header if there are any.
+func logUnmapped(header string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	log.Printf("%v (%v):\n  %v\n", header, len(values), strings.Join(values, "\n  "))
+}
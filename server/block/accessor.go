@@ -104,6 +104,18 @@ func (b CocoaBean) WithFacing(facing cube.Direction) world.Block {
 	return b
 }
 
+// FacingDirection returns the horizontal direction the block faces.
+func (b Comparator) FacingDirection() cube.Direction {
+	return b.Facing
+}
+
+// WithFacing returns a copy of the block with its facing set to facing. It does not update any
+// other blocks that the block may be part of, such as the second half of a bed or door.
+func (b Comparator) WithFacing(facing cube.Direction) world.Block {
+	b.Facing = facing
+	return b
+}
+
 // FacingDirection returns the horizontal direction the block faces.
 func (b CopperDoor) FacingDirection() cube.Direction {
 	return b.Facing
@@ -284,6 +296,18 @@ func (b Pumpkin) WithFacing(facing cube.Direction) world.Block {
 	return b
 }
 
+// FacingDirection returns the horizontal direction the block faces.
+func (b Repeater) FacingDirection() cube.Direction {
+	return b.Facing
+}
+
+// WithFacing returns a copy of the block with its facing set to facing. It does not update any
+// other blocks that the block may be part of, such as the second half of a bed or door.
+func (b Repeater) WithFacing(facing cube.Direction) world.Block {
+	b.Facing = facing
+	return b
+}
+
 // FacingDirection returns the horizontal direction the block faces.
 func (b Smoker) FacingDirection() cube.Direction {
 	return b.Facing
@@ -320,6 +344,18 @@ func (b Stonecutter) WithFacing(facing cube.Direction) world.Block {
 	return b
 }
 
+// FacingDirection returns the horizontal direction the block faces.
+func (b TripwireHook) FacingDirection() cube.Direction {
+	return b.Facing
+}
+
+// WithFacing returns a copy of the block with its facing set to facing. It does not update any
+// other blocks that the block may be part of, such as the second half of a bed or door.
+func (b TripwireHook) WithFacing(facing cube.Direction) world.Block {
+	b.Facing = facing
+	return b
+}
+
 // FacingDirection returns the horizontal direction the block faces.
 func (b WoodDoor) FacingDirection() cube.Direction {
 	return b.Facing
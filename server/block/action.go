@@ -34,6 +34,13 @@ type DecoratedPotWobbleAction struct {
 	Success bool
 }
 
+// PistonExtendAction is a world.BlockAction to animate a piston extending its arm.
+type PistonExtendAction struct{ action }
+
+// PistonRetractAction is a world.BlockAction to animate a piston retracting its arm, complementary to the
+// PistonExtendAction action.
+type PistonRetractAction struct{ action }
+
 // action implements the Action interface. Structures in this package may embed it to gets its functionality
 // out of the box.
 type action struct{}
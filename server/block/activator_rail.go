@@ -0,0 +1,105 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var (
+	_ world.RedstonePowerConsumer    = ActivatorRail{}
+	_ world.RedstonePowerPostUpdater = ActivatorRail{}
+)
+
+// ActivatorRail is a rail that activates minecarts riding over it while it is powered. Riders are ejected from
+// rideable minecarts, TNT minecarts are primed and hopper minecarts are locked. Like a PoweredRail, an activator
+// rail receives power from neighbouring powered activator rails. Activator rails cannot be curved.
+type ActivatorRail struct {
+	empty
+	transparent
+
+	// Shape is the shape of the rail.
+	Shape RailShape
+	// Powered is true while the rail is powered, either by redstone or by a neighbouring powered activator rail.
+	Powered bool
+}
+
+// RailShape ...
+func (r ActivatorRail) RailShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r ActivatorRail) withRailShape(s RailShape) world.Block {
+	r.Shape = s
+	return r
+}
+
+// curvable ...
+func (ActivatorRail) curvable() bool {
+	return false
+}
+
+// SupportsMinecart ...
+func (ActivatorRail) SupportsMinecart() bool {
+	return true
+}
+
+// SideClosed ...
+func (ActivatorRail) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// BreakInfo ...
+func (r ActivatorRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(ActivatorRail{}))
+}
+
+// UseOnBlock ...
+func (r ActivatorRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	r.Powered = false
+	return placeRail(r, pos, face, tx, user, ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r ActivatorRail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !railSupported(pos, r.Shape, tx) {
+		breakBlock(r, pos, tx)
+	}
+}
+
+// RedstonePowerUpdate powers the rail if it receives redstone power or is connected to an activator rail that does.
+func (r ActivatorRail) RedstonePowerUpdate(pos cube.Pos, tx *world.Tx, power int) (world.Block, bool) {
+	powered := power > 0 || railChainPowered(pos, r, tx)
+	if powered == r.Powered {
+		return r, false
+	}
+	r.Powered = powered
+	return r, true
+}
+
+// RedstonePowerPostUpdate updates the activator rails connected to the rail, so that power spreads along them.
+func (ActivatorRail) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, _, after world.Block, _, _ int) {
+	if r, ok := after.(ActivatorRail); ok {
+		updateRailChain(pos, r, tx)
+	}
+}
+
+// EncodeItem ...
+func (ActivatorRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:activator_rail", 0
+}
+
+// EncodeBlock ...
+func (r ActivatorRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:activator_rail", map[string]any{"rail_direction": int32(r.Shape.Uint8()), "rail_data_bit": boolByte(r.Powered)}
+}
+
+// allActivatorRails ...
+func allActivatorRails() (rails []world.Block) {
+	for _, s := range StraightRailShapes() {
+		rails = append(rails, ActivatorRail{Shape: s}, ActivatorRail{Shape: s, Powered: true})
+	}
+	return
+}
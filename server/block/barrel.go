@@ -55,6 +55,11 @@ func (b Barrel) Inventory(*world.Tx, cube.Pos) *inventory.Inventory {
 	return b.inventory
 }
 
+// ComparatorSignal returns the signal read from the barrel by a comparator, based on how full its inventory is.
+func (b Barrel) ComparatorSignal(cube.Pos, *world.Tx) int {
+	return inventoryComparatorSignal(b.inventory)
+}
+
 // WithName returns the barrel after applying a specific name to the block.
 func (b Barrel) WithName(a ...any) world.Item {
 	b.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
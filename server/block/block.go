@@ -70,6 +70,25 @@ type RedstoneWireStepDowner interface {
 	CanRedstoneWireStepDown(pos, from cube.Pos, tx *world.Tx) bool
 }
 
+// RedstoneWireConnector represents a redstone power source that redstone wire only connects to from specific faces,
+// such as a repeater.
+type RedstoneWireConnector interface {
+	// ConnectsRedstoneWire returns whether redstone wire connects to the face passed of the block at pos.
+	ConnectsRedstoneWire(pos cube.Pos, face cube.Face, tx *world.Tx) bool
+}
+
+// ComparatorReadable represents a block that a redstone comparator can read a signal from, such as a container.
+type ComparatorReadable interface {
+	// ComparatorSignal returns the signal strength, from 0-15, that a comparator reads from the block at pos.
+	ComparatorSignal(pos cube.Pos, tx *world.Tx) int
+}
+
+// PistonImmovable represents a block that cannot be moved by pistons, such as obsidian.
+type PistonImmovable interface {
+	// PistonImmovable is a marker method that prevents pistons from moving the block.
+	PistonImmovable()
+}
+
 // Replaceable represents a block that may be replaced by another block automatically. An example is grass,
 // which may be replaced by clicking it with another block.
 type Replaceable interface {
@@ -90,6 +109,14 @@ type EntityInsider interface {
 	EntityInside(pos cube.Pos, tx *world.Tx, e world.Entity)
 }
 
+// EntitySensor represents an EntityInsider that detects any entity going inside it, rather than just players.
+// Pressure plates and tripwire are examples of these blocks.
+type EntitySensor interface {
+	EntityInsider
+	// SensesEntities is a marker method that indicates EntityInside should be called for all entities.
+	SensesEntities()
+}
+
 // EntityStepper represents a block that reacts to an entity standing on top of it.
 type EntityStepper interface {
 	// EntityStepOn is called every tick while an entity is standing on the top face of the block.
@@ -102,6 +129,21 @@ type ProjectileHitter interface {
 	ProjectileHit(pos cube.Pos, tx *world.Tx, e world.Entity, face cube.Face)
 }
 
+// StickingProjectileType represents the world.EntityType of a projectile that gets stuck in the blocks that it hits,
+// such as an arrow. Wooden buttons stay pressed while such a projectile is stuck in them, and targets hit by one stay
+// powered for longer.
+type StickingProjectileType interface {
+	world.EntityType
+	// SticksInBlocks is a marker method that indicates the projectile gets stuck in the blocks that it hits.
+	SticksInBlocks()
+}
+
+// sticksInBlocks checks if the entity passed is a projectile that gets stuck in the blocks that it hits.
+func sticksInBlocks(e world.Entity) bool {
+	_, ok := e.H().Type().(StickingProjectileType)
+	return ok
+}
+
 // Frictional represents a block that may have a custom friction value. Friction is used for entity drag when the
 // entity is on ground. If a block does not implement this interface, it should be assumed that its friction is 0.6.
 type Frictional interface {
@@ -35,3 +35,71 @@ func TestTorchBreaksWithoutSupport(t *testing.T) {
 		t.Errorf("expected torch to break after removing its support, got %v", b)
 	}
 }
+
+// cancelTreeGrowHandler is a world.Handler that cancels every tree growth.
+type cancelTreeGrowHandler struct {
+	world.NopHandler
+}
+
+func (cancelTreeGrowHandler) HandleTreeGrow(ctx *world.Context, _ cube.Pos, _ world.Structure) {
+	ctx.Cancel()
+}
+
+// growSapling plants a sapling of the wood type passed on grass at each of the positions passed and applies bone
+// meal to the first sapling until it is no longer a sapling, or until 100 attempts were made. The block at the
+// first position is returned.
+func growSapling(t *testing.T, w *world.World, wood block.WoodType, positions ...cube.Pos) world.Block {
+	b, err := world.Call(context.Background(), w, func(tx *world.Tx) (world.Block, error) {
+		for _, pos := range positions {
+			tx.SetBlock(pos.Side(cube.FaceDown), block.Grass{}, nil)
+			tx.SetBlock(pos, block.Sapling{Wood: wood}, nil)
+		}
+		for range 100 {
+			sapling, ok := tx.Block(positions[0]).(block.Sapling)
+			if !ok {
+				break
+			}
+			sapling.BoneMeal(positions[0], tx)
+		}
+		return tx.Block(positions[0]), nil
+	})
+	if err != nil {
+		t.Fatalf("grow sapling: %v", err)
+	}
+	return b
+}
+
+// TestSaplingGrowsIntoTree verifies that applying bone meal to a sapling eventually grows it into a tree, and that
+// dark oak saplings only grow when planted in a 2x2 square.
+func TestSaplingGrowsIntoTree(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	defer w.Close()
+
+	if b := growSapling(t, w, block.OakWood(), cube.Pos{0, 1, 0}); b != (block.Log{Wood: block.OakWood(), Axis: cube.Y}) {
+		t.Errorf("expected oak sapling to grow into an oak log, got %#v", b)
+	}
+	if b := growSapling(t, w, block.DarkOakWood(), cube.Pos{20, 1, 0}); b != (block.Sapling{Wood: block.DarkOakWood(), Ready: true}) {
+		t.Errorf("expected a single dark oak sapling not to grow, got %#v", b)
+	}
+	square := []cube.Pos{{40, 1, 0}, {41, 1, 0}, {40, 1, 1}, {41, 1, 1}}
+	if b := growSapling(t, w, block.DarkOakWood(), square...); b != (block.Log{Wood: block.DarkOakWood(), Axis: cube.Y}) {
+		t.Errorf("expected 2x2 dark oak saplings to grow into a dark oak log, got %#v", b)
+	}
+	b, _ := world.Call(context.Background(), w, func(tx *world.Tx) (world.Block, error) {
+		return tx.Block(cube.Pos{41, 1, 1}), nil
+	})
+	if b != (block.Log{Wood: block.DarkOakWood(), Axis: cube.Y}) {
+		t.Errorf("expected dark oak tree to have a 2x2 trunk, got %#v", b)
+	}
+}
+
+// TestSaplingGrowthCancelled verifies that cancelling HandleTreeGrow prevents a sapling from growing.
+func TestSaplingGrowthCancelled(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	defer w.Close()
+	w.Handle(cancelTreeGrowHandler{})
+
+	if b := growSapling(t, w, block.BirchWood(), cube.Pos{0, 1, 0}); b != (block.Sapling{Wood: block.BirchWood(), Ready: true}) {
+		t.Errorf("expected sapling not to grow with a cancelling handler, got %#v", b)
+	}
+}
@@ -0,0 +1,176 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var (
+	_ world.RedstoneStrongPowerSource = Button{}
+	_ world.ScheduledTicker           = Button{}
+	_ ProjectileHitter                = Button{}
+)
+
+// Button is a non-solid block that emits a short redstone pulse when pressed. Wooden buttons may also be pressed
+// by projectiles such as arrows.
+type Button struct {
+	empty
+	transparent
+	flowingWaterDisplacer
+
+	// Block is the block the button is made of. This is Stone, polished Blackstone or Planks.
+	Block world.Block
+	// Facing is the face of the block that the button is attached to.
+	Facing cube.Face
+	// Pressed is true while the button is pressed and emitting power.
+	Pressed bool
+}
+
+// RedstonePower ...
+func (b Button) RedstonePower(cube.Pos, *world.Tx, cube.Face) int {
+	if b.Pressed {
+		return 15
+	}
+	return 0
+}
+
+// RedstoneStrongPower strongly powers the block the button is attached to while it is pressed.
+func (b Button) RedstoneStrongPower(_ cube.Pos, _ *world.Tx, face cube.Face) int {
+	if b.Pressed && b.Facing.Opposite() == face {
+		return 15
+	}
+	return 0
+}
+
+// SideClosed ...
+func (b Button) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// NeighbourUpdateTick ...
+func (b Button) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	supportPos := pos.Side(b.Facing.Opposite())
+	if !tx.Block(supportPos).Model().FaceSolid(supportPos, b.Facing, tx) {
+		breakBlock(b, pos, tx)
+	}
+}
+
+// UseOnBlock ...
+func (b Button) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(tx, pos, face, b)
+	if !used {
+		return false
+	}
+	supportPos := pos.Side(face.Opposite())
+	if !tx.Block(supportPos).Model().FaceSolid(supportPos, face, tx) {
+		return false
+	}
+
+	b.Pressed = false
+	b.Facing = face
+	place(tx, pos, b, user, ctx)
+	return placed(ctx)
+}
+
+// Activate presses the button if it is not already pressed.
+func (b Button) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
+	if !b.Pressed {
+		b.press(pos, tx)
+	}
+	return true
+}
+
+// ProjectileHit presses wooden buttons when they are hit by a projectile.
+func (b Button) ProjectileHit(pos cube.Pos, tx *world.Tx, _ world.Entity, _ cube.Face) {
+	if _, wooden := b.Block.(Planks); wooden && !b.Pressed {
+		b.press(pos, tx)
+	}
+}
+
+// press presses the button and schedules it to be released again.
+func (b Button) press(pos cube.Pos, tx *world.Tx) {
+	b.Pressed = true
+	tx.SetBlock(pos, b, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.PowerOn{})
+	tx.ScheduleBlockUpdate(pos, b, b.pressDuration())
+}
+
+// pressDuration returns the duration that the button remains pressed for. Wooden buttons stay pressed for longer
+// than stone buttons.
+func (b Button) pressDuration() time.Duration {
+	if _, wooden := b.Block.(Planks); wooden {
+		return redstoneTicks(15)
+	}
+	return redstoneTicks(10)
+}
+
+// ScheduledTick releases the button, unless it is a wooden button that still has an arrow stuck in it.
+func (b Button) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if tx == nil {
+		return
+	}
+	var ok bool
+	if b, ok = tx.Block(pos).(Button); !ok || !b.Pressed {
+		return
+	}
+	if _, wooden := b.Block.(Planks); wooden && arrowWithin(pos, tx) {
+		tx.ScheduleBlockUpdate(pos, b, b.pressDuration())
+		return
+	}
+	b.Pressed = false
+	tx.SetBlock(pos, b, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.PowerOff{})
+}
+
+// arrowWithin checks if there is an arrow, or another projectile that gets stuck in blocks, inside the block at the
+// position passed.
+func arrowWithin(pos cube.Pos, tx *world.Tx) bool {
+	for e := range tx.EntitiesWithin(cube.Box(0, 0, 0, 1, 1, 1).Translate(pos.Vec3())) {
+		if sticksInBlocks(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// BreakInfo ...
+func (b Button) BreakInfo() BreakInfo {
+	if _, wooden := b.Block.(Planks); wooden {
+		return newBreakInfo(0.5, alwaysHarvestable, axeEffective, oneOf(Button{Block: b.Block}))
+	}
+	return newBreakInfo(0.5, alwaysHarvestable, pickaxeEffective, oneOf(Button{Block: b.Block}))
+}
+
+// FuelInfo ...
+func (b Button) FuelInfo() item.FuelInfo {
+	if p, ok := b.Block.(Planks); ok && p.Wood.Flammable() {
+		return newFuelInfo(time.Second * 5)
+	}
+	return item.FuelInfo{}
+}
+
+// EncodeItem ...
+func (b Button) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + encodeButtonBlock(b.Block) + "_button", 0
+}
+
+// EncodeBlock ...
+func (b Button) EncodeBlock() (string, map[string]any) {
+	return "minecraft:" + encodeButtonBlock(b.Block) + "_button", map[string]any{"button_pressed_bit": boolByte(b.Pressed), "facing_direction": int32(b.Facing)}
+}
+
+// allButtons ...
+func allButtons() (buttons []world.Block) {
+	for _, block := range ButtonBlocks() {
+		for _, f := range cube.Faces() {
+			buttons = append(buttons, Button{Block: block, Facing: f}, Button{Block: block, Facing: f, Pressed: true})
+		}
+	}
+	return
+}
@@ -0,0 +1,37 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// encodeButtonBlock encodes the provided block in to an identifier that can be used to encode the button.
+func encodeButtonBlock(block world.Block) string {
+	switch block := block.(type) {
+	case Stone:
+		if !block.Smooth {
+			return "stone"
+		}
+	case Blackstone:
+		if block.Type == PolishedBlackstone() {
+			return "polished_blackstone"
+		}
+	case Planks:
+		if block.Wood == OakWood() {
+			return "wooden"
+		}
+		return block.Wood.String()
+	}
+	panic("invalid block used for button")
+}
+
+// ButtonBlocks returns a list of all possible blocks for a button.
+func ButtonBlocks() []world.Block {
+	b := []world.Block{
+		Stone{},
+		Blackstone{Type: PolishedBlackstone()},
+	}
+	for _, w := range WoodTypes() {
+		b = append(b, Planks{Wood: w})
+	}
+	return b
+}
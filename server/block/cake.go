@@ -145,6 +145,11 @@ func (c Cake) EntityInside(pos cube.Pos, tx *world.Tx, e world.Entity) {
 	}
 }
 
+// ComparatorSignal returns the signal read from the cake by a comparator, which decreases as bites are taken.
+func (c Cake) ComparatorSignal(cube.Pos, *world.Tx) int {
+	return (7 - c.Bites) * 2
+}
+
 // BreakInfo ...
 func (c Cake) BreakInfo() BreakInfo {
 	if c.Candle {
@@ -62,6 +62,11 @@ func (c Chest) Inventory(tx *world.Tx, pos cube.Pos) *inventory.Inventory {
 	return inv
 }
 
+// ComparatorSignal returns the signal read from the chest by a comparator, based on how full its inventory is.
+func (c Chest) ComparatorSignal(pos cube.Pos, tx *world.Tx) int {
+	return inventoryComparatorSignal(c.Inventory(tx, pos))
+}
+
 // tryPair attempts to pair the inventories of this chest with a potential
 // paired chest next to it. The (shared) inventory is returned and a bool is
 // returned indicating if the chest changed its pairing state.
@@ -0,0 +1,280 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var (
+	_ world.RedstoneStrongPowerSource  = Comparator{}
+	_ world.RedstonePowerContextAction = Comparator{}
+	_ world.ScheduledTicker            = Comparator{}
+	_ world.TickerBlock                = Comparator{}
+)
+
+// Comparator is a redstone component that compares the signal received from behind it with the strongest signal
+// received from its sides. Comparators may also read the fullness of containers and the state of several other
+// blocks behind them, either directly or through a solid block.
+type Comparator struct {
+	transparent
+	diode
+
+	// Facing is the direction the comparator outputs power to. It receives its main input from the opposite
+	// direction.
+	Facing cube.Direction
+	// Subtract is true if the comparator is in subtract mode. In subtract mode, the comparator outputs the signal
+	// from behind it minus the strongest signal from its sides. Otherwise, it outputs the signal from behind it,
+	// unless the signal from one of its sides is stronger.
+	Subtract bool
+	// Powered is true if the comparator is outputting power.
+	Powered bool
+	// Power is the strength of the signal output by the comparator, ranging from 0-15.
+	Power int
+}
+
+func (Comparator) HasLiquidDrops() bool {
+	return true
+}
+
+func (c Comparator) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(Comparator{}))
+}
+
+func (Comparator) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+func (c Comparator) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, c)
+	if !used || !redstoneDiodeSupported(pos, tx) {
+		return false
+	}
+	c.Facing = user.Rotation().Direction()
+	c.Powered, c.Power = false, 0
+
+	place(tx, pos, c, user, ctx)
+	return placed(ctx)
+}
+
+// Activate switches the comparator between compare and subtract mode.
+func (c Comparator) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
+	c.Subtract = !c.Subtract
+	tx.SetBlock(pos, c, &world.SetOpts{DisableRedstoneUpdates: true})
+	tx.PlaySound(pos.Vec3Centre(), sound.Click{})
+	c.scheduleUpdate(pos, tx)
+	return true
+}
+
+// NeighbourUpdateTick breaks unsupported comparators and otherwise schedules an output refresh, so that changes to
+// blocks read by the comparator are picked up.
+func (c Comparator) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !redstoneDiodeSupported(pos, tx) {
+		breakBlock(c, pos, tx)
+		return
+	}
+	c.scheduleUpdate(pos, tx)
+}
+
+// Tick refreshes the output of the comparator while it reads a block behind it. The contents of containers may
+// change without a block update, so these are polled every tick.
+func (c Comparator) Tick(_ int64, pos cube.Pos, tx *world.Tx) {
+	if _, _, ok := c.readable(pos, tx); ok {
+		c.scheduleUpdate(pos, tx)
+	}
+}
+
+// RedstonePower emits the output of the comparator from its front.
+func (c Comparator) RedstonePower(_ cube.Pos, _ *world.Tx, face cube.Face) int {
+	if face == c.Facing.Face() {
+		return c.Power
+	}
+	return 0
+}
+
+// RedstoneStrongPower strongly powers the block in front of the comparator.
+func (c Comparator) RedstoneStrongPower(pos cube.Pos, tx *world.Tx, face cube.Face) int {
+	return c.RedstonePower(pos, tx, face)
+}
+
+// RedstonePowerActionUpdate schedules an output change when the inputs of the comparator change.
+func (c Comparator) RedstonePowerActionUpdate(pos cube.Pos, tx *world.Tx, _ world.RedstoneUpdate) {
+	if tx == nil {
+		return
+	}
+	c.scheduleUpdate(pos, tx)
+}
+
+// ScheduledTick updates the output of the comparator one redstone tick after its inputs changed.
+func (c Comparator) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if tx == nil {
+		return
+	}
+	var ok bool
+	if c, ok = tx.Block(pos).(Comparator); !ok {
+		return
+	}
+	power := c.output(pos, tx)
+	if power == c.Power {
+		return
+	}
+	c.Power, c.Powered = power, power > 0
+	tx.SetBlock(pos, c, &world.SetOpts{DisableRedstoneUpdates: true})
+	tx.Redstone().ScheduleUpdate(pos)
+}
+
+// scheduleUpdate schedules a scheduled tick after one redstone tick if the output of the comparator does not match
+// its inputs.
+func (c Comparator) scheduleUpdate(pos cube.Pos, tx *world.Tx) {
+	if c.output(pos, tx) != c.Power {
+		tx.ScheduleBlockUpdate(pos, c, redstoneTicks(1))
+	}
+}
+
+// output computes the output of the comparator from its inputs.
+func (c Comparator) output(pos cube.Pos, tx *world.Tx) int {
+	rear, side := c.rearInput(pos, tx), c.sideInput(pos, tx)
+	if c.Subtract {
+		return max(rear-side, 0)
+	}
+	if side > rear {
+		return 0
+	}
+	return rear
+}
+
+// rearInput returns the signal received from behind the comparator. If a ComparatorReadable block is directly behind
+// the comparator, its signal is used instead. If it is behind a solid block behind the comparator, the strongest of its
+// signal and the power received from behind is used.
+func (c Comparator) rearInput(pos cube.Pos, tx *world.Tx) int {
+	power := tx.RedstonePowerFrom(pos, c.Facing.Opposite().Face())
+	readablePos, readable, ok := c.readable(pos, tx)
+	if !ok {
+		return power
+	}
+	signal := world.ClampRedstonePower(readable.ComparatorSignal(readablePos, tx))
+	if readablePos == pos.Side(c.Facing.Opposite().Face()) {
+		return signal
+	}
+	return max(power, signal)
+}
+
+// readable returns the ComparatorReadable block read by the comparator and its position, if any. The block is either
+// directly behind the comparator, or behind a solid block behind the comparator.
+func (c Comparator) readable(pos cube.Pos, tx *world.Tx) (cube.Pos, ComparatorReadable, bool) {
+	back := c.Facing.Opposite().Face()
+	behind := pos.Side(back)
+	b, ok := tx.BlockLoaded(behind)
+	if !ok {
+		return behind, nil, false
+	}
+	if readable, ok := b.(ComparatorReadable); ok {
+		return behind, readable, true
+	}
+	if !world.RedstoneFullPowerConductor(behind, b, tx) {
+		return behind, nil, false
+	}
+	further := behind.Side(back)
+	if b, ok := tx.BlockLoaded(further); ok {
+		if readable, ok := b.(ComparatorReadable); ok {
+			return further, readable, true
+		}
+	}
+	return further, nil, false
+}
+
+// sideInput returns the strongest signal received from the sides of the comparator. Only redstone wire, repeaters,
+// comparators and redstone blocks provide side input.
+func (c Comparator) sideInput(pos cube.Pos, tx *world.Tx) int {
+	power := 0
+	for _, d := range [...]cube.Direction{c.Facing.RotateLeft(), c.Facing.RotateRight()} {
+		switch tx.Block(pos.Side(d.Face())).(type) {
+		case RedstoneWire, Repeater, Comparator, RedstoneBlock:
+			power = max(power, tx.RedstoneDirectPowerFrom(pos, d.Face()))
+		}
+	}
+	return power
+}
+
+func (Comparator) EncodeItem() (name string, meta int16) {
+	return "minecraft:comparator", 0
+}
+
+func (c Comparator) EncodeBlock() (string, map[string]any) {
+	name := "minecraft:unpowered_comparator"
+	if c.Powered {
+		name = "minecraft:powered_comparator"
+	}
+	return name, map[string]any{
+		"minecraft:cardinal_direction": c.Facing.Opposite().String(),
+		"output_lit_bit":               boolByte(c.Powered),
+		"output_subtract_bit":          boolByte(c.Subtract),
+	}
+}
+
+// EncodeNBT ...
+func (c Comparator) EncodeNBT() map[string]any {
+	return map[string]any{"id": "Comparator", "OutputSignal": int32(c.Power)}
+}
+
+// DecodeNBT ...
+func (c Comparator) DecodeNBT(data map[string]any) any {
+	c.Power = world.ClampRedstonePower(int(nbtconv.Int32(data, "OutputSignal")))
+	return c
+}
+
+func allComparators() (all []world.Block) {
+	for _, d := range cube.Directions() {
+		for _, subtract := range []bool{false, true} {
+			all = append(all, Comparator{Facing: d, Subtract: subtract}, Comparator{Facing: d, Subtract: subtract, Powered: true})
+		}
+	}
+	return
+}
+
+// inventoryComparatorSignal returns the signal that a comparator reads from an inventory, based on how full its
+// slots are.
+func inventoryComparatorSignal(inv *inventory.Inventory) int {
+	if inv == nil || inv.Empty() {
+		return 0
+	}
+	var fullness float64
+	for _, it := range inv.Slots() {
+		if !it.Empty() {
+			fullness += float64(it.Count()) / float64(it.MaxCount())
+		}
+	}
+	return 1 + int(fullness/float64(inv.Size())*14)
+}
+
+// jukeboxComparatorSignals holds the signal that a comparator reads from a jukebox playing a music disc, indexed by
+// the sound.DiscType of the disc.
+var jukeboxComparatorSignals = [...]int{
+	0:  1,  // 13
+	1:  2,  // cat
+	2:  3,  // blocks
+	3:  4,  // chirp
+	4:  5,  // far
+	5:  6,  // mall
+	6:  7,  // mellohi
+	7:  8,  // stal
+	8:  9,  // strad
+	9:  10, // ward
+	10: 11, // 11
+	11: 12, // wait
+	12: 14, // otherside
+	13: 13, // pigstep
+	14: 15, // 5
+	15: 14, // relic
+	16: 12, // creator
+	17: 11, // creator (music box)
+	18: 13, // precipice
+	19: 10, // tears
+	20: 9,  // lava chicken
+}
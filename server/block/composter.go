@@ -138,6 +138,11 @@ func (c Composter) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
 	}
 }
 
+// ComparatorSignal returns the signal read from the composter by a comparator, which is equal to its level.
+func (c Composter) ComparatorSignal(cube.Pos, *world.Tx) int {
+	return c.Level
+}
+
 // EncodeItem ...
 func (c Composter) EncodeItem() (name string, meta int16) {
 	return "minecraft:composter", 0
@@ -154,6 +154,32 @@ func (d CopperDoor) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	attemptOxidation(pos, tx, r, d)
 }
 
+// RedstonePowerUpdate opens or closes the door when the redstone signal received by the door turns on or off. The
+// door stays open while the other half of it is still powered.
+func (d CopperDoor) RedstonePowerUpdate(pos cube.Pos, tx *world.Tx, power int) (world.Block, bool) {
+	powered, changed := redstoneSignalEdge(pos, tx, power)
+	if !changed || d.Open == powered || (!powered && tx.RedstonePower(pos.Side(cube.Face(boolByte(!d.Top)))) > 0) {
+		return d, false
+	}
+	d.Open = powered
+	return d, true
+}
+
+// RedstonePowerPostUpdate syncs the other half of the door and plays the sound of the door opening or closing.
+func (d CopperDoor) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, _, after world.Block, _, _ int) {
+	d = after.(CopperDoor)
+	otherPos := pos.Side(cube.Face(boolByte(!d.Top)))
+	if door, ok := tx.Block(otherPos).(CopperDoor); ok {
+		door.Open = d.Open
+		tx.SetBlock(otherPos, door, nil)
+	}
+	if d.Open {
+		tx.PlaySound(pos.Vec3Centre(), sound.DoorOpen{Block: d})
+		return
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.DoorClose{Block: d})
+}
+
 // BreakInfo ...
 func (d CopperDoor) BreakInfo() BreakInfo {
 	return newBreakInfo(3, func(t item.Tool) bool {
@@ -93,6 +93,25 @@ func (t CopperTrapdoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item
 	return true
 }
 
+// RedstonePowerUpdate opens or closes the trapdoor when the redstone signal received by it turns on or off.
+func (t CopperTrapdoor) RedstonePowerUpdate(pos cube.Pos, tx *world.Tx, power int) (world.Block, bool) {
+	powered, changed := redstoneSignalEdge(pos, tx, power)
+	if !changed || t.Open == powered {
+		return t, false
+	}
+	t.Open = powered
+	return t, true
+}
+
+// RedstonePowerPostUpdate plays the sound of the trapdoor opening or closing.
+func (t CopperTrapdoor) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, _, after world.Block, _, _ int) {
+	if after.(CopperTrapdoor).Open {
+		tx.PlaySound(pos.Vec3Centre(), sound.TrapdoorOpen{Block: after})
+		return
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.TrapdoorClose{Block: after})
+}
+
 func (t CopperTrapdoor) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	attemptOxidation(pos, tx, r, t)
 }
@@ -0,0 +1,152 @@
+package block
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var (
+	_ world.RedstoneStrongPowerSource = DetectorRail{}
+	_ world.ScheduledTicker           = DetectorRail{}
+	_ EntitySensor                    = DetectorRail{}
+)
+
+// DetectorRail is a rail that emits redstone power while a minecart is on top of it. Detector rails cannot be
+// curved.
+type DetectorRail struct {
+	empty
+	transparent
+
+	// Shape is the shape of the rail.
+	Shape RailShape
+	// Powered is true while a minecart is on the rail and the rail emits redstone power.
+	Powered bool
+}
+
+// RailShape ...
+func (r DetectorRail) RailShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r DetectorRail) withRailShape(s RailShape) world.Block {
+	r.Shape = s
+	return r
+}
+
+// curvable ...
+func (DetectorRail) curvable() bool {
+	return false
+}
+
+// SupportsMinecart ...
+func (DetectorRail) SupportsMinecart() bool {
+	return true
+}
+
+// SideClosed ...
+func (DetectorRail) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// BreakInfo ...
+func (r DetectorRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(DetectorRail{}))
+}
+
+// UseOnBlock ...
+func (r DetectorRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	r.Powered = false
+	return placeRail(r, pos, face, tx, user, ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r DetectorRail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !railSupported(pos, r.Shape, tx) {
+		breakBlock(r, pos, tx)
+	}
+}
+
+// RedstonePower ...
+func (r DetectorRail) RedstonePower(cube.Pos, *world.Tx, cube.Face) int {
+	if r.Powered {
+		return 15
+	}
+	return 0
+}
+
+// RedstoneStrongPower strongly powers the block below the detector rail.
+func (r DetectorRail) RedstoneStrongPower(pos cube.Pos, tx *world.Tx, face cube.Face) int {
+	if face == cube.FaceDown {
+		return r.RedstonePower(pos, tx, face)
+	}
+	return 0
+}
+
+// EntityInside powers the detector rail if a minecart enters it.
+func (r DetectorRail) EntityInside(pos cube.Pos, tx *world.Tx, e world.Entity) {
+	if !r.Powered && minecartEntity(e) {
+		r.update(pos, tx)
+	}
+}
+
+// SensesEntities ...
+func (DetectorRail) SensesEntities() {}
+
+// ScheduledTick checks if a minecart is still on the detector rail, releasing it once no minecarts are left.
+func (r DetectorRail) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if tx == nil {
+		return
+	}
+	var ok bool
+	if r, ok = tx.Block(pos).(DetectorRail); ok {
+		r.update(pos, tx)
+	}
+}
+
+// update powers the detector rail if a minecart is on top of it, and keeps checking for minecarts periodically as
+// long as it is powered.
+func (r DetectorRail) update(pos cube.Pos, tx *world.Tx) {
+	powered := false
+	for _, e := range entitiesIntersecting(tx, cube.Box(0.2, 0, 0.2, 0.8, 0.8, 0.8).Translate(pos.Vec3())) {
+		if minecartEntity(e) {
+			powered = true
+			break
+		}
+	}
+	if powered != r.Powered {
+		r.Powered = powered
+		tx.SetBlock(pos, r, nil)
+	}
+	if powered {
+		tx.ScheduleBlockUpdate(pos, r, redstoneTicks(10))
+	}
+}
+
+// minecartEntity checks if the entity passed is a minecart.
+func minecartEntity(e world.Entity) bool {
+	return strings.HasSuffix(e.H().Type().EncodeEntity(), "minecart")
+}
+
+// EncodeItem ...
+func (DetectorRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:detector_rail", 0
+}
+
+// EncodeBlock ...
+func (r DetectorRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:detector_rail", map[string]any{"rail_direction": int32(r.Shape.Uint8()), "rail_data_bit": boolByte(r.Powered)}
+}
+
+// allDetectorRails ...
+func allDetectorRails() (rails []world.Block) {
+	for _, s := range StraightRailShapes() {
+		rails = append(rails, DetectorRail{Shape: s}, DetectorRail{Shape: s, Powered: true})
+	}
+	return
+}
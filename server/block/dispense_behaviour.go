@@ -0,0 +1,274 @@
+package block
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// DispenseBehaviour is the behaviour of an item when it is dispensed by a Dispenser.
+type DispenseBehaviour interface {
+	// Dispense dispenses a single item out of the face passed of the dispenser at pos. It returns the item that is
+	// left in the dispenser in place of the dispensed item, such as an empty bucket after dispensing a water bucket,
+	// and false if the item could not be dispensed, in which case the item stays in the dispenser.
+	Dispense(pos cube.Pos, face cube.Face, it item.Stack, tx *world.Tx) (left item.Stack, ok bool)
+}
+
+// DispenseFunc is a function that implements DispenseBehaviour.
+type DispenseFunc func(pos cube.Pos, face cube.Face, it item.Stack, tx *world.Tx) (left item.Stack, ok bool)
+
+// Dispense ...
+func (f DispenseFunc) Dispense(pos cube.Pos, face cube.Face, it item.Stack, tx *world.Tx) (item.Stack, bool) {
+	return f(pos, face, it, tx)
+}
+
+var (
+	dispenseBehaviourMu sync.RWMutex
+	// dispenseBehaviours holds the DispenseBehaviour of items, indexed by the name of the item.
+	dispenseBehaviours = map[string]DispenseBehaviour{}
+)
+
+// RegisterDispenseBehaviour registers a DispenseBehaviour for all items with the same name as the item passed,
+// replacing any behaviour previously registered for them. Items without a DispenseBehaviour are dropped by
+// dispensers.
+func RegisterDispenseBehaviour(it world.Item, b DispenseBehaviour) {
+	name, _ := it.EncodeItem()
+
+	dispenseBehaviourMu.Lock()
+	defer dispenseBehaviourMu.Unlock()
+	dispenseBehaviours[name] = b
+}
+
+// dispenseBehaviour returns the DispenseBehaviour registered for the item passed. If no behaviour was registered,
+// a behaviour that drops the item is returned.
+func dispenseBehaviour(it world.Item) DispenseBehaviour {
+	name, _ := it.EncodeItem()
+
+	dispenseBehaviourMu.RLock()
+	defer dispenseBehaviourMu.RUnlock()
+	if b, ok := dispenseBehaviours[name]; ok {
+		return b
+	}
+	return DispenseFunc(dispenseDrop)
+}
+
+func init() {
+	RegisterDispenseBehaviour(item.Arrow{}, DispenseFunc(dispenseArrow))
+	RegisterDispenseBehaviour(item.Snowball{}, DispenseFunc(dispenseSnowball))
+	RegisterDispenseBehaviour(item.Egg{}, DispenseFunc(dispenseEgg))
+	RegisterDispenseBehaviour(item.Bucket{}, DispenseFunc(dispenseBucket))
+	RegisterDispenseBehaviour(item.Bucket{Content: item.LiquidBucketContent(Water{})}, DispenseFunc(dispenseBucket))
+	RegisterDispenseBehaviour(item.Bucket{Content: item.LiquidBucketContent(Lava{})}, DispenseFunc(dispenseBucket))
+	RegisterDispenseBehaviour(TNT{}, DispenseFunc(dispenseTNT))
+	RegisterDispenseBehaviour(item.BoneMeal{}, DispenseFunc(dispenseBoneMeal))
+	for _, t := range item.MinecartTypes() {
+		RegisterDispenseBehaviour(item.Minecart{Type: t}, DispenseFunc(dispenseMinecart))
+	}
+	for _, t := range item.BoatTypes() {
+		RegisterDispenseBehaviour(item.Boat{Type: t}, DispenseFunc(dispenseBoat))
+		RegisterDispenseBehaviour(item.Boat{Type: t, Chest: true}, DispenseFunc(dispenseBoat))
+	}
+	for _, it := range world.Items() {
+		if _, ok := it.(item.Armour); ok {
+			RegisterDispenseBehaviour(it, DispenseFunc(dispenseArmour))
+		}
+	}
+}
+
+// dispenseDirection returns the unit vector pointing out of the face passed.
+func dispenseDirection(face cube.Face) mgl64.Vec3 {
+	return cube.Pos{}.Side(face).Vec3()
+}
+
+// dispensePosition returns the position just in front of the face passed of the dispenser at pos, from which items
+// and projectiles are dispensed.
+func dispensePosition(pos cube.Pos, face cube.Face) mgl64.Vec3 {
+	return pos.Vec3Centre().Add(dispenseDirection(face).Mul(0.7))
+}
+
+// dispenseDrop drops the item out of the dispenser as an item entity. It is the behaviour of items without a
+// DispenseBehaviour and of all items dispensed by a Dropper.
+func dispenseDrop(pos cube.Pos, face cube.Face, it item.Stack, tx *world.Tx) (item.Stack, bool) {
+	spawnPos := dispensePosition(pos, face)
+	if face.Axis() == cube.Y {
+		spawnPos[1] -= 0.15625
+	} else {
+		spawnPos[1] -= 0.125
+	}
+	speed := rand.Float64()*0.1 + 0.2
+	vel := dispenseDirection(face).Mul(speed)
+	vel[1] = 0.2
+	vel = vel.Add(mgl64.Vec3{rand.NormFloat64(), rand.NormFloat64(), rand.NormFloat64()}.Mul(0.0075 * 6))
+
+	create := tx.World().EntityRegistry().Config().Item
+	tx.AddEntity(create(world.EntitySpawnOpts{Position: spawnPos, Velocity: vel}, it))
+	return item.Stack{}, true
+}
+
+// dispenseProjectileOpts returns the spawn options of a projectile launched out of the dispenser at pos.
+func dispenseProjectileOpts(pos cube.Pos, face cube.Face) world.EntitySpawnOpts {
+	dir := dispenseDirection(face).Add(mgl64.Vec3{0, 0.1, 0}).Normalize()
+	inaccuracy := mgl64.Vec3{rand.NormFloat64(), rand.NormFloat64(), rand.NormFloat64()}.Mul(0.0075 * 6)
+	return world.EntitySpawnOpts{Position: dispensePosition(pos, face), Velocity: dir.Add(inaccuracy).Mul(1.1)}
+}
+
+// dispenseArrow launches an arrow out of the dispenser. The arrow may be picked up again after it landed.
+func dispenseArrow(pos cube.Pos, face cube.Face, it item.Stack, tx *world.Tx) (item.Stack, bool) {
+	arrow, _ := it.Item().(item.Arrow)
+	create := tx.World().EntityRegistry().Config().Arrow
+	tx.AddEntity(create(dispenseProjectileOpts(pos, face), world.ArrowSpawnConfig{
+		Damage:              2,
+		Tip:                 arrow.Tip,
+		ObtainArrowOnPickup: true,
+	}))
+	tx.PlaySound(pos.Vec3Centre(), sound.BowShoot{})
+	return item.Stack{}, true
+}
+
+// dispenseSnowball launches a snowball out of the dispenser.
+func dispenseSnowball(pos cube.Pos, face cube.Face, _ item.Stack, tx *world.Tx) (item.Stack, bool) {
+	create := tx.World().EntityRegistry().Config().Snowball
+	tx.AddEntity(create(dispenseProjectileOpts(pos, face), nil))
+	tx.PlaySound(pos.Vec3Centre(), sound.ItemThrow{})
+	return item.Stack{}, true
+}
+
+// dispenseEgg launches an egg out of the dispenser.
+func dispenseEgg(pos cube.Pos, face cube.Face, _ item.Stack, tx *world.Tx) (item.Stack, bool) {
+	create := tx.World().EntityRegistry().Config().Egg
+	tx.AddEntity(create(dispenseProjectileOpts(pos, face), nil))
+	tx.PlaySound(pos.Vec3Centre(), sound.ItemThrow{})
+	return item.Stack{}, true
+}
+
+// dispenseBucket places the liquid in a filled bucket in front of the dispenser, leaving an empty bucket, or fills
+// an empty bucket with the liquid source in front of the dispenser. Filled buckets that cannot be emptied, such as
+// milk buckets, are dropped.
+func dispenseBucket(pos cube.Pos, face cube.Face, it item.Stack, tx *world.Tx) (item.Stack, bool) {
+	bucket, _ := it.Item().(item.Bucket)
+	front := pos.Side(face)
+	if front.OutOfBounds(tx.Range()) {
+		return it, false
+	}
+	if bucket.Empty() {
+		liquid, ok := tx.Liquid(front)
+		if !ok || liquid.LiquidDepth() != 8 || liquid.LiquidFalling() {
+			return it, false
+		}
+		tx.SetLiquid(front, nil)
+		tx.PlaySound(front.Vec3Centre(), sound.BucketFill{Liquid: liquid})
+		return item.NewStack(item.Bucket{Content: item.LiquidBucketContent(liquid)}, 1), true
+	}
+	liquid, ok := bucket.Content.Liquid()
+	if !ok {
+		return dispenseDrop(pos, face, it, tx)
+	}
+	liquid = liquid.WithDepth(8, false)
+	if d, ok := tx.Block(front).(world.LiquidDisplacer); !(ok && d.CanDisplace(liquid)) && !replaceableWith(tx, front, liquid) {
+		return it, false
+	}
+	tx.SetLiquid(front, liquid)
+	tx.PlaySound(front.Vec3Centre(), sound.BucketEmpty{Liquid: liquid})
+	return item.NewStack(item.Bucket{}, 1), true
+}
+
+// dispenseTNT primes TNT in front of the dispenser.
+func dispenseTNT(pos cube.Pos, face cube.Face, _ item.Stack, tx *world.Tx) (item.Stack, bool) {
+	front := pos.Side(face)
+	tx.PlaySound(front.Vec3Centre(), sound.TNT{})
+	create := tx.World().EntityRegistry().Config().TNT
+	tx.AddEntity(create(world.EntitySpawnOpts{Position: front.Vec3Middle()}, time.Second*4))
+	return item.Stack{}, true
+}
+
+// dispenseBoneMeal applies bone meal to the block in front of the dispenser.
+func dispenseBoneMeal(pos cube.Pos, face cube.Face, it item.Stack, tx *world.Tx) (item.Stack, bool) {
+	front := pos.Side(face)
+	affected, ok := tx.Block(front).(item.BoneMealAffected)
+	if !ok {
+		return it, false
+	}
+	result := affected.BoneMeal(front, tx)
+	if result == item.BoneMealResultNone {
+		return it, false
+	}
+	tx.AddParticle(front.Vec3(), particle.BoneMeal{Area: result == item.BoneMealResultArea})
+	return item.Stack{}, true
+}
+
+// dispenseMinecart places the minecart on the rail in front of the dispenser. If there is no rail in front of the
+// dispenser, the minecart is dropped.
+func dispenseMinecart(pos cube.Pos, face cube.Face, it item.Stack, tx *world.Tx) (item.Stack, bool) {
+	front := pos.Side(face)
+	if _, ok := tx.Block(front).(MinecartRail); !ok {
+		return dispenseDrop(pos, face, it, tx)
+	}
+	m, _ := it.Item().(item.Minecart)
+	create := tx.World().EntityRegistry().Config().Minecart
+	tx.AddEntity(create(world.EntitySpawnOpts{Position: front.Vec3Middle()}, m.Type))
+	return item.Stack{}, true
+}
+
+// dispenseBoat places the boat on the water in front of the dispenser. If there is no water in front of the
+// dispenser, the boat is dropped.
+func dispenseBoat(pos cube.Pos, face cube.Face, it item.Stack, tx *world.Tx) (item.Stack, bool) {
+	front := pos.Side(face)
+	if _, ok := tx.Block(front).(Water); !ok {
+		return dispenseDrop(pos, face, it, tx)
+	}
+	b, _ := it.Item().(item.Boat)
+	create := tx.World().EntityRegistry().Config().Boat
+	tx.AddEntity(create(world.EntitySpawnOpts{Position: front.Vec3Middle().Add(mgl64.Vec3{0, 1})}, b.Type, b.Chest))
+	return item.Stack{}, true
+}
+
+// armoured represents an entity that can wear armour, such as a player.
+type armoured interface {
+	world.Entity
+	Armour() *inventory.Armour
+}
+
+// dispenseArmour equips the armour on the first entity in front of the dispenser that has no armour in the
+// corresponding slot. If no such entity is present, the armour is dropped.
+func dispenseArmour(pos cube.Pos, face cube.Face, it item.Stack, tx *world.Tx) (item.Stack, bool) {
+	front := pos.Side(face)
+	box := cube.Box(0, 0, 0, 1, 1, 1).Translate(front.Vec3())
+	for _, e := range entitiesIntersecting(tx, box) {
+		a, ok := e.(armoured)
+		if !ok {
+			continue
+		}
+		armour := a.Armour()
+		switch it.Item().(type) {
+		case item.HelmetType:
+			if armour.Helmet().Empty() {
+				armour.SetHelmet(it)
+				return item.Stack{}, true
+			}
+		case item.ChestplateType:
+			if armour.Chestplate().Empty() {
+				armour.SetChestplate(it)
+				return item.Stack{}, true
+			}
+		case item.LeggingsType:
+			if armour.Leggings().Empty() {
+				armour.SetLeggings(it)
+				return item.Stack{}, true
+			}
+		case item.BootsType:
+			if armour.Boots().Empty() {
+				armour.SetBoots(it)
+				return item.Stack{}, true
+			}
+		}
+	}
+	return dispenseDrop(pos, face, it, tx)
+}
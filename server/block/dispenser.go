@@ -0,0 +1,233 @@
+package block
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Dispenser is a container block that dispenses one of its items when it starts receiving redstone power. What
+// happens to the item depends on the DispenseBehaviour registered for it: projectiles are launched, buckets are
+// emptied or filled and most other items are dropped.
+type Dispenser struct {
+	solid
+
+	// Facing is the face of the dispenser that items are dispensed out of.
+	Facing cube.Face
+	// Triggered is true while the dispenser receives redstone power. Triggered is updated by the redstone engine.
+	Triggered bool
+	// CustomName is the custom name of the dispenser. This name is displayed when the dispenser is opened, and may
+	// include colour codes.
+	CustomName string
+
+	inventory *inventory.Inventory
+	viewerMu  *sync.RWMutex
+	viewers   map[ContainerViewer]struct{}
+}
+
+// NewDispenser creates a new initialised dispenser. The inventory is properly initialised.
+func NewDispenser() Dispenser {
+	inv, m, v := newDispenserInventory()
+	return Dispenser{inventory: inv, viewerMu: m, viewers: v}
+}
+
+// newDispenserInventory creates the nine-slot inventory of a dispenser or dropper, along with the mutex and map of
+// viewers that are updated when a slot changes.
+func newDispenserInventory() (*inventory.Inventory, *sync.RWMutex, map[ContainerViewer]struct{}) {
+	m := new(sync.RWMutex)
+	v := make(map[ContainerViewer]struct{}, 1)
+	inv := inventory.New(9, func(slot int, _, item item.Stack) {
+		m.RLock()
+		defer m.RUnlock()
+		for viewer := range v {
+			viewer.ViewSlotChange(slot, item)
+		}
+	})
+	return inv, m, v
+}
+
+// BreakInfo ...
+func (d Dispenser) BreakInfo() BreakInfo {
+	return newBreakInfo(3.5, pickaxeHarvestable, pickaxeEffective, oneOf(Dispenser{})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, u item.User) {
+		for _, i := range d.Inventory(tx, pos).Clear() {
+			dropItem(tx, i, pos.Vec3())
+		}
+	})
+}
+
+// Inventory returns the inventory of the dispenser.
+func (d Dispenser) Inventory(*world.Tx, cube.Pos) *inventory.Inventory {
+	return d.inventory
+}
+
+// ComparatorSignal returns the signal read from the dispenser by a comparator, based on how full its inventory is.
+func (d Dispenser) ComparatorSignal(cube.Pos, *world.Tx) int {
+	return inventoryComparatorSignal(d.inventory)
+}
+
+// WithName returns the dispenser after applying a specific name to the block.
+func (d Dispenser) WithName(a ...any) world.Item {
+	d.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return d
+}
+
+// AddViewer adds a viewer to the dispenser, so that it is updated whenever the inventory of the dispenser is changed.
+func (d Dispenser) AddViewer(v ContainerViewer, _ *world.Tx, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	d.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the dispenser, so that slot updates in the inventory are no longer sent to it.
+func (d Dispenser) RemoveViewer(v ContainerViewer, _ *world.Tx, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	delete(d.viewers, v)
+}
+
+// Activate ...
+func (Dispenser) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos, tx)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (d Dispenser) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, d)
+	if !used {
+		return false
+	}
+
+	//noinspection GoAssignmentToReceiver
+	d = NewDispenser()
+	d.Facing = calculateFace(user, pos).Opposite()
+
+	place(tx, pos, d, user, ctx)
+	return placed(ctx)
+}
+
+// RedstonePowerUpdate updates whether the dispenser is triggered by redstone power.
+func (d Dispenser) RedstonePowerUpdate(_ cube.Pos, _ *world.Tx, power int) (world.Block, bool) {
+	triggered := power > 0
+	if triggered == d.Triggered {
+		return d, false
+	}
+	d.Triggered = triggered
+	return d, true
+}
+
+// RedstonePowerPostUpdate schedules the dispenser to dispense an item when it starts receiving power.
+func (Dispenser) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, before, after world.Block, _, _ int) {
+	b, _ := before.(Dispenser)
+	if a, ok := after.(Dispenser); ok && !b.Triggered && a.Triggered {
+		scheduleDispense(pos, tx, Dispenser{Facing: a.Facing}, Dispenser{Facing: a.Facing, Triggered: true})
+	}
+}
+
+// ScheduledTick dispenses a random item out of the dispenser.
+func (d Dispenser) ScheduledTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	slot, it, ok := randomDispenseSlot(d.inventory, r)
+	if !ok {
+		tx.PlaySound(pos.Vec3Centre(), sound.ClickFail{})
+		return
+	}
+	left, ok := dispenseBehaviour(it.Item()).Dispense(pos, d.Facing, it.Grow(1-it.Count()), tx)
+	if !ok {
+		tx.PlaySound(pos.Vec3Centre(), sound.ClickFail{})
+		return
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.Click{})
+	_ = d.inventory.SetItem(slot, it.Grow(-1))
+	if left.Empty() {
+		return
+	}
+	if rest, _ := d.inventory.Item(slot); rest.Empty() {
+		_ = d.inventory.SetItem(slot, left)
+	} else if _, err := d.inventory.AddItem(left); err != nil {
+		// The dispenser is full, so the item left behind is dropped instead.
+		dispenseDrop(pos, d.Facing, left, tx)
+	}
+}
+
+// scheduleDispense schedules a dispenser or dropper at pos to dispense an item after two redstone ticks. Scheduled
+// ticks only run if the block state is unchanged, so the tick is scheduled for both the triggered and the untriggered
+// state: the dispenser must dispense even if the redstone pulse ended before the tick.
+func scheduleDispense(pos cube.Pos, tx *world.Tx, untriggered, triggered world.Block) {
+	tx.ScheduleBlockUpdate(pos, untriggered, redstoneTicks(2))
+	tx.ScheduleBlockUpdate(pos, triggered, redstoneTicks(2))
+}
+
+// randomDispenseSlot selects a random non-empty slot of the inventory passed. False is returned if the inventory is
+// empty.
+func randomDispenseSlot(inv *inventory.Inventory, r *rand.Rand) (slot int, it item.Stack, ok bool) {
+	n := 0
+	for i, s := range inv.Slots() {
+		if s.Empty() {
+			continue
+		}
+		n++
+		if r.IntN(n) == 0 {
+			slot, it, ok = i, s, true
+		}
+	}
+	return slot, it, ok
+}
+
+// EncodeItem ...
+func (Dispenser) EncodeItem() (name string, meta int16) {
+	return "minecraft:dispenser", 0
+}
+
+// EncodeBlock ...
+func (d Dispenser) EncodeBlock() (string, map[string]any) {
+	return "minecraft:dispenser", map[string]any{"facing_direction": int32(d.Facing), "triggered_bit": boolByte(d.Triggered)}
+}
+
+// EncodeNBT ...
+func (d Dispenser) EncodeNBT() map[string]any {
+	if d.inventory == nil {
+		facing, triggered, customName := d.Facing, d.Triggered, d.CustomName
+		//noinspection GoAssignmentToReceiver
+		d = NewDispenser()
+		d.Facing, d.Triggered, d.CustomName = facing, triggered, customName
+	}
+	m := map[string]any{
+		"Items": nbtconv.InvToNBT(d.inventory),
+		"id":    "Dispenser",
+	}
+	if d.CustomName != "" {
+		m["CustomName"] = d.CustomName
+	}
+	return m
+}
+
+// DecodeNBT ...
+func (d Dispenser) DecodeNBT(data map[string]any) any {
+	facing, triggered := d.Facing, d.Triggered
+	//noinspection GoAssignmentToReceiver
+	d = NewDispenser()
+	d.Facing, d.Triggered = facing, triggered
+	d.CustomName = nbtconv.String(data, "CustomName")
+	nbtconv.InvFromNBT(d.inventory, nbtconv.Slice(data, "Items"))
+	return d
+}
+
+// allDispensers ...
+func allDispensers() (dispensers []world.Block) {
+	for _, f := range cube.Faces() {
+		dispensers = append(dispensers, Dispenser{Facing: f}, Dispenser{Facing: f, Triggered: true})
+	}
+	return dispensers
+}
@@ -0,0 +1,201 @@
+package block
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Dropper is a container block that drops one of its items when it starts receiving redstone power. Unlike a
+// Dispenser, a dropper always drops the item, or pushes it into the container in front of it.
+type Dropper struct {
+	solid
+
+	// Facing is the face of the dropper that items are dropped out of.
+	Facing cube.Face
+	// Triggered is true while the dropper receives redstone power. Triggered is updated by the redstone engine.
+	Triggered bool
+	// CustomName is the custom name of the dropper. This name is displayed when the dropper is opened, and may
+	// include colour codes.
+	CustomName string
+
+	inventory *inventory.Inventory
+	viewerMu  *sync.RWMutex
+	viewers   map[ContainerViewer]struct{}
+}
+
+// NewDropper creates a new initialised dropper. The inventory is properly initialised.
+func NewDropper() Dropper {
+	inv, m, v := newDispenserInventory()
+	return Dropper{inventory: inv, viewerMu: m, viewers: v}
+}
+
+// BreakInfo ...
+func (d Dropper) BreakInfo() BreakInfo {
+	return newBreakInfo(3.5, pickaxeHarvestable, pickaxeEffective, oneOf(Dropper{})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, u item.User) {
+		for _, i := range d.Inventory(tx, pos).Clear() {
+			dropItem(tx, i, pos.Vec3())
+		}
+	})
+}
+
+// Inventory returns the inventory of the dropper.
+func (d Dropper) Inventory(*world.Tx, cube.Pos) *inventory.Inventory {
+	return d.inventory
+}
+
+// ComparatorSignal returns the signal read from the dropper by a comparator, based on how full its inventory is.
+func (d Dropper) ComparatorSignal(cube.Pos, *world.Tx) int {
+	return inventoryComparatorSignal(d.inventory)
+}
+
+// WithName returns the dropper after applying a specific name to the block.
+func (d Dropper) WithName(a ...any) world.Item {
+	d.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return d
+}
+
+// AddViewer adds a viewer to the dropper, so that it is updated whenever the inventory of the dropper is changed.
+func (d Dropper) AddViewer(v ContainerViewer, _ *world.Tx, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	d.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the dropper, so that slot updates in the inventory are no longer sent to it.
+func (d Dropper) RemoveViewer(v ContainerViewer, _ *world.Tx, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	delete(d.viewers, v)
+}
+
+// Activate ...
+func (Dropper) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos, tx)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (d Dropper) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, d)
+	if !used {
+		return false
+	}
+
+	//noinspection GoAssignmentToReceiver
+	d = NewDropper()
+	d.Facing = calculateFace(user, pos).Opposite()
+
+	place(tx, pos, d, user, ctx)
+	return placed(ctx)
+}
+
+// RedstonePowerUpdate updates whether the dropper is triggered by redstone power.
+func (d Dropper) RedstonePowerUpdate(_ cube.Pos, _ *world.Tx, power int) (world.Block, bool) {
+	triggered := power > 0
+	if triggered == d.Triggered {
+		return d, false
+	}
+	d.Triggered = triggered
+	return d, true
+}
+
+// RedstonePowerPostUpdate schedules the dropper to drop an item when it starts receiving power.
+func (Dropper) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, before, after world.Block, _, _ int) {
+	b, _ := before.(Dropper)
+	if a, ok := after.(Dropper); ok && !b.Triggered && a.Triggered {
+		scheduleDispense(pos, tx, Dropper{Facing: a.Facing}, Dropper{Facing: a.Facing, Triggered: true})
+	}
+}
+
+// ScheduledTick drops a random item out of the dropper. If a container is in front of the dropper, the item is
+// inserted into it instead.
+func (d Dropper) ScheduledTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	slot, it, ok := randomDispenseSlot(d.inventory, r)
+	if !ok {
+		tx.PlaySound(pos.Vec3Centre(), sound.ClickFail{})
+		return
+	}
+	single := it.Grow(1 - it.Count())
+
+	destPos := pos.Side(d.Facing)
+	switch tx.Block(destPos).(type) {
+	case Container, HopperInsertable:
+		if !d.insertItem(pos, single, tx) {
+			// The container is full or does not accept the item.
+			return
+		}
+	default:
+		dispenseDrop(pos, d.Facing, single, tx)
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.Click{})
+	_ = d.inventory.SetItem(slot, it.Grow(-1))
+}
+
+// insertItem inserts a single item into the container in front of the dropper, following the same rules as a
+// Hopper facing the same direction.
+func (d Dropper) insertItem(pos cube.Pos, it item.Stack, tx *world.Tx) bool {
+	h := NewHopper()
+	h.Facing = d.Facing
+	_ = h.inventory.SetItem(0, it)
+	return h.insertItem(pos, tx)
+}
+
+// EncodeItem ...
+func (Dropper) EncodeItem() (name string, meta int16) {
+	return "minecraft:dropper", 0
+}
+
+// EncodeBlock ...
+func (d Dropper) EncodeBlock() (string, map[string]any) {
+	return "minecraft:dropper", map[string]any{"facing_direction": int32(d.Facing), "triggered_bit": boolByte(d.Triggered)}
+}
+
+// EncodeNBT ...
+func (d Dropper) EncodeNBT() map[string]any {
+	if d.inventory == nil {
+		facing, triggered, customName := d.Facing, d.Triggered, d.CustomName
+		//noinspection GoAssignmentToReceiver
+		d = NewDropper()
+		d.Facing, d.Triggered, d.CustomName = facing, triggered, customName
+	}
+	m := map[string]any{
+		"Items": nbtconv.InvToNBT(d.inventory),
+		"id":    "Dropper",
+	}
+	if d.CustomName != "" {
+		m["CustomName"] = d.CustomName
+	}
+	return m
+}
+
+// DecodeNBT ...
+func (d Dropper) DecodeNBT(data map[string]any) any {
+	facing, triggered := d.Facing, d.Triggered
+	//noinspection GoAssignmentToReceiver
+	d = NewDropper()
+	d.Facing, d.Triggered = facing, triggered
+	d.CustomName = nbtconv.String(data, "CustomName")
+	nbtconv.InvFromNBT(d.inventory, nbtconv.Slice(data, "Items"))
+	return d
+}
+
+// allDroppers ...
+func allDroppers() (droppers []world.Block) {
+	for _, f := range cube.Faces() {
+		droppers = append(droppers, Dropper{Facing: f}, Dropper{Facing: f, Triggered: true})
+	}
+	return droppers
+}
@@ -5,7 +5,8 @@ package block
 import "github.com/df-mc/dragonfly/server/world"
 
 const (
-	hashAir = iota
+	hashActivatorRail = iota
+	hashAir
 	hashAmethyst
 	hashAncientDebris
 	hashAndesite
@@ -29,6 +30,7 @@ const (
 	hashBookshelf
 	hashBrewingStand
 	hashBricks
+	hashButton
 	hashCactus
 	hashCake
 	hashCalcite
@@ -46,6 +48,7 @@ const (
 	hashCobblestone
 	hashCobweb
 	hashCocoaBean
+	hashComparator
 	hashComposter
 	hashConcrete
 	hashConcretePowder
@@ -67,16 +70,19 @@ const (
 	hashDeepslate
 	hashDeepslateBricks
 	hashDeepslateTiles
+	hashDetectorRail
 	hashDiamond
 	hashDiamondOre
 	hashDiorite
 	hashDirt
 	hashDirtPath
+	hashDispenser
 	hashDoubleFlower
 	hashDoubleTallGrass
 	hashDragonEgg
 	hashDriedKelp
 	hashDripstone
+	hashDropper
 	hashEmerald
 	hashEmeraldOre
 	hashEnchantingTable
@@ -134,7 +140,9 @@ const (
 	hashMagma
 	hashMelon
 	hashMelonSeeds
+	hashMobSpawner
 	hashMossCarpet
+	hashMovingBlock
 	hashMud
 	hashMudBricks
 	hashMuddyMangroveRoots
@@ -148,10 +156,13 @@ const (
 	hashNetherite
 	hashNetherrack
 	hashNote
+	hashObserver
 	hashObsidian
 	hashPackedIce
 	hashPackedMud
 	hashPinkPetals
+	hashPiston
+	hashPistonArm
 	hashPlanks
 	hashPodzol
 	hashPolishedBlackstoneBrick
@@ -160,6 +171,8 @@ const (
 	hashPolishedTuff
 	hashPortal
 	hashPotato
+	hashPoweredRail
+	hashPressurePlate
 	hashPrismarine
 	hashPumpkin
 	hashPumpkinSeeds
@@ -168,18 +181,22 @@ const (
 	hashQuartz
 	hashQuartzBricks
 	hashQuartzPillar
+	hashRail
 	hashRawCopper
 	hashRawGold
 	hashRawIron
 	hashRedstoneBlock
+	hashRedstoneLamp
 	hashRedstoneOre
 	hashRedstoneTorch
 	hashRedstoneWire
 	hashReinforcedDeepslate
+	hashRepeater
 	hashResin
 	hashResinBricks
 	hashSand
 	hashSandstone
+	hashSapling
 	hashSeaLantern
 	hashSeaPickle
 	hashShortGrass
@@ -201,6 +218,7 @@ const (
 	hashStainedGlassPane
 	hashStainedTerracotta
 	hashStairs
+	hashStickyPiston
 	hashStone
 	hashStoneBricks
 	hashStonecutter
@@ -209,9 +227,11 @@ const (
 	hashSulfur
 	hashSulfurBricks
 	hashTNT
+	hashTarget
 	hashTerracotta
 	hashTintedGlass
 	hashTorch
+	hashTripwireHook
 	hashTuff
 	hashTuffBricks
 	hashVines
@@ -236,6 +256,10 @@ func NextHash() uint64 {
 	return customBlockBase
 }
 
+func (a ActivatorRail) Hash() (uint64, uint64) {
+	return hashActivatorRail, uint64(a.Shape.Uint8()) | uint64(boolByte(a.Powered))<<4
+}
+
 func (Air) Hash() (uint64, uint64) {
 	return hashAir, 0
 }
@@ -332,6 +356,10 @@ func (Bricks) Hash() (uint64, uint64) {
 	return hashBricks, 0
 }
 
+func (b Button) Hash() (uint64, uint64) {
+	return hashButton, world.BlockHash(b.Block) | uint64(b.Facing)<<32 | uint64(boolByte(b.Pressed))<<35
+}
+
 func (c Cactus) Hash() (uint64, uint64) {
 	return hashCactus, uint64(c.Age)
 }
@@ -400,6 +428,10 @@ func (c CocoaBean) Hash() (uint64, uint64) {
 	return hashCocoaBean, uint64(c.Facing) | uint64(c.Age)<<2
 }
 
+func (c Comparator) Hash() (uint64, uint64) {
+	return hashComparator, uint64(c.Facing) | uint64(boolByte(c.Subtract))<<2 | uint64(boolByte(c.Powered))<<3
+}
+
 func (c Composter) Hash() (uint64, uint64) {
 	return hashComposter, uint64(c.Level)
 }
@@ -484,6 +516,10 @@ func (d DeepslateTiles) Hash() (uint64, uint64) {
 	return hashDeepslateTiles, uint64(boolByte(d.Cracked))
 }
 
+func (d DetectorRail) Hash() (uint64, uint64) {
+	return hashDetectorRail, uint64(d.Shape.Uint8()) | uint64(boolByte(d.Powered))<<4
+}
+
 func (Diamond) Hash() (uint64, uint64) {
 	return hashDiamond, 0
 }
@@ -504,6 +540,10 @@ func (DirtPath) Hash() (uint64, uint64) {
 	return hashDirtPath, 0
 }
 
+func (d Dispenser) Hash() (uint64, uint64) {
+	return hashDispenser, uint64(d.Facing) | uint64(boolByte(d.Triggered))<<3
+}
+
 func (d DoubleFlower) Hash() (uint64, uint64) {
 	return hashDoubleFlower, uint64(boolByte(d.UpperPart)) | uint64(d.Type.Uint8())<<1
 }
@@ -524,6 +564,10 @@ func (Dripstone) Hash() (uint64, uint64) {
 	return hashDripstone, 0
 }
 
+func (d Dropper) Hash() (uint64, uint64) {
+	return hashDropper, uint64(d.Facing) | uint64(boolByte(d.Triggered))<<3
+}
+
 func (Emerald) Hash() (uint64, uint64) {
 	return hashEmerald, 0
 }
@@ -752,10 +796,18 @@ func (m MelonSeeds) Hash() (uint64, uint64) {
 	return hashMelonSeeds, uint64(m.Growth) | uint64(m.Direction)<<8
 }
 
+func (MobSpawner) Hash() (uint64, uint64) {
+	return hashMobSpawner, 0
+}
+
 func (MossCarpet) Hash() (uint64, uint64) {
 	return hashMossCarpet, 0
 }
 
+func (MovingBlock) Hash() (uint64, uint64) {
+	return hashMovingBlock, 0
+}
+
 func (Mud) Hash() (uint64, uint64) {
 	return hashMud, 0
 }
@@ -808,6 +860,10 @@ func (Note) Hash() (uint64, uint64) {
 	return hashNote, 0
 }
 
+func (o Observer) Hash() (uint64, uint64) {
+	return hashObserver, uint64(o.Facing) | uint64(boolByte(o.Powered))<<3
+}
+
 func (o Obsidian) Hash() (uint64, uint64) {
 	return hashObsidian, uint64(boolByte(o.Crying))
 }
@@ -824,6 +880,14 @@ func (p PinkPetals) Hash() (uint64, uint64) {
 	return hashPinkPetals, uint64(p.AdditionalCount) | uint64(p.Facing)<<8
 }
 
+func (p Piston) Hash() (uint64, uint64) {
+	return hashPiston, uint64(p.Facing)
+}
+
+func (p PistonArm) Hash() (uint64, uint64) {
+	return hashPistonArm, uint64(p.Facing) | uint64(boolByte(p.Sticky))<<3
+}
+
 func (p Planks) Hash() (uint64, uint64) {
 	return hashPlanks, uint64(p.Wood.Uint8())
 }
@@ -856,6 +920,14 @@ func (p Potato) Hash() (uint64, uint64) {
 	return hashPotato, uint64(p.Growth)
 }
 
+func (p PoweredRail) Hash() (uint64, uint64) {
+	return hashPoweredRail, uint64(p.Shape.Uint8()) | uint64(boolByte(p.Powered))<<4
+}
+
+func (p PressurePlate) Hash() (uint64, uint64) {
+	return hashPressurePlate, world.BlockHash(p.Block) | uint64(p.Power)<<32
+}
+
 func (p Prismarine) Hash() (uint64, uint64) {
 	return hashPrismarine, uint64(p.Type.Uint8())
 }
@@ -888,6 +960,10 @@ func (q QuartzPillar) Hash() (uint64, uint64) {
 	return hashQuartzPillar, uint64(q.Axis)
 }
 
+func (r Rail) Hash() (uint64, uint64) {
+	return hashRail, uint64(r.Shape.Uint8())
+}
+
 func (RawCopper) Hash() (uint64, uint64) {
 	return hashRawCopper, 0
 }
@@ -904,6 +980,10 @@ func (RedstoneBlock) Hash() (uint64, uint64) {
 	return hashRedstoneBlock, 0
 }
 
+func (l RedstoneLamp) Hash() (uint64, uint64) {
+	return hashRedstoneLamp, uint64(boolByte(l.Lit))
+}
+
 func (r RedstoneOre) Hash() (uint64, uint64) {
 	return hashRedstoneOre, uint64(r.Type.Uint8()) | uint64(boolByte(r.Lit))<<1
 }
@@ -920,6 +1000,10 @@ func (ReinforcedDeepslate) Hash() (uint64, uint64) {
 	return hashReinforcedDeepslate, 0
 }
 
+func (r Repeater) Hash() (uint64, uint64) {
+	return hashRepeater, uint64(r.Facing) | uint64(r.Delay)<<2 | uint64(boolByte(r.Powered))<<10
+}
+
 func (Resin) Hash() (uint64, uint64) {
 	return hashResin, 0
 }
@@ -936,6 +1020,10 @@ func (s Sandstone) Hash() (uint64, uint64) {
 	return hashSandstone, uint64(s.Type.Uint8()) | uint64(boolByte(s.Red))<<2
 }
 
+func (s Sapling) Hash() (uint64, uint64) {
+	return hashSapling, uint64(s.Wood.Uint8()) | uint64(boolByte(s.Ready))<<4
+}
+
 func (SeaLantern) Hash() (uint64, uint64) {
 	return hashSeaLantern, 0
 }
@@ -1020,6 +1108,10 @@ func (s Stairs) Hash() (uint64, uint64) {
 	return hashStairs, world.BlockHash(s.Block) | uint64(boolByte(s.UpsideDown))<<32 | uint64(s.Facing)<<33
 }
 
+func (p StickyPiston) Hash() (uint64, uint64) {
+	return hashStickyPiston, uint64(p.Facing)
+}
+
 func (s Stone) Hash() (uint64, uint64) {
 	return hashStone, uint64(boolByte(s.Smooth))
 }
@@ -1052,6 +1144,10 @@ func (TNT) Hash() (uint64, uint64) {
 	return hashTNT, 0
 }
 
+func (Target) Hash() (uint64, uint64) {
+	return hashTarget, 0
+}
+
 func (Terracotta) Hash() (uint64, uint64) {
 	return hashTerracotta, 0
 }
@@ -1064,6 +1160,10 @@ func (t Torch) Hash() (uint64, uint64) {
 	return hashTorch, uint64(t.Facing) | uint64(t.Type.Uint8())<<3
 }
 
+func (h TripwireHook) Hash() (uint64, uint64) {
+	return hashTripwireHook, uint64(h.Facing) | uint64(boolByte(h.Attached))<<2 | uint64(boolByte(h.Powered))<<3
+}
+
 func (t Tuff) Hash() (uint64, uint64) {
 	return hashTuff, uint64(boolByte(t.Chiseled))
 }
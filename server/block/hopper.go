@@ -22,7 +22,7 @@ type Hopper struct {
 	// Facing is the direction the hopper is facing.
 	Facing cube.Face
 	// Powered is whether the hopper is powered or not. If the hopper is powered it will be locked and will stop
-	// moving items into or out of itself.
+	// moving items into or out of itself. Powered is updated by the redstone engine when the hopper receives power.
 	Powered bool
 	// CustomName is the custom name of the hopper. This name is displayed when the hopper is opened, and may include
 	// colour codes.
@@ -84,6 +84,11 @@ func (h Hopper) Inventory(*world.Tx, cube.Pos) *inventory.Inventory {
 	return h.inventory
 }
 
+// ComparatorSignal returns the signal read from the hopper by a comparator, based on how full its inventory is.
+func (h Hopper) ComparatorSignal(cube.Pos, *world.Tx) int {
+	return inventoryComparatorSignal(h.inventory)
+}
+
 // WithName returns the hopper after applying a specific name to the block.
 func (h Hopper) WithName(a ...any) world.Item {
 	h.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
@@ -156,6 +161,16 @@ func (h Hopper) Tick(_ int64, pos cube.Pos, tx *world.Tx) {
 	}
 }
 
+// RedstonePowerUpdate locks the hopper while it receives redstone power.
+func (h Hopper) RedstonePowerUpdate(_ cube.Pos, _ *world.Tx, power int) (world.Block, bool) {
+	powered := power > 0
+	if powered == h.Powered {
+		return h, false
+	}
+	h.Powered = powered
+	return h, true
+}
+
 // HopperInsertable represents a block that can have its contents inserted into by a hopper.
 type HopperInsertable interface {
 	// InsertItem handles the insert logic for that block.
@@ -104,6 +104,15 @@ func (j Jukebox) Disc() (sound.DiscType, bool) {
 	return sound.DiscType{}, false
 }
 
+// ComparatorSignal returns the signal read from the jukebox by a comparator, which depends on the music disc in the
+// jukebox.
+func (j Jukebox) ComparatorSignal(cube.Pos, *world.Tx) int {
+	if disc, ok := j.Disc(); ok && int(disc.Uint8()) < len(jukeboxComparatorSignals) {
+		return jukeboxComparatorSignals[disc.Uint8()]
+	}
+	return 0
+}
+
 // EncodeNBT ...
 func (j Jukebox) EncodeNBT() map[string]any {
 	m := map[string]any{"id": "Jukebox"}
@@ -2,6 +2,7 @@ package block
 
 import (
 	"math/rand/v2"
+	"slices"
 
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
@@ -105,8 +106,15 @@ func (l Leaves) BreakInfo() BreakInfo {
 		fortune := fortuneLevel(enchantments)
 		var drops []item.Stack
 
-		// TODO: Drop saplings.
-
+		if wood, ok := l.Type.Wood(); ok && slices.Contains(saplingWoodTypes(), wood) {
+			saplingChances := []float64{0.05, 0.0625, 0.083333334, 0.1}
+			if wood == JungleWood() {
+				saplingChances = []float64{0.025, 0.027777778, 0.03125, 0.041666668}
+			}
+			if rand.Float64() < saplingChances[min(fortune, 3)] {
+				drops = append(drops, item.NewStack(Sapling{Wood: wood}, 1))
+			}
+		}
 		stickChances := []float64{0.02, 0.022222222, 0.025, 0.033333333}
 		if rand.Float64() < stickChances[min(fortune, 3)] {
 			drops = append(drops, item.NewStack(item.Stick{}, rand.IntN(2)+1))
@@ -127,6 +127,19 @@ func (l Lectern) TurnPage(pos cube.Pos, tx *world.Tx, page int) error {
 	return nil
 }
 
+// ComparatorSignal returns the signal read from the lectern by a comparator, which increases as pages further in the
+// book are opened. A lectern without a book gives no signal.
+func (l Lectern) ComparatorSignal(cube.Pos, *world.Tx) int {
+	book, ok := l.Book.Item().(readableBook)
+	if l.Book.Empty() || !ok {
+		return 0
+	}
+	if pages := book.TotalPages(); pages > 1 {
+		return int(float64(l.Page)/float64(pages-1)*14) + 1
+	}
+	return 15
+}
+
 // EncodeNBT ...
 func (l Lectern) EncodeNBT() map[string]any {
 	m := map[string]any{
@@ -0,0 +1,248 @@
+package block
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// MobSpawner is a cage-like block that spawns entities of a specific type around it while a player is nearby.
+// The entity that it spawns is shown spinning inside the cage.
+type MobSpawner struct {
+	solid
+	transparent
+	sourceWaterDisplacer
+
+	// EntityType is the type of the entities spawned by the mob spawner. The mob spawner does not spawn any
+	// entities if EntityType is nil.
+	EntityType world.EntityType
+	// EntityConfig returns the world.EntityConfig that entities spawned are created with. If nil, entities are
+	// created as if they were decoded from NBT without any data. EntityConfig is not saved with the mob
+	// spawner.
+	EntityConfig func() world.EntityConfig
+	// Delay is the time that the mob spawner waits before it next spawns entities. Once Delay has passed, the
+	// mob spawner spawns entities as soon as a player is within RequiredPlayerRange. The time already waited is
+	// not saved, so a mob spawner that is loaded again waits the full Delay.
+	Delay time.Duration
+	// MinSpawnDelay and MaxSpawnDelay are the minimum and maximum time between two spawns. After spawning,
+	// Delay is set to a random duration between the two. MinSpawnDelay defaults to 10 seconds and MaxSpawnDelay
+	// defaults to 40 seconds.
+	MinSpawnDelay, MaxSpawnDelay time.Duration
+	// SpawnCount is the number of entities that the mob spawner attempts to spawn at once. SpawnCount defaults
+	// to 4.
+	SpawnCount int
+	// MaxNearbyEntities is the maximum number of entities of EntityType around the mob spawner. No entities are
+	// spawned while this many entities are within SpawnRange. MaxNearbyEntities defaults to 6.
+	MaxNearbyEntities int
+	// RequiredPlayerRange is the distance in blocks within which a player must be for the mob spawner to spawn
+	// entities. RequiredPlayerRange defaults to 16.
+	RequiredPlayerRange int
+	// SpawnRange is the maximum horizontal distance in blocks from the mob spawner that entities are spawned at.
+	// SpawnRange defaults to 4.
+	SpawnRange int
+
+	// entityID is the name of the EntityType of a mob spawner decoded from NBT. It is looked up in the
+	// world.EntityRegistry of the world that the mob spawner is in when ticked.
+	entityID string
+	// spawnTick is the world tick at which Delay has passed. It is 0 if the mob spawner has not yet scheduled its
+	// next spawn.
+	spawnTick int64
+}
+
+// BreakInfo ...
+func (s MobSpawner) BreakInfo() BreakInfo {
+	return newBreakInfo(5, pickaxeHarvestable, pickaxeEffective, simpleDrops()).withXPDropRange(15, 43)
+}
+
+// SideClosed ...
+func (MobSpawner) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// Tick spawns entities around the mob spawner once its delay has passed, provided a player is nearby.
+func (s MobSpawner) Tick(currentTick int64, pos cube.Pos, tx *world.Tx) {
+	if s.EntityType == nil && s.entityID != "" {
+		t, ok := tx.World().EntityRegistry().Lookup(s.entityID)
+		if !ok {
+			return
+		}
+		s.EntityType, s.entityID = t, ""
+		tx.SetBlockEntity(pos, s)
+	}
+	if s.EntityType == nil {
+		return
+	}
+	if s.spawnTick == 0 && s.Delay > 0 {
+		// Schedule the next spawn once rather than counting Delay down every tick, so that the block entity
+		// is only written when the schedule changes.
+		s.spawnTick = max(currentTick+int64(s.Delay/(time.Second/20)), 1)
+		tx.SetBlockEntity(pos, s)
+		return
+	}
+	if currentTick < s.spawnTick || !s.playerNearby(pos, tx) {
+		return
+	}
+	if s.spawn(pos, tx) {
+		minDelay, maxDelay := s.withDefaults().MinSpawnDelay, s.withDefaults().MaxSpawnDelay
+		s.Delay = minDelay
+		if maxDelay > minDelay {
+			s.Delay += time.Duration(rand.Int64N(int64(maxDelay - minDelay)))
+		}
+		s.spawnTick = currentTick + int64(s.Delay/(time.Second/20))
+		tx.SetBlockEntity(pos, s)
+	}
+}
+
+// spawn attempts to spawn up to SpawnCount entities at random positions around the mob spawner. spawn returns
+// true if the mob spawner should wait for its delay before spawning again, which is either if any entity was
+// spawned or if there are already too many entities nearby.
+func (s MobSpawner) spawn(pos cube.Pos, tx *world.Tx) bool {
+	conf := s.withDefaults()
+	r := float64(conf.SpawnRange)
+	box := cube.Box(0, 0, 0, 1, 1, 1).Translate(pos.Vec3()).Grow(r)
+
+	nearby := 0
+	for e := range tx.EntitiesWithin(box) {
+		if e.H().Type() == s.EntityType {
+			nearby++
+		}
+	}
+	if nearby >= conf.MaxNearbyEntities {
+		return true
+	}
+	spawned := false
+	for range conf.SpawnCount {
+		vec := mgl64.Vec3{
+			float64(pos[0]) + (rand.Float64()-rand.Float64())*r + 0.5,
+			float64(pos[1] + rand.IntN(3) - 1),
+			float64(pos[2]) + (rand.Float64()-rand.Float64())*r + 0.5,
+		}
+		if !s.spaceFor(cube.PosFromVec3(vec), tx) {
+			continue
+		}
+		handle, ok := s.newEntity(vec, tx)
+		if !ok {
+			return false
+		}
+		tx.AddEntity(handle)
+		tx.AddParticle(vec, particle.MobSpawn{})
+		spawned = true
+
+		if nearby++; nearby >= conf.MaxNearbyEntities {
+			break
+		}
+	}
+	return spawned
+}
+
+// newEntity creates a new entity of the EntityType of the mob spawner at the position passed. If the mob spawner
+// has no EntityConfig, the entity is decoded from NBT using the world.EntityRegistry of the world.
+func (s MobSpawner) newEntity(pos mgl64.Vec3, tx *world.Tx) (*world.EntityHandle, bool) {
+	yaw := rand.Float64() * 360
+	if s.EntityConfig != nil {
+		return world.EntitySpawnOpts{Position: pos, Rotation: cube.Rotation{yaw}}.New(s.EntityType, s.EntityConfig()), true
+	}
+	return tx.World().EntityRegistry().DecodeNBT(map[string]any{
+		"identifier": s.EntityType.EncodeEntity(),
+		"Pos":        nbtconv.Vec3ToFloat32Slice(pos),
+		"Yaw":        float32(yaw),
+	})
+}
+
+// spaceFor checks if an entity fits in the block at the position passed and the block above it.
+func (s MobSpawner) spaceFor(pos cube.Pos, tx *world.Tx) bool {
+	for _, p := range [...]cube.Pos{pos, pos.Side(cube.FaceUp)} {
+		if p.OutOfBounds(tx.Range()) || len(tx.Block(p).Model().BBox(p, tx)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// playerNearby checks if a player that is not a spectator is within RequiredPlayerRange of the mob spawner.
+func (s MobSpawner) playerNearby(pos cube.Pos, tx *world.Tx) bool {
+	r := float64(s.withDefaults().RequiredPlayerRange)
+	centre := pos.Vec3Centre()
+	for e := range tx.EntitiesWithin(cube.Box(-r, -r, -r, r, r, r).Translate(centre)) {
+		if g, ok := e.(interface{ GameMode() world.GameMode }); ok && g.GameMode().Visible() && e.Position().Sub(centre).Len() <= r {
+			return true
+		}
+	}
+	return false
+}
+
+// withDefaults returns the mob spawner with the defaults applied to all spawn parameters that are not set.
+func (s MobSpawner) withDefaults() MobSpawner {
+	if s.MinSpawnDelay <= 0 {
+		s.MinSpawnDelay = time.Second * 10
+	}
+	if s.MaxSpawnDelay <= 0 {
+		s.MaxSpawnDelay = time.Second * 40
+	}
+	if s.SpawnCount <= 0 {
+		s.SpawnCount = 4
+	}
+	if s.MaxNearbyEntities <= 0 {
+		s.MaxNearbyEntities = 6
+	}
+	if s.RequiredPlayerRange <= 0 {
+		s.RequiredPlayerRange = 16
+	}
+	if s.SpawnRange <= 0 {
+		s.SpawnRange = 4
+	}
+	return s
+}
+
+// DecodeNBT ...
+func (s MobSpawner) DecodeNBT(data map[string]any) any {
+	s.EntityType, s.entityID = nil, nbtconv.String(data, "EntityIdentifier")
+	s.Delay, s.spawnTick = nbtconv.TickDuration[int16](data, "Delay"), 0
+	s.MinSpawnDelay = nbtconv.TickDuration[int16](data, "MinSpawnDelay")
+	s.MaxSpawnDelay = nbtconv.TickDuration[int16](data, "MaxSpawnDelay")
+	s.SpawnCount = int(nbtconv.Int16(data, "SpawnCount"))
+	s.MaxNearbyEntities = int(nbtconv.Int16(data, "MaxNearbyEntities"))
+	s.RequiredPlayerRange = int(nbtconv.Int16(data, "RequiredPlayerRange"))
+	s.SpawnRange = int(nbtconv.Int16(data, "SpawnRange"))
+	return s
+}
+
+// EncodeNBT ...
+func (s MobSpawner) EncodeNBT() map[string]any {
+	conf := s.withDefaults()
+	m := map[string]any{
+		"id":                  "MobSpawner",
+		"Delay":               int16(min(s.Delay/(time.Second/20), math.MaxInt16)),
+		"MinSpawnDelay":       int16(min(conf.MinSpawnDelay/(time.Second/20), math.MaxInt16)),
+		"MaxSpawnDelay":       int16(min(conf.MaxSpawnDelay/(time.Second/20), math.MaxInt16)),
+		"SpawnCount":          int16(conf.SpawnCount),
+		"MaxNearbyEntities":   int16(conf.MaxNearbyEntities),
+		"RequiredPlayerRange": int16(conf.RequiredPlayerRange),
+		"SpawnRange":          int16(conf.SpawnRange),
+		"DisplayEntityWidth":  float32(1),
+		"DisplayEntityHeight": float32(1),
+		"DisplayEntityScale":  float32(1),
+	}
+	if s.EntityType != nil {
+		m["EntityIdentifier"] = s.EntityType.EncodeEntity()
+	} else if s.entityID != "" {
+		m["EntityIdentifier"] = s.entityID
+	}
+	return m
+}
+
+// EncodeItem ...
+func (MobSpawner) EncodeItem() (name string, meta int16) {
+	return "minecraft:mob_spawner", 0
+}
+
+// EncodeBlock ...
+func (MobSpawner) EncodeBlock() (string, map[string]any) {
+	return "minecraft:mob_spawner", nil
+}
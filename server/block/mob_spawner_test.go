@@ -0,0 +1,184 @@
+package block_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// TestMobSpawnerSpawnsNearPlayer verifies that a mob spawner only spawns entities while a player is within range
+// and waits for a new delay after spawning.
+func TestMobSpawnerSpawnsNearPlayer(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 10, 0}
+	w.Do(func(tx *world.Tx) {
+		tx.SetBlock(pos, block.MobSpawner{EntityType: entity.ZombieType}, nil)
+	})
+	w.AdvanceTick()
+	if n := countEntities(t, w, entity.ZombieType); n != 0 {
+		t.Fatalf("spawner without player nearby spawned %v zombies, want 0", n)
+	}
+
+	w.Do(func(tx *world.Tx) {
+		tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{0, 10, 20}}.New(spawnerTestPlayerType{}, spawnerTestPlayerConfig{}))
+	})
+	w.AdvanceTick()
+	if n := countEntities(t, w, entity.ZombieType); n != 0 {
+		t.Fatalf("spawner with player 20 blocks away spawned %v zombies, want 0", n)
+	}
+
+	w.Do(func(tx *world.Tx) {
+		tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{0, 10, 8}}.New(spawnerTestPlayerType{}, spawnerTestPlayerConfig{}))
+	})
+	w.AdvanceTick()
+	if n := countEntities(t, w, entity.ZombieType); n == 0 || n > 4 {
+		t.Fatalf("spawner with player nearby spawned %v zombies, want 1 to 4", n)
+	}
+	s := spawnerAt(t, w, pos)
+	if s.Delay < time.Second*10 || s.Delay > time.Second*40 {
+		t.Errorf("spawner delay after spawning = %v, want between 10s and 40s", s.Delay)
+	}
+}
+
+// TestMobSpawnerDelayWithoutPlayer verifies that the delay of a mob spawner passes while no player is nearby and
+// that the mob spawner spawns as soon as a player comes near afterwards.
+func TestMobSpawnerDelayWithoutPlayer(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 10, 0}
+	w.Do(func(tx *world.Tx) {
+		tx.SetBlock(pos, block.MobSpawner{EntityType: entity.ZombieType, Delay: time.Second / 10}, nil)
+	})
+	for range 4 {
+		w.AdvanceTick()
+	}
+	if s := spawnerAt(t, w, pos); s.Delay != time.Second/10 {
+		t.Errorf("spawner delay while waiting = %v, want %v", s.Delay, time.Second/10)
+	}
+
+	w.Do(func(tx *world.Tx) {
+		tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{3, 10, 3}}.New(spawnerTestPlayerType{}, spawnerTestPlayerConfig{}))
+	})
+	w.AdvanceTick()
+	if n := countEntities(t, w, entity.ZombieType); n == 0 {
+		t.Fatalf("spawner with passed delay spawned no zombies after a player came nearby")
+	}
+}
+
+// TestMobSpawnerFromItemNBT verifies that a mob spawner configured through the NBT of an item stack spawns the
+// entity type stored in the NBT using its spawn parameters.
+func TestMobSpawnerFromItemNBT(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	defer w.Close()
+
+	stack := item.ReadNBT(map[string]any{
+		"Name":  "minecraft:mob_spawner",
+		"Count": uint8(1),
+		"tag": map[string]any{
+			"EntityIdentifier": "minecraft:creeper",
+			"SpawnCount":       int16(1),
+			"MinSpawnDelay":    int16(100),
+			"MaxSpawnDelay":    int16(100),
+		},
+	}, nil)
+	spawner, ok := stack.Item().(block.MobSpawner)
+	if !ok {
+		t.Fatalf("item decoded from NBT = %#v, want block.MobSpawner", stack.Item())
+	}
+
+	pos := cube.Pos{0, 10, 0}
+	w.Do(func(tx *world.Tx) {
+		tx.SetBlock(pos, spawner, nil)
+		tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{3, 10, 3}}.New(spawnerTestPlayerType{}, spawnerTestPlayerConfig{}))
+	})
+	w.AdvanceTick()
+	if n := countEntities(t, w, entity.CreeperType); n != 1 {
+		t.Fatalf("spawner spawned %v creepers, want 1", n)
+	}
+	s := spawnerAt(t, w, pos)
+	if s.EntityType != entity.CreeperType {
+		t.Errorf("spawner entity type = %v, want creeper", s.EntityType)
+	}
+	if s.Delay != time.Second*5 {
+		t.Errorf("spawner delay after spawning = %v, want 5s", s.Delay)
+	}
+	if id := s.EncodeNBT()["EntityIdentifier"]; id != "minecraft:creeper" {
+		t.Errorf("encoded entity identifier = %v, want minecraft:creeper", id)
+	}
+}
+
+func spawnerAt(t *testing.T, w *world.World, pos cube.Pos) block.MobSpawner {
+	t.Helper()
+	b, err := world.Call(context.Background(), w, func(tx *world.Tx) (world.Block, error) {
+		return tx.Block(pos), nil
+	})
+	if err != nil {
+		t.Fatalf("read spawner block: %v", err)
+	}
+	s, ok := b.(block.MobSpawner)
+	if !ok {
+		t.Fatalf("block at %v = %v, want mob spawner", pos, b)
+	}
+	return s
+}
+
+func countEntities(t *testing.T, w *world.World, typ world.EntityType) int {
+	t.Helper()
+	n, err := world.Call(context.Background(), w, func(tx *world.Tx) (int, error) {
+		n := 0
+		for e := range tx.Entities() {
+			if e.H().Type() == typ {
+				n++
+			}
+		}
+		return n, nil
+	})
+	if err != nil {
+		t.Fatalf("count entities: %v", err)
+	}
+	return n
+}
+
+type spawnerTestPlayerConfig struct{}
+
+func (spawnerTestPlayerConfig) Apply(*world.EntityData) {}
+
+// spawnerTestPlayerType is the type of an entity with a game mode, which mob spawners treat as a player.
+type spawnerTestPlayerType struct{}
+
+func (spawnerTestPlayerType) Open(_ *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return spawnerTestPlayer{handle: handle, data: data}
+}
+
+func (spawnerTestPlayerType) EncodeEntity() string { return "test:player" }
+func (spawnerTestPlayerType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.8, 0.3)
+}
+func (spawnerTestPlayerType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (spawnerTestPlayerType) EncodeNBT(*world.EntityData) map[string]any {
+	return nil
+}
+
+type spawnerTestPlayer struct {
+	handle *world.EntityHandle
+	data   *world.EntityData
+}
+
+func (e spawnerTestPlayer) Close() error            { return nil }
+func (e spawnerTestPlayer) H() *world.EntityHandle  { return e.handle }
+func (e spawnerTestPlayer) Position() mgl64.Vec3    { return e.data.Pos }
+func (e spawnerTestPlayer) Rotation() cube.Rotation { return e.data.Rot }
+
+func (spawnerTestPlayer) GameMode() world.GameMode {
+	return world.GameModeSurvival
+}
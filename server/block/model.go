@@ -38,6 +38,14 @@ func (carpet) Model() world.BlockModel {
 	return model.Carpet{}
 }
 
+// diode represents a block that has a model of a redstone repeater or comparator.
+type diode struct{}
+
+// Model ...
+func (diode) Model() world.BlockModel {
+	return model.Diode{}
+}
+
 // tilledGrass represents a block that has a model of farmland or dirt paths.
 type tilledGrass struct{}
 
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Diode is a model used by redstone repeaters and comparators.
+type Diode struct{}
+
+// BBox returns a flat BBox with a height of 0.125.
+func (Diode) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	return []cube.BBox{cube.Box(0, 0, 0, 1, 0.125, 1)}
+}
+
+// FaceSolid always returns false.
+func (Diode) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return false
+}
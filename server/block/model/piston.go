@@ -0,0 +1,49 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Piston is the model of a piston and a sticky piston. When extended, the base of the piston is only 0.75 blocks
+// deep, as the remaining part of the block is filled by the arm of the piston.
+type Piston struct {
+	// Facing is the face that the piston pushes blocks towards.
+	Facing cube.Face
+	// Extended specifies if the arm of the piston is extended.
+	Extended bool
+}
+
+// BBox returns a full BBox if the piston is not extended, or a BBox with a depth of 0.75 if it is.
+func (p Piston) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	if p.Extended {
+		return []cube.BBox{full.ExtendTowards(p.Facing, -0.25)}
+	}
+	return []cube.BBox{full}
+}
+
+// FaceSolid returns true for all faces if the piston is not extended. Otherwise, only the back of the piston is
+// solid.
+func (p Piston) FaceSolid(_ cube.Pos, face cube.Face, _ world.BlockSource) bool {
+	return !p.Extended || face == p.Facing.Opposite()
+}
+
+// PistonArm is the model of the arm of an extended piston. It consists of the head of the arm and the rod that
+// connects it to the base of the piston.
+type PistonArm struct {
+	// Facing is the face that the piston of the arm pushes blocks towards.
+	Facing cube.Face
+}
+
+// BBox returns the BBoxes of the head and the rod of the arm.
+func (p PistonArm) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	return []cube.BBox{
+		full.ExtendTowards(p.Facing.Opposite(), -0.75),
+		cube.Box(0.375, 0.375, 0.375, 0.625, 0.625, 0.625).Stretch(p.Facing.Axis(), 0.375),
+	}
+}
+
+// FaceSolid only returns true for the face of the head of the arm.
+func (p PistonArm) FaceSolid(_ cube.Pos, face cube.Face, _ world.BlockSource) bool {
+	return face == p.Facing
+}
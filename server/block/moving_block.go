@@ -0,0 +1,74 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// MovingBlock is a block that is being moved by a piston. It holds the block that is moved until the piston
+// finishes moving, after which the moving block is replaced by the block it holds. Moving blocks cannot be obtained
+// or broken.
+type MovingBlock struct {
+	empty
+	transparent
+
+	// Block is the block that is being moved.
+	Block world.Block
+	// PistonPos is the position of the piston that is moving the block.
+	PistonPos cube.Pos
+}
+
+// Tick places the block moved if the piston moving it is no longer moving, for example because it was broken.
+func (b MovingBlock) Tick(_ int64, pos cube.Pos, tx *world.Tx) {
+	if p, _, ok := pistonAt(b.PistonPos, tx); ok && p.moving() {
+		return
+	}
+	b.finish(pos, tx)
+}
+
+// finish replaces the moving block with the block it holds.
+func (b MovingBlock) finish(pos cube.Pos, tx *world.Tx) {
+	tx.SetBlock(pos, b.Block, nil)
+}
+
+// EncodeBlock ...
+func (MovingBlock) EncodeBlock() (string, map[string]any) {
+	return "minecraft:moving_block", nil
+}
+
+// EncodeNBT ...
+func (b MovingBlock) EncodeNBT() map[string]any {
+	var inner world.Block = Air{}
+	if b.Block != nil {
+		inner = b.Block
+	}
+	data := map[string]any{
+		"id":               "MovingBlock",
+		"movingBlock":      nbtconv.WriteBlock(inner),
+		"movingBlockExtra": nbtconv.WriteBlock(Air{}),
+		"pistonPosX":       int32(b.PistonPos[0]),
+		"pistonPosY":       int32(b.PistonPos[1]),
+		"pistonPosZ":       int32(b.PistonPos[2]),
+	}
+	if nbter, ok := inner.(world.NBTer); ok {
+		data["movingEntity"] = nbter.EncodeNBT()
+	}
+	return data
+}
+
+// DecodeNBT ...
+func (b MovingBlock) DecodeNBT(data map[string]any) any {
+	b.Block = nbtconv.Block(data, "movingBlock")
+	if nbter, ok := b.Block.(world.NBTer); ok {
+		if entity, ok := data["movingEntity"].(map[string]any); ok {
+			b.Block = nbter.DecodeNBT(entity).(world.Block)
+		}
+	}
+	b.PistonPos = cube.Pos{
+		int(nbtconv.Int32(data, "pistonPosX")),
+		int(nbtconv.Int32(data, "pistonPosY")),
+		int(nbtconv.Int32(data, "pistonPosZ")),
+	}
+	return b
+}
@@ -0,0 +1,107 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var (
+	_ world.RedstoneStrongPowerSource = Observer{}
+	_ world.NeighbourStateObserver    = Observer{}
+	_ world.ScheduledTicker           = Observer{}
+	_ world.RedstoneNonConductive     = Observer{}
+	_ RedstoneWireConnector           = Observer{}
+)
+
+// Observer is a block that emits a short redstone pulse out of its back when the block in front of it changes its
+// block state.
+type Observer struct {
+	solid
+
+	// Facing is the face of the observer that watches the block in front of it. The observer emits power out of the
+	// opposite face.
+	Facing cube.Face
+	// Powered is true while the observer is emitting a pulse.
+	Powered bool
+}
+
+// BreakInfo ...
+func (o Observer) BreakInfo() BreakInfo {
+	return newBreakInfo(3, pickaxeHarvestable, pickaxeEffective, oneOf(Observer{}))
+}
+
+// UseOnBlock ...
+func (o Observer) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, o)
+	if !used {
+		return false
+	}
+	place(tx, pos, Observer{Facing: calculateFace(user, pos).Opposite()}, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourStateChange schedules a pulse one redstone tick after the block in front of the observer changed.
+func (o Observer) NeighbourStateChange(pos, changedNeighbour cube.Pos, tx *world.Tx) {
+	if changedNeighbour != pos.Side(o.Facing) || o.Powered {
+		return
+	}
+	tx.ScheduleBlockUpdate(pos, o, redstoneTicks(1))
+}
+
+// ScheduledTick starts the pulse of the observer, or ends it one redstone tick after it started.
+func (o Observer) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if tx == nil {
+		return
+	}
+	var ok bool
+	if o, ok = tx.Block(pos).(Observer); !ok {
+		return
+	}
+	o.Powered = !o.Powered
+	tx.SetBlock(pos, o, nil)
+	if o.Powered {
+		tx.ScheduleBlockUpdate(pos, o, redstoneTicks(1))
+	}
+}
+
+// RedstonePower emits full power out of the back of the observer while it is powered.
+func (o Observer) RedstonePower(_ cube.Pos, _ *world.Tx, face cube.Face) int {
+	if o.Powered && face == o.Facing.Opposite() {
+		return 15
+	}
+	return 0
+}
+
+// RedstoneStrongPower strongly powers the block behind the observer while it is powered.
+func (o Observer) RedstoneStrongPower(pos cube.Pos, tx *world.Tx, face cube.Face) int {
+	return o.RedstonePower(pos, tx, face)
+}
+
+// ConnectsRedstoneWire only connects redstone wire to the back of the observer.
+func (o Observer) ConnectsRedstoneWire(_ cube.Pos, face cube.Face, _ *world.Tx) bool {
+	return face == o.Facing.Opposite()
+}
+
+// RedstoneNonConductive ...
+func (Observer) RedstoneNonConductive() {}
+
+// EncodeItem ...
+func (Observer) EncodeItem() (name string, meta int16) {
+	return "minecraft:observer", 0
+}
+
+// EncodeBlock ...
+func (o Observer) EncodeBlock() (string, map[string]any) {
+	return "minecraft:observer", map[string]any{"minecraft:facing_direction": o.Facing.String(), "powered_bit": boolByte(o.Powered)}
+}
+
+func allObservers() (observers []world.Block) {
+	for _, f := range cube.Faces() {
+		observers = append(observers, Observer{Facing: f}, Observer{Facing: f, Powered: true})
+	}
+	return
+}
@@ -22,6 +22,9 @@ func (o Obsidian) LightEmissionLevel() uint8 {
 	return 0
 }
 
+// PistonImmovable ...
+func (Obsidian) PistonImmovable() {}
+
 // EncodeItem ...
 func (o Obsidian) EncodeItem() (name string, meta int16) {
 	if o.Crying {
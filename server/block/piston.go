@@ -0,0 +1,544 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var (
+	_ world.RedstonePowerConsumer    = Piston{}
+	_ world.RedstonePowerPostUpdater = Piston{}
+	_ world.TickerBlock              = Piston{}
+	_ world.RedstoneNonConductive    = Piston{}
+)
+
+// Piston is a block that pushes up to 12 blocks in front of it when it is powered by redstone. It retracts its arm
+// again when it is no longer powered.
+type Piston struct {
+	// Facing is the face that the piston pushes blocks towards.
+	Facing cube.Face
+	// Extended is true if the arm of the piston is extended or extending.
+	Extended bool
+	// Progress is the progress of the arm of the piston, ranging from 0 (retracted) to 1 (extended). The piston is
+	// moving while its Progress does not yet match its Extended state.
+	Progress float64
+
+	// attached holds the positions of the blocks moved by the piston while it is moving.
+	attached []cube.Pos
+}
+
+// Model ...
+func (p Piston) Model() world.BlockModel {
+	return model.Piston{Facing: p.Facing, Extended: p.Extended}
+}
+
+// BreakInfo ...
+func (p Piston) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, pickaxeEffective, oneOf(Piston{})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, _ item.User) {
+		pistonBase(p).broken(pos, tx)
+	})
+}
+
+// UseOnBlock ...
+func (p Piston) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, p)
+	if !used {
+		return false
+	}
+	place(tx, pos, Piston{Facing: calculateFace(user, pos)}, user, ctx)
+	return placed(ctx)
+}
+
+// RedstonePowerUpdate extends the piston when it becomes powered and retracts it when it is no longer powered.
+func (p Piston) RedstonePowerUpdate(pos cube.Pos, tx *world.Tx, _ int) (world.Block, bool) {
+	b, changed := pistonBase(p).powerUpdate(pos, tx)
+	return b.block(false), changed
+}
+
+// RedstonePowerPostUpdate moves the blocks in front of the piston after it started extending or retracting.
+func (p Piston) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, _, after world.Block, _, _ int) {
+	if after, ok := after.(Piston); ok {
+		pistonBase(after).move(pos, tx, false)
+	}
+}
+
+// NeighbourUpdateTick schedules a redstone update for the piston if its state does not match its power, for example
+// because blocks that previously prevented it from extending were removed.
+func (p Piston) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	pistonBase(p).neighbourUpdate(pos, tx)
+}
+
+// Tick moves the arm of the piston while it is extending or retracting.
+func (p Piston) Tick(_ int64, pos cube.Pos, tx *world.Tx) {
+	pistonBase(p).tick(pos, tx, false)
+}
+
+// RedstoneNonConductive ...
+func (Piston) RedstoneNonConductive() {}
+
+// EncodeItem ...
+func (Piston) EncodeItem() (name string, meta int16) {
+	return "minecraft:piston", 0
+}
+
+// EncodeBlock ...
+func (p Piston) EncodeBlock() (string, map[string]any) {
+	return "minecraft:piston", map[string]any{"facing_direction": pistonFacingDirection(p.Facing)}
+}
+
+// EncodeNBT ...
+func (p Piston) EncodeNBT() map[string]any {
+	return pistonBase(p).encodeNBT(false)
+}
+
+// DecodeNBT ...
+func (p Piston) DecodeNBT(data map[string]any) any {
+	return pistonBase(p).decodeNBT(data).block(false)
+}
+
+// StickyPiston is a variant of the Piston that pulls the block in front of it back when it retracts.
+type StickyPiston struct {
+	// Facing is the face that the piston pushes blocks towards.
+	Facing cube.Face
+	// Extended is true if the arm of the piston is extended or extending.
+	Extended bool
+	// Progress is the progress of the arm of the piston, ranging from 0 (retracted) to 1 (extended). The piston is
+	// moving while its Progress does not yet match its Extended state.
+	Progress float64
+
+	// attached holds the positions of the blocks moved by the piston while it is moving.
+	attached []cube.Pos
+}
+
+// Model ...
+func (p StickyPiston) Model() world.BlockModel {
+	return model.Piston{Facing: p.Facing, Extended: p.Extended}
+}
+
+// BreakInfo ...
+func (p StickyPiston) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, pickaxeEffective, oneOf(StickyPiston{})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, _ item.User) {
+		pistonBase(p).broken(pos, tx)
+	})
+}
+
+// UseOnBlock ...
+func (p StickyPiston) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, p)
+	if !used {
+		return false
+	}
+	place(tx, pos, StickyPiston{Facing: calculateFace(user, pos)}, user, ctx)
+	return placed(ctx)
+}
+
+// RedstonePowerUpdate extends the piston when it becomes powered and retracts it when it is no longer powered.
+func (p StickyPiston) RedstonePowerUpdate(pos cube.Pos, tx *world.Tx, _ int) (world.Block, bool) {
+	b, changed := pistonBase(p).powerUpdate(pos, tx)
+	return b.block(true), changed
+}
+
+// RedstonePowerPostUpdate moves the blocks in front of the piston after it started extending or retracting.
+func (p StickyPiston) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, _, after world.Block, _, _ int) {
+	if after, ok := after.(StickyPiston); ok {
+		pistonBase(after).move(pos, tx, true)
+	}
+}
+
+// NeighbourUpdateTick schedules a redstone update for the piston if its state does not match its power, for example
+// because blocks that previously prevented it from extending were removed.
+func (p StickyPiston) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	pistonBase(p).neighbourUpdate(pos, tx)
+}
+
+// Tick moves the arm of the piston while it is extending or retracting.
+func (p StickyPiston) Tick(_ int64, pos cube.Pos, tx *world.Tx) {
+	pistonBase(p).tick(pos, tx, true)
+}
+
+// RedstoneNonConductive ...
+func (StickyPiston) RedstoneNonConductive() {}
+
+// EncodeItem ...
+func (StickyPiston) EncodeItem() (name string, meta int16) {
+	return "minecraft:sticky_piston", 0
+}
+
+// EncodeBlock ...
+func (p StickyPiston) EncodeBlock() (string, map[string]any) {
+	return "minecraft:sticky_piston", map[string]any{"facing_direction": pistonFacingDirection(p.Facing)}
+}
+
+// EncodeNBT ...
+func (p StickyPiston) EncodeNBT() map[string]any {
+	return pistonBase(p).encodeNBT(true)
+}
+
+// DecodeNBT ...
+func (p StickyPiston) DecodeNBT(data map[string]any) any {
+	return pistonBase(p).decodeNBT(data).block(true)
+}
+
+// pistonBase holds the state shared by Piston and StickyPiston. Both types may be converted to a pistonBase to
+// share their behaviour.
+type pistonBase struct {
+	Facing   cube.Face
+	Extended bool
+	Progress float64
+
+	attached []cube.Pos
+}
+
+// pistonMaxPush is the maximum amount of blocks that a piston can move at once.
+const pistonMaxPush = 12
+
+// pistonAt returns the piston at the position passed, if any, and whether it is a sticky piston.
+func pistonAt(pos cube.Pos, tx *world.Tx) (p pistonBase, sticky bool, ok bool) {
+	switch b := tx.Block(pos).(type) {
+	case Piston:
+		return pistonBase(b), false, true
+	case StickyPiston:
+		return pistonBase(b), true, true
+	}
+	return pistonBase{}, false, false
+}
+
+// block returns the Piston or StickyPiston holding the state of p.
+func (p pistonBase) block(sticky bool) world.Block {
+	if sticky {
+		return StickyPiston(p)
+	}
+	return Piston(p)
+}
+
+// moving reports whether the arm of the piston is still extending or retracting.
+func (p pistonBase) moving() bool {
+	if p.Extended {
+		return p.Progress < 1
+	}
+	return p.Progress > 0
+}
+
+// powered reports whether the piston receives power from any of its faces other than its front.
+func (p pistonBase) powered(pos cube.Pos, tx *world.Tx) bool {
+	for _, face := range cube.Faces() {
+		if face != p.Facing && tx.RedstonePowerFrom(pos, face) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// powerUpdate returns the state of the piston after a change in redstone power. Pistons ignore power while they are
+// moving, and do not extend if the blocks in front of them cannot be pushed.
+func (p pistonBase) powerUpdate(pos cube.Pos, tx *world.Tx) (pistonBase, bool) {
+	if p.moving() {
+		return p, false
+	}
+	powered := p.powered(pos, tx)
+	if powered == p.Extended {
+		return p, false
+	}
+	if powered {
+		if _, ok := resolvePistonStructure(pos, p.Facing, true, tx); !ok {
+			return p, false
+		}
+	}
+	p.Extended = powered
+	return p, true
+}
+
+// move moves the blocks in front of the piston after it started extending or retracting, and places or removes the
+// arm of the piston.
+func (p pistonBase) move(pos cube.Pos, tx *world.Tx, sticky bool) {
+	head := pos.Side(p.Facing)
+	var s pistonStructure
+	if p.Extended {
+		var ok bool
+		if s, ok = resolvePistonStructure(pos, p.Facing, true, tx); !ok {
+			// The blocks in front of the piston changed after it started extending, so it can no longer extend.
+			p.Extended = false
+			tx.SetBlock(pos, p.block(sticky), &world.SetOpts{DisableRedstoneUpdates: true})
+			return
+		}
+		p.attached = s.move(pos, p.Facing, tx)
+		tx.SetBlock(head, PistonArm{Facing: p.Facing, Sticky: sticky}, nil)
+		tx.PlaySound(pos.Vec3Centre(), sound.PistonExtend{})
+		for _, v := range tx.Viewers(pos.Vec3()) {
+			v.ViewBlockAction(pos, PistonExtendAction{})
+		}
+		pistonPushEntities(append([]cube.Pos{head}, p.attached...), p.Facing, tx)
+	} else {
+		if arm, ok := tx.Block(head).(PistonArm); ok && arm.Facing == p.Facing {
+			tx.SetBlock(head, nil, nil)
+		}
+		if sticky {
+			if s, ok := resolvePistonStructure(pos, p.Facing, false, tx); ok {
+				p.attached = s.move(pos, p.Facing.Opposite(), tx)
+			}
+		}
+		tx.PlaySound(pos.Vec3Centre(), sound.PistonRetract{})
+		for _, v := range tx.Viewers(pos.Vec3()) {
+			v.ViewBlockAction(pos, PistonRetractAction{})
+		}
+		pistonPushEntities(p.attached, p.Facing.Opposite(), tx)
+	}
+	tx.SetBlock(pos, p.block(sticky), &world.SetOpts{DisableRedstoneUpdates: true})
+}
+
+// tick advances the arm of the piston while it is moving. Once the arm reaches its destination, the blocks moved are
+// placed and the power of the piston is checked again.
+func (p pistonBase) tick(pos cube.Pos, tx *world.Tx, sticky bool) {
+	if !p.moving() {
+		return
+	}
+	if p.Extended {
+		p.Progress = min(p.Progress+0.5, 1)
+	} else {
+		p.Progress = max(p.Progress-0.5, 0)
+	}
+	if !p.moving() {
+		p.finish(pos, tx)
+		p.attached = nil
+	}
+	tx.SetBlock(pos, p.block(sticky), &world.SetOpts{DisableBlockUpdates: true, DisableRedstoneUpdates: true})
+	if !p.moving() {
+		// The power of the piston may have changed while it was moving, which is ignored until the arm stops moving.
+		tx.Redstone().ScheduleUpdate(pos)
+	}
+}
+
+// finish places the blocks moved by the piston at their destination.
+func (p pistonBase) finish(pos cube.Pos, tx *world.Tx) {
+	for _, attachedPos := range p.attached {
+		if b, ok := tx.Block(attachedPos).(MovingBlock); ok && b.PistonPos == pos {
+			b.finish(attachedPos, tx)
+		}
+	}
+}
+
+// neighbourUpdate schedules a redstone update for the piston if it is not moving and its state does not match the
+// power it receives.
+func (p pistonBase) neighbourUpdate(pos cube.Pos, tx *world.Tx) {
+	if !p.moving() && p.powered(pos, tx) != p.Extended {
+		tx.Redstone().ScheduleUpdate(pos)
+	}
+}
+
+// broken places the blocks moved by the piston and removes its arm after the piston was broken.
+func (p pistonBase) broken(pos cube.Pos, tx *world.Tx) {
+	p.finish(pos, tx)
+	head := pos.Side(p.Facing)
+	if arm, ok := tx.Block(head).(PistonArm); ok && arm.Facing == p.Facing {
+		tx.SetBlock(head, nil, nil)
+	}
+}
+
+// encodeNBT encodes the state of the piston to the PistonArm block entity data used by Bedrock Edition.
+func (p pistonBase) encodeNBT(sticky bool) map[string]any {
+	state, newState, last := uint8(0), uint8(0), p.Progress
+	switch {
+	case p.Extended && p.moving():
+		state, newState, last = 1, 2, max(p.Progress-0.5, 0)
+	case p.Extended:
+		state, newState = 2, 2
+	case p.moving():
+		state, newState, last = 3, 0, min(p.Progress+0.5, 1)
+	}
+	attached := make([]int32, 0, len(p.attached)*3)
+	for _, pos := range p.attached {
+		attached = append(attached, int32(pos[0]), int32(pos[1]), int32(pos[2]))
+	}
+	return map[string]any{
+		"id":             "PistonArm",
+		"Progress":       float32(p.Progress),
+		"LastProgress":   float32(last),
+		"State":          state,
+		"NewState":       newState,
+		"Sticky":         boolByte(sticky),
+		"AttachedBlocks": attached,
+		"BreakBlocks":    []int32{},
+		"isMovable":      uint8(1),
+	}
+}
+
+// decodeNBT decodes the PistonArm block entity data of a piston.
+func (p pistonBase) decodeNBT(data map[string]any) pistonBase {
+	p.Progress = min(max(float64(nbtconv.Float32(data, "Progress")), 0), 1)
+	switch nbtconv.Uint8(data, "State") {
+	case 1, 2:
+		p.Extended = true
+	default:
+		p.Extended = false
+	}
+	p.attached = nil
+	switch attached := data["AttachedBlocks"].(type) {
+	case []int32:
+		for i := 0; i+2 < len(attached); i += 3 {
+			p.attached = append(p.attached, cube.Pos{int(attached[i]), int(attached[i+1]), int(attached[i+2])})
+		}
+	case []any:
+		for i := 0; i+2 < len(attached); i += 3 {
+			x, _ := attached[i].(int32)
+			y, _ := attached[i+1].(int32)
+			z, _ := attached[i+2].(int32)
+			p.attached = append(p.attached, cube.Pos{int(x), int(y), int(z)})
+		}
+	}
+	return p
+}
+
+// pistonFacingDirection returns the facing_direction block state of a piston or piston arm facing the face passed.
+// Bedrock Edition uses the opposite of horizontal faces for pistons.
+func pistonFacingDirection(face cube.Face) int32 {
+	if face.Axis() == cube.Y {
+		return int32(face)
+	}
+	return int32(face.Opposite())
+}
+
+// pistonStructure holds the blocks affected by a piston extending or retracting.
+type pistonStructure struct {
+	// moved holds the positions of the blocks that are moved.
+	moved []cube.Pos
+	// destroyed holds the positions of the blocks that are destroyed because they are in the way of the blocks
+	// moved.
+	destroyed []cube.Pos
+}
+
+// resolvePistonStructure resolves the blocks moved by a piston at pos facing the face passed when it extends or, if
+// extend is false, the blocks pulled by a sticky piston when it retracts. Slime blocks moved also move the blocks
+// attached to them. False is returned if a block that must be moved cannot be moved, or if more than 12 blocks would
+// be moved.
+func resolvePistonStructure(pos cube.Pos, facing cube.Face, extend bool, tx *world.Tx) (pistonStructure, bool) {
+	type entry struct {
+		pos cube.Pos
+		// required specifies if the block at pos must be moved for the piston to be able to move.
+		required bool
+	}
+	dir, queue := facing, []entry{{pos: pos.Side(facing), required: true}}
+	if !extend {
+		dir, queue = facing.Opposite(), []entry{{pos: pos.Side(facing).Side(facing)}}
+	}
+
+	var s pistonStructure
+	handled := make(map[cube.Pos]struct{})
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+		if _, ok := handled[e.pos]; ok || e.pos == pos {
+			continue
+		}
+		if e.pos.OutOfBounds(tx.Range()) {
+			if e.required {
+				return s, false
+			}
+			continue
+		}
+		b := tx.Block(e.pos)
+		if _, ok := b.(Air); ok {
+			continue
+		}
+		if _, ok := b.(world.Liquid); ok {
+			continue
+		}
+		if _, ok := b.(LiquidRemovable); ok {
+			if e.required {
+				handled[e.pos] = struct{}{}
+				s.destroyed = append(s.destroyed, e.pos)
+			}
+			continue
+		}
+		if pistonImmovable(b) {
+			if e.required {
+				return s, false
+			}
+			continue
+		}
+		handled[e.pos] = struct{}{}
+		s.moved = append(s.moved, e.pos)
+		if len(s.moved) > pistonMaxPush || e.pos.Side(dir).OutOfBounds(tx.Range()) {
+			return s, false
+		}
+		queue = append(queue, entry{pos: e.pos.Side(dir), required: true})
+		if _, ok := b.(Slime); ok {
+			for _, face := range cube.Faces() {
+				if face != dir {
+					queue = append(queue, entry{pos: e.pos.Side(face)})
+				}
+			}
+		}
+	}
+	return s, true
+}
+
+// move destroys the blocks in the way of the structure and moves the blocks of the structure one block towards dir.
+// The blocks moved are replaced with MovingBlocks until the piston at pos finishes moving. The positions of these
+// MovingBlocks are returned.
+func (s pistonStructure) move(pos cube.Pos, dir cube.Face, tx *world.Tx) []cube.Pos {
+	for _, destroyedPos := range s.destroyed {
+		b := tx.Block(destroyedPos)
+		if r, ok := b.(LiquidRemovable); ok && r.HasLiquidDrops() {
+			breakBlock(b, destroyedPos, tx)
+			continue
+		}
+		breakBlockNoDrops(b, destroyedPos, tx)
+	}
+	blocks := make([]world.Block, len(s.moved))
+	for i, movedPos := range s.moved {
+		blocks[i] = tx.Block(movedPos)
+		tx.SetBlock(movedPos, nil, nil)
+	}
+	attached := make([]cube.Pos, len(s.moved))
+	for i, movedPos := range s.moved {
+		attached[i] = movedPos.Side(dir)
+		tx.SetBlock(attached[i], MovingBlock{Block: blocks[i], PistonPos: pos}, nil)
+	}
+	return attached
+}
+
+// pistonImmovable reports whether a piston is unable to move the block passed.
+func pistonImmovable(b world.Block) bool {
+	switch b := b.(type) {
+	case Piston:
+		return b.Extended || pistonBase(b).moving()
+	case StickyPiston:
+		return b.Extended || pistonBase(b).moving()
+	case PistonArm, MovingBlock, PistonImmovable, world.NBTer:
+		return true
+	}
+	breakable, ok := b.(Breakable)
+	return !ok || breakable.BreakInfo().Hardness < 0
+}
+
+// pistonPushEntities moves the entities within the blocks at the positions passed one block towards dir.
+func pistonPushEntities(positions []cube.Pos, dir cube.Face, tx *world.Tx) {
+	delta := cube.Pos{}.Side(dir).Vec3()
+	pushed := make(map[*world.EntityHandle]struct{})
+	for _, pos := range positions {
+		for e := range tx.EntitiesWithin(cube.Box(0, 0, 0, 1, 1, 1).Translate(pos.Vec3())) {
+			if _, ok := pushed[e.H()]; ok {
+				continue
+			}
+			pushed[e.H()] = struct{}{}
+			switch mover := e.(type) {
+			case interface{ Displace(deltaPos mgl64.Vec3) }:
+				mover.Displace(delta)
+			case interface{ Teleport(pos mgl64.Vec3) }:
+				mover.Teleport(e.Position().Add(delta))
+			}
+		}
+	}
+}
+
+func allPistons() (pistons []world.Block) {
+	for _, f := range cube.Faces() {
+		pistons = append(pistons, Piston{Facing: f}, StickyPiston{Facing: f})
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// PistonArm is the arm of an extended piston or sticky piston. It is placed in front of the piston when it extends
+// and removed when it retracts. Breaking the arm breaks the piston it belongs to.
+type PistonArm struct {
+	transparent
+
+	// Facing is the face that the piston of the arm pushes blocks towards.
+	Facing cube.Face
+	// Sticky specifies if the arm belongs to a sticky piston.
+	Sticky bool
+}
+
+// Model ...
+func (a PistonArm) Model() world.BlockModel {
+	return model.PistonArm{Facing: a.Facing}
+}
+
+// BreakInfo ...
+func (a PistonArm) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, pickaxeEffective, simpleDrops()).withBreakHandler(func(pos cube.Pos, tx *world.Tx, _ item.User) {
+		base := pos.Side(a.Facing.Opposite())
+		if p, sticky, ok := pistonAt(base, tx); ok && sticky == a.Sticky && p.Facing == a.Facing {
+			breakBlock(tx.Block(base), base, tx)
+		}
+	})
+}
+
+// NeighbourUpdateTick removes the arm if the piston it belongs to is no longer behind it.
+func (a PistonArm) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if p, sticky, ok := pistonAt(pos.Side(a.Facing.Opposite()), tx); !ok || sticky != a.Sticky || p.Facing != a.Facing || !p.Extended {
+		tx.SetBlock(pos, nil, nil)
+	}
+}
+
+// EncodeBlock ...
+func (a PistonArm) EncodeBlock() (string, map[string]any) {
+	name := "minecraft:piston_arm_collision"
+	if a.Sticky {
+		name = "minecraft:sticky_piston_arm_collision"
+	}
+	return name, map[string]any{"facing_direction": pistonFacingDirection(a.Facing)}
+}
+
+func allPistonArms() (arms []world.Block) {
+	for _, f := range cube.Faces() {
+		arms = append(arms, PistonArm{Facing: f}, PistonArm{Facing: f, Sticky: true})
+	}
+	return
+}
@@ -0,0 +1,145 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var (
+	_ world.RedstonePowerConsumer    = PoweredRail{}
+	_ world.RedstonePowerPostUpdater = PoweredRail{}
+)
+
+// PoweredRail is a rail that accelerates minecarts riding over it while it is powered, and brakes them while it is
+// not. A powered rail also receives power from a powered rail next to it, up to eight rails away from the rail that
+// receives redstone power. Powered rails cannot be curved.
+type PoweredRail struct {
+	empty
+	transparent
+
+	// Shape is the shape of the rail.
+	Shape RailShape
+	// Powered is true while the rail is powered, either by redstone or by a neighbouring powered rail.
+	Powered bool
+}
+
+// RailShape ...
+func (r PoweredRail) RailShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r PoweredRail) withRailShape(s RailShape) world.Block {
+	r.Shape = s
+	return r
+}
+
+// curvable ...
+func (PoweredRail) curvable() bool {
+	return false
+}
+
+// SupportsMinecart ...
+func (PoweredRail) SupportsMinecart() bool {
+	return true
+}
+
+// SideClosed ...
+func (PoweredRail) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// BreakInfo ...
+func (r PoweredRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(PoweredRail{}))
+}
+
+// UseOnBlock ...
+func (r PoweredRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	r.Powered = false
+	return placeRail(r, pos, face, tx, user, ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r PoweredRail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !railSupported(pos, r.Shape, tx) {
+		breakBlock(r, pos, tx)
+	}
+}
+
+// RedstonePowerUpdate powers the rail if it receives redstone power or is connected to a rail that does.
+func (r PoweredRail) RedstonePowerUpdate(pos cube.Pos, tx *world.Tx, power int) (world.Block, bool) {
+	powered := power > 0 || railChainPowered(pos, r, tx)
+	if powered == r.Powered {
+		return r, false
+	}
+	r.Powered = powered
+	return r, true
+}
+
+// RedstonePowerPostUpdate updates the powered rails connected to the rail, so that power spreads along them.
+func (PoweredRail) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, _, after world.Block, _, _ int) {
+	if r, ok := after.(PoweredRail); ok {
+		updateRailChain(pos, r, tx)
+	}
+}
+
+// EncodeItem ...
+func (PoweredRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:golden_rail", 0
+}
+
+// EncodeBlock ...
+func (r PoweredRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:golden_rail", map[string]any{"rail_direction": int32(r.Shape.Uint8()), "rail_data_bit": boolByte(r.Powered)}
+}
+
+// allPoweredRails ...
+func allPoweredRails() (rails []world.Block) {
+	for _, s := range StraightRailShapes() {
+		rails = append(rails, PoweredRail{Shape: s}, PoweredRail{Shape: s, Powered: true})
+	}
+	return
+}
+
+// maxRailChainLength is the maximum number of rails that redstone power spreads through along powered and activator
+// rails.
+const maxRailChainLength = 8
+
+// railChain calls f for every rail of the same type as r that is connected to the rail at pos, walking up to
+// maxRailChainLength rails in both directions. The walk stops early in a direction if f returns false.
+func railChain[R railBlock](pos cube.Pos, r R, tx *world.Tx, f func(pos cube.Pos) bool) {
+	a, b := r.RailShape().Connections()
+	for _, d := range [...]cube.Direction{a, b} {
+		current := pos
+		for range maxRailChainLength {
+			np, nr, ok := railNeighbour(current, d, tx)
+			if !ok {
+				break
+			}
+			if _, same := nr.(R); !same || !railLinksTo(np, nr, current) || !f(np) {
+				break
+			}
+			current = np
+		}
+	}
+}
+
+// railChainPowered checks if any rail in the chain of the rail r at pos receives redstone power.
+func railChainPowered[R railBlock](pos cube.Pos, r R, tx *world.Tx) (powered bool) {
+	railChain(pos, r, tx, func(p cube.Pos) bool {
+		powered = tx.RedstonePower(p) > 0
+		return !powered
+	})
+	return powered
+}
+
+// updateRailChain schedules a redstone update for all rails in the chain of the rail r at pos.
+func updateRailChain[R railBlock](pos cube.Pos, r R, tx *world.Tx) {
+	railChain(pos, r, tx, func(p cube.Pos) bool {
+		tx.Redstone().ScheduleUpdate(p)
+		return true
+	})
+}
@@ -0,0 +1,198 @@
+package block
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var (
+	_ world.RedstoneStrongPowerSource = PressurePlate{}
+	_ world.ScheduledTicker           = PressurePlate{}
+	_ EntitySensor                    = PressurePlate{}
+)
+
+// PressurePlate is a non-solid block that emits redstone power while entities are standing on it. Stone pressure
+// plates only detect living entities, while wooden pressure plates detect all entities. Weighted pressure plates
+// emit a signal that depends on the number of entities on them.
+type PressurePlate struct {
+	empty
+	transparent
+
+	// Block is the block the pressure plate is made of. This is Stone, polished Blackstone, Planks, Gold for light
+	// weighted pressure plates or Iron for heavy weighted pressure plates.
+	Block world.Block
+	// Power is the redstone power currently emitted by the pressure plate, ranging from 0 to 15.
+	Power int
+}
+
+// RedstonePower ...
+func (p PressurePlate) RedstonePower(cube.Pos, *world.Tx, cube.Face) int {
+	return p.Power
+}
+
+// RedstoneStrongPower strongly powers the block below the pressure plate.
+func (p PressurePlate) RedstoneStrongPower(_ cube.Pos, _ *world.Tx, face cube.Face) int {
+	if face == cube.FaceDown {
+		return p.Power
+	}
+	return 0
+}
+
+// SideClosed ...
+func (p PressurePlate) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// NeighbourUpdateTick ...
+func (p PressurePlate) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !pressurePlateSupported(pos, tx) {
+		breakBlock(p, pos, tx)
+	}
+}
+
+// UseOnBlock ...
+func (p PressurePlate) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, p)
+	if !used || !pressurePlateSupported(pos, tx) {
+		return false
+	}
+	p.Power = 0
+	place(tx, pos, p, user, ctx)
+	return placed(ctx)
+}
+
+// pressurePlateSupported checks if a pressure plate at the position passed has a solid block below it.
+func pressurePlateSupported(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	return tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx)
+}
+
+// EntityInside activates the pressure plate if it was not yet powered.
+func (p PressurePlate) EntityInside(pos cube.Pos, tx *world.Tx, _ world.Entity) {
+	if p.Power == 0 {
+		p.update(pos, tx)
+	}
+}
+
+// SensesEntities ...
+func (PressurePlate) SensesEntities() {}
+
+// ScheduledTick recalculates the power of the pressure plate and releases it once no entities are left on it.
+func (p PressurePlate) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if tx == nil {
+		return
+	}
+	var ok bool
+	if p, ok = tx.Block(pos).(PressurePlate); ok {
+		p.update(pos, tx)
+	}
+}
+
+// update recalculates the power of the pressure plate from the entities on it. As long as the pressure plate is
+// powered, it keeps checking for entities periodically.
+func (p PressurePlate) update(pos cube.Pos, tx *world.Tx) {
+	power := p.entityPower(pos, tx)
+	if power != p.Power {
+		if power > 0 && p.Power == 0 {
+			tx.PlaySound(pos.Vec3Centre(), sound.PowerOn{})
+		} else if power == 0 {
+			tx.PlaySound(pos.Vec3Centre(), sound.PowerOff{})
+		}
+		p.Power = power
+		tx.SetBlock(pos, p, nil)
+	}
+	if power > 0 {
+		tx.ScheduleBlockUpdate(pos, p, p.checkDelay())
+	}
+}
+
+// entityPower calculates the power the pressure plate should emit based on the entities on it.
+func (p PressurePlate) entityPower(pos cube.Pos, tx *world.Tx) int {
+	n := 0
+	for _, e := range entitiesIntersecting(tx, cube.Box(0.125, 0, 0.125, 0.875, 0.25, 0.875).Translate(pos.Vec3())) {
+		if _, living := e.(livingEntity); living || p.detectsAllEntities() {
+			n++
+		}
+	}
+	switch p.Block.(type) {
+	case Gold:
+		return min(n, 15)
+	case Iron:
+		return min(int(math.Ceil(float64(n)/10)), 15)
+	}
+	if n > 0 {
+		return 15
+	}
+	return 0
+}
+
+// detectsAllEntities checks if the pressure plate is activated by any entity, rather than only living entities.
+func (p PressurePlate) detectsAllEntities() bool {
+	switch p.Block.(type) {
+	case Stone, Blackstone:
+		return false
+	}
+	return true
+}
+
+// checkDelay returns the delay between checks for entities while the pressure plate is powered.
+func (p PressurePlate) checkDelay() time.Duration {
+	switch p.Block.(type) {
+	case Gold, Iron:
+		return redstoneTicks(5)
+	}
+	return redstoneTicks(10)
+}
+
+// entitiesIntersecting returns all entities whose bounding box intersects with the box passed.
+func entitiesIntersecting(tx *world.Tx, box cube.BBox) (entities []world.Entity) {
+	for e := range tx.EntitiesWithin(box.Grow(2)) {
+		if e.H().Type().BBox(e).Translate(e.Position()).IntersectsWith(box) {
+			entities = append(entities, e)
+		}
+	}
+	return entities
+}
+
+// BreakInfo ...
+func (p PressurePlate) BreakInfo() BreakInfo {
+	if _, wooden := p.Block.(Planks); wooden {
+		return newBreakInfo(0.5, alwaysHarvestable, axeEffective, oneOf(PressurePlate{Block: p.Block}))
+	}
+	return newBreakInfo(0.5, pickaxeHarvestable, pickaxeEffective, oneOf(PressurePlate{Block: p.Block}))
+}
+
+// FuelInfo ...
+func (p PressurePlate) FuelInfo() item.FuelInfo {
+	if planks, ok := p.Block.(Planks); ok && planks.Wood.Flammable() {
+		return newFuelInfo(time.Second * 15)
+	}
+	return item.FuelInfo{}
+}
+
+// EncodeItem ...
+func (p PressurePlate) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + encodePressurePlateBlock(p.Block) + "_pressure_plate", 0
+}
+
+// EncodeBlock ...
+func (p PressurePlate) EncodeBlock() (string, map[string]any) {
+	return "minecraft:" + encodePressurePlateBlock(p.Block) + "_pressure_plate", map[string]any{"redstone_signal": int32(p.Power)}
+}
+
+// allPressurePlates ...
+func allPressurePlates() (plates []world.Block) {
+	for _, block := range PressurePlateBlocks() {
+		for power := 0; power <= 15; power++ {
+			plates = append(plates, PressurePlate{Block: block, Power: power})
+		}
+	}
+	return
+}
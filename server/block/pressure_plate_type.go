@@ -0,0 +1,44 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// encodePressurePlateBlock encodes the provided block in to an identifier that can be used to encode the pressure
+// plate.
+func encodePressurePlateBlock(block world.Block) string {
+	switch block := block.(type) {
+	case Stone:
+		if !block.Smooth {
+			return "stone"
+		}
+	case Blackstone:
+		if block.Type == PolishedBlackstone() {
+			return "polished_blackstone"
+		}
+	case Planks:
+		if block.Wood == OakWood() {
+			return "wooden"
+		}
+		return block.Wood.String()
+	case Gold:
+		return "light_weighted"
+	case Iron:
+		return "heavy_weighted"
+	}
+	panic("invalid block used for pressure plate")
+}
+
+// PressurePlateBlocks returns a list of all possible blocks for a pressure plate.
+func PressurePlateBlocks() []world.Block {
+	b := []world.Block{
+		Stone{},
+		Blackstone{Type: PolishedBlackstone()},
+		Gold{},
+		Iron{},
+	}
+	for _, w := range WoodTypes() {
+		b = append(b, Planks{Wood: w})
+	}
+	return b
+}
@@ -0,0 +1,268 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// MinecartRail represents a rail block that minecarts are able to ride on.
+type MinecartRail interface {
+	world.Block
+	// RailShape returns the current shape of the rail.
+	RailShape() RailShape
+}
+
+// railBlock is implemented by all rail blocks in this package. It allows the shape of a rail to be updated when
+// neighbouring rails are placed.
+type railBlock interface {
+	MinecartRail
+	// withRailShape returns the rail with its shape changed to the shape passed.
+	withRailShape(s RailShape) world.Block
+	// curvable checks if the rail can be curved.
+	curvable() bool
+}
+
+// Rail is a block that minecarts can ride on. Unlike other rails, a rail can be curved to connect two rails that
+// are not in line with each other.
+type Rail struct {
+	empty
+	transparent
+
+	// Shape is the shape of the rail.
+	Shape RailShape
+}
+
+// RailShape ...
+func (r Rail) RailShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r Rail) withRailShape(s RailShape) world.Block {
+	r.Shape = s
+	return r
+}
+
+// curvable ...
+func (Rail) curvable() bool {
+	return true
+}
+
+// SupportsMinecart ...
+func (Rail) SupportsMinecart() bool {
+	return true
+}
+
+// SideClosed ...
+func (Rail) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// BreakInfo ...
+func (r Rail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(Rail{}))
+}
+
+// UseOnBlock ...
+func (r Rail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	return placeRail(r, pos, face, tx, user, ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r Rail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !railSupported(pos, r.Shape, tx) {
+		breakBlock(r, pos, tx)
+	}
+}
+
+// EncodeItem ...
+func (Rail) EncodeItem() (name string, meta int16) {
+	return "minecraft:rail", 0
+}
+
+// EncodeBlock ...
+func (r Rail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:rail", map[string]any{"rail_direction": int32(r.Shape.Uint8())}
+}
+
+// allRails ...
+func allRails() (rails []world.Block) {
+	for _, s := range RailShapes() {
+		rails = append(rails, Rail{Shape: s})
+	}
+	return
+}
+
+// railDirections holds the directions that rails look for neighbouring rails in, in order of preference.
+var railDirections = [...]cube.Direction{cube.North, cube.South, cube.West, cube.East}
+
+// placeRail places the rail r at the first replaceable position from pos and face. The shape of the rail is
+// calculated from the rails around it, after which neighbouring rails are connected to it.
+func placeRail(r railBlock, pos cube.Pos, face cube.Face, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, r)
+	if !used || !railSupported(pos, RailShapeNorthSouth(), tx) {
+		return false
+	}
+	shape := RailShapeNorthSouth()
+	if d := user.Rotation().Direction(); d == cube.East || d == cube.West {
+		shape = RailShapeEastWest()
+	}
+	r = r.withRailShape(shape).(railBlock)
+	r = r.withRailShape(railShapeAt(pos, r, tx)).(railBlock)
+	if !railSupported(pos, r.RailShape(), tx) {
+		r = r.withRailShape(shape).(railBlock)
+	}
+	place(tx, pos, r, user, ctx)
+	if placed(ctx) {
+		connectRailNeighbours(pos, tx)
+		return true
+	}
+	return false
+}
+
+// railSupported checks if a rail with the shape passed is supported at pos. Rails need a solid block below them, and
+// ascending rails also need a solid block on the side they ascend towards.
+func railSupported(pos cube.Pos, s RailShape, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	if !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+		return false
+	}
+	if d, ok := s.Ascending(); ok {
+		side := pos.Side(d.Face())
+		return tx.Block(side).Model().FaceSolid(side, cube.FaceUp, tx)
+	}
+	return true
+}
+
+// railAt returns the rail at pos, if there is one.
+func railAt(pos cube.Pos, tx *world.Tx) (railBlock, bool) {
+	r, ok := tx.Block(pos).(railBlock)
+	return r, ok
+}
+
+// railEndpoints returns the two positions that a rail at pos with the shape passed connects to. The end towards
+// which the rail ascends is one block higher than the rail itself.
+func railEndpoints(pos cube.Pos, s RailShape) [2]cube.Pos {
+	a, b := s.Connections()
+	ends := [2]cube.Pos{pos.Side(a.Face()), pos.Side(b.Face())}
+	if up, ok := s.Ascending(); ok {
+		if up == a {
+			ends[0] = ends[0].Side(cube.FaceUp)
+		} else {
+			ends[1] = ends[1].Side(cube.FaceUp)
+		}
+	}
+	return ends
+}
+
+// railNeighbour returns the rail next to pos in the direction passed. Rails at the same height are preferred over
+// rails one block higher, which are preferred over rails one block lower.
+func railNeighbour(pos cube.Pos, d cube.Direction, tx *world.Tx) (cube.Pos, railBlock, bool) {
+	side := pos.Side(d.Face())
+	for _, p := range [...]cube.Pos{side, side.Side(cube.FaceUp), side.Side(cube.FaceDown)} {
+		if r, ok := railAt(p, tx); ok {
+			return p, r, true
+		}
+	}
+	return cube.Pos{}, nil, false
+}
+
+// railLinksTo checks if the rail r at pos has one of its ends pointing at target. An end one block above target also
+// counts, as the rail at target is then able to ascend towards it.
+func railLinksTo(pos cube.Pos, r railBlock, target cube.Pos) bool {
+	for _, end := range railEndpoints(pos, r.RailShape()) {
+		if end[0] == target[0] && end[2] == target[2] && (end[1] == target[1] || end[1] == target[1]+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// railFull checks if both ends of the rail r at pos are connected to rails other than the rail at exclude.
+func railFull(pos cube.Pos, r railBlock, exclude cube.Pos, tx *world.Tx) bool {
+	a, b := r.RailShape().Connections()
+	for _, d := range [...]cube.Direction{a, b} {
+		np, nr, ok := railNeighbour(pos, d, tx)
+		if !ok || np == exclude || !railLinksTo(np, nr, pos) {
+			return false
+		}
+	}
+	return true
+}
+
+// railShapeAt calculates the shape that the rail r at pos should have based on the rails around it. Rails that
+// already connect to pos are preferred over rails that are able to connect to it. If no rails are around, the
+// current shape of r is returned.
+func railShapeAt(pos cube.Pos, r railBlock, tx *world.Tx) RailShape {
+	var linked, free []cube.Direction
+	for _, d := range railDirections {
+		np, nr, ok := railNeighbour(pos, d, tx)
+		if !ok {
+			continue
+		}
+		if railLinksTo(np, nr, pos) {
+			linked = append(linked, d)
+		} else if !railFull(np, nr, pos, tx) {
+			free = append(free, d)
+		}
+	}
+	if len(linked) == 0 && len(free) == 0 {
+		return r.RailShape()
+	}
+	var first cube.Direction
+	if len(linked) > 0 {
+		first, linked = linked[0], linked[1:]
+	} else {
+		first, free = free[0], free[1:]
+	}
+	second, ok := railSecondConnection(first, linked, r.curvable())
+	if !ok {
+		if second, ok = railSecondConnection(first, free, r.curvable()); !ok {
+			second = first.Opposite()
+		}
+	}
+	if first != second.Opposite() {
+		return railShapeFromConnections(first, second, false)
+	}
+	for _, d := range [...]cube.Direction{first, second} {
+		if _, ok := railAt(pos.Side(d.Face()).Side(cube.FaceUp), tx); ok {
+			return railShapeFromConnections(d, d.Opposite(), true)
+		}
+	}
+	return railShapeFromConnections(first, second, false)
+}
+
+// railSecondConnection selects the direction from candidates that a rail connecting to first should connect to.
+// The opposite direction is preferred, so that rails only curve if they cannot continue straight.
+func railSecondConnection(first cube.Direction, candidates []cube.Direction, curvable bool) (cube.Direction, bool) {
+	for _, d := range candidates {
+		if d == first.Opposite() {
+			return d, true
+		}
+	}
+	if curvable && len(candidates) > 0 {
+		return candidates[0], true
+	}
+	return 0, false
+}
+
+// connectRailNeighbours updates the shape of the rails that the rail at pos points at, so that they connect back to
+// it if they are able to.
+func connectRailNeighbours(pos cube.Pos, tx *world.Tx) {
+	r, ok := railAt(pos, tx)
+	if !ok {
+		return
+	}
+	a, b := r.RailShape().Connections()
+	for _, d := range [...]cube.Direction{a, b} {
+		np, nr, ok := railNeighbour(pos, d, tx)
+		if !ok || railLinksTo(np, nr, pos) || !railLinksTo(pos, r, np) {
+			continue
+		}
+		if s := railShapeAt(np, nr, tx); s != nr.RailShape() && railSupported(np, s, tx) {
+			tx.SetBlock(np, nr.withRailShape(s), nil)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package block
+
+import "github.com/df-mc/dragonfly/server/block/cube"
+
+// RailShape represents the shape of a rail, which is the pair of directions the rail connects to. A rail is either
+// straight, ascending towards one of its ends or curved.
+type RailShape struct {
+	railShape
+}
+
+type railShape uint8
+
+// RailShapeNorthSouth is a flat, straight rail running from north to south.
+func RailShapeNorthSouth() RailShape {
+	return RailShape{0}
+}
+
+// RailShapeEastWest is a flat, straight rail running from east to west.
+func RailShapeEastWest() RailShape {
+	return RailShape{1}
+}
+
+// RailShapeAscendingEast is a straight rail running from east to west that ascends towards the east.
+func RailShapeAscendingEast() RailShape {
+	return RailShape{2}
+}
+
+// RailShapeAscendingWest is a straight rail running from east to west that ascends towards the west.
+func RailShapeAscendingWest() RailShape {
+	return RailShape{3}
+}
+
+// RailShapeAscendingNorth is a straight rail running from north to south that ascends towards the north.
+func RailShapeAscendingNorth() RailShape {
+	return RailShape{4}
+}
+
+// RailShapeAscendingSouth is a straight rail running from north to south that ascends towards the south.
+func RailShapeAscendingSouth() RailShape {
+	return RailShape{5}
+}
+
+// RailShapeSouthEast is a curved rail connecting the south and the east.
+func RailShapeSouthEast() RailShape {
+	return RailShape{6}
+}
+
+// RailShapeSouthWest is a curved rail connecting the south and the west.
+func RailShapeSouthWest() RailShape {
+	return RailShape{7}
+}
+
+// RailShapeNorthWest is a curved rail connecting the north and the west.
+func RailShapeNorthWest() RailShape {
+	return RailShape{8}
+}
+
+// RailShapeNorthEast is a curved rail connecting the north and the east.
+func RailShapeNorthEast() RailShape {
+	return RailShape{9}
+}
+
+// RailShapes returns all rail shapes, including curved shapes.
+func RailShapes() []RailShape {
+	return []RailShape{
+		RailShapeNorthSouth(), RailShapeEastWest(), RailShapeAscendingEast(), RailShapeAscendingWest(),
+		RailShapeAscendingNorth(), RailShapeAscendingSouth(), RailShapeSouthEast(), RailShapeSouthWest(),
+		RailShapeNorthWest(), RailShapeNorthEast(),
+	}
+}
+
+// StraightRailShapes returns all rail shapes that are not curved. Powered, detector and activator rails can only
+// have these shapes.
+func StraightRailShapes() []RailShape {
+	return RailShapes()[:6]
+}
+
+// Uint8 returns the rail shape as a uint8.
+func (r railShape) Uint8() uint8 {
+	return uint8(r)
+}
+
+// Curved checks if the rail shape is a curve, connecting two directions that are not opposite.
+func (r railShape) Curved() bool {
+	return r >= 6
+}
+
+// Ascending returns the direction towards which the rail ascends, and false if the rail is flat.
+func (r railShape) Ascending() (cube.Direction, bool) {
+	switch r {
+	case 2:
+		return cube.East, true
+	case 3:
+		return cube.West, true
+	case 4:
+		return cube.North, true
+	case 5:
+		return cube.South, true
+	}
+	return 0, false
+}
+
+// Connections returns the two directions that the rail shape connects.
+func (r railShape) Connections() (cube.Direction, cube.Direction) {
+	switch r {
+	case 0, 4, 5:
+		return cube.North, cube.South
+	case 1, 2, 3:
+		return cube.East, cube.West
+	case 6:
+		return cube.South, cube.East
+	case 7:
+		return cube.South, cube.West
+	case 8:
+		return cube.North, cube.West
+	case 9:
+		return cube.North, cube.East
+	}
+	panic("unknown rail shape")
+}
+
+// String ...
+func (r railShape) String() string {
+	switch r {
+	case 0:
+		return "north_south"
+	case 1:
+		return "east_west"
+	case 2:
+		return "ascending_east"
+	case 3:
+		return "ascending_west"
+	case 4:
+		return "ascending_north"
+	case 5:
+		return "ascending_south"
+	case 6:
+		return "south_east"
+	case 7:
+		return "south_west"
+	case 8:
+		return "north_west"
+	case 9:
+		return "north_east"
+	}
+	panic("unknown rail shape")
+}
+
+// railShapeFromConnections returns the rail shape connecting the two directions passed. If a and b are the same
+// direction, a straight rail along that direction is returned. If ascending is true, the straight rail ascends
+// towards a.
+func railShapeFromConnections(a, b cube.Direction, ascending bool) RailShape {
+	if a == b || a == b.Opposite() {
+		switch {
+		case ascending && a == cube.North:
+			return RailShapeAscendingNorth()
+		case ascending && a == cube.South:
+			return RailShapeAscendingSouth()
+		case ascending && a == cube.West:
+			return RailShapeAscendingWest()
+		case ascending && a == cube.East:
+			return RailShapeAscendingEast()
+		case a == cube.North || a == cube.South:
+			return RailShapeNorthSouth()
+		}
+		return RailShapeEastWest()
+	}
+	if a == cube.North || a == cube.South {
+		a, b = b, a
+	}
+	// a is now either west or east, b is north or south.
+	switch {
+	case b == cube.South && a == cube.East:
+		return RailShapeSouthEast()
+	case b == cube.South && a == cube.West:
+		return RailShapeSouthWest()
+	case b == cube.North && a == cube.West:
+		return RailShapeNorthWest()
+	}
+	return RailShapeNorthEast()
+}
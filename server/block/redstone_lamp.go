@@ -0,0 +1,78 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+var (
+	_ world.RedstonePowerConsumer = RedstoneLamp{}
+	_ world.RedstonePowerAction   = RedstoneLamp{}
+	_ world.ScheduledTicker       = RedstoneLamp{}
+)
+
+// RedstoneLamp is a block that emits light while it is powered by redstone. The lamp turns on as soon as it receives
+// power, but turns off two redstone ticks after losing it.
+type RedstoneLamp struct {
+	solid
+
+	// Lit is whether the redstone lamp is turned on.
+	Lit bool
+}
+
+// RedstonePowerUpdate turns the lamp on when it receives power. Turning the lamp off is delayed and handled by
+// RedstonePowerAction.
+func (l RedstoneLamp) RedstonePowerUpdate(_ cube.Pos, _ *world.Tx, power int) (world.Block, bool) {
+	if power == 0 || l.Lit {
+		return l, false
+	}
+	l.Lit = true
+	return l, true
+}
+
+// RedstonePowerAction schedules the lamp to turn off when it stops receiving power.
+func (l RedstoneLamp) RedstonePowerAction(pos cube.Pos, tx *world.Tx, _, newPower int) {
+	if newPower == 0 && l.Lit {
+		tx.ScheduleBlockUpdate(pos, l, redstoneTicks(2))
+	}
+}
+
+// ScheduledTick turns the lamp off if it is still unpowered.
+func (l RedstoneLamp) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if tx == nil {
+		return
+	}
+	var ok bool
+	if l, ok = tx.Block(pos).(RedstoneLamp); ok && l.Lit && tx.RedstonePower(pos) == 0 {
+		l.Lit = false
+		tx.SetBlock(pos, l, nil)
+	}
+}
+
+// LightEmissionLevel ...
+func (l RedstoneLamp) LightEmissionLevel() uint8 {
+	if l.Lit {
+		return 15
+	}
+	return 0
+}
+
+// BreakInfo ...
+func (l RedstoneLamp) BreakInfo() BreakInfo {
+	return newBreakInfo(0.3, alwaysHarvestable, nothingEffective, oneOf(RedstoneLamp{}))
+}
+
+// EncodeItem ...
+func (RedstoneLamp) EncodeItem() (name string, meta int16) {
+	return "minecraft:redstone_lamp", 0
+}
+
+// EncodeBlock ...
+func (l RedstoneLamp) EncodeBlock() (string, map[string]any) {
+	if l.Lit {
+		return "minecraft:lit_redstone_lamp", nil
+	}
+	return "minecraft:redstone_lamp", nil
+}
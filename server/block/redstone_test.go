@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
@@ -1361,3 +1363,1169 @@ func redstoneTorchBurnoutTestWaitFor(t *testing.T, w *world.World, ready func()
 	}
 	t.Fatal(fail())
 }
+
+func TestRepeaterDelaysSignal(t *testing.T) {
+	for delay := range 4 {
+		t.Run(fmt.Sprint(delay+1), func(t *testing.T) {
+			w := world.Config{Synchronous: true}.New()
+			defer w.Close()
+
+			repeaterPos := cube.Pos{0, 64, 0}
+			sourcePos := repeaterPos.Side(cube.FaceWest)
+			var start int64
+			runWorld(w, func(tx *world.Tx) {
+				tx.SetBlock(repeaterPos.Side(cube.FaceDown), Stone{}, nil)
+				tx.SetBlock(repeaterPos, Repeater{Facing: cube.East, Delay: delay}, nil)
+				start = tx.CurrentTick()
+				tx.SetBlock(sourcePos, RedstoneBlock{}, nil)
+			})
+
+			var end int64
+			redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+				end = tx.CurrentTick()
+				return tx.Block(repeaterPos).(Repeater).Powered
+			})
+			if want := int64(delay+1) * 2; end-start < want {
+				t.Fatalf("repeater powered after %d ticks, want at least %d", end-start, want)
+			}
+
+			var frontPower, backPower int
+			runWorld(w, func(tx *world.Tx) {
+				frontPower = tx.RedstoneStrongPowerFrom(repeaterPos.Side(cube.FaceEast), cube.FaceWest)
+				backPower = tx.Block(repeaterPos).(Repeater).RedstonePower(repeaterPos, tx, cube.FaceWest)
+			})
+			if frontPower != 15 || backPower != 0 {
+				t.Fatalf("repeater front/back power = %d/%d, want 15/0", frontPower, backPower)
+			}
+		})
+	}
+}
+
+func TestRepeaterLockedBySidePoweredRepeater(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	repeaterPos := cube.Pos{0, 64, 0}
+	lockPos := repeaterPos.Side(cube.FaceSouth)
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(repeaterPos.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(lockPos.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(repeaterPos, Repeater{Facing: cube.East}, nil)
+		tx.SetBlock(lockPos.Side(cube.FaceSouth), RedstoneBlock{}, nil)
+		tx.SetBlock(lockPos, Repeater{Facing: cube.North}, nil)
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		return tx.Block(lockPos).(Repeater).Powered
+	})
+
+	redstoneWireTestSetBlockAndWait(t, w, repeaterPos.Side(cube.FaceWest), RedstoneBlock{})
+	for range 10 {
+		w.AdvanceTick()
+	}
+	var powered bool
+	runWorld(w, func(tx *world.Tx) {
+		powered = tx.Block(repeaterPos).(Repeater).Powered
+	})
+	if powered {
+		t.Fatal("locked repeater changed its output")
+	}
+
+	redstoneWireTestSetBlockAndWait(t, w, lockPos.Side(cube.FaceSouth), Air{})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		return tx.Block(repeaterPos).(Repeater).Powered
+	})
+}
+
+func TestComparatorReadsContainerFullness(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+	loader := world.NewLoader(1, w, world.NopViewer{})
+	runWorld(w, func(tx *world.Tx) {
+		loader.Load(tx, 1)
+	})
+	defer func() {
+		runWorld(w, func(tx *world.Tx) {
+			loader.Close(tx)
+		})
+	}()
+
+	comparatorPos := cube.Pos{0, 64, 0}
+	hopperPos := comparatorPos.Side(cube.FaceWest)
+	hopper := NewHopper()
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(comparatorPos.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(hopperPos, hopper, nil)
+		tx.SetBlock(comparatorPos, Comparator{Facing: cube.East}, nil)
+	})
+	for range 10 {
+		w.AdvanceTick()
+	}
+	runWorld(w, func(tx *world.Tx) {
+		if c := tx.Block(comparatorPos).(Comparator); c.Powered || c.Power != 0 {
+			t.Errorf("comparator behind empty hopper = %+v, want unpowered", c)
+		}
+		_, _ = tx.Block(hopperPos).(Hopper).Inventory(tx, hopperPos).AddItem(item.NewStack(Stone{}, 64))
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		return tx.Block(comparatorPos).(Comparator).Power == 3
+	})
+
+	runWorld(w, func(tx *world.Tx) {
+		if !tx.Block(comparatorPos).(Comparator).Powered {
+			t.Error("comparator with output was not powered")
+		}
+	})
+}
+
+func TestComparatorReadsBlockThroughSolidBlock(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	comparatorPos := cube.Pos{0, 64, 0}
+	stonePos := comparatorPos.Side(cube.FaceWest)
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(comparatorPos.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(stonePos, Stone{}, nil)
+		tx.SetBlock(stonePos.Side(cube.FaceWest).Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(stonePos.Side(cube.FaceWest), Cake{Bites: 3}, nil)
+		tx.SetBlock(comparatorPos, Comparator{Facing: cube.East}, nil)
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		return tx.Block(comparatorPos).(Comparator).Power == 8
+	})
+}
+
+func TestComparatorModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		subtract bool
+		want     int
+	}{
+		{name: "compare", want: 15},
+		{name: "subtract", subtract: true, want: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := world.Config{Synchronous: true}.New()
+			defer w.Close()
+
+			comparatorPos := cube.Pos{0, 64, 0}
+			wirePos := comparatorPos.Side(cube.FaceSouth)
+			runWorld(w, func(tx *world.Tx) {
+				for _, pos := range []cube.Pos{comparatorPos, wirePos, wirePos.Side(cube.FaceSouth)} {
+					tx.SetBlock(pos.Side(cube.FaceDown), Stone{}, nil)
+				}
+				tx.SetBlock(comparatorPos, Comparator{Facing: cube.East, Subtract: test.subtract}, nil)
+				tx.SetBlock(wirePos, RedstoneWire{}, nil)
+				tx.SetBlock(wirePos.Side(cube.FaceSouth), RedstoneWire{}, nil)
+				tx.SetBlock(wirePos.Side(cube.FaceSouth).Side(cube.FaceSouth), RedstoneBlock{}, nil)
+				tx.SetBlock(comparatorPos.Side(cube.FaceWest), RedstoneBlock{}, nil)
+			})
+			redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+				return tx.Block(comparatorPos).(Comparator).Power == test.want
+			})
+		})
+	}
+}
+
+func TestComparatorSideInputSuppressesOutput(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	comparatorPos := cube.Pos{0, 64, 0}
+	stonePos := comparatorPos.Side(cube.FaceWest)
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(comparatorPos.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(stonePos, Stone{}, nil)
+		tx.SetBlock(stonePos.Side(cube.FaceWest), Composter{Level: 4}, nil)
+		tx.SetBlock(comparatorPos, Comparator{Facing: cube.East}, nil)
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		return tx.Block(comparatorPos).(Comparator).Power == 4
+	})
+	redstoneWireTestSetBlockAndWait(t, w, comparatorPos.Side(cube.FaceNorth), RedstoneBlock{})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		return tx.Block(comparatorPos).(Comparator).Power == 0
+	})
+}
+
+func TestComparatorSignals(t *testing.T) {
+	book := item.NewStack(item.BookAndQuill{Pages: []string{"a", "b", "c"}}, 1)
+	tests := []struct {
+		name string
+		b    ComparatorReadable
+		want int
+	}{
+		{name: "full cake", b: Cake{}, want: 14},
+		{name: "eaten cake", b: Cake{Bites: 6}, want: 2},
+		{name: "composter", b: Composter{Level: 8}, want: 8},
+		{name: "empty lectern", b: Lectern{}, want: 0},
+		{name: "lectern first page", b: Lectern{Book: book}, want: 1},
+		{name: "lectern last page", b: Lectern{Book: book, Page: 2}, want: 15},
+		{name: "empty jukebox", b: Jukebox{}, want: 0},
+		{name: "jukebox 13", b: Jukebox{Item: item.NewStack(item.MusicDisc{DiscType: sound.Disc13()}, 1)}, want: 1},
+		{name: "jukebox 5", b: Jukebox{Item: item.NewStack(item.MusicDisc{DiscType: sound.Disc5()}, 1)}, want: 15},
+		{name: "empty furnace", b: NewFurnace(cube.North), want: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.b.ComparatorSignal(cube.Pos{}, nil); got != test.want {
+				t.Fatalf("comparator signal = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPistonPushesAndRetracts(t *testing.T) {
+	w, closeWorld := redstonePistonTestWorld()
+	defer closeWorld()
+
+	pistonPos := cube.Pos{0, 64, 0}
+	powerPos := pistonPos.Side(cube.FaceWest)
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pistonPos, Piston{Facing: cube.FaceEast}, nil)
+		tx.SetBlock(pistonPos.Add(cube.Pos{1, 0, 0}), Stone{}, nil)
+		tx.SetBlock(pistonPos.Add(cube.Pos{2, 0, 0}), Stone{}, nil)
+		tx.SetBlock(powerPos, RedstoneBlock{}, nil)
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		p := tx.Block(pistonPos).(Piston)
+		return p.Extended && p.Progress == 1
+	})
+	runWorld(w, func(tx *world.Tx) {
+		if arm, ok := tx.Block(pistonPos.Add(cube.Pos{1, 0, 0})).(PistonArm); !ok || arm.Facing != cube.FaceEast {
+			t.Errorf("block in front of extended piston = %#v, want piston arm", tx.Block(pistonPos.Add(cube.Pos{1, 0, 0})))
+		}
+		for x := 2; x <= 3; x++ {
+			if _, ok := tx.Block(pistonPos.Add(cube.Pos{x, 0, 0})).(Stone); !ok {
+				t.Errorf("block %d blocks in front of extended piston = %#v, want stone", x, tx.Block(pistonPos.Add(cube.Pos{x, 0, 0})))
+			}
+		}
+		tx.SetBlock(powerPos, nil, nil)
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		p := tx.Block(pistonPos).(Piston)
+		return !p.Extended && p.Progress == 0
+	})
+	runWorld(w, func(tx *world.Tx) {
+		if _, ok := tx.Block(pistonPos.Add(cube.Pos{1, 0, 0})).(Air); !ok {
+			t.Errorf("block in front of retracted piston = %#v, want air", tx.Block(pistonPos.Add(cube.Pos{1, 0, 0})))
+		}
+		if _, ok := tx.Block(pistonPos.Add(cube.Pos{2, 0, 0})).(Stone); !ok {
+			t.Error("piston pulled a block back without being sticky")
+		}
+	})
+}
+
+func TestPistonPushLimit(t *testing.T) {
+	for _, tc := range []struct {
+		blocks   int
+		extended bool
+	}{{blocks: 12, extended: true}, {blocks: 13, extended: false}} {
+		t.Run(fmt.Sprint(tc.blocks), func(t *testing.T) {
+			w, closeWorld := redstonePistonTestWorld()
+			defer closeWorld()
+
+			pistonPos := cube.Pos{0, 64, 0}
+			runWorld(w, func(tx *world.Tx) {
+				tx.SetBlock(pistonPos, Piston{Facing: cube.FaceEast}, nil)
+				for x := 1; x <= tc.blocks; x++ {
+					tx.SetBlock(pistonPos.Add(cube.Pos{x, 0, 0}), Stone{}, nil)
+				}
+				tx.SetBlock(pistonPos.Side(cube.FaceWest), RedstoneBlock{}, nil)
+			})
+			for range 10 {
+				w.AdvanceTick()
+			}
+			runWorld(w, func(tx *world.Tx) {
+				if extended := tx.Block(pistonPos).(Piston).Extended; extended != tc.extended {
+					t.Errorf("piston pushing %d blocks extended = %v, want %v", tc.blocks, extended, tc.extended)
+				}
+				if _, ok := tx.Block(pistonPos.Add(cube.Pos{tc.blocks + 1, 0, 0})).(Stone); ok != tc.extended {
+					t.Errorf("block pushed past the end of the row = %v, want %v", ok, tc.extended)
+				}
+			})
+		})
+	}
+}
+
+func TestPistonRespectsImmovableBlocks(t *testing.T) {
+	for _, b := range []world.Block{Obsidian{}, Bedrock{}, NewChest()} {
+		t.Run(fmt.Sprintf("%T", b), func(t *testing.T) {
+			w, closeWorld := redstonePistonTestWorld()
+			defer closeWorld()
+
+			pistonPos := cube.Pos{0, 64, 0}
+			runWorld(w, func(tx *world.Tx) {
+				tx.SetBlock(pistonPos, Piston{Facing: cube.FaceUp}, nil)
+				tx.SetBlock(pistonPos.Add(cube.Pos{0, 1, 0}), Stone{}, nil)
+				tx.SetBlock(pistonPos.Add(cube.Pos{0, 2, 0}), b, nil)
+				tx.SetBlock(pistonPos.Side(cube.FaceWest), RedstoneBlock{}, nil)
+			})
+			for range 10 {
+				w.AdvanceTick()
+			}
+			runWorld(w, func(tx *world.Tx) {
+				if tx.Block(pistonPos).(Piston).Extended {
+					t.Errorf("piston extended into immovable %T", b)
+				}
+			})
+		})
+	}
+}
+
+func TestPistonDestroysBreakableBlocks(t *testing.T) {
+	w, closeWorld := redstonePistonTestWorld()
+	defer closeWorld()
+
+	pistonPos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pistonPos, Piston{Facing: cube.FaceEast}, nil)
+		tx.SetBlock(pistonPos.Add(cube.Pos{1, 0, 0}), Stone{}, nil)
+		tx.SetBlock(pistonPos.Add(cube.Pos{2, 0, 0}), Torch{Facing: cube.FaceDown}, nil)
+		tx.SetBlock(pistonPos.Add(cube.Pos{2, -1, 0}), Stone{}, nil)
+		tx.SetBlock(pistonPos.Side(cube.FaceWest), RedstoneBlock{}, nil)
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		_, ok := tx.Block(pistonPos.Add(cube.Pos{2, 0, 0})).(Stone)
+		return ok
+	})
+}
+
+func TestStickyPistonPullsSlimeStructure(t *testing.T) {
+	w, closeWorld := redstonePistonTestWorld()
+	defer closeWorld()
+
+	pistonPos := cube.Pos{0, 64, 0}
+	powerPos := pistonPos.Side(cube.FaceWest)
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pistonPos, StickyPiston{Facing: cube.FaceEast}, nil)
+		tx.SetBlock(pistonPos.Add(cube.Pos{1, 0, 0}), Slime{}, nil)
+		tx.SetBlock(pistonPos.Add(cube.Pos{1, 1, 0}), Stone{}, nil)
+		tx.SetBlock(powerPos, RedstoneBlock{}, nil)
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		p := tx.Block(pistonPos).(StickyPiston)
+		return p.Extended && p.Progress == 1
+	})
+	runWorld(w, func(tx *world.Tx) {
+		if _, ok := tx.Block(pistonPos.Add(cube.Pos{2, 0, 0})).(Slime); !ok {
+			t.Error("slime was not pushed")
+		}
+		if _, ok := tx.Block(pistonPos.Add(cube.Pos{2, 1, 0})).(Stone); !ok {
+			t.Error("block attached to slime was not moved")
+		}
+		if arm, ok := tx.Block(pistonPos.Add(cube.Pos{1, 0, 0})).(PistonArm); !ok || !arm.Sticky {
+			t.Errorf("block in front of extended sticky piston = %#v, want sticky piston arm", tx.Block(pistonPos.Add(cube.Pos{1, 0, 0})))
+		}
+		tx.SetBlock(powerPos, nil, nil)
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		p := tx.Block(pistonPos).(StickyPiston)
+		return !p.Extended && p.Progress == 0
+	})
+	for range 2 {
+		w.AdvanceTick()
+	}
+	runWorld(w, func(tx *world.Tx) {
+		if _, ok := tx.Block(pistonPos.Add(cube.Pos{1, 0, 0})).(Slime); !ok {
+			t.Errorf("block in front of retracted sticky piston = %#v, want slime", tx.Block(pistonPos.Add(cube.Pos{1, 0, 0})))
+		}
+		if _, ok := tx.Block(pistonPos.Add(cube.Pos{1, 1, 0})).(Stone); !ok {
+			t.Error("block attached to slime was not pulled back")
+		}
+		if _, ok := tx.Block(pistonPos.Add(cube.Pos{2, 0, 0})).(Air); !ok {
+			t.Errorf("block at previous slime position = %#v, want air", tx.Block(pistonPos.Add(cube.Pos{2, 0, 0})))
+		}
+	})
+}
+
+func TestPistonRedstoneUpdateCancelled(t *testing.T) {
+	w, closeWorld := redstonePistonTestWorld()
+	defer closeWorld()
+
+	pistonPos := cube.Pos{0, 64, 0}
+	w.Handle(&redstonePistonTestCancelHandler{pos: pistonPos})
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pistonPos, Piston{Facing: cube.FaceEast}, nil)
+		tx.SetBlock(pistonPos.Add(cube.Pos{1, 0, 0}), Stone{}, nil)
+		tx.SetBlock(pistonPos.Side(cube.FaceWest), RedstoneBlock{}, nil)
+	})
+	for range 10 {
+		w.AdvanceTick()
+	}
+	runWorld(w, func(tx *world.Tx) {
+		if tx.Block(pistonPos).(Piston).Extended {
+			t.Error("piston extended after its redstone update was cancelled")
+		}
+		if _, ok := tx.Block(pistonPos.Add(cube.Pos{1, 0, 0})).(Stone); !ok {
+			t.Error("piston moved a block after its redstone update was cancelled")
+		}
+	})
+}
+
+func TestPistonNBT(t *testing.T) {
+	p := StickyPiston{Facing: cube.FaceNorth, Extended: true, Progress: 0.5, attached: []cube.Pos{{1, 2, 3}}}
+	decoded := StickyPiston{Facing: cube.FaceNorth}.DecodeNBT(p.EncodeNBT()).(StickyPiston)
+	if decoded.Extended != p.Extended || decoded.Progress != p.Progress || len(decoded.attached) != 1 || decoded.attached[0] != p.attached[0] {
+		t.Fatalf("decoded piston = %+v, want %+v", decoded, p)
+	}
+
+	m := MovingBlock{Block: Stone{}, PistonPos: cube.Pos{4, 5, 6}}
+	decodedMoving := MovingBlock{}.DecodeNBT(m.EncodeNBT()).(MovingBlock)
+	if decodedMoving != m {
+		t.Fatalf("decoded moving block = %+v, want %+v", decodedMoving, m)
+	}
+}
+
+// redstonePistonTestWorld returns a world with a loader around the origin, so that pistons in it are ticked, and a
+// function to close it.
+func redstonePistonTestWorld() (*world.World, func()) {
+	w := world.Config{Synchronous: true, Entities: redstoneBreakDropTestEntityRegistry()}.New()
+	loader := world.NewLoader(1, w, world.NopViewer{})
+	runWorld(w, func(tx *world.Tx) {
+		loader.Load(tx, 1)
+	})
+	return w, func() {
+		runWorld(w, func(tx *world.Tx) {
+			loader.Close(tx)
+		})
+		_ = w.Close()
+	}
+}
+
+type redstonePistonTestCancelHandler struct {
+	world.NopHandler
+	pos cube.Pos
+}
+
+func (h *redstonePistonTestCancelHandler) HandleRedstoneUpdate(ctx *world.Context, update world.RedstoneUpdate) {
+	if update.Pos == h.pos {
+		ctx.Cancel()
+	}
+}
+
+func TestObserverPulsesWhenFrontBlockChanges(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	observerPos := cube.Pos{0, 64, 0}
+	frontPos := observerPos.Side(cube.FaceEast)
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(observerPos, Observer{Facing: cube.FaceEast}, nil)
+		tx.SetBlock(frontPos, Stone{}, nil)
+	})
+	var start int64
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		start = tx.CurrentTick()
+		return tx.Block(observerPos).(Observer).Powered
+	})
+	runWorld(w, func(tx *world.Tx) {
+		if power := tx.RedstonePowerFrom(observerPos.Side(cube.FaceWest), cube.FaceEast); power != 15 {
+			t.Errorf("power behind pulsing observer = %d, want 15", power)
+		}
+		if power := tx.RedstonePowerFrom(frontPos, cube.FaceWest); power != 0 {
+			t.Errorf("power in front of pulsing observer = %d, want 0", power)
+		}
+	})
+	var end int64
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		end = tx.CurrentTick()
+		return !tx.Block(observerPos).(Observer).Powered
+	})
+	if end-start != 2 {
+		t.Errorf("observer pulse lasted %d ticks, want 2", end-start)
+	}
+}
+
+func TestObserverIgnoresUnchangedAndSideBlocks(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	observerPos := cube.Pos{0, 64, 0}
+	frontPos := observerPos.Side(cube.FaceEast)
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(frontPos, Stone{}, nil)
+		tx.SetBlock(observerPos, Observer{Facing: cube.FaceEast}, nil)
+	})
+	for range 5 {
+		w.AdvanceTick()
+	}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(frontPos, Stone{}, nil)
+		tx.SetBlock(observerPos.Side(cube.FaceNorth), Stone{}, nil)
+	})
+	for range 5 {
+		w.AdvanceTick()
+		runWorld(w, func(tx *world.Tx) {
+			if tx.Block(observerPos).(Observer).Powered {
+				t.Fatal("observer pulsed without a block state change in front of it")
+			}
+		})
+	}
+}
+
+func TestObserverClock(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	a, b := cube.Pos{0, 64, 0}, cube.Pos{1, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(a, Observer{Facing: cube.FaceEast}, nil)
+		tx.SetBlock(b, Observer{Facing: cube.FaceWest}, nil)
+	})
+	pulses, powered := 0, false
+	for range 40 {
+		w.AdvanceTick()
+		runWorld(w, func(tx *world.Tx) {
+			if p := tx.Block(a).(Observer).Powered; p != powered {
+				powered = p
+				if p {
+					pulses++
+				}
+			}
+		})
+	}
+	if pulses < 4 {
+		t.Fatalf("observer clock pulsed %d times in 40 ticks, want a continuous clock", pulses)
+	}
+}
+
+func redstoneEntityTestSpawn(tx *world.Tx, pos mgl64.Vec3) world.Entity {
+	return tx.AddEntity(world.EntitySpawnOpts{Position: pos}.New(redstoneTNTTestEntityType{}, redstoneTNTTestEntityConfig{}))
+}
+
+func TestButtonReleasesAfterDelay(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	tests := []struct {
+		block world.Block
+		ticks int64
+	}{
+		{block: Stone{}, ticks: 20},
+		{block: Planks{Wood: OakWood()}, ticks: 30},
+	}
+	for i, test := range tests {
+		supportPos := cube.Pos{i * 2, 64, 0}
+		pos := supportPos.Side(cube.FaceUp)
+		runWorld(w, func(tx *world.Tx) {
+			tx.SetBlock(supportPos, Stone{}, nil)
+			tx.SetBlock(pos, Button{Block: test.block, Facing: cube.FaceUp}, nil)
+		})
+		var start int64
+		runWorld(w, func(tx *world.Tx) {
+			start = tx.CurrentTick()
+			b := tx.Block(pos).(Button)
+			b.Activate(pos, cube.FaceUp, tx, nil, nil)
+			if !tx.Block(pos).(Button).Pressed {
+				t.Fatalf("%v button was not pressed", test.block)
+			}
+			if power := tx.RedstoneStrongPowerFrom(supportPos, cube.FaceUp); power != 15 {
+				t.Errorf("strong power into support of pressed button = %d, want 15", power)
+			}
+		})
+		var end int64
+		redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+			end = tx.CurrentTick()
+			return !tx.Block(pos).(Button).Pressed
+		})
+		if end-start != test.ticks {
+			t.Errorf("%v button stayed pressed for %d ticks, want %d", test.block, end-start, test.ticks)
+		}
+	}
+}
+
+func TestPressurePlateDetectsEntities(t *testing.T) {
+	w, closeWorld := redstonePistonTestWorld()
+	defer closeWorld()
+
+	woodPos, stonePos := cube.Pos{0, 64, 0}, cube.Pos{2, 64, 0}
+	var e world.Entity
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(woodPos.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(stonePos.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(woodPos, PressurePlate{Block: Planks{Wood: OakWood()}}, nil)
+		tx.SetBlock(stonePos, PressurePlate{Block: Stone{}}, nil)
+
+		e = redstoneEntityTestSpawn(tx, woodPos.Vec3Middle())
+		tx.Block(woodPos).(PressurePlate).EntityInside(woodPos, tx, e)
+		if power := tx.Block(woodPos).(PressurePlate).Power; power != 15 {
+			t.Errorf("wooden pressure plate power with entity = %d, want 15", power)
+		}
+
+		redstoneEntityTestSpawn(tx, stonePos.Vec3Middle())
+		tx.Block(stonePos).(PressurePlate).EntityInside(stonePos, tx, e)
+		if power := tx.Block(stonePos).(PressurePlate).Power; power != 0 {
+			t.Errorf("stone pressure plate power with non-living entity = %d, want 0", power)
+		}
+	})
+	for range 25 {
+		w.AdvanceTick()
+	}
+	runWorld(w, func(tx *world.Tx) {
+		if power := tx.Block(woodPos).(PressurePlate).Power; power != 15 {
+			t.Errorf("wooden pressure plate released while entity is still on it, power = %d", power)
+		}
+		tx.RemoveEntity(e)
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		return tx.Block(woodPos).(PressurePlate).Power == 0
+	})
+}
+
+func TestWeightedPressurePlatePower(t *testing.T) {
+	w, closeWorld := redstonePistonTestWorld()
+	defer closeWorld()
+
+	tests := []struct {
+		block    world.Block
+		entities int
+		want     int
+	}{
+		{block: Gold{}, entities: 3, want: 3},
+		{block: Gold{}, entities: 20, want: 15},
+		{block: Iron{}, entities: 3, want: 1},
+		{block: Iron{}, entities: 11, want: 2},
+	}
+	for i, test := range tests {
+		pos := cube.Pos{i * 2, 64, 2}
+		runWorld(w, func(tx *world.Tx) {
+			tx.SetBlock(pos.Side(cube.FaceDown), Stone{}, nil)
+			tx.SetBlock(pos, PressurePlate{Block: test.block}, nil)
+			var e world.Entity
+			for range test.entities {
+				e = redstoneEntityTestSpawn(tx, pos.Vec3Middle())
+			}
+			tx.Block(pos).(PressurePlate).EntityInside(pos, tx, e)
+			if power := tx.Block(pos).(PressurePlate).Power; power != test.want {
+				t.Errorf("%v pressure plate power with %d entities = %d, want %d", test.block, test.entities, power, test.want)
+			}
+		})
+	}
+}
+
+func TestTripwireHooksAttachAndPower(t *testing.T) {
+	w, closeWorld := redstonePistonTestWorld()
+	defer closeWorld()
+
+	westHook, eastHook := cube.Pos{0, 64, 0}, cube.Pos{4, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(westHook.Side(cube.FaceWest), Stone{}, nil)
+		tx.SetBlock(eastHook.Side(cube.FaceEast), Stone{}, nil)
+		for x := 1; x < 4; x++ {
+			tx.SetBlock(cube.Pos{x, 64, 0}, String{}, nil)
+		}
+		tx.SetBlock(westHook, TripwireHook{Facing: cube.East}, nil)
+		tx.SetBlock(eastHook, TripwireHook{Facing: cube.West}, nil)
+		tx.Block(westHook).(TripwireHook).calculateState(westHook, tx)
+
+		for _, pos := range []cube.Pos{westHook, eastHook} {
+			if h := tx.Block(pos).(TripwireHook); !h.Attached || h.Powered {
+				t.Errorf("tripwire hook at %v: attached = %v, powered = %v, want attached and unpowered", pos, h.Attached, h.Powered)
+			}
+		}
+		for x := 1; x < 4; x++ {
+			if !tx.Block(cube.Pos{x, 64, 0}).(String).Attached {
+				t.Errorf("tripwire at x = %d was not attached", x)
+			}
+		}
+	})
+
+	wirePos := cube.Pos{2, 64, 0}
+	var e world.Entity
+	runWorld(w, func(tx *world.Tx) {
+		e = redstoneEntityTestSpawn(tx, wirePos.Vec3Middle())
+		tx.Block(wirePos).(String).EntityInside(wirePos, tx, e)
+		for _, pos := range []cube.Pos{westHook, eastHook} {
+			if !tx.Block(pos).(TripwireHook).Powered {
+				t.Errorf("tripwire hook at %v was not powered by entity on tripwire", pos)
+			}
+		}
+		if power := tx.RedstoneStrongPowerFrom(westHook.Side(cube.FaceWest), cube.FaceEast); power != 15 {
+			t.Errorf("strong power into support of powered tripwire hook = %d, want 15", power)
+		}
+		tx.RemoveEntity(e)
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		return !tx.Block(westHook).(TripwireHook).Powered && !tx.Block(eastHook).(TripwireHook).Powered
+	})
+
+	runWorld(w, func(tx *world.Tx) {
+		breakBlock(tx.Block(eastHook), eastHook, tx)
+		if tx.Block(westHook).(TripwireHook).Attached {
+			t.Error("tripwire hook stayed attached after the hook at the other end was broken")
+		}
+		if tx.Block(wirePos).(String).Attached {
+			t.Error("tripwire stayed attached after a tripwire hook was broken")
+		}
+	})
+}
+
+func TestTargetPower(t *testing.T) {
+	tests := []struct {
+		hit  mgl64.Vec3
+		face cube.Face
+		want int
+	}{
+		{hit: mgl64.Vec3{0.5, 1, 0.5}, face: cube.FaceUp, want: 15},
+		{hit: mgl64.Vec3{0, 0, 0.5}, face: cube.FaceWest, want: 1},
+		{hit: mgl64.Vec3{0.25, 0.5, 1}, face: cube.FaceSouth, want: 8},
+	}
+	for _, test := range tests {
+		if power := targetPower(test.hit, test.face); power != test.want {
+			t.Errorf("target power hit at %v on %v = %d, want %d", test.hit, test.face, power, test.want)
+		}
+	}
+
+	w, closeWorld := redstonePistonTestWorld()
+	defer closeWorld()
+
+	pos := cube.Pos{0, 64, 0}
+	var start int64
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos, Target{}, nil)
+		e := redstoneEntityTestSpawn(tx, pos.Vec3().Add(mgl64.Vec3{0.5, 1, 0.5}))
+		tx.Block(pos).(Target).ProjectileHit(pos, tx, e, cube.FaceUp)
+		start = tx.CurrentTick()
+		if power := tx.RedstonePowerFrom(pos.Side(cube.FaceEast), cube.FaceWest); power != 15 {
+			t.Errorf("power next to target hit in the centre = %d, want 15", power)
+		}
+	})
+	var end int64
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		end = tx.CurrentTick()
+		return tx.Block(pos).(Target).Power == 0
+	})
+	if end-start != 8 {
+		t.Errorf("target stayed powered for %d ticks, want 8", end-start)
+	}
+}
+
+func TestDoorOpensAndClosesWithRedstone(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	for i, door := range []world.Block{WoodDoor{Wood: OakWood()}, CopperDoor{}} {
+		bottom := cube.Pos{i * 4, 64, 0}
+		top, inputPos := bottom.Side(cube.FaceUp), bottom.Side(cube.FaceEast).Side(cube.FaceUp)
+		open := func(tx *world.Tx) (bool, bool) {
+			switch door.(type) {
+			case WoodDoor:
+				return tx.Block(bottom).(WoodDoor).Open, tx.Block(top).(WoodDoor).Open
+			default:
+				return tx.Block(bottom).(CopperDoor).Open, tx.Block(top).(CopperDoor).Open
+			}
+		}
+		runWorld(w, func(tx *world.Tx) {
+			tx.SetBlock(bottom.Side(cube.FaceDown), Stone{}, nil)
+			switch d := door.(type) {
+			case WoodDoor:
+				tx.SetBlock(bottom, d, nil)
+				d.Top = true
+				tx.SetBlock(top, d, nil)
+			case CopperDoor:
+				tx.SetBlock(bottom, d, nil)
+				d.Top = true
+				tx.SetBlock(top, d, nil)
+			}
+		})
+
+		// Powering only the top half opens both halves of the door.
+		redstoneWireTestSetBlockAndWait(t, w, inputPos, RedstoneBlock{})
+		runWorld(w, func(tx *world.Tx) {
+			if bottomOpen, topOpen := open(tx); !bottomOpen || !topOpen {
+				t.Errorf("%T powered: bottom open = %v, top open = %v, want both open", door, bottomOpen, topOpen)
+			}
+		})
+		redstoneWireTestSetBlockAndWait(t, w, inputPos, Air{})
+		runWorld(w, func(tx *world.Tx) {
+			if bottomOpen, topOpen := open(tx); bottomOpen || topOpen {
+				t.Errorf("%T unpowered: bottom open = %v, top open = %v, want both closed", door, bottomOpen, topOpen)
+			}
+		})
+	}
+}
+
+func TestDoorOpenedByHandStaysOpenWithoutPower(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	bottom := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(bottom.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(bottom, WoodDoor{Wood: OakWood()}, nil)
+		tx.SetBlock(bottom.Side(cube.FaceUp), WoodDoor{Wood: OakWood(), Top: true}, nil)
+		tx.Block(bottom).(WoodDoor).Activate(bottom, cube.FaceNorth, tx, nil, nil)
+	})
+	redstoneWireTestSetBlockAndWait(t, w, bottom.Side(cube.FaceEast), Stone{})
+	for range 5 {
+		w.AdvanceTick()
+	}
+	runWorld(w, func(tx *world.Tx) {
+		if !tx.Block(bottom).(WoodDoor).Open {
+			t.Fatal("unpowered door opened by hand was closed by a redstone update")
+		}
+	})
+}
+
+func TestTrapdoorsAndFenceGatesOpenWithRedstone(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	blocks := []world.Block{WoodTrapdoor{Wood: OakWood()}, CopperTrapdoor{}, WoodFenceGate{Wood: OakWood()}}
+	for i, b := range blocks {
+		pos := cube.Pos{i * 3, 64, 0}
+		open := func(tx *world.Tx) bool {
+			switch b := tx.Block(pos).(type) {
+			case WoodTrapdoor:
+				return b.Open
+			case CopperTrapdoor:
+				return b.Open
+			case WoodFenceGate:
+				return b.Open
+			}
+			t.Fatalf("unexpected block %#v", tx.Block(pos))
+			return false
+		}
+		runWorld(w, func(tx *world.Tx) {
+			tx.SetBlock(pos, b, nil)
+		})
+		redstoneWireTestSetBlockAndWait(t, w, pos.Side(cube.FaceWest), RedstoneBlock{})
+		runWorld(w, func(tx *world.Tx) {
+			if !open(tx) {
+				t.Errorf("powered %T was not opened", b)
+			}
+		})
+		redstoneWireTestSetBlockAndWait(t, w, pos.Side(cube.FaceWest), Air{})
+		runWorld(w, func(tx *world.Tx) {
+			if open(tx) {
+				t.Errorf("unpowered %T was not closed", b)
+			}
+		})
+	}
+}
+
+func TestRedstoneLampTurnsOffAfterDelay(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos, inputPos := cube.Pos{0, 64, 0}, cube.Pos{1, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos, RedstoneLamp{}, nil)
+	})
+	redstoneWireTestSetBlockAndWait(t, w, inputPos, RedstoneBlock{})
+	runWorld(w, func(tx *world.Tx) {
+		if !tx.Block(pos).(RedstoneLamp).Lit {
+			t.Fatal("powered redstone lamp was not lit")
+		}
+	})
+
+	var start int64
+	runWorld(w, func(tx *world.Tx) {
+		start = tx.CurrentTick()
+		tx.SetBlock(inputPos, Air{}, nil)
+	})
+	var end int64
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		end = tx.CurrentTick()
+		return !tx.Block(pos).(RedstoneLamp).Lit
+	})
+	// The redstone engine evaluates the removal in the next tick, after which the lamp turns off 4 ticks later.
+	if end-start != 5 {
+		t.Errorf("redstone lamp turned off after %d ticks, want 5", end-start)
+	}
+}
+
+func TestHopperLockedByRedstone(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos, inputPos := cube.Pos{0, 64, 0}, cube.Pos{1, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		h := NewHopper()
+		h.Facing = cube.FaceDown
+		tx.SetBlock(pos, h, nil)
+	})
+	redstoneWireTestSetBlockAndWait(t, w, inputPos, RedstoneBlock{})
+	runWorld(w, func(tx *world.Tx) {
+		if !tx.Block(pos).(Hopper).Powered {
+			t.Fatal("hopper next to redstone block was not powered")
+		}
+	})
+	redstoneWireTestSetBlockAndWait(t, w, inputPos, Air{})
+	runWorld(w, func(tx *world.Tx) {
+		if tx.Block(pos).(Hopper).Powered {
+			t.Fatal("hopper stayed powered after the redstone block was removed")
+		}
+	})
+}
+
+func TestDispenserBucketsOnRisingEdge(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos, inputPos := cube.Pos{0, 64, 0}, cube.Pos{1, 64, 0}
+	front := pos.Side(cube.FaceUp)
+	var inv *inventory.Inventory
+	runWorld(w, func(tx *world.Tx) {
+		d := NewDispenser()
+		d.Facing = cube.FaceUp
+		inv = d.Inventory(tx, pos)
+		_ = inv.SetItem(0, item.NewStack(item.Bucket{Content: item.LiquidBucketContent(Water{})}, 1))
+		tx.SetBlock(pos, d, nil)
+	})
+	bucketEmpty := func() bool {
+		it, _ := inv.Item(0)
+		b, ok := it.Item().(item.Bucket)
+		return ok && b.Empty()
+	}
+
+	redstoneWireTestSetBlockAndWait(t, w, inputPos, RedstoneBlock{})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		_, ok := tx.Liquid(front)
+		return ok
+	})
+	if !bucketEmpty() {
+		t.Fatal("dispenser did not keep an empty bucket after placing water")
+	}
+	for range 10 {
+		w.AdvanceTick()
+	}
+	if !bucketEmpty() {
+		t.Fatal("dispenser dispensed again while it stayed powered")
+	}
+
+	redstoneWireTestSetBlockAndWait(t, w, inputPos, Air{})
+	redstoneWireTestSetBlockAndWait(t, w, inputPos, RedstoneBlock{})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		_, ok := tx.Liquid(front)
+		return !ok
+	})
+	if bucketEmpty() {
+		t.Fatal("dispenser did not fill the empty bucket")
+	}
+}
+
+func TestDispenserDropsItemsWithoutBehaviour(t *testing.T) {
+	w, closeWorld := redstonePistonTestWorld()
+	defer closeWorld()
+
+	pos, inputPos := cube.Pos{0, 64, 0}, cube.Pos{1, 64, 0}
+	var inv *inventory.Inventory
+	runWorld(w, func(tx *world.Tx) {
+		d := NewDispenser()
+		d.Facing = cube.FaceUp
+		inv = d.Inventory(tx, pos)
+		_ = inv.SetItem(4, item.NewStack(Stone{}, 2))
+		tx.SetBlock(pos, d, nil)
+	})
+	redstoneWireTestSetBlockAndWait(t, w, inputPos, RedstoneBlock{})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		for range tx.EntitiesWithin(cube.Box(-2, 63, -2, 3, 67, 3)) {
+			return true
+		}
+		return false
+	})
+	if it, _ := inv.Item(4); it.Count() != 1 {
+		t.Fatalf("dispenser holds %d stone after dispensing, want 1", it.Count())
+	}
+}
+
+func TestDropperInsertsIntoContainer(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos, inputPos, barrelPos := cube.Pos{0, 64, 0}, cube.Pos{-1, 64, 0}, cube.Pos{1, 64, 0}
+	var inv, barrelInv *inventory.Inventory
+	runWorld(w, func(tx *world.Tx) {
+		d := NewDropper()
+		d.Facing = cube.FaceEast
+		inv = d.Inventory(tx, pos)
+		_ = inv.SetItem(0, item.NewStack(Stone{}, 3))
+		tx.SetBlock(pos, d, nil)
+
+		b := NewBarrel()
+		barrelInv = b.Inventory(tx, barrelPos)
+		tx.SetBlock(barrelPos, b, nil)
+	})
+	redstoneWireTestSetBlockAndWait(t, w, inputPos, RedstoneBlock{})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		return len(barrelInv.Items()) > 0
+	})
+	for range 10 {
+		w.AdvanceTick()
+	}
+	if it, _ := barrelInv.Item(0); it.Count() != 1 {
+		t.Fatalf("barrel holds %d stone, want 1", it.Count())
+	}
+	if it, _ := inv.Item(0); it.Count() != 2 {
+		t.Fatalf("dropper holds %d stone, want 2", it.Count())
+	}
+}
+
+func TestDispenserDispensesAfterShortPulse(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos, inputPos := cube.Pos{0, 64, 0}, cube.Pos{1, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		d := NewDispenser()
+		d.Facing = cube.FaceUp
+		_ = d.Inventory(tx, pos).SetItem(0, item.NewStack(item.Bucket{Content: item.LiquidBucketContent(Water{})}, 1))
+		tx.SetBlock(pos, d, nil)
+	})
+	redstoneWireTestSetBlockAndWait(t, w, inputPos, RedstoneBlock{})
+	redstoneWireTestSetBlockAndWait(t, w, inputPos, Air{})
+	runWorld(w, func(tx *world.Tx) {
+		if tx.Block(pos).(Dispenser).Triggered {
+			t.Fatal("dispenser stayed triggered after the pulse ended")
+		}
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		_, ok := tx.Liquid(pos.Side(cube.FaceUp))
+		return ok
+	})
+}
+
+// redstoneRailTestPlace places the rail r at pos the same way placeRail does, without requiring a user.
+func redstoneRailTestPlace(tx *world.Tx, pos cube.Pos, r railBlock) {
+	r = r.withRailShape(railShapeAt(pos, r, tx)).(railBlock)
+	tx.SetBlock(pos, r, nil)
+	connectRailNeighbours(pos, tx)
+}
+
+func TestRailsConnectCurveAndSlope(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	runWorld(w, func(tx *world.Tx) {
+		for x := range 4 {
+			for z := range 8 {
+				tx.SetBlock(cube.Pos{x, 63, z}, Stone{}, nil)
+			}
+		}
+		a, b, c := cube.Pos{0, 64, 0}, cube.Pos{1, 64, 0}, cube.Pos{0, 64, 1}
+		redstoneRailTestPlace(tx, a, Rail{Shape: RailShapeNorthSouth()})
+		redstoneRailTestPlace(tx, b, Rail{Shape: RailShapeNorthSouth()})
+		if s := tx.Block(a).(Rail).Shape; s != RailShapeEastWest() {
+			t.Errorf("first rail shape = %v, want east_west", s)
+		}
+		if s := tx.Block(b).(Rail).Shape; s != RailShapeEastWest() {
+			t.Errorf("second rail shape = %v, want east_west", s)
+		}
+
+		redstoneRailTestPlace(tx, c, Rail{Shape: RailShapeEastWest()})
+		if s := tx.Block(c).(Rail).Shape; s != RailShapeNorthSouth() {
+			t.Errorf("third rail shape = %v, want north_south", s)
+		}
+		if s := tx.Block(a).(Rail).Shape; s != RailShapeSouthEast() {
+			t.Errorf("first rail shape after connecting a third rail = %v, want south_east", s)
+		}
+
+		// Powered rails cannot curve, so the rail stays straight.
+		d := cube.Pos{3, 64, 3}
+		redstoneRailTestPlace(tx, d, PoweredRail{Shape: RailShapeNorthSouth()})
+		redstoneRailTestPlace(tx, d.Side(cube.FaceWest), Rail{Shape: RailShapeNorthSouth()})
+		if s := tx.Block(d).(PoweredRail).Shape; s != RailShapeEastWest() {
+			t.Errorf("powered rail shape = %v, want east_west", s)
+		}
+
+		upper, lower := cube.Pos{1, 65, 6}, cube.Pos{0, 64, 6}
+		tx.SetBlock(upper.Side(cube.FaceDown), Stone{}, nil)
+		redstoneRailTestPlace(tx, upper, Rail{Shape: RailShapeNorthSouth()})
+		redstoneRailTestPlace(tx, lower, Rail{Shape: RailShapeNorthSouth()})
+		if s := tx.Block(lower).(Rail).Shape; s != RailShapeAscendingEast() {
+			t.Errorf("lower rail shape = %v, want ascending_east", s)
+		}
+		if s := tx.Block(upper).(Rail).Shape; s != RailShapeEastWest() {
+			t.Errorf("upper rail shape = %v, want east_west", s)
+		}
+	})
+}
+
+func TestPoweredRailsSpreadPowerEightRails(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	runWorld(w, func(tx *world.Tx) {
+		for x := range 10 {
+			tx.SetBlock(cube.Pos{x, 63, 0}, Stone{}, nil)
+			tx.SetBlock(cube.Pos{x, 64, 0}, PoweredRail{Shape: RailShapeEastWest()}, nil)
+		}
+	})
+	redstoneWireTestSetBlockAndWait(t, w, cube.Pos{-1, 64, 0}, RedstoneBlock{})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		return tx.Block(cube.Pos{8, 64, 0}).(PoweredRail).Powered
+	})
+	for range 5 {
+		w.AdvanceTick()
+	}
+	runWorld(w, func(tx *world.Tx) {
+		for x := range 9 {
+			if !tx.Block(cube.Pos{x, 64, 0}).(PoweredRail).Powered {
+				t.Errorf("powered rail %d rails away from the redstone block was not powered", x)
+			}
+		}
+		if tx.Block(cube.Pos{9, 64, 0}).(PoweredRail).Powered {
+			t.Error("powered rail 9 rails away from the redstone block was powered")
+		}
+	})
+
+	redstoneWireTestSetBlockAndWait(t, w, cube.Pos{-1, 64, 0}, Air{})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		for x := range 10 {
+			if tx.Block(cube.Pos{x, 64, 0}).(PoweredRail).Powered {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+type redstoneMinecartTestEntityType struct {
+	redstoneTNTTestEntityType
+}
+
+func (redstoneMinecartTestEntityType) Open(_ *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return redstoneTNTTestEntity{handle: handle, data: data}
+}
+
+func (redstoneMinecartTestEntityType) EncodeEntity() string { return "test:minecart" }
+
+func TestDetectorRailPowersWhileMinecartIsOnTop(t *testing.T) {
+	w := world.Config{
+		Synchronous: true,
+		Entities:    world.EntityRegistryConfig{}.New([]world.EntityType{redstoneMinecartTestEntityType{}}),
+	}.New()
+	defer w.Close()
+
+	pos, lampPos := cube.Pos{0, 64, 0}, cube.Pos{0, 62, 0}
+	var e world.Entity
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(lampPos, RedstoneLamp{}, nil)
+		tx.SetBlock(pos, DetectorRail{Shape: RailShapeNorthSouth()}, nil)
+
+		e = tx.AddEntity(world.EntitySpawnOpts{Position: pos.Vec3Middle()}.New(redstoneMinecartTestEntityType{}, redstoneTNTTestEntityConfig{}))
+		tx.Block(pos).(DetectorRail).EntityInside(pos, tx, e)
+		if !tx.Block(pos).(DetectorRail).Powered {
+			t.Fatal("detector rail was not powered by a minecart")
+		}
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		return tx.Block(lampPos).(RedstoneLamp).Lit
+	})
+	for range 25 {
+		w.AdvanceTick()
+	}
+	runWorld(w, func(tx *world.Tx) {
+		if !tx.Block(pos).(DetectorRail).Powered {
+			t.Error("detector rail released while the minecart is still on it")
+		}
+		tx.RemoveEntity(e)
+	})
+	redstoneWireTestWaitFor(t, w, func(tx *world.Tx) bool {
+		return !tx.Block(pos).(DetectorRail).Powered
+	})
+}
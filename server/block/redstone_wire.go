@@ -131,6 +131,14 @@ func redstoneTicks(ticks int) time.Duration {
 	return time.Duration(max(ticks, 1)) * time.Second / 10
 }
 
+// redstoneSignalEdge reports whether the power passed turns the redstone signal at pos on or off compared to the last
+// power the redstone engine observed there. Consumers without a powered block state, such as doors, only react to
+// these edges so that they may still be opened and closed by hand.
+func redstoneSignalEdge(pos cube.Pos, tx *world.Tx, power int) (powered, changed bool) {
+	powered = power > 0
+	return powered, powered != (tx.Redstone().LastPower(pos) > 0)
+}
+
 // redstoneWireSupported reports whether redstone wire can stay placed at pos.
 func redstoneWireSupported(tx *world.Tx, pos cube.Pos) bool {
 	below := pos.Side(cube.FaceDown)
@@ -237,6 +245,9 @@ func redstoneWireDirectConnectionLoaded(tx *world.Tx, pos cube.Pos, face cube.Fa
 	if !ok {
 		return false
 	}
+	if connector, ok := b.(RedstoneWireConnector); ok {
+		return connector.ConnectsRedstoneWire(pos, face, tx)
+	}
 	switch b.(type) {
 	case RedstoneWire, world.RedstonePowerSource, world.RedstoneStrongPowerSource, world.RedstonePowerRelayer:
 		return true
@@ -83,7 +83,9 @@ func init() {
 	world.RegisterBlock(LilyPad{})
 	world.RegisterBlock(Magma{})
 	world.RegisterBlock(Melon{})
+	world.RegisterBlock(MobSpawner{})
 	world.RegisterBlock(MossCarpet{})
+	world.RegisterBlock(MovingBlock{})
 	world.RegisterBlock(MudBricks{})
 	world.RegisterBlock(Mud{})
 	world.RegisterBlock(NetherBrickFence{})
@@ -109,6 +111,8 @@ func init() {
 	world.RegisterBlock(RawGold{})
 	world.RegisterBlock(RawIron{})
 	world.RegisterBlock(RedstoneBlock{})
+	world.RegisterBlock(RedstoneLamp{})
+	world.RegisterBlock(RedstoneLamp{Lit: true})
 	world.RegisterBlock(ReinforcedDeepslate{})
 	world.RegisterBlock(ResinBricks{Chiseled: true})
 	world.RegisterBlock(ResinBricks{})
@@ -133,6 +137,7 @@ func init() {
 	world.RegisterBlock(SulfurBricks{})
 	world.RegisterBlock(PolishedSulfur{})
 	world.RegisterBlock(TNT{})
+	world.RegisterBlock(Target{})
 	world.RegisterBlock(Terracotta{})
 	world.RegisterBlock(TintedGlass{})
 	world.RegisterBlock(Tuff{})
@@ -155,6 +160,7 @@ func init() {
 		world.RegisterBlock(RedstoneOre{Type: ore, Lit: true})
 	}
 
+	registerAll(allActivatorRails())
 	registerAll(allAnvils())
 	registerAll(allBambooBlocks())
 	registerAll(allBamboos())
@@ -168,6 +174,7 @@ func init() {
 	registerAll(allBlastFurnaces())
 	registerAll(allBoneBlock())
 	registerAll(allBrewingStands())
+	registerAll(allButtons())
 	registerAll(allCactus())
 	registerAll(allCake())
 	registerAll(allCampfires())
@@ -177,15 +184,19 @@ func init() {
 	registerAll(allIronChains())
 	registerAll(allChests())
 	registerAll(allCocoaBeans())
+	registerAll(allComparators())
 	registerAll(allComposters())
 	registerAll(allConcrete())
 	registerAll(allConcretePowder())
 	registerAll(allCoral())
 	registerAll(allCoralBlocks())
 	registerAll(allDeepslate())
+	registerAll(allDetectorRails())
+	registerAll(allDispensers())
 	registerAll(allDoors())
 	registerAll(allDoubleFlowers())
 	registerAll(allDoubleTallGrass())
+	registerAll(allDroppers())
 	registerAll(allEndRods())
 	registerAll(allEnderChests())
 	registerAll(allFarmland())
@@ -215,17 +226,25 @@ func init() {
 	registerAll(allMuddyMangroveRoots())
 	registerAll(allNetherBricks())
 	registerAll(allNetherWart())
+	registerAll(allObservers())
 	registerAll(allPinkPetals())
+	registerAll(allPistonArms())
+	registerAll(allPistons())
 	registerAll(allPlanks())
 	registerAll(allPotato())
+	registerAll(allPoweredRails())
+	registerAll(allPressurePlates())
 	registerAll(allPrismarine())
 	registerAll(allPumpkinStems())
 	registerAll(allPumpkins())
 	registerAll(allPurpurs())
 	registerAll(allQuartz())
+	registerAll(allRails())
 	registerAll(allRedstoneTorches())
 	registerAll(allRedstoneWires())
+	registerAll(allRepeaters())
 	registerAll(allSandstones())
+	registerAll(allSaplings())
 	registerAll(allSeaPickles())
 	registerAll(allSigns())
 	registerAll(allSkulls())
@@ -240,6 +259,7 @@ func init() {
 	registerAll(allString())
 	registerAll(allSugarCane())
 	registerAll(allTorches())
+	registerAll(allTripwireHooks())
 	registerAll(allTrapdoors())
 	registerAll(allVines())
 	registerAll(allWalls())
@@ -261,6 +281,7 @@ func init() {
 }
 
 func init() {
+	world.RegisterItem(ActivatorRail{})
 	world.RegisterItem(Air{})
 	world.RegisterItem(Amethyst{})
 	world.RegisterItem(AncientDebris{})
@@ -300,6 +321,7 @@ func init() {
 	world.RegisterItem(Cobblestone{})
 	world.RegisterItem(Cobweb{})
 	world.RegisterItem(CocoaBean{})
+	world.RegisterItem(Comparator{})
 	world.RegisterItem(Composter{})
 	world.RegisterItem(CopperTorch{})
 	world.RegisterItem(CraftingTable{})
@@ -308,15 +330,18 @@ func init() {
 	world.RegisterItem(DeepslateBricks{})
 	world.RegisterItem(DeepslateTiles{Cracked: true})
 	world.RegisterItem(DeepslateTiles{})
+	world.RegisterItem(DetectorRail{})
 	world.RegisterItem(Diamond{})
 	world.RegisterItem(Diorite{Polished: true})
 	world.RegisterItem(Diorite{})
 	world.RegisterItem(DirtPath{})
 	world.RegisterItem(Dirt{Coarse: true})
 	world.RegisterItem(Dirt{})
+	world.RegisterItem(Dispenser{})
 	world.RegisterItem(DragonEgg{})
 	world.RegisterItem(DriedKelp{})
 	world.RegisterItem(Dripstone{})
+	world.RegisterItem(Dropper{})
 	world.RegisterItem(Emerald{})
 	world.RegisterItem(EnchantingTable{})
 	world.RegisterItem(EndBricks{})
@@ -362,6 +387,7 @@ func init() {
 	world.RegisterItem(Loom{})
 	world.RegisterItem(MelonSeeds{})
 	world.RegisterItem(Melon{})
+	world.RegisterItem(MobSpawner{})
 	world.RegisterItem(MossCarpet{})
 	world.RegisterItem(MudBricks{})
 	world.RegisterItem(MuddyMangroveRoots{})
@@ -376,15 +402,18 @@ func init() {
 	world.RegisterItem(Netherite{})
 	world.RegisterItem(Netherrack{})
 	world.RegisterItem(Note{Pitch: 24})
+	world.RegisterItem(Observer{})
 	world.RegisterItem(Obsidian{Crying: true})
 	world.RegisterItem(Obsidian{})
 	world.RegisterItem(PackedIce{})
 	world.RegisterItem(PackedMud{})
 	world.RegisterItem(PinkPetals{})
+	world.RegisterItem(Piston{})
 	world.RegisterItem(Podzol{})
 	world.RegisterItem(PolishedBlackstoneBrick{Cracked: true})
 	world.RegisterItem(PolishedBlackstoneBrick{})
 	world.RegisterItem(Potato{})
+	world.RegisterItem(PoweredRail{})
 	world.RegisterItem(PumpkinSeeds{})
 	world.RegisterItem(Pumpkin{Carved: true})
 	world.RegisterItem(Pumpkin{})
@@ -394,13 +423,16 @@ func init() {
 	world.RegisterItem(QuartzPillar{})
 	world.RegisterItem(Quartz{Smooth: true})
 	world.RegisterItem(Quartz{})
+	world.RegisterItem(Rail{})
 	world.RegisterItem(RawCopper{})
 	world.RegisterItem(RawGold{})
 	world.RegisterItem(RawIron{})
 	world.RegisterItem(RedstoneBlock{})
+	world.RegisterItem(RedstoneLamp{})
 	world.RegisterItem(RedstoneTorch{})
 	world.RegisterItem(RedstoneWire{})
 	world.RegisterItem(ReinforcedDeepslate{})
+	world.RegisterItem(Repeater{})
 	world.RegisterItem(ResinBricks{Chiseled: true})
 	world.RegisterItem(ResinBricks{})
 	world.RegisterItem(Resin{})
@@ -419,6 +451,7 @@ func init() {
 	world.RegisterItem(Sponge{Wet: true})
 	world.RegisterItem(Sponge{})
 	world.RegisterItem(SporeBlossom{})
+	world.RegisterItem(StickyPiston{})
 	world.RegisterItem(Stonecutter{})
 	world.RegisterItem(Stone{Smooth: true})
 	world.RegisterItem(Stone{})
@@ -429,8 +462,10 @@ func init() {
 	world.RegisterItem(SulfurBricks{})
 	world.RegisterItem(PolishedSulfur{})
 	world.RegisterItem(TNT{})
+	world.RegisterItem(Target{})
 	world.RegisterItem(Terracotta{})
 	world.RegisterItem(TintedGlass{})
+	world.RegisterItem(TripwireHook{})
 	world.RegisterItem(Tuff{})
 	world.RegisterItem(Tuff{Chiseled: true})
 	world.RegisterItem(TuffBricks{})
@@ -493,6 +528,9 @@ func init() {
 		world.RegisterItem(WoodFence{Wood: w})
 		world.RegisterItem(WoodTrapdoor{Wood: w})
 	}
+	for _, w := range saplingWoodTypes() {
+		world.RegisterItem(Sapling{Wood: w})
+	}
 	world.RegisterItem(Leaves{Type: AzaleaLeaves(), Persistent: true})
 	world.RegisterItem(Leaves{Type: FloweringAzaleaLeaves(), Persistent: true})
 	for _, ore := range OreTypes() {
@@ -543,6 +581,12 @@ func init() {
 	for _, s := range StairsBlocks() {
 		world.RegisterItem(Stairs{Block: s})
 	}
+	for _, b := range ButtonBlocks() {
+		world.RegisterItem(Button{Block: b})
+	}
+	for _, b := range PressurePlateBlocks() {
+		world.RegisterItem(PressurePlate{Block: b})
+	}
 	for _, t := range BlackstoneTypes() {
 		world.RegisterItem(Blackstone{Type: t})
 	}
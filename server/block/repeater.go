@@ -0,0 +1,189 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var (
+	_ world.RedstoneStrongPowerSource  = Repeater{}
+	_ world.RedstonePowerContextAction = Repeater{}
+	_ world.ScheduledTicker            = Repeater{}
+	_ RedstoneWireConnector            = Repeater{}
+)
+
+// Repeater is a redstone component that repeats a redstone signal received from behind it at full strength, after a
+// delay of 1-4 redstone ticks. A repeater is locked while a powered repeater or comparator faces into one of its
+// sides, in which case it keeps its output until it is unlocked.
+type Repeater struct {
+	transparent
+	diode
+
+	// Facing is the direction the repeater outputs power to. It receives power from the opposite direction.
+	Facing cube.Direction
+	// Delay is the delay of the repeater, ranging from 0-3. The repeater changes its output 1-4 redstone ticks
+	// after its input changes, so a Delay of 0 equals a delay of one redstone tick.
+	Delay int
+	// Powered is true if the repeater is outputting power.
+	Powered bool
+}
+
+func (Repeater) HasLiquidDrops() bool {
+	return true
+}
+
+func (r Repeater) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(Repeater{}))
+}
+
+func (Repeater) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+func (r Repeater) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, r)
+	if !used || !redstoneDiodeSupported(pos, tx) {
+		return false
+	}
+	r.Facing = user.Rotation().Direction()
+	r.Powered = false
+
+	place(tx, pos, r, user, ctx)
+	return placed(ctx)
+}
+
+// Activate cycles the delay of the repeater.
+func (r Repeater) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
+	r.Delay = (r.Delay + 1) % 4
+	tx.SetBlock(pos, r, &world.SetOpts{DisableRedstoneUpdates: true})
+	// Ticks scheduled with the previous delay no longer match the block, so the update is scheduled again.
+	r.scheduleUpdate(pos, tx)
+	return true
+}
+
+// NeighbourUpdateTick breaks unsupported repeaters and otherwise schedules an output refresh.
+func (r Repeater) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !redstoneDiodeSupported(pos, tx) {
+		breakBlock(r, pos, tx)
+		return
+	}
+	r.scheduleUpdate(pos, tx)
+}
+
+// RedstonePower emits full power from the front of the repeater while it is powered.
+func (r Repeater) RedstonePower(_ cube.Pos, _ *world.Tx, face cube.Face) int {
+	if r.Powered && face == r.Facing.Face() {
+		return 15
+	}
+	return 0
+}
+
+// RedstoneStrongPower strongly powers the block in front of the repeater while it is powered.
+func (r Repeater) RedstoneStrongPower(pos cube.Pos, tx *world.Tx, face cube.Face) int {
+	return r.RedstonePower(pos, tx, face)
+}
+
+// ConnectsRedstoneWire only connects redstone wire to the front and back of the repeater.
+func (r Repeater) ConnectsRedstoneWire(_ cube.Pos, face cube.Face, _ *world.Tx) bool {
+	return face.Axis() == r.Facing.Face().Axis()
+}
+
+// RedstonePowerActionUpdate schedules an output change when the input of the repeater changes.
+func (r Repeater) RedstonePowerActionUpdate(pos cube.Pos, tx *world.Tx, _ world.RedstoneUpdate) {
+	if tx == nil {
+		return
+	}
+	r.scheduleUpdate(pos, tx)
+}
+
+// ScheduledTick changes the output of the repeater after its delay. A repeater that is powered always stays powered
+// for at least its delay, so that short pulses are extended to the delay of the repeater.
+func (r Repeater) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if tx == nil {
+		return
+	}
+	var ok bool
+	if r, ok = tx.Block(pos).(Repeater); !ok || r.locked(pos, tx) {
+		return
+	}
+	input := r.inputPowered(pos, tx)
+	if r.Powered && input {
+		return
+	}
+	r.Powered = !r.Powered
+	tx.SetBlock(pos, r, &world.SetOpts{DisableRedstoneUpdates: true})
+	tx.Redstone().ScheduleUpdate(pos)
+	if r.Powered && !input {
+		// The input was only powered briefly, so the pulse is extended to the delay of the repeater.
+		tx.ScheduleBlockUpdate(pos, r, r.delay())
+	}
+}
+
+// scheduleUpdate schedules a scheduled tick after the delay of the repeater if its output does not match its input.
+func (r Repeater) scheduleUpdate(pos cube.Pos, tx *world.Tx) {
+	if !r.locked(pos, tx) && r.Powered != r.inputPowered(pos, tx) {
+		tx.ScheduleBlockUpdate(pos, r, r.delay())
+	}
+}
+
+// delay returns the delay of the repeater as a duration.
+func (r Repeater) delay() time.Duration {
+	return redstoneTicks(r.Delay + 1)
+}
+
+// inputPowered reports whether the repeater receives power from behind.
+func (r Repeater) inputPowered(pos cube.Pos, tx *world.Tx) bool {
+	return tx.RedstonePowerFrom(pos, r.Facing.Opposite().Face()) > 0
+}
+
+// locked reports whether a powered repeater or comparator faces into one of the sides of the repeater.
+func (r Repeater) locked(pos cube.Pos, tx *world.Tx) bool {
+	for _, d := range [...]cube.Direction{r.Facing.RotateLeft(), r.Facing.RotateRight()} {
+		switch side := tx.Block(pos.Side(d.Face())).(type) {
+		case Repeater:
+			if side.Powered && side.Facing == d.Opposite() {
+				return true
+			}
+		case Comparator:
+			if side.Powered && side.Facing == d.Opposite() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (Repeater) EncodeItem() (name string, meta int16) {
+	return "minecraft:repeater", 0
+}
+
+func (r Repeater) EncodeBlock() (string, map[string]any) {
+	name := "minecraft:unpowered_repeater"
+	if r.Powered {
+		name = "minecraft:powered_repeater"
+	}
+	return name, map[string]any{"minecraft:cardinal_direction": r.Facing.Opposite().String(), "repeater_delay": int32(r.Delay)}
+}
+
+// redstoneDiodeSupported reports whether a repeater or comparator can stay placed at pos.
+func redstoneDiodeSupported(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	if below.OutOfBounds(tx.Range()) {
+		return false
+	}
+	return tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx)
+}
+
+func allRepeaters() (all []world.Block) {
+	for _, d := range cube.Directions() {
+		for delay := range 4 {
+			all = append(all, Repeater{Facing: d, Delay: delay}, Repeater{Facing: d, Delay: delay, Powered: true})
+		}
+	}
+	return
+}
@@ -0,0 +1,156 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Sapling is a non-solid plant that grows into a tree over time. Dark oak and pale oak saplings only grow when
+// planted in a 2x2 square, while spruce and jungle saplings planted in a 2x2 square grow into a large tree.
+// Mangrove, crimson, warped and bamboo wood have no sapling.
+type Sapling struct {
+	empty
+	transparent
+
+	// Wood is the type of wood of the tree that the sapling grows into.
+	Wood WoodType
+	// Ready specifies if the sapling has passed its first growth stage. A sapling that is ready grows into a tree
+	// in the next growth stage.
+	Ready bool
+}
+
+var (
+	_ item.BoneMealAffected = Sapling{}
+	_ Flammable             = Sapling{}
+)
+
+// BoneMeal ...
+func (s Sapling) BoneMeal(pos cube.Pos, tx *world.Tx) item.BoneMealResult {
+	if rand.Float64() < 0.45 {
+		s.grow(pos, tx, rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())))
+	}
+	return item.BoneMealResultSmall
+}
+
+// RandomTick ...
+func (s Sapling) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	if tx.Light(pos.Side(cube.FaceUp)) >= 9 && r.IntN(7) == 0 {
+		s.grow(pos, tx, r)
+	}
+}
+
+// grow advances the sapling to its next growth stage. A sapling that is not yet ready becomes ready, while a sapling
+// that is ready attempts to grow into a tree.
+func (s Sapling) grow(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	if !s.Ready {
+		s.Ready = true
+		tx.SetBlock(pos, s, nil)
+		return
+	}
+	base, large := s.square(pos, tx)
+	t, ok := NewTree(s.Wood, large, r)
+	if !ok && large {
+		// Spruce and jungle saplings in a square that do not fit a large tree may still grow into a small one.
+		base, large = pos, false
+		t, ok = NewTree(s.Wood, large, r)
+	}
+	if !ok || !t.canGrow(base, tx) {
+		return
+	}
+	ctx := tx.Event()
+	if tx.World().Handler().HandleTreeGrow(ctx, base, t); ctx.Cancelled() {
+		return
+	}
+	tx.BuildStructure(base.Add(t.min), t)
+}
+
+// square looks for a 2x2 square of saplings of the same wood type that the sapling at pos is part of. If found, the
+// position of the north-west sapling of the square is returned along with true.
+func (s Sapling) square(pos cube.Pos, tx *world.Tx) (cube.Pos, bool) {
+	for _, offset := range [...]cube.Pos{{0, 0, 0}, {-1, 0, 0}, {0, 0, -1}, {-1, 0, -1}} {
+		base := pos.Add(offset)
+		if s.sameWood(tx.Block(base)) && s.sameWood(tx.Block(base.Add(cube.Pos{1, 0, 0}))) &&
+			s.sameWood(tx.Block(base.Add(cube.Pos{0, 0, 1}))) && s.sameWood(tx.Block(base.Add(cube.Pos{1, 0, 1}))) {
+			return base, true
+		}
+	}
+	return pos, false
+}
+
+// sameWood checks if the block passed is a sapling of the same wood type.
+func (s Sapling) sameWood(b world.Block) bool {
+	sapling, ok := b.(Sapling)
+	return ok && sapling.Wood == s.Wood
+}
+
+// NeighbourUpdateTick ...
+func (s Sapling) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !supportsVegetation(s, tx.Block(pos.Side(cube.FaceDown))) {
+		breakBlock(s, pos, tx)
+	}
+}
+
+// UseOnBlock ...
+func (s Sapling) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, s)
+	if !used || !supportsVegetation(s, tx.Block(pos.Side(cube.FaceDown))) {
+		return false
+	}
+	s.Ready = false
+
+	place(tx, pos, s, user, ctx)
+	return placed(ctx)
+}
+
+// HasLiquidDrops ...
+func (Sapling) HasLiquidDrops() bool {
+	return true
+}
+
+// FlammabilityInfo ...
+func (Sapling) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(60, 100, false)
+}
+
+// BreakInfo ...
+func (s Sapling) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(Sapling{Wood: s.Wood}))
+}
+
+// CompostChance ...
+func (Sapling) CompostChance() float64 {
+	return 0.3
+}
+
+// FuelInfo ...
+func (Sapling) FuelInfo() item.FuelInfo {
+	return newFuelInfo(time.Second * 5)
+}
+
+// EncodeItem ...
+func (s Sapling) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + s.Wood.String() + "_sapling", 0
+}
+
+// EncodeBlock ...
+func (s Sapling) EncodeBlock() (string, map[string]any) {
+	return "minecraft:" + s.Wood.String() + "_sapling", map[string]any{"age_bit": boolByte(s.Ready)}
+}
+
+// saplingWoodTypes returns all wood types that have a sapling.
+func saplingWoodTypes() []WoodType {
+	return []WoodType{OakWood(), SpruceWood(), BirchWood(), JungleWood(), AcaciaWood(), DarkOakWood(), CherryWood(), PaleOakWood()}
+}
+
+// allSaplings returns a list of all possible sapling states.
+func allSaplings() (saplings []world.Block) {
+	for _, w := range saplingWoodTypes() {
+		saplings = append(saplings, Sapling{Wood: w}, Sapling{Wood: w, Ready: true})
+	}
+	return
+}
@@ -140,6 +140,14 @@ func (s *smelter) Inventory(*world.Tx, cube.Pos) *inventory.Inventory {
 	return s.inventory
 }
 
+// ComparatorSignal returns the signal read from the smelter by a comparator, based on how full its inventory is.
+func (s *smelter) ComparatorSignal(cube.Pos, *world.Tx) int {
+	if s == nil {
+		return 0
+	}
+	return inventoryComparatorSignal(s.inventory)
+}
+
 // AddViewer adds a viewer to the furnace, so that it is updated whenever the inventory of the furnace is changed.
 func (s *smelter) AddViewer(v ContainerViewer, _ *world.Tx, _ cube.Pos) {
 	s.mu.Lock()
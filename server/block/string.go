@@ -1,6 +1,8 @@
 package block
 
 import (
+	"math/rand/v2"
+
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
@@ -9,7 +11,7 @@ import (
 
 // String is an item obtained from spiders and cobwebs. When placed, it creates a tripwire that
 // detects entities passing through it.
-// TODO: Redstone functionality (entity detection, tripwire hook circuits, shears-disarm propagation).
+// TODO: Shears-disarm propagation.
 type String struct {
 	empty
 	transparent
@@ -33,9 +35,48 @@ func (s String) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world
 	below := pos.Side(cube.FaceDown)
 	s.Suspended = !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx)
 	place(tx, pos, s, user, ctx)
+	if placed(ctx) {
+		updateTripwireHooks(pos, tx)
+	}
 	return placed(ctx)
 }
 
+// EntityInside activates the tripwire if it was not yet powered.
+func (s String) EntityInside(pos cube.Pos, tx *world.Tx, _ world.Entity) {
+	if !s.Powered {
+		s.update(pos, tx)
+	}
+}
+
+// SensesEntities ...
+func (String) SensesEntities() {}
+
+// ScheduledTick releases the tripwire once no entities are left inside it.
+func (s String) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if tx == nil {
+		return
+	}
+	var ok bool
+	if s, ok = tx.Block(pos).(String); ok {
+		s.update(pos, tx)
+	}
+}
+
+// update updates the powered state of the tripwire depending on the entities inside it. The tripwire hooks it is
+// attached to are updated if the powered state changes.
+func (s String) update(pos cube.Pos, tx *world.Tx) {
+	powered := len(entitiesIntersecting(tx, cube.Box(0, 0, 0, 1, 0.15625, 1).Translate(pos.Vec3()))) > 0
+	if powered != s.Powered {
+		s.Powered = powered
+		tx.SetBlock(pos, s, nil)
+		updateTripwireHooks(pos, tx)
+	}
+	if powered {
+		// Updating the tripwire hooks may have attached or detached the tripwire, so the block is fetched again.
+		tx.ScheduleBlockUpdate(pos, tx.Block(pos), redstoneTicks(5))
+	}
+}
+
 // NeighbourUpdateTick ...
 func (s String) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
 	below := pos.Side(cube.FaceDown)
@@ -43,6 +84,10 @@ func (s String) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
 	if suspended != s.Suspended {
 		s.Suspended = suspended
 		tx.SetBlock(pos, s, nil)
+		if s.Powered {
+			// Changing the block invalidates the tick scheduled to release the tripwire, so it is scheduled again.
+			tx.ScheduleBlockUpdate(pos, s, redstoneTicks(5))
+		}
 	}
 }
 
@@ -53,7 +98,9 @@ func (s String) HasLiquidDrops() bool {
 
 // BreakInfo ...
 func (s String) BreakInfo() BreakInfo {
-	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(String{}))
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(String{})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, _ item.User) {
+		updateTripwireHooks(pos, tx)
+	})
 }
 
 // EncodeItem ...
@@ -0,0 +1,106 @@
+package block
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var (
+	_ world.RedstonePowerSource = Target{}
+	_ world.ScheduledTicker     = Target{}
+	_ ProjectileHitter          = Target{}
+)
+
+// Target is a block that emits a short redstone signal when it is hit by a projectile. The closer the projectile
+// hits to the centre of the face, the stronger the signal is.
+type Target struct {
+	solid
+
+	// Power is the redstone power currently emitted by the target, ranging from 0 to 15. Bedrock Edition has no
+	// block state for it, so it is stored in the NBT of the block instead.
+	Power int
+}
+
+// ProjectileHit powers the target depending on how close to the centre of the face the projectile hit it. Hits
+// are ignored while the target is still powered by an earlier hit.
+func (t Target) ProjectileHit(pos cube.Pos, tx *world.Tx, e world.Entity, face cube.Face) {
+	if t.Power > 0 {
+		return
+	}
+	t.Power = targetPower(e.Position().Sub(pos.Vec3()), face)
+	tx.SetBlock(pos, t, nil)
+
+	duration := redstoneTicks(4)
+	if sticksInBlocks(e) {
+		duration = redstoneTicks(10)
+	}
+	tx.ScheduleBlockUpdate(pos, t, duration)
+}
+
+// targetPower calculates the power of a target hit at a position relative to the target on the face passed.
+func targetPower(hit mgl64.Vec3, face cube.Face) int {
+	x, y, z := targetOffset(hit[0]), targetOffset(hit[1]), targetOffset(hit[2])
+	var dist float64
+	switch face.Axis() {
+	case cube.X:
+		dist = max(y, z)
+	case cube.Y:
+		dist = max(x, z)
+	case cube.Z:
+		dist = max(x, y)
+	}
+	return max(int(math.Ceil(15*mgl64.Clamp((0.5-dist)/0.5, 0, 1))), 1)
+}
+
+// targetOffset returns the distance of a coordinate relative to a target from the centre of the target.
+func targetOffset(v float64) float64 {
+	return math.Abs(mgl64.Clamp(v, 0, 1) - 0.5)
+}
+
+// ScheduledTick turns off the target after it was hit.
+func (t Target) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if tx == nil {
+		return
+	}
+	var ok bool
+	if t, ok = tx.Block(pos).(Target); ok && t.Power > 0 {
+		t.Power = 0
+		tx.SetBlock(pos, t, nil)
+	}
+}
+
+// RedstonePower ...
+func (t Target) RedstonePower(cube.Pos, *world.Tx, cube.Face) int {
+	return t.Power
+}
+
+// BreakInfo ...
+func (t Target) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, hoeEffective, oneOf(Target{}))
+}
+
+// EncodeItem ...
+func (Target) EncodeItem() (name string, meta int16) {
+	return "minecraft:target", 0
+}
+
+// EncodeBlock ...
+func (Target) EncodeBlock() (string, map[string]any) {
+	return "minecraft:target", nil
+}
+
+// EncodeNBT ...
+func (t Target) EncodeNBT() map[string]any {
+	return map[string]any{"id": "Target", "Power": int32(t.Power)}
+}
+
+// DecodeNBT ...
+func (t Target) DecodeNBT(data map[string]any) any {
+	t.Power = int(nbtconv.Int32(data, "Power"))
+	return t
+}
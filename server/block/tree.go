@@ -0,0 +1,307 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Tree is a world.Structure holding the logs and leaves of a tree. Trees are grown from Saplings and placed by world
+// generators. The positions of the blocks are relative to the base of the trunk, or to the north-west log of the
+// trunk if the tree is large. A Tree may be generated using NewTree.
+type Tree struct {
+	wood  WoodType
+	large bool
+
+	blocks   map[cube.Pos]world.Block
+	min, max cube.Pos
+}
+
+// NewTree generates a tree of the wood type passed. If large is true, a tree with a 2x2 trunk is generated. false
+// is returned if no tree exists for the wood type and size passed.
+func NewTree(wood WoodType, large bool, r *rand.Rand) (*Tree, bool) {
+	t := &Tree{wood: wood, large: large, blocks: make(map[cube.Pos]world.Block)}
+	switch {
+	case wood == OakWood() && !large:
+		t.round(4+r.IntN(3), r)
+	case wood == BirchWood() && !large:
+		t.round(5+r.IntN(3), r)
+	case wood == JungleWood() && !large:
+		t.round(4+r.IntN(7), r)
+	case wood == JungleWood():
+		t.megaJungle(r)
+	case wood == SpruceWood() && !large:
+		t.spruce(r)
+	case wood == SpruceWood():
+		t.megaSpruce(r)
+	case wood == AcaciaWood() && !large:
+		t.acacia(r)
+	case wood == CherryWood() && !large:
+		t.cherry(r)
+	case (wood == DarkOakWood() || wood == PaleOakWood()) && large:
+		t.darkOak(r)
+	default:
+		return nil, false
+	}
+	return t, true
+}
+
+// round generates a tree with a trunk of the height passed and a rounded crown around the top of the trunk, like
+// oak, birch and small jungle trees.
+func (t *Tree) round(height int, r *rand.Rand) {
+	for y := height - 3; y <= height; y++ {
+		rel := y - height
+		t.layer(y, 1-rel/2, func(ax, az, radius int) bool {
+			// Corners of the crown are randomly left out, and always for the top layer.
+			return ax == radius && az == radius && (rel == 0 || r.IntN(2) == 0)
+		})
+	}
+	t.trunk(cube.Pos{}, height)
+}
+
+// spruce generates a spruce tree with a conical crown of leaves.
+func (t *Tree) spruce(r *rand.Rand) {
+	height := 6 + r.IntN(4)
+	radius, minRadius, maxRadius, leafRadius := r.IntN(2), 0, 1, 2+r.IntN(2)
+	bare := 1 + r.IntN(2)
+	for y := height; y >= bare; y-- {
+		t.layer(y, radius, cutCorners)
+		if radius >= maxRadius {
+			radius, minRadius, maxRadius = minRadius, 1, min(maxRadius+1, leafRadius)
+		} else {
+			radius++
+		}
+	}
+	t.trunk(cube.Pos{}, height)
+}
+
+// megaSpruce generates a tall spruce tree with a 2x2 trunk and a long, conical crown.
+func (t *Tree) megaSpruce(r *rand.Rand) {
+	height := 13 + r.IntN(13)
+	crown := height/2 + r.IntN(4)
+	for y := height; y > height-crown; y-- {
+		t.layer(y, min((height-y+1)/3, 4), cutCorners)
+	}
+	t.trunk(cube.Pos{}, height)
+}
+
+// megaJungle generates a tall jungle tree with a 2x2 trunk, a wide crown at the top and a few short branches with
+// leaves along the trunk.
+func (t *Tree) megaJungle(r *rand.Rand) {
+	height := 10 + r.IntN(20)
+	for y := height - 3; y <= height+1; y++ {
+		t.layer(y, max(1, 3-max(0, y-height+1)), cutCorners)
+	}
+	for y := height - 2 - r.IntN(4); y > 4; y -= 2 + r.IntN(4) {
+		dir := cube.Directions()[r.IntN(4)]
+		base := cube.Pos{0, y, 0}
+		if dir == cube.East {
+			base[0] = 1
+		} else if dir == cube.South {
+			base[2] = 1
+		}
+		branch := base.Side(dir.Face())
+		end := branch.Side(dir.Face())
+		t.log(branch)
+		t.log(end)
+		t.leavesAround(end.Add(cube.Pos{0, 1, 0}), 2)
+		t.leavesAround(end.Add(cube.Pos{0, 2, 0}), 1)
+	}
+	t.trunk(cube.Pos{}, height)
+}
+
+// acacia generates an acacia tree with a trunk that bends to one side and a flat crown.
+func (t *Tree) acacia(r *rand.Rand) {
+	height := 5 + r.IntN(3)
+	bend := height - 1 - r.IntN(3)
+	dir := cube.Directions()[r.IntN(4)]
+
+	pos := cube.Pos{}
+	for y := range height {
+		if y >= bend {
+			pos = pos.Side(dir.Face())
+		}
+		pos[1] = y
+		t.log(pos)
+	}
+	top := pos.Add(cube.Pos{0, 1, 0})
+	for dx := -3; dx <= 3; dx++ {
+		for dz := -3; dz <= 3; dz++ {
+			if abs(dx)+abs(dz) <= 4 && (abs(dx) < 3 || abs(dz) < 3) {
+				t.leaves(top.Add(cube.Pos{dx, 0, dz}))
+			}
+		}
+	}
+	t.leavesAround(top.Add(cube.Pos{0, 1, 0}), 1)
+	t.soil(cube.Pos{})
+}
+
+// cherry generates a cherry tree with a wide, irregular crown.
+func (t *Tree) cherry(r *rand.Rand) {
+	height := 5 + r.IntN(3)
+	for y := height - 2; y <= height+1; y++ {
+		t.layer(y, []int{3, 4, 3, 2}[y-height+2], func(ax, az, radius int) bool {
+			return ax == radius && az == radius || (ax == radius || az == radius) && r.IntN(3) == 0
+		})
+	}
+	t.trunk(cube.Pos{}, height)
+}
+
+// darkOak generates a tree with a 2x2 trunk and a wide, flat crown, like dark oak and pale oak trees.
+func (t *Tree) darkOak(r *rand.Rand) {
+	height := 6 + r.IntN(3)
+	for y := height - 2; y <= height+1; y++ {
+		t.layer(y, []int{3, 3, 2, 1}[y-height+2], cutCorners)
+	}
+	t.trunk(cube.Pos{}, height)
+}
+
+// cutCorners may be passed to Tree.layer to leave out the corners of a layer of leaves.
+func cutCorners(ax, az, radius int) bool {
+	return ax == radius && az == radius
+}
+
+// layer places a square layer of leaves with the radius passed around the trunk at a y level. skip is called with
+// the horizontal distance to the trunk for every position in the layer and may return true to leave out the leaves
+// at that position. Layers with a radius of 3 or more are rounded.
+func (t *Tree) layer(y, radius int, skip func(ax, az, radius int) bool) {
+	hi := radius
+	if t.large {
+		hi++
+	}
+	for dx := -radius; dx <= hi; dx++ {
+		for dz := -radius; dz <= hi; dz++ {
+			ax, az := t.distance(dx), t.distance(dz)
+			if radius >= 3 && ax*ax+az*az > radius*radius+1 {
+				continue
+			}
+			if !skip(ax, az, radius) {
+				t.leaves(cube.Pos{dx, y, dz})
+			}
+		}
+	}
+}
+
+// distance returns the horizontal distance of a relative x or z to the trunk of the tree.
+func (t *Tree) distance(v int) int {
+	if t.large && v > 0 {
+		return v - 1
+	}
+	return abs(v)
+}
+
+// leavesAround places a small layer of leaves with the radius passed around a position, leaving out the corners.
+func (t *Tree) leavesAround(pos cube.Pos, radius int) {
+	for dx := -radius; dx <= radius; dx++ {
+		for dz := -radius; dz <= radius; dz++ {
+			if radius == 0 || abs(dx) != radius || abs(dz) != radius {
+				t.leaves(pos.Add(cube.Pos{dx, 0, dz}))
+			}
+		}
+	}
+}
+
+// trunk places a vertical trunk of the height passed at pos. The trunk is 2x2 for large trees. The soil below the
+// trunk is turned into dirt.
+func (t *Tree) trunk(pos cube.Pos, height int) {
+	size := 1
+	if t.large {
+		size = 2
+	}
+	for dx := range size {
+		for dz := range size {
+			for y := range height {
+				t.log(pos.Add(cube.Pos{dx, y, dz}))
+			}
+			t.soil(pos.Add(cube.Pos{dx, 0, dz}))
+		}
+	}
+}
+
+// log places a log at a position in the tree, replacing any leaves at that position.
+func (t *Tree) log(pos cube.Pos) {
+	t.set(pos, Log{Wood: t.wood, Axis: cube.Y})
+}
+
+// leaves places leaves at a position in the tree if no log was placed there yet.
+func (t *Tree) leaves(pos cube.Pos) {
+	if _, ok := t.blocks[pos]; ok {
+		return
+	}
+	l, _ := t.wood.Leaves()
+	t.set(pos, Leaves{Type: l})
+}
+
+// soil turns the soil below a position of the trunk into dirt.
+func (t *Tree) soil(pos cube.Pos) {
+	t.set(pos.Side(cube.FaceDown), Dirt{})
+}
+
+// set sets a block at a position in the tree and updates the bounds of the tree.
+func (t *Tree) set(pos cube.Pos, b world.Block) {
+	if len(t.blocks) == 0 {
+		t.min, t.max = pos, pos
+	}
+	for i := range 3 {
+		t.min[i], t.max[i] = min(t.min[i], pos[i]), max(t.max[i], pos[i])
+	}
+	t.blocks[pos] = b
+}
+
+// Blocks returns the blocks of the tree by their position relative to the base of the trunk. This includes the dirt
+// that the soil below the trunk is turned into. The map returned must not be modified.
+func (t *Tree) Blocks() map[cube.Pos]world.Block {
+	return t.blocks
+}
+
+// canGrow checks if the tree can be grown from a sapling at pos. All logs of the tree must be within the world and
+// be placed in blocks that a tree may grow into.
+func (t *Tree) canGrow(pos cube.Pos, tx *world.Tx) bool {
+	for rel, b := range t.blocks {
+		if _, ok := b.(Log); !ok {
+			continue
+		}
+		p := pos.Add(rel)
+		if p.OutOfBounds(tx.Range()) || !treeReplaceable(tx.Block(p)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Dimensions ...
+func (t *Tree) Dimensions() [3]int {
+	return [3]int{t.max[0] - t.min[0] + 1, t.max[1] - t.min[1] + 1, t.max[2] - t.min[2] + 1}
+}
+
+// At ...
+func (t *Tree) At(x, y, z int, blockAt func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	b, ok := t.blocks[t.min.Add(cube.Pos{x, y, z})]
+	if !ok {
+		return nil, nil
+	}
+	existing := blockAt(x, y, z)
+	if _, ok := b.(Dirt); ok {
+		if _, soil := existing.(Soil); !soil {
+			return nil, nil
+		}
+		return b, nil
+	}
+	if !treeReplaceable(existing) {
+		return nil, nil
+	}
+	return b, nil
+}
+
+// treeReplaceable checks if a tree may grow into the block passed.
+func treeReplaceable(b world.Block) bool {
+	switch b.(type) {
+	case Air, Leaves, Sapling:
+		return true
+	case Replaceable:
+		_, liquid := b.(world.Liquid)
+		return !liquid
+	}
+	return false
+}
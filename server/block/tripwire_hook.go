@@ -0,0 +1,202 @@
+package block
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var _ world.RedstoneStrongPowerSource = TripwireHook{}
+
+// maxTripwireLength is the maximum number of tripwire blocks that may be placed between two tripwire hooks for
+// them to attach.
+const maxTripwireLength = 40
+
+// TripwireHook is a non-solid block that, together with a second tripwire hook facing it and a line of tripwire
+// between them, emits redstone power when an entity passes through the tripwire.
+type TripwireHook struct {
+	empty
+	transparent
+	flowingWaterDisplacer
+
+	// Facing is the direction the tripwire hook is facing, away from the block it is attached to and towards the
+	// tripwire.
+	Facing cube.Direction
+	// Attached is true if the tripwire hook is connected to another tripwire hook through tripwire.
+	Attached bool
+	// Powered is true if an entity is passing through the tripwire attached to the hook.
+	Powered bool
+}
+
+// RedstonePower ...
+func (h TripwireHook) RedstonePower(cube.Pos, *world.Tx, cube.Face) int {
+	if h.Powered {
+		return 15
+	}
+	return 0
+}
+
+// RedstoneStrongPower strongly powers the block the tripwire hook is attached to while it is powered.
+func (h TripwireHook) RedstoneStrongPower(_ cube.Pos, _ *world.Tx, face cube.Face) int {
+	if h.Powered && h.Facing.Opposite().Face() == face {
+		return 15
+	}
+	return 0
+}
+
+// SideClosed ...
+func (h TripwireHook) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// NeighbourUpdateTick ...
+func (h TripwireHook) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	supportPos := pos.Side(h.Facing.Opposite().Face())
+	if !tx.Block(supportPos).Model().FaceSolid(supportPos, h.Facing.Face(), tx) {
+		breakBlock(h, pos, tx)
+	}
+}
+
+// UseOnBlock ...
+func (h TripwireHook) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(tx, pos, face, h)
+	if !used || face.Axis() == cube.Y {
+		return false
+	}
+	supportPos := pos.Side(face.Opposite())
+	if !tx.Block(supportPos).Model().FaceSolid(supportPos, face, tx) {
+		return false
+	}
+
+	h = TripwireHook{Facing: face.Direction()}
+	place(tx, pos, h, user, ctx)
+	if placed(ctx) {
+		h.calculateState(pos, tx)
+	}
+	return placed(ctx)
+}
+
+// calculateState looks for a tripwire hook facing this one at the other end of a line of tripwire. If found, both
+// hooks and the tripwire between them are attached, and the hooks are powered if any of the tripwire is powered.
+func (h TripwireHook) calculateState(pos cube.Pos, tx *world.Tx) {
+	wires, otherPos, other, found := tripwireLine(pos, h.Facing, tx)
+	attached, powered := found && len(wires) > 0, false
+	if attached {
+		for _, p := range wires {
+			if s := tx.Block(p).(String); s.Powered && !s.Disarmed {
+				powered = true
+			}
+		}
+	}
+
+	h.setState(pos, tx, attached, powered)
+	if found {
+		other.setState(otherPos, tx, attached, powered)
+	}
+	for _, p := range wires {
+		if s := tx.Block(p).(String); s.Attached != attached {
+			s.Attached = attached
+			tx.SetBlock(p, s, nil)
+			if s.Powered {
+				// Changing the block invalidates the tick scheduled to release the tripwire, so it is scheduled
+				// again.
+				tx.ScheduleBlockUpdate(p, s, redstoneTicks(5))
+			}
+		}
+	}
+}
+
+// setState updates the attached and powered state of the tripwire hook at pos if it changed.
+func (h TripwireHook) setState(pos cube.Pos, tx *world.Tx, attached, powered bool) {
+	if h.Attached == attached && h.Powered == powered {
+		return
+	}
+	if powered && !h.Powered {
+		tx.PlaySound(pos.Vec3Centre(), sound.PowerOn{})
+	} else if !powered && h.Powered {
+		tx.PlaySound(pos.Vec3Centre(), sound.PowerOff{})
+	}
+	h.Attached, h.Powered = attached, powered
+	tx.SetBlock(pos, h, nil)
+}
+
+// tripwireLine walks along the line of tripwire next to pos in the direction passed. It returns the positions of
+// the tripwire and, if the line ends in a tripwire hook facing back towards pos, the position of that hook.
+func tripwireLine(pos cube.Pos, d cube.Direction, tx *world.Tx) (wires []cube.Pos, hookPos cube.Pos, hook TripwireHook, ok bool) {
+	p := pos
+	for range maxTripwireLength + 1 {
+		p = p.Side(d.Face())
+		switch b := tx.Block(p).(type) {
+		case String:
+			wires = append(wires, p)
+			continue
+		case TripwireHook:
+			return wires, p, b, b.Facing == d.Opposite()
+		}
+		break
+	}
+	return wires, hookPos, hook, false
+}
+
+// updateTripwireHooks recalculates the state of the tripwire hooks that the tripwire at pos may be connected to.
+// It is called when tripwire is placed, broken or changes its powered state.
+func updateTripwireHooks(pos cube.Pos, tx *world.Tx) {
+	for _, d := range cube.Directions() {
+		if _, hookPos, hook, ok := tripwireLine(pos, d, tx); ok {
+			hook.calculateState(hookPos, tx)
+		}
+	}
+}
+
+// BreakInfo ...
+func (h TripwireHook) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(TripwireHook{})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, _ item.User) {
+		// The hook is already removed at this point, so the hook at the other end of the tripwire no longer finds
+		// it and detaches itself and the tripwire between them.
+		wires, hookPos, hook, ok := tripwireLine(pos, h.Facing, tx)
+		if ok {
+			hook.calculateState(hookPos, tx)
+			return
+		}
+		for _, p := range wires {
+			if s := tx.Block(p).(String); s.Attached {
+				s.Attached = false
+				tx.SetBlock(p, s, nil)
+			}
+		}
+	})
+}
+
+// FuelInfo ...
+func (TripwireHook) FuelInfo() item.FuelInfo {
+	return newFuelInfo(time.Second * 15)
+}
+
+// EncodeItem ...
+func (TripwireHook) EncodeItem() (name string, meta int16) {
+	return "minecraft:tripwire_hook", 0
+}
+
+// EncodeBlock ...
+func (h TripwireHook) EncodeBlock() (string, map[string]any) {
+	return "minecraft:tripwire_hook", map[string]any{
+		"attached_bit": boolByte(h.Attached),
+		"direction":    int32(horizontalDirection(h.Facing)),
+		"powered_bit":  boolByte(h.Powered),
+	}
+}
+
+// allTripwireHooks ...
+func allTripwireHooks() (hooks []world.Block) {
+	for _, d := range cube.Directions() {
+		hooks = append(hooks, TripwireHook{Facing: d})
+		hooks = append(hooks, TripwireHook{Facing: d, Attached: true})
+		hooks = append(hooks, TripwireHook{Facing: d, Powered: true})
+		hooks = append(hooks, TripwireHook{Facing: d, Attached: true, Powered: true})
+	}
+	return
+}
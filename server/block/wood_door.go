@@ -119,6 +119,32 @@ func (d WoodDoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User,
 	return true
 }
 
+// RedstonePowerUpdate opens or closes the door when the redstone signal received by the door turns on or off. The
+// door stays open while the other half of it is still powered.
+func (d WoodDoor) RedstonePowerUpdate(pos cube.Pos, tx *world.Tx, power int) (world.Block, bool) {
+	powered, changed := redstoneSignalEdge(pos, tx, power)
+	if !changed || d.Open == powered || (!powered && tx.RedstonePower(pos.Side(cube.Face(boolByte(!d.Top)))) > 0) {
+		return d, false
+	}
+	d.Open = powered
+	return d, true
+}
+
+// RedstonePowerPostUpdate syncs the other half of the door and plays the sound of the door opening or closing.
+func (d WoodDoor) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, _, after world.Block, _, _ int) {
+	d = after.(WoodDoor)
+	otherPos := pos.Side(cube.Face(boolByte(!d.Top)))
+	if door, ok := tx.Block(otherPos).(WoodDoor); ok {
+		door.Open = d.Open
+		tx.SetBlock(otherPos, door, nil)
+	}
+	if d.Open {
+		tx.PlaySound(pos.Vec3Centre(), sound.DoorOpen{Block: d})
+		return
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.DoorClose{Block: d})
+}
+
 // BreakInfo ...
 func (d WoodDoor) BreakInfo() BreakInfo {
 	return newBreakInfo(3, alwaysHarvestable, axeEffective, oneOf(d))
@@ -92,6 +92,25 @@ func (f WoodFenceGate) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.
 	return true
 }
 
+// RedstonePowerUpdate opens or closes the fence gate when the redstone signal received by it turns on or off.
+func (f WoodFenceGate) RedstonePowerUpdate(pos cube.Pos, tx *world.Tx, power int) (world.Block, bool) {
+	powered, changed := redstoneSignalEdge(pos, tx, power)
+	if !changed || f.Open == powered {
+		return f, false
+	}
+	f.Open = powered
+	return f, true
+}
+
+// RedstonePowerPostUpdate plays the sound of the fence gate opening or closing.
+func (f WoodFenceGate) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, _, after world.Block, _, _ int) {
+	if after.(WoodFenceGate).Open {
+		tx.PlaySound(pos.Vec3Centre(), sound.FenceGateOpen{Block: after})
+		return
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.FenceGateClose{Block: after})
+}
+
 // SideClosed ...
 func (f WoodFenceGate) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
 	return false
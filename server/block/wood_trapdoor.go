@@ -67,6 +67,25 @@ func (t WoodTrapdoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.U
 	return true
 }
 
+// RedstonePowerUpdate opens or closes the trapdoor when the redstone signal received by it turns on or off.
+func (t WoodTrapdoor) RedstonePowerUpdate(pos cube.Pos, tx *world.Tx, power int) (world.Block, bool) {
+	powered, changed := redstoneSignalEdge(pos, tx, power)
+	if !changed || t.Open == powered {
+		return t, false
+	}
+	t.Open = powered
+	return t, true
+}
+
+// RedstonePowerPostUpdate plays the sound of the trapdoor opening or closing.
+func (t WoodTrapdoor) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, _, after world.Block, _, _ int) {
+	if after.(WoodTrapdoor).Open {
+		tx.PlaySound(pos.Vec3Centre(), sound.TrapdoorOpen{Block: after})
+		return
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.TrapdoorClose{Block: after})
+}
+
 // BreakInfo ...
 func (t WoodTrapdoor) BreakInfo() BreakInfo {
 	return newBreakInfo(3, alwaysHarvestable, axeEffective, oneOf(t))
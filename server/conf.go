@@ -96,10 +96,15 @@ type Config struct {
 	ReadOnlyWorld bool
 	// Generator should return a function that specifies the world.Generator to
 	// use for every world.Dimension (world.Overworld, world.Nether and
-	// world.End). If left empty, Generator will be set to a flat world for each
-	// of the dimensions (with netherrack and end stone for nether/end
-	// respectively).
+	// world.End). If left empty, Generator will be set to a flat world for the
+	// overworld and to a generator.Nether and generator.End using Seed for the
+	// nether and end respectively.
 	Generator func(dim world.Dimension) world.Generator
+	// Seed is the seed used by the default Generator to generate the terrain of
+	// the nether and end. Seed is not used if Generator is set. Changing Seed
+	// for an existing world leads to mismatching terrain at the border of
+	// chunks that were generated before.
+	Seed int64
 	// RandomTickSpeed specifies the rate at which blocks should be ticked in
 	// the default worlds. Setting this value to -1 or lower will stop random
 	// ticking altogether, while setting it higher results in faster ticking. If
@@ -121,6 +126,15 @@ type Config struct {
 	// chunks in each world, defaulting to 1. Values above 1 generate chunks
 	// concurrently and require a concurrency-safe Generator.
 	ChunkLoadWorkers int
+	// RedstoneBudget limits the work the redstone engine of each world may
+	// perform. Networks exceeding the budget are reported through the
+	// world.Handler of the world and may be throttled or frozen. By default,
+	// no limits are imposed.
+	RedstoneBudget world.RedstoneBudget
+	// MobSpawning holds the parameters for the natural spawning of mobs in
+	// the overworld. If MobSpawning.Spawns is nil, it will be set to
+	// entity.MobSpawns. Mobs do not spawn naturally in the nether and the end.
+	MobSpawning world.MobSpawning
 	// Entities is a world.EntityRegistry with all entity types registered that
 	// may be added to the Server's worlds. If no entity types are registered,
 	// Entities will be set to entity.DefaultRegistry.
@@ -156,9 +170,6 @@ func (conf Config) New() *Server {
 	if conf.WorldProvider == nil {
 		conf.WorldProvider = world.NopProvider{}
 	}
-	if conf.Generator == nil {
-		conf.Generator = loadGenerator
-	}
 	if conf.MaxChunkRadius == 0 {
 		conf.MaxChunkRadius = 12
 	}
@@ -168,9 +179,15 @@ func (conf Config) New() *Server {
 	if len(conf.Entities.Types()) == 0 {
 		conf.Entities = entity.DefaultRegistry
 	}
+	if conf.MobSpawning.Spawns == nil {
+		conf.MobSpawning.Spawns = entity.MobSpawns
+	}
 	if conf.Blocks == nil {
 		conf.Blocks = world.DefaultBlockRegistry
 	}
+	if conf.Generator == nil {
+		conf.Generator = loadGenerator(conf.Seed, conf.Blocks)
+	}
 
 	// Initialize the passed block registry and also initialize the default block registry which
 	// is used in some vanilla paths.
@@ -241,6 +258,9 @@ type UserConfig struct {
 		SaveData bool
 		// Folder is the folder that the data of the world resides in.
 		Folder string
+		// Seed is the seed used to generate the terrain of the nether and
+		// end.
+		Seed int64
 	}
 	Players struct {
 		// MaxCount is the maximum amount of players allowed to join the server
@@ -288,6 +308,7 @@ func (uc UserConfig) Config(log *slog.Logger) (Config, error) {
 		MaxPlayers:              uc.Players.MaxCount,
 		MaxChunkRadius:          uc.Players.MaximumChunkRadius,
 		DisableResourceBuilding: !uc.Resources.AutoBuildPack,
+		Seed:                    uc.World.Seed,
 	}
 	if !uc.Server.DisableJoinQuitMessages {
 		conf.JoinMessage, conf.QuitMessage = chat.MessageJoin, chat.MessageQuit
@@ -330,19 +351,23 @@ func loadResources(dir string) ([]*resource.Pack, error) {
 	return packs, nil
 }
 
-// loadGenerator loads a standard world.Generator for a world.Dimension. The
-// generators returned are flat generators with grass/dirt, netherrack or end
-// stone depending on the dimension passed.
-func loadGenerator(dim world.Dimension) world.Generator {
-	switch dim {
-	case world.Overworld:
-		return generator.NewFlat(biome.Plains{}, []world.Block{block.Grass{}, block.Dirt{}, block.Dirt{}, block.Bedrock{}})
-	case world.Nether:
-		return generator.NewFlat(biome.NetherWastes{}, []world.Block{block.Netherrack{}, block.Netherrack{}, block.Netherrack{}, block.Bedrock{}})
-	case world.End:
-		return generator.NewFlat(biome.End{}, []world.Block{block.EndStone{}, block.EndStone{}, block.EndStone{}, block.Bedrock{}})
+// loadGenerator returns a function that loads a standard world.Generator for
+// a world.Dimension. The overworld is generated as a flat world with
+// grass/dirt, while the nether and end are generated by a generator.Nether
+// and generator.End using the seed passed. Blocks are resolved using the
+// world.BlockRegistry passed.
+func loadGenerator(seed int64, br world.BlockRegistry) func(dim world.Dimension) world.Generator {
+	return func(dim world.Dimension) world.Generator {
+		switch dim {
+		case world.Overworld:
+			return generator.NewFlatWithRegistry(biome.Plains{}, []world.Block{block.Grass{}, block.Dirt{}, block.Dirt{}, block.Bedrock{}}, br)
+		case world.Nether:
+			return generator.NewNetherWithRegistry(seed, br)
+		case world.End:
+			return generator.NewEndWithRegistry(seed, br)
+		}
+		panic("should never happen")
 	}
-	panic("should never happen")
 }
 
 // DefaultConfig returns a configuration with the default values filled out.
@@ -59,6 +59,43 @@ type FireworkExplosionAction struct{ action }
 // TotemUseAction is a world.EntityAction that displays the totem use particles and animation.
 type TotemUseAction struct{ action }
 
+// MountAction is a world.EntityAction that makes an entity start riding another entity, such as a minecart.
+type MountAction struct {
+	// Vehicle is the entity that the entity started riding.
+	Vehicle world.Entity
+	// Seat is the index of the seat that the entity took. The entity in the first seat controls the vehicle.
+	Seat int
+
+	action
+}
+
+// DismountAction is a world.EntityAction that makes an entity stop riding the entity it was riding.
+type DismountAction struct {
+	// Vehicle is the entity that the entity stopped riding.
+	Vehicle world.Entity
+
+	action
+}
+
+// LoveAction is a world.EntityAction that makes an animal display heart particles, for example when it is fed
+// and ready to breed.
+type LoveAction struct{ action }
+
+// EatGrassAction is a world.EntityAction that makes a sheep display the animation of eating grass.
+type EatGrassAction struct{ action }
+
+// VillagerHappyAction is a world.EntityAction that makes a villager display green particles, for example when it
+// claims a workstation or levels up.
+type VillagerHappyAction struct{ action }
+
+// TamingSucceededAction is a world.EntityAction that makes an entity display heart particles after it was tamed,
+// such as a horse that accepted its rider.
+type TamingSucceededAction struct{ action }
+
+// TamingFailedAction is a world.EntityAction that makes an entity display smoke particles after an attempt to tame
+// it failed, such as a horse throwing off its rider.
+type TamingFailedAction struct{ action }
+
 // action implements the Action interface. Structures in this package may embed it to gets its functionality
 // out of the box.
 type action struct{}
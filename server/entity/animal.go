@@ -0,0 +1,389 @@
+package entity
+
+import (
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// AnimalBehaviourConfig holds optional parameters for an AnimalBehaviour.
+type AnimalBehaviourConfig struct {
+	// Mob holds the parameters of the MobBehaviour of the animal. The goals
+	// returned by Mob.Goals are added to the goals that all animals have:
+	// swimming, panicking when hurt, breeding, being tempted by food,
+	// following their parents as a baby, wandering around and looking at
+	// players. Babies do not drop Mob.Drops or experience when they die.
+	Mob MobBehaviourConfig
+	// Food holds the items that the animal is tempted by and may be fed with.
+	// Feeding an adult animal makes it fall in love, so that it breeds with
+	// another animal of the same type that is in love. Feeding a baby makes it
+	// grow up faster.
+	Food []world.Item
+	// Baby specifies if the animal is a baby.
+	Baby bool
+	// GrowthDuration is the time that it takes for a baby to grow up.
+	// GrowthDuration defaults to 20 minutes.
+	GrowthDuration time.Duration
+	// BreedCooldown is the time after breeding before an animal may fall in
+	// love again. BreedCooldown defaults to 5 minutes.
+	BreedCooldown time.Duration
+	// Produce is an item dropped by adult animals every 5 to 10 minutes, such
+	// as the eggs laid by chickens.
+	Produce world.Item
+	// Offspring returns the configuration that the baby of the animal e and
+	// its partner is created with. If Offspring is nil, the baby is created
+	// with the same configuration as e.
+	Offspring func(e, partner *Ent) world.EntityConfig
+	// Interact is called when a user uses an item that is not food on the
+	// animal, for example to shear a sheep. Interact returns true if the
+	// interaction had an effect.
+	Interact func(e *Ent, user item.User, held item.Stack, ctx *item.UseContext) bool
+}
+
+func (conf AnimalBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates an AnimalBehaviour using the parameters in conf.
+func (conf AnimalBehaviourConfig) New() *AnimalBehaviour {
+	if conf.GrowthDuration <= 0 {
+		conf.GrowthDuration = time.Minute * 20
+	}
+	if conf.BreedCooldown <= 0 {
+		conf.BreedCooldown = time.Minute * 5
+	}
+	a := &AnimalBehaviour{conf: conf, produceTicks: produceDelay()}
+	if conf.Baby {
+		a.age = -durationTicks(conf.GrowthDuration)
+	}
+
+	mob := conf.Mob
+	mob.Goals = func() []PrioritisedGoal {
+		goals := []PrioritisedGoal{
+			{Priority: 0, Goal: &FloatGoal{}},
+			{Priority: 1, Goal: &FleeGoal{}},
+			{Priority: 2, Goal: &animalBreedGoal{a: a}},
+			{Priority: 3, Goal: &FollowGoal{Follow: a.tempted, Distance: 10, MinDistance: 2.5, Speed: 1.25}},
+			{Priority: 4, Goal: &animalFollowParentGoal{a: a}},
+			{Priority: 5, Goal: &WanderGoal{}},
+			{Priority: 6, Goal: &LookAtPlayerGoal{}},
+		}
+		if conf.Mob.Goals != nil {
+			goals = append(goals, conf.Mob.Goals()...)
+		}
+		return goals
+	}
+	mob.Drops, mob.Experience = nil, 0
+	mob.Death = func(e *Ent, tx *world.Tx, src world.DamageSource) {
+		if !a.Baby() {
+			a.dropLoot(e, conf.Mob.Drops, conf.Mob.Experience)
+		}
+		if conf.Mob.Death != nil {
+			conf.Mob.Death(e, tx, src)
+		}
+	}
+	a.MobBehaviour = mob.New()
+	return a
+}
+
+// AnimalBehaviour implements the behaviour of passive animals such as cows
+// and pigs. Animals may be fed to breed them, after which they get a baby
+// that grows up over time.
+type AnimalBehaviour struct {
+	*MobBehaviour
+	conf AnimalBehaviourConfig
+
+	// age is the age of the animal in ticks. A negative age means that the
+	// animal is a baby, while a positive age is the number of ticks left
+	// before the animal may breed again.
+	age          int
+	love         int
+	produceTicks int
+}
+
+// loveTicks is the number of ticks that an animal stays in love after being
+// fed.
+const loveTicks = 600
+
+// Baby checks if the animal is a baby.
+func (a *AnimalBehaviour) Baby() bool {
+	return a.age < 0
+}
+
+// InLove checks if the animal is in love, that is, if it is ready to breed.
+func (a *AnimalBehaviour) InLove() bool {
+	return a.love > 0
+}
+
+// Food checks if the item passed is food for the animal.
+func (a *AnimalBehaviour) Food(it world.Item) bool {
+	name, _ := it.EncodeItem()
+	return slices.ContainsFunc(a.conf.Food, func(food world.Item) bool {
+		foodName, _ := food.EncodeItem()
+		return name == foodName
+	})
+}
+
+// Tick ticks the animal as a mob, makes babies grow up and drops the produce
+// of the animal.
+func (a *AnimalBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	m := a.MobBehaviour.Tick(e, tx)
+	if a.Dead() {
+		return m
+	}
+	switch {
+	case a.age < 0:
+		if a.age++; a.age == 0 {
+			a.grow(e)
+		}
+	case a.age > 0:
+		a.age--
+	}
+	if a.love > 0 {
+		if a.love--; a.love == 0 {
+			e.updateState()
+		} else if a.love%10 == 0 {
+			for _, v := range tx.Viewers(e.data.Pos) {
+				v.ViewEntityAction(e, LoveAction{})
+			}
+		}
+	}
+	if a.conf.Produce != nil && !a.Baby() {
+		if a.produceTicks--; a.produceTicks <= 0 {
+			a.produceTicks = produceDelay()
+			tx.PlaySound(e.data.Pos, sound.Pop{})
+			tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: e.data.Pos}, item.NewStack(a.conf.Produce, 1)))
+		}
+	}
+	return m
+}
+
+// Interact feeds the animal if the item held is food, making an adult fall in
+// love or a baby grow up faster. Other items are passed to the Interact
+// function of the AnimalBehaviourConfig.
+func (a *AnimalBehaviour) Interact(e *Ent, user item.User, held item.Stack, ctx *item.UseContext) bool {
+	if a.Dead() {
+		return false
+	}
+	if held.Empty() || !a.Food(held.Item()) {
+		return a.conf.Interact != nil && a.conf.Interact(e, user, held, ctx)
+	}
+	switch {
+	case a.Baby():
+		// Feeding a baby takes 10% off the time left until it grows up.
+		if a.age -= a.age / 10; a.age >= 0 {
+			a.age = 0
+			a.grow(e)
+		}
+	case a.age == 0 && a.love == 0:
+		a.love = loveTicks
+		e.updateState()
+		for _, v := range e.tx.Viewers(e.data.Pos) {
+			v.ViewEntityAction(e, LoveAction{})
+		}
+	default:
+		return false
+	}
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// grow makes a baby animal an adult.
+func (a *AnimalBehaviour) grow(e *Ent) {
+	// The pathfinder of the animal was created for the size of a baby.
+	a.nav.pf = nil
+	e.updateState()
+}
+
+// breed makes the animal breed with its partner, creating a baby.
+func (a *AnimalBehaviour) breed(e *Ent, partner *Ent, pa *AnimalBehaviour) {
+	a.love, a.age = 0, durationTicks(a.conf.BreedCooldown)
+	pa.love, pa.age = 0, durationTicks(pa.conf.BreedCooldown)
+	e.updateState()
+	partner.updateState()
+
+	var conf world.EntityConfig
+	if a.conf.Offspring != nil {
+		conf = a.conf.Offspring(e, partner)
+	} else {
+		offspring := a.conf
+		offspring.Baby = true
+		conf = offspring
+	}
+	tx, pos := e.tx, e.data.Pos
+	tx.AddEntity(world.EntitySpawnOpts{Position: pos, Rotation: e.data.Rot}.New(e.H().Type(), conf))
+	for _, v := range tx.Viewers(pos) {
+		v.ViewEntityAction(e, LoveAction{})
+	}
+	for _, orb := range NewExperienceOrbs(pos, 1+rand.IntN(7)) {
+		tx.AddEntity(orb)
+	}
+}
+
+// tempted checks if the entity passed tempts the animal by holding its food.
+func (a *AnimalBehaviour) tempted(e world.Entity) bool {
+	c, ok := e.(item.Carrier)
+	if !ok || !visible(e) {
+		return false
+	}
+	held, _ := c.HeldItems()
+	return !held.Empty() && a.Food(held.Item())
+}
+
+// animal returns the AnimalBehaviour itself. It is used to find the
+// AnimalBehaviour of behaviours that embed it.
+func (a *AnimalBehaviour) animal() *AnimalBehaviour {
+	return a
+}
+
+// animalOf returns the AnimalBehaviour of the entity passed, if it is an
+// animal.
+func animalOf(e world.Entity) (*AnimalBehaviour, bool) {
+	ent, ok := e.(*Ent)
+	if !ok {
+		return nil, false
+	}
+	a, ok := ent.Behaviour().(interface{ animal() *AnimalBehaviour })
+	if !ok {
+		return nil, false
+	}
+	return a.animal(), true
+}
+
+// animalBBox returns a bounding box of the width and height passed, scaled
+// down to half its size if the entity passed is a baby.
+func animalBBox(e world.Entity, width, height float64) cube.BBox {
+	if isBaby(e) {
+		width, height = width/2, height/2
+	}
+	return cube.Box(-width/2, 0, -width/2, width/2, height, width/2)
+}
+
+// decodeAnimalNBT decodes the health, age and love of an animal from the NBT
+// map passed.
+func decodeAnimalNBT(m map[string]any, a *AnimalBehaviour) {
+	decodeMobNBT(m, a.MobBehaviour)
+	if age, ok := m["Age"].(int32); ok {
+		a.age = int(age)
+	}
+	a.love = int(nbtconv.Int32(m, "InLove"))
+}
+
+// encodeAnimalNBT encodes the health, age and love of an animal to a new NBT
+// map.
+func encodeAnimalNBT(a *AnimalBehaviour) map[string]any {
+	m := encodeMobNBT(a.MobBehaviour)
+	m["Age"], m["InLove"] = int32(a.age), int32(a.love)
+	return m
+}
+
+// produceDelay returns a random number of ticks between 5 and 10 minutes
+// after which an animal drops its produce.
+func produceDelay() int {
+	return 6000 + rand.IntN(6000)
+}
+
+// durationTicks returns the number of ticks in the duration passed.
+func durationTicks(d time.Duration) int {
+	return int(d / (time.Second / 20))
+}
+
+// animalBreedGoal is a Goal that makes an animal in love walk towards another
+// animal of the same type in love and breed with it.
+type animalBreedGoal struct {
+	a       *AnimalBehaviour
+	partner *world.EntityHandle
+	ticks   int
+}
+
+// Controls ...
+func (g *animalBreedGoal) Controls() GoalControl { return GoalControlMove | GoalControlLook }
+
+// CanStart ...
+func (g *animalBreedGoal) CanStart(m *Mob) bool {
+	if !g.a.InLove() {
+		return false
+	}
+	partner, ok := nearestEntity(m, m.tx.EntitiesWithin(mobSearchBox(m, 8)), 8, func(e world.Entity) bool {
+		return g.mate(m, e) != nil
+	})
+	if ok {
+		g.partner = partner.H()
+	}
+	return ok
+}
+
+// CanContinue ...
+func (g *animalBreedGoal) CanContinue(m *Mob) bool {
+	partner, ok := g.partner.Entity(m.tx)
+	return ok && g.a.InLove() && g.mate(m, partner) != nil && g.ticks < 60
+}
+
+// Start ...
+func (g *animalBreedGoal) Start(*Mob) { g.ticks = 0 }
+
+// Tick ...
+func (g *animalBreedGoal) Tick(m *Mob) {
+	partner, ok := g.partner.Entity(m.tx)
+	if !ok {
+		return
+	}
+	m.LookAt(eyePosition(partner))
+	if g.ticks%10 == 0 || !m.Moving() {
+		m.MoveTo(partner.Position(), 1)
+	}
+	if g.ticks++; g.ticks >= 60 && m.Distance(partner) < 3 {
+		g.a.breed(m.e, partner.(*Ent), g.mate(m, partner))
+	}
+}
+
+// Stop ...
+func (g *animalBreedGoal) Stop(m *Mob) {
+	g.partner = nil
+	m.StopMoving()
+}
+
+// mate returns the AnimalBehaviour of the entity passed if the animal may
+// breed with it, or nil if it may not.
+func (g *animalBreedGoal) mate(m *Mob, e world.Entity) *AnimalBehaviour {
+	if e.H().Type() != m.e.H().Type() {
+		return nil
+	}
+	if a, ok := animalOf(e); ok && a.InLove() && !a.Dead() {
+		return a
+	}
+	return nil
+}
+
+// animalFollowParentGoal is a Goal that makes a baby animal follow an adult
+// animal of the same type.
+type animalFollowParentGoal struct {
+	FollowGoal
+	a *AnimalBehaviour
+}
+
+// CanStart ...
+func (g *animalFollowParentGoal) CanStart(m *Mob) bool {
+	if !g.a.Baby() {
+		return false
+	}
+	if g.Follow == nil {
+		t := m.e.H().Type()
+		g.Follow = func(e world.Entity) bool {
+			return e.H().Type() == t && !isBaby(e)
+		}
+		g.MinDistance, g.Speed = 3, 1.1
+	}
+	return g.FollowGoal.CanStart(m)
+}
+
+// CanContinue ...
+func (g *animalFollowParentGoal) CanContinue(m *Mob) bool {
+	return g.a.Baby() && g.FollowGoal.CanContinue(m)
+}
@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestAnimalsBreedWhenFed(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := range 6 {
+			for z := range 6 {
+				tx.SetBlock(cube.Pos{x, 63, z}, block.Stone{}, nil)
+			}
+		}
+		a := tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{1.5, 64, 1.5}})).(*Ent)
+		b := tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{3.5, 64, 1.5}})).(*Ent)
+
+		if InteractEntity(a, nil, item.NewStack(item.Leather{}, 1), &item.UseContext{}) {
+			t.Fatalf("cow interacted with leather, want only food to have an effect")
+		}
+		for _, cow := range []*Ent{a, b} {
+			ctx := &item.UseContext{}
+			if !InteractEntity(cow, nil, item.NewStack(item.Wheat{}, 1), ctx) || ctx.CountSub != 1 {
+				t.Fatalf("feeding cow wheat: count subtracted = %v, want 1", ctx.CountSub)
+			}
+			if !cow.Behaviour().(*AnimalBehaviour).InLove() {
+				t.Fatalf("cow not in love after being fed")
+			}
+		}
+
+		babies := func() (n int) {
+			for e := range tx.Entities() {
+				if e.H().Type() == CowType && isBaby(e) {
+					n++
+				}
+			}
+			return n
+		}
+		for i := range int64(200) {
+			a.Tick(tx, i)
+			b.Tick(tx, i)
+			if babies() > 0 {
+				break
+			}
+		}
+		if n := babies(); n != 1 {
+			t.Fatalf("babies after breeding = %v, want 1", n)
+		}
+		ab := a.Behaviour().(*AnimalBehaviour)
+		if ab.InLove() || InteractEntity(a, nil, item.NewStack(item.Wheat{}, 1), &item.UseContext{}) {
+			t.Errorf("cow fell in love again right after breeding, want a cooldown")
+		}
+	})
+}
+
+func TestBabyAnimalGrowsUp(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		conf := cowConf
+		conf.Baby = true
+		baby := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}}.New(CowType, conf)).(*Ent)
+		adultHeight := CowType.BBox(tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{8.5, 64, 0.5}}))).Height()
+		if h := CowType.BBox(baby).Height(); h >= adultHeight {
+			t.Fatalf("baby height = %v, want it smaller than adult height %v", h, adultHeight)
+		}
+
+		a := baby.Behaviour().(*AnimalBehaviour)
+		left := -a.age
+		if !InteractEntity(baby, nil, item.NewStack(item.Wheat{}, 1), &item.UseContext{}) || -a.age != left-left/10 {
+			t.Errorf("growth left after feeding = %v ticks, want %v", -a.age, left-left/10)
+		}
+		a.age = -2
+		baby.Tick(tx, 0)
+		if !a.Baby() {
+			t.Fatalf("cow grew up one tick early")
+		}
+		baby.Tick(tx, 1)
+		if a.Baby() || CowType.BBox(baby).Height() != adultHeight {
+			t.Errorf("cow did not grow up after its growth duration passed")
+		}
+	})
+}
+
+func TestSheepShearingAndDyeing(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		e := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}}.New(SheepType, SheepBehaviourConfig{Colour: item.ColourWhite()})).(*Ent)
+		s := e.Behaviour().(*SheepBehaviour)
+
+		ctx := &item.UseContext{}
+		if !InteractEntity(e, nil, item.NewStack(item.Dye{Colour: item.ColourBlue()}, 1), ctx) || ctx.CountSub != 1 {
+			t.Fatalf("dyeing sheep had no effect")
+		}
+		if s.Colour() != item.ColourBlue() {
+			t.Errorf("sheep colour = %v, want blue", s.Colour())
+		}
+
+		ctx = &item.UseContext{}
+		if !InteractEntity(e, nil, item.NewStack(item.Shears{}, 1), ctx) || ctx.Damage != 1 {
+			t.Fatalf("shearing sheep: damage = %v, want 1", ctx.Damage)
+		}
+		if !s.Sheared() {
+			t.Errorf("sheep not sheared after using shears on it")
+		}
+		wool := 0
+		for other := range tx.Entities() {
+			if i, ok := other.(*Ent); ok && i.H().Type() == ItemType {
+				stack := i.Behaviour().(*ItemBehaviour).Item()
+				if wl, ok := stack.Item().(block.Wool); !ok || wl.Colour != item.ColourBlue() {
+					t.Errorf("sheep dropped %v, want blue wool", stack)
+				}
+				wool += stack.Count()
+			}
+		}
+		if wool < 1 || wool > 3 {
+			t.Errorf("wool dropped = %v, want between 1 and 3", wool)
+		}
+		if InteractEntity(e, nil, item.NewStack(item.Shears{}, 1), &item.UseContext{}) {
+			t.Errorf("sheared sheep was sheared again")
+		}
+	})
+}
@@ -33,7 +33,9 @@ func NewTippedArrowWithDamage(opts world.EntitySpawnOpts, damage float64, owner
 	conf := arrowConf
 	conf.Damage = damage
 	conf.Potion = tip
-	conf.Owner = owner.H()
+	if owner != nil {
+		conf.Owner = owner.H()
+	}
 	return opts.New(ArrowType, conf)
 }
 
@@ -63,6 +65,7 @@ func (t arrowType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.En
 }
 
 func (arrowType) EncodeEntity() string { return "minecraft:arrow" }
+func (arrowType) SticksInBlocks()      {}
 func (arrowType) BBox(world.Entity) cube.BBox {
 	return cube.Box(-0.125, 0, -0.125, 0.125, 0.25, 0.125)
 }
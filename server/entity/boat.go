@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewBoat creates a new boat entity of the type passed. Boats float on water
+// and may be ridden by two entities, the first of which steers the boat.
+func NewBoat(opts world.EntitySpawnOpts, t item.BoatType) *world.EntityHandle {
+	return opts.New(BoatType, BoatBehaviourConfig{Type: t})
+}
+
+// NewChestBoat creates a new boat entity of the type passed that carries a
+// chest. Chest boats have room for only one rider.
+func NewChestBoat(opts world.EntitySpawnOpts, t item.BoatType) *world.EntityHandle {
+	return opts.New(ChestBoatType, BoatBehaviourConfig{Type: t, Chest: true})
+}
+
+// BoatType is a world.EntityType implementation for boats.
+var BoatType boatType
+
+// ChestBoatType is a world.EntityType implementation for boats with a chest.
+var ChestBoatType = boatType{chest: true}
+
+type boatType struct {
+	chest bool
+}
+
+func (boatType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (t boatType) EncodeEntity() string {
+	if t.chest {
+		return "minecraft:chest_boat"
+	}
+	return "minecraft:boat"
+}
+
+func (boatType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.7, 0, -0.7, 0.7, 0.455, 0.7)
+}
+
+func (t boatType) DecodeNBT(data map[string]any, d *world.EntityData) {
+	boatType := item.BoatTypeOak()
+	variant := nbtconv.Int32(data, "Variant")
+	for _, bt := range item.BoatTypes() {
+		if int32(bt.Uint8()) == variant {
+			boatType = bt
+		}
+	}
+	b := BoatBehaviourConfig{Type: boatType, Chest: t.chest}.New()
+	if b.inventory != nil {
+		nbtconv.InvFromNBT(b.inventory, nbtconv.Slice(data, "Items"))
+	}
+	d.Data = b
+}
+
+func (boatType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*BoatBehaviour)
+	m := map[string]any{"Variant": int32(b.conf.Type.Uint8())}
+	if b.inventory != nil {
+		m["Items"] = nbtconv.InvToNBT(b.inventory)
+	}
+	return m
+}
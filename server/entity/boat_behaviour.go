@@ -0,0 +1,269 @@
+package entity
+
+import (
+	"math"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// boatForwardSpeed is the speed in blocks/tick added every tick to a boat paddled forward by its rider.
+	boatForwardSpeed = 0.04
+	// boatBackwardSpeed is the speed in blocks/tick added every tick to a boat paddled backward by its rider.
+	boatBackwardSpeed = 0.005
+	// boatTurnSpeed is the change in degrees/tick of the yaw velocity of a boat every tick that its rider steers
+	// it to the side.
+	boatTurnSpeed = 1.0
+	// boatDraft is the depth in blocks that a floating boat sinks below the surface of the water.
+	boatDraft = 0.1
+)
+
+// BoatBehaviourConfig holds optional parameters for a BoatBehaviour.
+type BoatBehaviourConfig struct {
+	// Type is the type of the boat, which specifies the wood that the boat is made of.
+	Type item.BoatType
+	// Chest specifies if the boat carries a chest that holds 27 stacks of items. Boats with a chest have room for
+	// only one rider.
+	Chest bool
+}
+
+func (conf BoatBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a BoatBehaviour using the parameters in conf.
+func (conf BoatBehaviourConfig) New() *BoatBehaviour {
+	b := &BoatBehaviour{
+		BaseBehaviour: NewBaseBehaviour(),
+		conf:          conf,
+		mc:            &MovementComputer{},
+		hurtDirection: 1,
+	}
+	if conf.Chest {
+		b.riderSeats = newRiderSeats(1)
+		b.initInventory(27)
+	} else {
+		b.riderSeats = newRiderSeats(2)
+	}
+	return b
+}
+
+// BoatBehaviour implements the behaviour of boats. Boats float on water and are steered by the entity riding in
+// their first seat. Mobs that walk into a boat with a free seat start riding it.
+type BoatBehaviour struct {
+	BaseBehaviour
+
+	conf BoatBehaviourConfig
+	mc   *MovementComputer
+
+	riderSeats
+	containerInventory
+
+	input  RideInput
+	yawVel float64
+
+	damage                   float64
+	hurtTicks, hurtDirection int
+}
+
+// Type returns the type of the boat.
+func (b *BoatBehaviour) Type() item.BoatType {
+	return b.conf.Type
+}
+
+// Chest checks if the boat carries a chest.
+func (b *BoatBehaviour) Chest() bool {
+	return b.conf.Chest
+}
+
+// Variant returns the variant of the boat, which is shown as the wood that it is made of.
+func (b *BoatBehaviour) Variant() int32 {
+	return int32(b.conf.Type.Uint8())
+}
+
+// Seats returns the seats of the boat. A single rider sits in the middle of the boat, while two riders sit
+// behind each other. The rider of a chest boat sits in front of the chest.
+func (b *BoatBehaviour) Seats() []Seat {
+	if b.conf.Chest {
+		return []Seat{{Offset: mgl64.Vec3{0, -0.2, 0.2}, MaxRotation: 90}}
+	}
+	if b.riderCount() < 2 {
+		return []Seat{{Offset: mgl64.Vec3{0, -0.2}, MaxRotation: 90}, {Offset: mgl64.Vec3{0, -0.2}, MaxRotation: 90}}
+	}
+	return []Seat{{Offset: mgl64.Vec3{0, -0.2, 0.2}, MaxRotation: 90}, {Offset: mgl64.Vec3{0, -0.2, -0.6}, MaxRotation: 90}}
+}
+
+// canBeRidden always returns true: Boats may be ridden as long as they have a free seat.
+func (b *BoatBehaviour) canBeRidden() bool {
+	return true
+}
+
+// steer stores the input of the rider steering the boat, so that it is used the next time the boat is ticked.
+func (b *BoatBehaviour) steer(_ *Ent, input RideInput) {
+	b.input = input
+}
+
+// Wobble returns the number of ticks that the boat keeps wobbling after being hit, and the direction in which it
+// wobbles.
+func (b *BoatBehaviour) Wobble() (ticks, direction int) {
+	return b.hurtTicks, b.hurtDirection
+}
+
+// Tick makes the boat float on water or fall if it is not in water, and moves it according to the input of the
+// rider steering it.
+func (b *BoatBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if b.hurtTicks > 0 {
+		if b.hurtTicks--; b.hurtTicks == 0 {
+			e.updateState()
+		}
+	}
+	if b.damage > 0 {
+		b.damage--
+	}
+	b.removeClosedRiders(tx)
+	if _, ok := RiderOf(e); !ok {
+		b.input = RideInput{}
+	}
+
+	pos, vel, rot := e.data.Pos, e.data.Vel, e.data.Rot
+	friction := 0.9
+	if surface, ok := boatWaterSurface(pos, tx); ok {
+		vel[1] = (vel[1] + (surface-boatDraft-pos[1])*0.1) * 0.6
+	} else {
+		vel[1] = (vel[1] - 0.04) * 0.98
+		if b.mc.OnGround() {
+			friction = 0.5
+		}
+	}
+	vel[0] *= friction
+	vel[2] *= friction
+	b.yawVel *= friction
+
+	if b.input.Sideways != 0 {
+		b.yawVel -= b.input.Sideways * boatTurnSpeed
+	}
+	rot[0] += b.yawVel
+	if b.input.Forward != 0 {
+		speed := boatForwardSpeed
+		if b.input.Forward < 0 {
+			speed = boatBackwardSpeed
+		}
+		yaw := mgl64.DegToRad(rot[0])
+		vel = vel.Add(mgl64.Vec3{-math.Sin(yaw), 0, math.Cos(yaw)}.Mul(speed * b.input.Forward))
+	}
+
+	dpos, newVel := b.mc.CheckCollision(tx, e, pos, vel)
+	mv := &Movement{v: tx.Viewers(pos), e: e,
+		pos: pos.Add(dpos), vel: newVel, dpos: dpos, dvel: newVel.Sub(e.data.Vel),
+		rot: rot, onGround: b.mc.OnGround(),
+	}
+	e.data.Pos, e.data.Vel, e.data.Rot = mv.pos, mv.vel, mv.rot
+
+	b.pickUp(e, tx)
+	return mv
+}
+
+// pickUp makes mobs that walk into the boat start riding it, as long as the boat has a free seat.
+func (b *BoatBehaviour) pickUp(e *Ent, tx *world.Tx) {
+	if b.riderCount() == len(b.riders) {
+		return
+	}
+	box := e.H().Type().BBox(e).Translate(e.data.Pos).Grow(0.2)
+	for other := range tx.EntitiesWithin(box.Grow(2)) {
+		ent, ok := other.(*Ent)
+		if !ok || ent == e {
+			continue
+		}
+		if _, ok := ent.Behaviour().(behaviourRider); !ok {
+			continue
+		}
+		if _, ok := rideable(ent); ok {
+			// Entities that may be ridden themselves, such as horses, are too large to fit in a boat.
+			continue
+		}
+		if _, riding := ent.Riding(); riding || !ent.H().Type().BBox(ent).Translate(ent.Position()).IntersectsWith(box) {
+			continue
+		}
+		if ent.Mount(e) && b.riderCount() == len(b.riders) {
+			return
+		}
+	}
+}
+
+// Hurt damages the boat, making it wobble. The boat breaks once it has taken enough damage in a short time, or
+// immediately if hit by a player in creative mode.
+func (b *BoatBehaviour) Hurt(e *Ent, damage float64, src world.DamageSource) (float64, bool) {
+	damage = max(damage, 0)
+	if _, ok := src.(VoidDamageSource); ok {
+		ejectRiders(e, e.tx)
+		_ = e.Close()
+		return damage, true
+	}
+	creative := false
+	if s, ok := src.(AttackDamageSource); ok {
+		if g, ok := s.Attacker.(interface{ GameMode() world.GameMode }); ok {
+			creative = g.GameMode().CreativeInventory()
+		}
+	}
+	b.hurtTicks, b.hurtDirection = 10, -b.hurtDirection
+	b.damage += damage * 10
+	e.updateState()
+
+	if creative || b.damage > 40 {
+		b.destroy(e, e.tx, !creative)
+	}
+	return damage, true
+}
+
+// Explode breaks the boat when it is hit by an explosion.
+func (b *BoatBehaviour) Explode(e *Ent, _ world.ExplosionSource, impact float64) {
+	if impact > 0 {
+		b.destroy(e, e.tx, true)
+	}
+}
+
+// destroy breaks the boat, ejecting its riders. If drop is true, the boat drops itself and the contents of its
+// chest.
+func (b *BoatBehaviour) destroy(e *Ent, tx *world.Tx, drop bool) {
+	if _, ok := e.H().Entity(tx); !ok {
+		return
+	}
+	ejectRiders(e, tx)
+	_ = e.Close()
+	if !drop {
+		return
+	}
+	drops := []item.Stack{item.NewStack(item.Boat{Type: b.conf.Type, Chest: b.conf.Chest}, 1)}
+	if b.inventory != nil {
+		drops = append(drops, b.inventory.Clear()...)
+	}
+	for _, it := range drops {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: e.data.Pos.Add(mgl64.Vec3{0, 0.5})}, it))
+	}
+}
+
+// boatWaterSurface returns the height of the surface of the water that a boat at pos floats in. False is returned
+// if there is no water at the bottom of the boat or directly below it.
+func boatWaterSurface(pos mgl64.Vec3, tx *world.Tx) (float64, bool) {
+	p := cube.PosFromVec3(pos)
+	surface, ok := 0.0, false
+	for _, p := range [...]cube.Pos{p.Side(cube.FaceDown), p, p.Side(cube.FaceUp)} {
+		l, found := tx.Liquid(p)
+		if !found {
+			continue
+		}
+		if _, water := l.(block.Water); !water {
+			continue
+		}
+		height := 1.0
+		if !l.LiquidFalling() {
+			height = float64(l.LiquidDepth()) / 9
+		}
+		surface, ok = float64(p[1])+height, true
+	}
+	return surface, ok
+}
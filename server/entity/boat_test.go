@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// fillWater creates a pool of still water of the size passed at y=63, on top of a layer of stone.
+func fillWater(tx *world.Tx, size int) {
+	for x := range size {
+		for z := range size {
+			tx.SetBlock(cube.Pos{x, 62, z}, block.Stone{}, nil)
+			tx.SetBlock(cube.Pos{x, 63, z}, block.Water{Depth: 8, Still: true}, nil)
+		}
+	}
+}
+
+func TestBoatSeats(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		fillWater(tx, 8)
+		boat := tx.AddEntity(NewBoat(world.EntitySpawnOpts{Position: mgl64.Vec3{4, 64, 4}}, item.BoatTypeOak())).(*Ent)
+		a := tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{1.5, 64, 1.5}})).(*Ent)
+		b := tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{6.5, 64, 1.5}})).(*Ent)
+		c := tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{1.5, 64, 6.5}})).(*Ent)
+
+		if !Rideable(boat) || !a.Mount(boat) {
+			t.Fatalf("cow could not mount empty boat")
+		}
+		if seat, s, ok := SeatOf(boat, a.H()); !ok || seat != 0 || s.Offset != (mgl64.Vec3{0, -0.2}) {
+			t.Fatalf("seat of single rider = %v %v (%v), want seat 0 in the middle of the boat", seat, s, ok)
+		}
+		if !b.Mount(boat) || c.Mount(boat) {
+			t.Fatalf("mounting boat: second rider should fit, third should not")
+		}
+		if _, s, _ := SeatOf(boat, a.H()); s.Offset[2] <= 0 {
+			t.Errorf("seat of first of two riders = %v, want it in the front of the boat", s)
+		}
+		if h, ok := RiderOf(boat); !ok || h != a.H() || len(RidersOf(boat)) != 2 {
+			t.Errorf("riders of boat = %v, want %v controlling it and 2 riders in total", RidersOf(boat), a.H())
+		}
+
+		a.Tick(tx, 0)
+		if _, s, _ := SeatOf(boat, a.H()); a.Position() != SeatPosition(boat, s) {
+			t.Errorf("rider position = %v, want seat position %v", a.Position(), SeatPosition(boat, s))
+		}
+
+		a.Dismount()
+		if _, riding := a.Riding(); riding {
+			t.Fatalf("cow still riding boat after dismounting")
+		}
+		if _, ok := RiderOf(boat); ok || !c.Mount(boat) {
+			t.Errorf("seat of dismounted rider was not freed")
+		}
+
+		_ = boat.Close()
+		if _, riding := b.Riding(); riding {
+			t.Errorf("cow still riding boat after the boat was removed")
+		}
+	})
+}
+
+func TestBoatFloatsAndSteers(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		fillWater(tx, 24)
+		boat := tx.AddEntity(NewBoat(world.EntitySpawnOpts{Position: mgl64.Vec3{4, 64.5, 4}}, item.BoatTypeSpruce())).(*Ent)
+		for i := range int64(60) {
+			boat.Tick(tx, i)
+		}
+		if y := boat.Position()[1]; y < 63.5 || y > 64 {
+			t.Fatalf("boat floating at y = %v, want it on the surface of the water", y)
+		}
+
+		cow := tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{4, 64, 4}})).(*Ent)
+		if !cow.Mount(boat) {
+			t.Fatalf("cow could not mount boat")
+		}
+		start := boat.Position()
+		SteerEntity(cow, boat, RideInput{Forward: 1})
+		for i := range int64(20) {
+			boat.Tick(tx, i)
+		}
+		if moved := boat.Position().Sub(start); moved[2] < 1 || moved[0] > 0.01 || moved[0] < -0.01 {
+			t.Fatalf("boat steered forward moved %v, want it to move along the Z axis", moved)
+		}
+
+		SteerEntity(cow, boat, RideInput{Sideways: 1})
+		for i := range int64(10) {
+			boat.Tick(tx, i)
+		}
+		if yaw := boat.Rotation().Yaw(); yaw >= 0 {
+			t.Errorf("boat steered left has yaw %v, want it to turn left", yaw)
+		}
+	})
+}
+
+func TestBoatPicksUpMobs(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		fillWater(tx, 8)
+		boat := tx.AddEntity(NewChestBoat(world.EntitySpawnOpts{Position: mgl64.Vec3{4, 63.8, 4}}, item.BoatTypeCherry())).(*Ent)
+		cow := tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{4.5, 63.8, 4}})).(*Ent)
+		horse := tx.AddEntity(NewHorse(world.EntitySpawnOpts{Position: mgl64.Vec3{3.5, 63.8, 4}})).(*Ent)
+		boat.Tick(tx, 0)
+
+		if vehicle, ok := cow.Riding(); !ok || vehicle.H() != boat.H() {
+			t.Fatalf("cow that walked into a chest boat is not riding it")
+		}
+		if _, ok := horse.Riding(); ok {
+			t.Errorf("horse was picked up by a boat")
+		}
+		if inv, ok := boat.Behaviour().(*BoatBehaviour).Inventory(); !ok || inv.Size() != 27 {
+			t.Errorf("chest boat has no inventory with 27 slots")
+		}
+	})
+}
+
+func TestBoatNBT(t *testing.T) {
+	b := BoatBehaviourConfig{Type: item.BoatTypeMangrove(), Chest: true}.New()
+	_ = b.inventory.SetItem(3, item.NewStack(item.Diamond{}, 5))
+
+	data := &world.EntityData{}
+	ChestBoatType.DecodeNBT(ChestBoatType.EncodeNBT(&world.EntityData{Data: b}), data)
+	d := data.Data.(*BoatBehaviour)
+	if d.Type() != item.BoatTypeMangrove() || !d.Chest() {
+		t.Errorf("decoded boat type %v (chest %v), want %v (chest true)", d.Type(), d.Chest(), item.BoatTypeMangrove())
+	}
+	if it, _ := d.inventory.Item(3); !it.Equal(item.NewStack(item.Diamond{}, 5)) {
+		t.Errorf("decoded chest boat item = %v, want 5 diamonds", it)
+	}
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewChicken creates a new adult chicken. Chickens lay an egg every 5 to 10
+// minutes.
+func NewChicken(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(ChickenType, chickenConf)
+}
+
+var chickenConf = AnimalBehaviourConfig{
+	Mob: MobBehaviourConfig{
+		MaxHealth:   4,
+		Speed:       0.25,
+		SlowFalling: true,
+		Drops: []MobDrop{
+			{Item: item.Feather{}, Max: 2},
+			{Item: item.Chicken{}, Cooked: item.Chicken{Cooked: true}, Min: 1, Max: 1},
+		},
+		Experience: 2,
+	},
+	Food:    []world.Item{block.WheatSeeds{}, block.MelonSeeds{}, block.PumpkinSeeds{}, block.BeetrootSeeds{}},
+	Produce: item.Egg{},
+}
+
+// ChickenType is a world.EntityType implementation for chickens.
+var ChickenType chickenType
+
+type chickenType struct{}
+
+func (chickenType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (chickenType) EncodeEntity() string           { return "minecraft:chicken" }
+func (chickenType) MobCategory() world.MobCategory { return world.MobCategoryCreature }
+func (chickenType) BBox(e world.Entity) cube.BBox {
+	return animalBBox(e, 0.4, 0.7)
+}
+
+func (chickenType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	a := chickenConf.New()
+	decodeAnimalNBT(m, a)
+	data.Data = a
+}
+
+func (chickenType) EncodeNBT(data *world.EntityData) map[string]any {
+	return encodeAnimalNBT(data.Data.(*AnimalBehaviour))
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+)
+
+// ContainerBehaviour is implemented by the Behaviour of entities that carry an inventory which players may open,
+// such as a chest minecart.
+type ContainerBehaviour interface {
+	// Inventory returns the inventory carried by the entity. False is returned if the entity does not carry an
+	// inventory.
+	Inventory() (*inventory.Inventory, bool)
+	// AddViewer adds a viewer to the inventory, so that it is updated whenever the inventory is changed.
+	AddViewer(v block.ContainerViewer)
+	// RemoveViewer removes a viewer from the inventory, so that slot updates are no longer sent to it.
+	RemoveViewer(v block.ContainerViewer)
+}
+
+// containerInventory is an inventory carried by an entity, such as a chest minecart, together with the viewers
+// that have it opened. It may be embedded by behaviours to implement ContainerBehaviour. The inventory is nil
+// until initInventory is called.
+type containerInventory struct {
+	inventory *inventory.Inventory
+	viewerMu  sync.RWMutex
+	viewers   map[block.ContainerViewer]struct{}
+}
+
+// initInventory creates the inventory with the size passed.
+func (c *containerInventory) initInventory(size int) {
+	c.viewers = make(map[block.ContainerViewer]struct{})
+	c.inventory = inventory.New(size, func(slot int, _, after item.Stack) {
+		c.viewerMu.RLock()
+		defer c.viewerMu.RUnlock()
+		for v := range c.viewers {
+			v.ViewSlotChange(slot, after)
+		}
+	})
+}
+
+// Inventory returns the inventory carried by the entity. False is returned if the entity does not carry an
+// inventory.
+func (c *containerInventory) Inventory() (*inventory.Inventory, bool) {
+	return c.inventory, c.inventory != nil
+}
+
+// AddViewer adds a viewer to the inventory, so that it is updated whenever the inventory is changed.
+func (c *containerInventory) AddViewer(v block.ContainerViewer) {
+	c.viewerMu.Lock()
+	defer c.viewerMu.Unlock()
+	if c.viewers != nil {
+		c.viewers[v] = struct{}{}
+	}
+}
+
+// RemoveViewer removes a viewer from the inventory, so that slot updates are no longer sent to it.
+func (c *containerInventory) RemoveViewer(v block.ContainerViewer) {
+	c.viewerMu.Lock()
+	defer c.viewerMu.Unlock()
+	delete(c.viewers, v)
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewCow creates a new adult cow.
+func NewCow(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(CowType, cowConf)
+}
+
+var cowConf = AnimalBehaviourConfig{
+	Mob: MobBehaviourConfig{
+		MaxHealth: 10,
+		Speed:     0.2,
+		Drops: []MobDrop{
+			{Item: item.Leather{}, Max: 2},
+			{Item: item.Beef{}, Cooked: item.Beef{Cooked: true}, Min: 1, Max: 3},
+		},
+		Experience: 2,
+	},
+	Food: []world.Item{item.Wheat{}},
+}
+
+// CowType is a world.EntityType implementation for cows.
+var CowType cowType
+
+type cowType struct{}
+
+func (cowType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (cowType) EncodeEntity() string           { return "minecraft:cow" }
+func (cowType) MobCategory() world.MobCategory { return world.MobCategoryCreature }
+func (cowType) BBox(e world.Entity) cube.BBox {
+	return animalBBox(e, 0.9, 1.4)
+}
+
+func (cowType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	a := cowConf.New()
+	decodeAnimalNBT(m, a)
+	data.Data = a
+}
+
+func (cowType) EncodeNBT(data *world.EntityData) map[string]any {
+	return encodeAnimalNBT(data.Data.(*AnimalBehaviour))
+}
@@ -0,0 +1,206 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// NewCreeper creates a new creeper.
+func NewCreeper(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(CreeperType, CreeperBehaviourConfig{})
+}
+
+// CreeperBehaviourConfig holds optional parameters for a CreeperBehaviour.
+type CreeperBehaviourConfig struct {
+	// Charged specifies if the creeper is charged. Charged creepers explode
+	// with twice the size of regular creepers. Creepers struck by lightning
+	// become charged.
+	Charged bool
+	// Fuse is the time that a creeper swells for before it explodes. Fuse
+	// defaults to 1.5 seconds.
+	Fuse time.Duration
+	// ExplosionSize is the size of the explosion of the creeper if it is not
+	// charged. ExplosionSize defaults to 3.
+	ExplosionSize float64
+}
+
+func (conf CreeperBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a CreeperBehaviour using the parameters in conf.
+func (conf CreeperBehaviourConfig) New() *CreeperBehaviour {
+	if conf.Fuse <= 0 {
+		conf.Fuse = time.Second * 3 / 2
+	}
+	if conf.ExplosionSize <= 0 {
+		conf.ExplosionSize = 3
+	}
+	c := &CreeperBehaviour{conf: conf, charged: conf.Charged}
+	mob := creeperConf
+	mob.Goals = func() []PrioritisedGoal {
+		return []PrioritisedGoal{
+			{Priority: 0, Goal: &FloatGoal{}},
+			{Priority: 1, Goal: &creeperSwellGoal{c: c}},
+			{Priority: 3, Goal: &MeleeAttackGoal{}},
+			{Priority: 5, Goal: &WanderGoal{Speed: 0.8}},
+			{Priority: 6, Goal: &LookAtPlayerGoal{}},
+		}
+	}
+	c.MobBehaviour = mob.New()
+	return c
+}
+
+var creeperConf = MobBehaviourConfig{
+	Speed:      0.25,
+	Drops:      []MobDrop{{Item: item.Gunpowder{}, Max: 2}},
+	Experience: 5,
+	Targets:    hostileTargets,
+}
+
+// CreeperBehaviour implements the behaviour of creepers. Creepers walk
+// towards their target and swell once they get close to it, exploding if
+// their target stays close until the fuse runs out.
+type CreeperBehaviour struct {
+	*MobBehaviour
+	conf CreeperBehaviourConfig
+
+	charged  bool
+	swelling bool
+	fuse     time.Duration
+}
+
+// Charged checks if the creeper is charged.
+func (c *CreeperBehaviour) Charged() bool {
+	return c.charged
+}
+
+// Primed returns the time left until the creeper explodes, and false if the
+// creeper is not currently swelling.
+func (c *CreeperBehaviour) Primed() (time.Duration, bool) {
+	return c.conf.Fuse - c.fuse, c.swelling
+}
+
+// Tick ticks the creeper as a mob and progresses its fuse while it swells,
+// exploding once the fuse runs out.
+func (c *CreeperBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	m := c.MobBehaviour.Tick(e, tx)
+	if c.Dead() {
+		return m
+	}
+	if c.swelling {
+		c.fuse += time.Second / 20
+	} else {
+		c.fuse = max(c.fuse-time.Second/20, 0)
+	}
+	if c.fuse >= c.conf.Fuse {
+		c.explode(e, tx)
+		return nil
+	}
+	return m
+}
+
+// Hurt hurts the creeper for the damage passed. Creepers struck by lightning
+// become charged.
+func (c *CreeperBehaviour) Hurt(e *Ent, damage float64, src world.DamageSource) (float64, bool) {
+	if _, ok := src.(LightningDamageSource); ok && !c.charged && !c.Dead() {
+		c.charged = true
+		e.updateState()
+	}
+	return c.MobBehaviour.Hurt(e, damage, src)
+}
+
+// setSwelling changes whether the creeper is swelling and updates its state
+// for viewers.
+func (c *CreeperBehaviour) setSwelling(e *Ent, tx *world.Tx, swelling bool) {
+	if c.swelling == swelling {
+		return
+	}
+	c.swelling = swelling
+	if swelling {
+		tx.PlaySound(e.Position(), sound.TNT{})
+	}
+	e.updateState()
+}
+
+// explode removes the creeper and creates an explosion at its position.
+func (c *CreeperBehaviour) explode(e *Ent, tx *world.Tx) {
+	_ = e.Close()
+	size := c.conf.ExplosionSize
+	if c.charged {
+		size *= 2
+	}
+	block.ExplosionConfig{}.Explode(tx, world.EntityExplosionSource{
+		Entity:        e,
+		ExplosionSize: size,
+	})
+}
+
+// creeperSwellGoal is a Goal that makes a creeper swell when its target is
+// close. The creeper stops swelling if its target gets too far away or out of
+// sight.
+type creeperSwellGoal struct {
+	c *CreeperBehaviour
+}
+
+// Controls ...
+func (g *creeperSwellGoal) Controls() GoalControl { return GoalControlMove }
+
+// CanStart ...
+func (g *creeperSwellGoal) CanStart(m *Mob) bool {
+	t, ok := m.Target()
+	return ok && attackable(t) && (g.c.fuse > 0 || m.Distance(t) < 3)
+}
+
+// CanContinue ...
+func (g *creeperSwellGoal) CanContinue(m *Mob) bool { return g.CanStart(m) }
+
+// Start ...
+func (g *creeperSwellGoal) Start(m *Mob) { m.StopMoving() }
+
+// Tick ...
+func (g *creeperSwellGoal) Tick(m *Mob) {
+	t, ok := m.Target()
+	swelling := ok && m.Distance(t) <= 7 && m.CanSee(t)
+	if ok {
+		m.LookAt(eyePosition(t))
+	}
+	g.c.setSwelling(m.Ent(), m.Tx(), swelling)
+}
+
+// Stop ...
+func (g *creeperSwellGoal) Stop(m *Mob) { g.c.setSwelling(m.Ent(), m.Tx(), false) }
+
+// CreeperType is a world.EntityType implementation for creepers.
+var CreeperType creeperType
+
+type creeperType struct{}
+
+func (creeperType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (creeperType) EncodeEntity() string           { return "minecraft:creeper" }
+func (creeperType) MobCategory() world.MobCategory { return world.MobCategoryMonster }
+func (creeperType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.7, 0.3)
+}
+
+func (creeperType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	c := CreeperBehaviourConfig{Charged: nbtconv.Bool(m, "powered")}.New()
+	decodeMobNBT(m, c.MobBehaviour)
+	data.Data = c
+}
+
+func (creeperType) EncodeNBT(data *world.EntityData) map[string]any {
+	c := data.Data.(*CreeperBehaviour)
+	m := encodeMobNBT(c.MobBehaviour)
+	m["powered"] = boolByte(c.Charged())
+	return m
+}
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
@@ -115,9 +116,13 @@ func (e *Ent) NameTag() string {
 }
 
 // SetNameTag changes the name tag of an entity. The name tag is removed if an
-// empty string is passed.
+// empty string is passed. Naturally spawned mobs that are given a name tag no
+// longer despawn.
 func (e *Ent) SetNameTag(s string) {
 	e.data.Name = s
+	if s != "" {
+		e.data.Despawnable = false
+	}
 	e.updateState()
 }
 
@@ -134,6 +139,45 @@ func (e *Ent) SetAlwaysShowNameTag(alwaysShow bool) {
 	e.updateState()
 }
 
+// Riding returns the entity that the entity is currently riding. False is
+// returned if the entity is not riding any entity.
+func (e *Ent) Riding() (world.Entity, bool) {
+	r, ok := e.Behaviour().(behaviourRider)
+	if !ok || r.vehicle() == nil {
+		return nil, false
+	}
+	if vehicle, ok := r.vehicle().Entity(e.tx); ok {
+		if _, _, riding := SeatOf(vehicle, e.handle); riding {
+			return vehicle, true
+		}
+	}
+	r.setVehicle(nil)
+	return nil, false
+}
+
+// Mount makes the entity start riding the entity passed. False is returned if
+// the entity cannot ride other entities or if the vehicle has no free seat.
+func (e *Ent) Mount(vehicle world.Entity) bool {
+	if _, ok := e.Behaviour().(behaviourRider); !ok {
+		return false
+	}
+	if current, ok := e.Riding(); ok {
+		if current.H() == vehicle.H() {
+			return true
+		}
+		DismountEntity(e, current, e.tx)
+	}
+	return MountEntity(e, vehicle, e.tx)
+}
+
+// Dismount makes the entity stop riding the entity it is currently riding, if
+// any.
+func (e *Ent) Dismount() {
+	if vehicle, ok := e.Riding(); ok {
+		DismountEntity(e, vehicle, e.tx)
+	}
+}
+
 // updateState updates the state of the entity for all viewers of the entity.
 func (e *Ent) updateState() {
 	for _, v := range e.tx.Viewers(e.data.Pos) {
@@ -163,6 +207,7 @@ func (e *Ent) Tick(tx *world.Tx, current int64) {
 	if m != nil {
 		m.Send()
 	}
+	e.checkEntitySensors(m)
 	if e.checkPortalInsiders() && e.finishPendingPortalTravel(tx) {
 		return
 	}
@@ -225,6 +270,32 @@ type portalBlock interface {
 	Portal() world.Dimension
 }
 
+// checkEntitySensors activates block.EntitySensor blocks, such as pressure
+// plates, that the entity is inside of. Sensors are only checked if the
+// Movement passed moved the entity into different blocks, as sensors keep
+// track of entities that stay inside them by themselves.
+func (e *Ent) checkEntitySensors(m *Movement) {
+	if m == nil {
+		return
+	}
+	box := e.H().Type().BBox(e).Grow(-0.0001)
+	low, high := blocksWithin(box.Translate(m.pos))
+	if prevLow, prevHigh := blocksWithin(box.Translate(m.pos.Sub(m.dpos))); prevLow == low && prevHigh == high {
+		return
+	}
+	for blockPos := range cube.Range3D(low, high) {
+		if s, ok := e.tx.Block(blockPos).(block.EntitySensor); ok {
+			s.EntityInside(blockPos, e.tx, e)
+		}
+	}
+}
+
+// blocksWithin returns the lowest and highest block positions that a BBox
+// intersects with.
+func blocksWithin(box cube.BBox) (low, high cube.Pos) {
+	return cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+}
+
 // checkPortalInsiders checks whether the entity is inside portal blocks.
 // Other EntityInsider blocks are intentionally left to entity physics.
 func (e *Ent) checkPortalInsiders() bool {
@@ -0,0 +1,188 @@
+package entity
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// GoalControl is a set of controls over a mob that a Goal uses while it is running. Only one goal in a GoalSelector
+// may hold a control at a time, so that, for example, a mob does not wander around while chasing its target.
+// GoalControl values may be combined using a bitwise OR.
+type GoalControl uint8
+
+const (
+	// GoalControlMove is held by goals that move the mob around.
+	GoalControlMove GoalControl = 1 << iota
+	// GoalControlLook is held by goals that change where the mob looks.
+	GoalControlLook
+	// GoalControlJump is held by goals that make the mob jump.
+	GoalControlJump
+	// GoalControlTarget is held by goals that select the target of the mob.
+	GoalControlTarget
+
+	goalControlCount = iota
+)
+
+// Goal is a single task that a mob may perform, such as wandering around or attacking its target. Goals are added to
+// the GoalSelector of a MobBehaviour with a priority, which decides which goals run when several goals want to use
+// the same controls.
+// Goals may hold state for the mob they were created for, so every mob must be given its own Goal values. Goals are
+// compared when removed from a GoalSelector, so implementations should generally be pointer types.
+type Goal interface {
+	// Controls returns the controls of the mob that the goal uses while it is running.
+	Controls() GoalControl
+	// CanStart checks if the goal should start running. It is called every tick while the goal is not running.
+	CanStart(m *Mob) bool
+	// CanContinue checks if the goal should keep running. It is called every tick while the goal is running.
+	CanContinue(m *Mob) bool
+	// Start is called when the goal starts running.
+	Start(m *Mob)
+	// Tick is called every tick while the goal is running, after all goals that should start or stop have done so.
+	Tick(m *Mob)
+	// Stop is called when the goal stops running, either because CanContinue returned false or because a goal
+	// with a higher priority took over one of its controls.
+	Stop(m *Mob)
+}
+
+// PrioritisedGoal is a Goal with the priority it has in a GoalSelector. Goals with a lower Priority value take
+// precedence over goals with a higher value.
+type PrioritisedGoal struct {
+	// Priority is the priority of the goal. A lower value means a higher priority.
+	Priority int
+	// Goal is the goal itself.
+	Goal Goal
+}
+
+// GoalSelector decides which goals of a mob run. Every tick, running goals that can no longer continue are stopped,
+// after which goals that can start are started if none of the controls they need are held by a goal with the same or
+// a higher priority. Goals holding those controls with a lower priority are stopped.
+type GoalSelector struct {
+	goals    []*selectorGoal
+	controls [goalControlCount]*selectorGoal
+}
+
+// selectorGoal is a goal added to a GoalSelector.
+type selectorGoal struct {
+	PrioritisedGoal
+	running, removed bool
+}
+
+// NewGoalSelector creates a GoalSelector holding the goals passed.
+func NewGoalSelector(goals ...PrioritisedGoal) *GoalSelector {
+	s := &GoalSelector{}
+	for _, g := range goals {
+		s.Add(g.Priority, g.Goal)
+	}
+	return s
+}
+
+// Add adds a goal with the priority passed to the GoalSelector. Goals with the same priority run in the order they
+// were added.
+func (s *GoalSelector) Add(priority int, g Goal) {
+	i, _ := slices.BinarySearchFunc(s.goals, priority+1, func(g *selectorGoal, priority int) int {
+		return g.Priority - priority
+	})
+	s.goals = slices.Insert(s.goals, i, &selectorGoal{PrioritisedGoal: PrioritisedGoal{Priority: priority, Goal: g}})
+}
+
+// Remove removes a goal from the GoalSelector. If the goal is running, it is stopped during the next tick.
+func (s *GoalSelector) Remove(g Goal) {
+	for _, sg := range s.goals {
+		if sg.Goal == g {
+			sg.removed = true
+		}
+	}
+}
+
+// Goals returns all goals in the GoalSelector, ordered by priority.
+func (s *GoalSelector) Goals() []PrioritisedGoal {
+	goals := make([]PrioritisedGoal, 0, len(s.goals))
+	for _, g := range s.goals {
+		if !g.removed {
+			goals = append(goals, g.PrioritisedGoal)
+		}
+	}
+	return goals
+}
+
+// Running returns the goals that are currently running, ordered by priority.
+func (s *GoalSelector) Running() []Goal {
+	var goals []Goal
+	for _, g := range s.goals {
+		if g.running {
+			goals = append(goals, g.Goal)
+		}
+	}
+	return goals
+}
+
+// Tick stops, starts and ticks the goals in the GoalSelector for the Mob passed.
+func (s *GoalSelector) Tick(m *Mob) {
+	for _, g := range s.goals {
+		if g.running && (g.removed || !g.Goal.CanContinue(m)) {
+			s.stop(g, m)
+		}
+	}
+	s.goals = slices.DeleteFunc(s.goals, func(g *selectorGoal) bool {
+		return g.removed
+	})
+	for _, g := range s.goals {
+		if g.running || !s.available(g) || !g.Goal.CanStart(m) {
+			continue
+		}
+		for c := range goalControls(g.Goal.Controls()) {
+			if owner := s.controls[c]; owner != nil {
+				s.stop(owner, m)
+			}
+			s.controls[c] = g
+		}
+		g.running = true
+		g.Goal.Start(m)
+	}
+	for _, g := range s.goals {
+		if g.running {
+			g.Goal.Tick(m)
+		}
+	}
+}
+
+// Stop stops all running goals in the GoalSelector.
+func (s *GoalSelector) Stop(m *Mob) {
+	for _, g := range s.goals {
+		if g.running {
+			s.stop(g, m)
+		}
+	}
+}
+
+// available checks if all controls needed by a goal are free or held by goals with a lower priority.
+func (s *GoalSelector) available(g *selectorGoal) bool {
+	for c := range goalControls(g.Goal.Controls()) {
+		if owner := s.controls[c]; owner != nil && owner.Priority <= g.Priority {
+			return false
+		}
+	}
+	return true
+}
+
+// stop stops a running goal and releases the controls it holds.
+func (s *GoalSelector) stop(g *selectorGoal, m *Mob) {
+	g.running = false
+	for c, owner := range s.controls {
+		if owner == g {
+			s.controls[c] = nil
+		}
+	}
+	g.Goal.Stop(m)
+}
+
+// goalControls returns an iterator over the indices of the controls set in c.
+func goalControls(c GoalControl) func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		for v := uint8(c); v != 0; v &= v - 1 {
+			if !yield(bits.TrailingZeros8(v)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package entity
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// MeleeAttackGoal is a Goal that makes a mob walk towards its target and
+// attack it once it is close enough. The target of the mob is selected by
+// its target goals, such as NearestTargetGoal and HurtByTargetGoal.
+type MeleeAttackGoal struct {
+	// Speed is the multiple of the base speed of the mob that it chases its
+	// target at. Speed defaults to 1.
+	Speed float64
+	// Reach is the maximum horizontal distance in blocks between the mob and
+	// its target at which the mob can attack it. Reach defaults to 2.
+	Reach float64
+	// Cooldown is the minimum time between two attacks of the mob. Cooldown
+	// defaults to one second.
+	Cooldown time.Duration
+
+	cooldown  int
+	pathTicks int
+}
+
+// Controls ...
+func (g *MeleeAttackGoal) Controls() GoalControl { return GoalControlMove | GoalControlLook }
+
+// CanStart ...
+func (g *MeleeAttackGoal) CanStart(m *Mob) bool {
+	t, ok := m.Target()
+	return ok && attackable(t)
+}
+
+// CanContinue ...
+func (g *MeleeAttackGoal) CanContinue(m *Mob) bool { return g.CanStart(m) }
+
+// Start ...
+func (g *MeleeAttackGoal) Start(*Mob) { g.pathTicks = 0 }
+
+// Tick ...
+func (g *MeleeAttackGoal) Tick(m *Mob) {
+	t, ok := m.Target()
+	if !ok {
+		return
+	}
+	m.LookAt(eyePosition(t))
+	if g.pathTicks--; g.pathTicks <= 0 || !m.Moving() {
+		// Finding a new path is expensive, so the path is only updated every
+		// so often while chasing a target.
+		g.pathTicks = 4 + rand.IntN(7)
+		m.MoveTo(t.Position(), defaultValue(g.Speed, 1))
+	}
+	if g.cooldown > 0 {
+		g.cooldown--
+	}
+	pos := m.Position()
+	if g.cooldown > 0 || horizontalDistance(pos, t.Position()) > defaultValue(g.Reach, 2) || t.Position()[1]-pos[1] > 2 {
+		return
+	}
+	g.cooldown = int(defaultValue(float64(g.Cooldown), float64(time.Second)) / float64(time.Second/20))
+	m.Attack(t)
+}
+
+// Stop ...
+func (g *MeleeAttackGoal) Stop(m *Mob) { m.StopMoving() }
+
+// BowAttackGoal is a Goal that makes a mob shoot arrows at its target with a
+// bow, like skeletons do. The mob walks towards its target until it is in
+// range and can see it, after which it charges its bow and shoots.
+type BowAttackGoal struct {
+	// Speed is the multiple of the base speed of the mob that it walks
+	// towards its target at. Speed defaults to 1.
+	Speed float64
+	// Range is the maximum distance in blocks between the mob and its target
+	// at which the mob shoots at it. Range defaults to 15.
+	Range float64
+	// Interval is the minimum time between shooting an arrow and starting to
+	// charge the bow again. Interval defaults to one second.
+	Interval time.Duration
+
+	seeTicks  int
+	charge    int
+	cooldown  int
+	pathTicks int
+}
+
+// bowChargeTicks is the number of ticks that a mob charges its bow for before
+// shooting an arrow.
+const bowChargeTicks = 20
+
+// Controls ...
+func (g *BowAttackGoal) Controls() GoalControl { return GoalControlMove | GoalControlLook }
+
+// CanStart ...
+func (g *BowAttackGoal) CanStart(m *Mob) bool {
+	t, ok := m.Target()
+	return ok && attackable(t)
+}
+
+// CanContinue ...
+func (g *BowAttackGoal) CanContinue(m *Mob) bool { return g.CanStart(m) }
+
+// Start ...
+func (g *BowAttackGoal) Start(*Mob) { g.seeTicks, g.charge, g.pathTicks = 0, 0, 0 }
+
+// Tick ...
+func (g *BowAttackGoal) Tick(m *Mob) {
+	t, ok := m.Target()
+	if !ok {
+		return
+	}
+	m.LookAt(eyePosition(t))
+	inRange := m.Distance(t) <= defaultValue(g.Range, 15)
+	if m.CanSee(t) {
+		g.seeTicks++
+	} else {
+		g.seeTicks = 0
+	}
+	if inRange && g.seeTicks >= 20 {
+		m.StopMoving()
+	} else if g.pathTicks--; g.pathTicks <= 0 || !m.Moving() {
+		g.pathTicks = 4 + rand.IntN(7)
+		m.MoveTo(t.Position(), defaultValue(g.Speed, 1))
+	}
+
+	if g.cooldown > 0 {
+		g.cooldown--
+		return
+	}
+	if !inRange || g.seeTicks == 0 {
+		g.charge = 0
+		m.SetUsingItem(false)
+		return
+	}
+	m.SetUsingItem(true)
+	if g.charge++; g.charge < bowChargeTicks {
+		return
+	}
+	g.charge = 0
+	g.cooldown = int(defaultValue(float64(g.Interval), float64(time.Second)) / float64(time.Second/20))
+	m.SetUsingItem(false)
+	g.shoot(m, t)
+}
+
+// Stop ...
+func (g *BowAttackGoal) Stop(m *Mob) {
+	g.charge = 0
+	m.SetUsingItem(false)
+	m.StopMoving()
+}
+
+// shoot shoots an arrow from the mob towards the entity passed. The arrow is
+// aimed slightly above the entity to make up for gravity.
+func (g *BowAttackGoal) shoot(m *Mob, t world.Entity) {
+	start := m.EyePosition()
+	end := t.Position().Add(mgl64.Vec3{0, t.H().Type().BBox(t).Height() / 3})
+	dir := end.Sub(start)
+	dir[1] += horizontalDistance(start, end) * 0.2
+	if dir.Len() == 0 {
+		return
+	}
+	opts := world.EntitySpawnOpts{
+		Position: start,
+		Velocity: dir.Normalize().Mul(1.6),
+		Rotation: rotationTowards(start, start.Add(dir)).Neg(),
+	}
+	m.tx.AddEntity(NewArrow(opts, m.e))
+	m.tx.PlaySound(start, sound.BowShoot{})
+}
+
+// LeapAtTargetGoal is a Goal that makes a mob leap towards its target when it
+// gets close to it, like spiders do.
+type LeapAtTargetGoal struct {
+	// Height is the upward velocity that the mob leaps with. Height defaults
+	// to 0.4.
+	Height float64
+}
+
+// Controls ...
+func (g *LeapAtTargetGoal) Controls() GoalControl { return GoalControlMove | GoalControlJump }
+
+// CanStart ...
+func (g *LeapAtTargetGoal) CanStart(m *Mob) bool {
+	t, ok := m.Target()
+	if !ok || !m.OnGround() || !attackable(t) {
+		return false
+	}
+	dist := horizontalDistance(m.Position(), t.Position())
+	return dist >= 2 && dist <= 4 && rand.IntN(5) == 0
+}
+
+// CanContinue ...
+func (g *LeapAtTargetGoal) CanContinue(m *Mob) bool { return !m.OnGround() }
+
+// Start ...
+func (g *LeapAtTargetGoal) Start(m *Mob) {
+	t, _ := m.Target()
+	vel := m.e.data.Vel
+	dir := t.Position().Sub(m.Position())
+	dir[1] = 0
+	if dir.Len() > 0 {
+		dir = dir.Normalize().Mul(0.4)
+	}
+	m.e.data.Vel = mgl64.Vec3{dir[0] + vel[0]*0.2, defaultValue(g.Height, 0.4), dir[2] + vel[2]*0.2}
+}
+
+// Tick ...
+func (g *LeapAtTargetGoal) Tick(*Mob) {}
+
+// Stop ...
+func (g *LeapAtTargetGoal) Stop(*Mob) {}
+
+// NearestTargetGoal is a target Goal that makes a mob target the nearest
+// entity around it, such as the nearest player for hostile mobs.
+type NearestTargetGoal struct {
+	// Target returns true for entities that the mob may target. If Target is
+	// nil, the mob targets players.
+	Target func(e world.Entity) bool
+	// Distance is the maximum distance in blocks of the entity that the mob
+	// targets. Distance defaults to 16.
+	Distance float64
+	// Chance is the chance per tick that the mob looks for a new target while
+	// it has none. Chance defaults to 0.1.
+	Chance float64
+
+	target *world.EntityHandle
+}
+
+// Controls ...
+func (g *NearestTargetGoal) Controls() GoalControl { return GoalControlTarget }
+
+// CanStart ...
+func (g *NearestTargetGoal) CanStart(m *Mob) bool {
+	if rand.Float64() >= defaultValue(g.Chance, 0.1) {
+		return false
+	}
+	dist := defaultValue(g.Distance, 16)
+	entities := m.tx.Players()
+	if g.Target != nil {
+		entities = m.tx.EntitiesWithin(mobSearchBox(m, dist))
+	}
+	t, ok := nearestEntity(m, entities, dist, g.targets)
+	if ok {
+		g.target = t.H()
+	}
+	return ok
+}
+
+// CanContinue ...
+func (g *NearestTargetGoal) CanContinue(m *Mob) bool {
+	t, ok := m.Target()
+	return ok && t.H() == g.target && g.targets(t) && m.Distance(t) <= defaultValue(g.Distance, 16)
+}
+
+// Start ...
+func (g *NearestTargetGoal) Start(m *Mob) {
+	if t, ok := g.target.Entity(m.tx); ok {
+		m.SetTarget(t)
+	}
+}
+
+// Tick ...
+func (g *NearestTargetGoal) Tick(*Mob) {}
+
+// Stop ...
+func (g *NearestTargetGoal) Stop(m *Mob) {
+	stopTargeting(m, g.target)
+	g.target = nil
+}
+
+// targets checks if the mob may target the entity passed.
+func (g *NearestTargetGoal) targets(e world.Entity) bool {
+	if !attackable(e) {
+		return false
+	}
+	return g.Target == nil || g.Target(e)
+}
+
+// HurtByTargetGoal is a target Goal that makes a mob target the entity that
+// last attacked it.
+type HurtByTargetGoal struct {
+	// Distance is the maximum distance in blocks that the mob keeps targeting
+	// its attacker from. Distance defaults to 32.
+	Distance float64
+
+	target     *world.EntityHandle
+	attackedAt time.Duration
+}
+
+// Controls ...
+func (g *HurtByTargetGoal) Controls() GoalControl { return GoalControlTarget }
+
+// CanStart ...
+func (g *HurtByTargetGoal) CanStart(m *Mob) bool {
+	attacker, at, ok := m.LastAttacker()
+	return ok && at != g.attackedAt && attackable(attacker)
+}
+
+// CanContinue ...
+func (g *HurtByTargetGoal) CanContinue(m *Mob) bool {
+	t, ok := m.Target()
+	return ok && t.H() == g.target && attackable(t) && m.Distance(t) <= defaultValue(g.Distance, 32)
+}
+
+// Start ...
+func (g *HurtByTargetGoal) Start(m *Mob) {
+	attacker, at, _ := m.LastAttacker()
+	g.target, g.attackedAt = attacker.H(), at
+	m.SetTarget(attacker)
+}
+
+// Tick ...
+func (g *HurtByTargetGoal) Tick(*Mob) {}
+
+// Stop ...
+func (g *HurtByTargetGoal) Stop(m *Mob) {
+	stopTargeting(m, g.target)
+	g.target = nil
+}
+
+// stopTargeting clears the target of the mob if it is still the entity
+// passed, so that a target goal does not clear a target selected by another
+// goal.
+func stopTargeting(m *Mob, target *world.EntityHandle) {
+	if m.b.target == target {
+		m.SetTarget(nil)
+	}
+}
+
+// attackable checks if a mob may attack the entity passed. Dead entities and
+// players that cannot take damage are not attackable.
+func attackable(e world.Entity) bool {
+	if !DamageableEntity(e) || !visible(e) {
+		return false
+	}
+	if l, ok := e.(interface{ Dead() bool }); ok && l.Dead() {
+		return false
+	}
+	if ent, ok := e.(*Ent); ok {
+		if b, ok := ent.Behaviour().(interface{ Dead() bool }); ok && b.Dead() {
+			return false
+		}
+	}
+	if g, ok := e.(interface{ GameMode() world.GameMode }); ok {
+		return g.GameMode().AllowsTakingDamage()
+	}
+	return true
+}
@@ -0,0 +1,339 @@
+package entity
+
+import (
+	"iter"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// WanderGoal is a Goal that makes a mob walk to random positions around it
+// every now and then.
+type WanderGoal struct {
+	// Speed is the multiple of the base speed of the mob that it wanders at.
+	// Speed defaults to 1.
+	Speed float64
+	// Chance is the chance per tick that the mob starts wandering while it is
+	// idle. Chance defaults to 1/120.
+	Chance float64
+	// Radius is the maximum horizontal distance in blocks of the positions
+	// that the mob wanders to. Radius defaults to 10.
+	Radius int
+}
+
+// Controls ...
+func (g *WanderGoal) Controls() GoalControl { return GoalControlMove }
+
+// CanStart ...
+func (g *WanderGoal) CanStart(m *Mob) bool {
+	return !m.Moving() && rand.Float64() < defaultValue(g.Chance, 1.0/120)
+}
+
+// CanContinue ...
+func (g *WanderGoal) CanContinue(m *Mob) bool { return m.Moving() }
+
+// Start ...
+func (g *WanderGoal) Start(m *Mob) {
+	r := int(defaultValue(float64(g.Radius), 10))
+	origin := cube.PosFromVec3(m.Position())
+	for range 10 {
+		pos := origin.Add(cube.Pos{rand.IntN(r*2+1) - r, 0, rand.IntN(r*2+1) - r})
+		if dst, ok := groundBelow(m.tx, pos, 7); ok {
+			m.MoveTo(dst, defaultValue(g.Speed, 1))
+			return
+		}
+	}
+}
+
+// Tick ...
+func (g *WanderGoal) Tick(*Mob) {}
+
+// Stop ...
+func (g *WanderGoal) Stop(m *Mob) { m.StopMoving() }
+
+// LookAtPlayerGoal is a Goal that makes a mob look at a nearby player for a
+// few seconds every now and then.
+type LookAtPlayerGoal struct {
+	// Distance is the maximum distance in blocks of the player that the mob
+	// looks at. Distance defaults to 8.
+	Distance float64
+	// Chance is the chance per tick that the mob starts looking at a player
+	// while one is close enough. Chance defaults to 0.02.
+	Chance float64
+
+	player *world.EntityHandle
+	ticks  int
+}
+
+// Controls ...
+func (g *LookAtPlayerGoal) Controls() GoalControl { return GoalControlLook }
+
+// CanStart ...
+func (g *LookAtPlayerGoal) CanStart(m *Mob) bool {
+	if rand.Float64() >= defaultValue(g.Chance, 0.02) {
+		return false
+	}
+	p, ok := nearestEntity(m, m.tx.Players(), defaultValue(g.Distance, 8), visible)
+	if ok {
+		g.player = p.H()
+	}
+	return ok
+}
+
+// CanContinue ...
+func (g *LookAtPlayerGoal) CanContinue(m *Mob) bool {
+	p, ok := g.player.Entity(m.tx)
+	return ok && g.ticks > 0 && visible(p) && m.Distance(p) <= defaultValue(g.Distance, 8)
+}
+
+// Start ...
+func (g *LookAtPlayerGoal) Start(*Mob) { g.ticks = 40 + rand.IntN(40) }
+
+// Tick ...
+func (g *LookAtPlayerGoal) Tick(m *Mob) {
+	if p, ok := g.player.Entity(m.tx); ok {
+		m.LookAt(eyePosition(p))
+	}
+	g.ticks--
+}
+
+// Stop ...
+func (g *LookAtPlayerGoal) Stop(*Mob) { g.player = nil }
+
+// FleeGoal is a Goal that makes a mob run away from nearby entities. If no
+// entities to avoid are set, the mob runs away from the entity that attacked
+// it for a short time after being attacked, like passive animals do.
+type FleeGoal struct {
+	// Avoid returns true for entities that the mob should run away from. If
+	// Avoid is nil, the mob runs away from the entity that last attacked it.
+	Avoid func(e world.Entity) bool
+	// Distance is the distance in blocks within which the mob starts to run
+	// away from an entity, and how far it runs. Distance defaults to 8.
+	Distance float64
+	// Speed is the multiple of the base speed of the mob that it runs away
+	// at. Speed defaults to 1.25.
+	Speed float64
+
+	attackedAt time.Duration
+}
+
+// Controls ...
+func (g *FleeGoal) Controls() GoalControl { return GoalControlMove }
+
+// CanStart ...
+func (g *FleeGoal) CanStart(m *Mob) bool {
+	threat, ok := g.threat(m)
+	if !ok {
+		return false
+	}
+	pos, from := m.Position(), threat.Position()
+	dir := mgl64.Vec3{pos[0] - from[0], 0, pos[2] - from[2]}
+	if dir.Len() == 0 {
+		angle := rand.Float64() * math.Pi * 2
+		dir = mgl64.Vec3{math.Cos(angle), 0, math.Sin(angle)}
+	}
+	dst := pos.Add(dir.Normalize().Mul(defaultValue(g.Distance, 8)))
+	target, ok := groundBelow(m.tx, cube.PosFromVec3(dst), 4)
+	if !ok {
+		return false
+	}
+	if g.Avoid == nil {
+		_, g.attackedAt, _ = m.LastAttacker()
+	}
+	return m.MoveTo(target, defaultValue(g.Speed, 1.25))
+}
+
+// CanContinue ...
+func (g *FleeGoal) CanContinue(m *Mob) bool { return m.Moving() }
+
+// Start ...
+func (g *FleeGoal) Start(*Mob) {}
+
+// Tick ...
+func (g *FleeGoal) Tick(*Mob) {}
+
+// Stop ...
+func (g *FleeGoal) Stop(m *Mob) { m.StopMoving() }
+
+// threat returns the entity that the mob should currently run away from.
+func (g *FleeGoal) threat(m *Mob) (world.Entity, bool) {
+	dist := defaultValue(g.Distance, 8)
+	if g.Avoid != nil {
+		return nearestEntity(m, m.tx.EntitiesWithin(mobSearchBox(m, dist)), dist, g.Avoid)
+	}
+	attacker, at, ok := m.LastAttacker()
+	if !ok || at == g.attackedAt || m.e.Age()-at > 5*time.Second {
+		return nil, false
+	}
+	return attacker, true
+}
+
+// FollowGoal is a Goal that makes a mob follow a nearby entity, for example a
+// player holding food or the parent of a baby animal.
+type FollowGoal struct {
+	// Follow returns true for entities that the mob should follow. If Follow
+	// is nil, the mob follows players.
+	Follow func(e world.Entity) bool
+	// Distance is the maximum distance in blocks of the entity that the mob
+	// follows. Distance defaults to 8.
+	Distance float64
+	// MinDistance is the distance in blocks at which the mob stops walking
+	// towards the entity it follows. MinDistance defaults to 2.
+	MinDistance float64
+	// Speed is the multiple of the base speed of the mob that it follows the
+	// entity at. Speed defaults to 1.
+	Speed float64
+
+	followed *world.EntityHandle
+	ticks    int
+}
+
+// Controls ...
+func (g *FollowGoal) Controls() GoalControl { return GoalControlMove | GoalControlLook }
+
+// CanStart ...
+func (g *FollowGoal) CanStart(m *Mob) bool {
+	e, ok := g.find(m)
+	if ok {
+		g.followed = e.H()
+	}
+	return ok
+}
+
+// CanContinue ...
+func (g *FollowGoal) CanContinue(m *Mob) bool {
+	e, ok := g.followed.Entity(m.tx)
+	return ok && g.follows(e) && m.Distance(e) <= defaultValue(g.Distance, 8)
+}
+
+// Start ...
+func (g *FollowGoal) Start(*Mob) { g.ticks = 0 }
+
+// Tick ...
+func (g *FollowGoal) Tick(m *Mob) {
+	e, ok := g.followed.Entity(m.tx)
+	if !ok {
+		return
+	}
+	m.LookAt(eyePosition(e))
+	if m.Distance(e) <= defaultValue(g.MinDistance, 2) {
+		m.StopMoving()
+		return
+	}
+	if g.ticks--; g.ticks <= 0 || !m.Moving() {
+		g.ticks = 10
+		m.MoveTo(e.Position(), defaultValue(g.Speed, 1))
+	}
+}
+
+// Stop ...
+func (g *FollowGoal) Stop(m *Mob) {
+	g.followed = nil
+	m.StopMoving()
+}
+
+// find returns the nearest entity that the mob should follow.
+func (g *FollowGoal) find(m *Mob) (world.Entity, bool) {
+	dist := defaultValue(g.Distance, 8)
+	if g.Follow == nil {
+		return nearestEntity(m, m.tx.Players(), dist, g.follows)
+	}
+	return nearestEntity(m, m.tx.EntitiesWithin(mobSearchBox(m, dist)), dist, g.follows)
+}
+
+// follows checks if the mob should follow the entity passed.
+func (g *FollowGoal) follows(e world.Entity) bool {
+	if g.Follow == nil {
+		return visible(e)
+	}
+	return g.Follow(e)
+}
+
+// FloatGoal is a Goal that makes a mob swim up when it is in water, so that
+// it does not drown.
+type FloatGoal struct{}
+
+// Controls ...
+func (g *FloatGoal) Controls() GoalControl { return GoalControlJump }
+
+// CanStart ...
+func (g *FloatGoal) CanStart(m *Mob) bool { return m.InWater() }
+
+// CanContinue ...
+func (g *FloatGoal) CanContinue(m *Mob) bool { return m.InWater() }
+
+// Start ...
+func (g *FloatGoal) Start(*Mob) {}
+
+// Tick ...
+func (g *FloatGoal) Tick(m *Mob) {
+	if rand.Float64() < 0.8 {
+		m.Jump()
+	}
+}
+
+// Stop ...
+func (g *FloatGoal) Stop(*Mob) {}
+
+// nearestEntity returns the entity closest to the mob out of the entities
+// passed that is within dist blocks of the mob and for which f returns true.
+func nearestEntity(m *Mob, entities iter.Seq[world.Entity], dist float64, f func(e world.Entity) bool) (world.Entity, bool) {
+	var nearest world.Entity
+	for e := range entities {
+		if e.H() == m.e.H() || !f(e) {
+			continue
+		}
+		if d := m.Distance(e); d <= dist {
+			nearest, dist = e, d
+		}
+	}
+	return nearest, nearest != nil
+}
+
+// mobSearchBox returns a box around the mob that holds all entities within
+// dist blocks of it.
+func mobSearchBox(m *Mob, dist float64) cube.BBox {
+	return cube.Box(-dist, -dist, -dist, dist, dist, dist).Translate(m.Position())
+}
+
+// visible checks if an entity may be seen by mobs. Players in a game mode
+// that does not allow them to be seen, such as spectator mode, are not.
+func visible(e world.Entity) bool {
+	if g, ok := e.(interface{ GameMode() world.GameMode }); ok {
+		return g.GameMode().Visible()
+	}
+	return true
+}
+
+// groundBelow finds the highest position in the column of pos, at most depth
+// blocks above or below it, at which a mob can stand. The position returned
+// is the centre of the bottom of the block that the mob would stand in.
+func groundBelow(tx *world.Tx, pos cube.Pos, depth int) (mgl64.Vec3, bool) {
+	for y := pos[1] + depth; y >= pos[1]-depth; y-- {
+		p := cube.Pos{pos[0], y, pos[2]}
+		if p.OutOfBounds(tx.Range()) {
+			continue
+		}
+		if passable(tx, p) && passable(tx, p.Side(cube.FaceUp)) && !passable(tx, p.Side(cube.FaceDown)) {
+			return p.Vec3Middle(), true
+		}
+	}
+	return mgl64.Vec3{}, false
+}
+
+// passable checks if an entity can move through the block at pos.
+func passable(tx *world.Tx, pos cube.Pos) bool {
+	return len(tx.Block(pos).Model().BBox(pos, tx)) == 0
+}
+
+// defaultValue returns v if it is not 0, or def if it is.
+func defaultValue(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
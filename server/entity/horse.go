@@ -0,0 +1,383 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// horseMaxTemper is the temper at which an untamed horse always accepts
+	// its rider.
+	horseMaxTemper = 100
+	// horseTameInterval is the number of ticks between the attempts of an
+	// untamed horse to throw off its rider.
+	horseTameInterval = 40
+	// horseJumpChargeTicks is the number of ticks that the rider of a horse
+	// needs to hold its jump button for the horse to jump at full strength.
+	horseJumpChargeTicks = 10
+)
+
+// NewHorse creates a new untamed adult horse with a random coat and random
+// speed, jump strength and health.
+func NewHorse(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(HorseType, HorseBehaviourConfig{Colour: rand.IntN(7), Markings: rand.IntN(5)})
+}
+
+// HorseBehaviourConfig holds optional parameters for a HorseBehaviour.
+type HorseBehaviourConfig struct {
+	// Colour is the colour of the coat of the horse, ranging from 0 to 6:
+	// white, creamy, chestnut, brown, black, grey and dark brown.
+	Colour int
+	// Markings is the pattern of markings on the coat of the horse, ranging
+	// from 0 to 4: none, white, white field, white dots and black dots.
+	Markings int
+	// Tamed specifies if the horse is tamed. Untamed horses throw off their
+	// rider until they accept it.
+	Tamed bool
+	// Saddled specifies if the horse wears a saddle. Only tamed horses with a
+	// saddle may be controlled by their rider.
+	Saddled bool
+	// JumpStrength is the upward velocity of the horse when it jumps at full
+	// strength. JumpStrength defaults to a random value between 0.4 and 1.
+	JumpStrength float64
+	// Speed is the base movement speed of the horse in blocks per tick.
+	// Speed defaults to a random value between 0.1125 and 0.3375.
+	Speed float64
+	// MaxHealth is the maximum health of the horse. MaxHealth defaults to a
+	// random value between 15 and 30.
+	MaxHealth float64
+	// Baby specifies if the horse is a baby.
+	Baby bool
+}
+
+func (conf HorseBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a HorseBehaviour using the parameters in conf.
+func (conf HorseBehaviourConfig) New() *HorseBehaviour {
+	if conf.JumpStrength <= 0 {
+		conf.JumpStrength = randomHorseJumpStrength()
+	}
+	if conf.Speed <= 0 {
+		conf.Speed = randomHorseSpeed()
+	}
+	if conf.MaxHealth <= 0 {
+		conf.MaxHealth = randomHorseHealth()
+	}
+	h := &HorseBehaviour{
+		riderSeats: newRiderSeats(1),
+		conf:       conf,
+		tamed:      conf.Tamed,
+		saddled:    conf.Saddled,
+	}
+	animal := horseConf
+	animal.Baby = conf.Baby
+	animal.Interact = h.interact
+	animal.Offspring = h.offspring
+	animal.Mob.MaxHealth, animal.Mob.Speed = conf.MaxHealth, conf.Speed
+	animal.Mob.Ridden = h.ridden
+	animal.Mob.Death = h.dropSaddle
+	h.AnimalBehaviour = animal.New()
+	return h
+}
+
+var horseConf = AnimalBehaviourConfig{
+	Mob: MobBehaviourConfig{
+		Drops:      []MobDrop{{Item: item.Leather{}, Max: 2}},
+		Experience: 3,
+	},
+	Food: []world.Item{item.GoldenCarrot{}, item.GoldenApple{}},
+}
+
+// HorseBehaviour implements the behaviour of horses. Horses may be ridden by
+// a single rider. Untamed horses throw off their rider until they accept it,
+// after which they may be saddled so that the rider can control them.
+type HorseBehaviour struct {
+	*AnimalBehaviour
+	riderSeats
+
+	conf           HorseBehaviourConfig
+	tamed, saddled bool
+	temper         int
+	tameTicks      int
+
+	input      RideInput
+	jumpCharge int
+}
+
+// Variant returns the colour of the coat of the horse.
+func (h *HorseBehaviour) Variant() int32 {
+	return int32(h.conf.Colour)
+}
+
+// MarkVariant returns the markings on the coat of the horse.
+func (h *HorseBehaviour) MarkVariant() int32 {
+	return int32(h.conf.Markings)
+}
+
+// Tamed checks if the horse is tamed.
+func (h *HorseBehaviour) Tamed() bool {
+	return h.tamed
+}
+
+// Saddled checks if the horse wears a saddle.
+func (h *HorseBehaviour) Saddled() bool {
+	return h.saddled
+}
+
+// Temper returns the temper of an untamed horse. The higher the temper, the
+// more likely the horse is to accept its rider.
+func (h *HorseBehaviour) Temper() int {
+	return h.temper
+}
+
+// JumpStrength returns the upward velocity of the horse when it jumps at full
+// strength.
+func (h *HorseBehaviour) JumpStrength() float64 {
+	return h.conf.JumpStrength
+}
+
+// Controllable checks if the horse may be controlled by its rider, which is
+// the case if it is tamed and saddled.
+func (h *HorseBehaviour) Controllable() bool {
+	return h.tamed && h.saddled
+}
+
+// Seats returns the single seat on the back of the horse.
+func (h *HorseBehaviour) Seats() []Seat {
+	return []Seat{{Offset: mgl64.Vec3{0, 1.1, -0.2}}}
+}
+
+// canBeRidden checks if the horse is an adult that is alive.
+func (h *HorseBehaviour) canBeRidden() bool {
+	return !h.Baby() && !h.Dead()
+}
+
+// steer stores the input of the rider of the horse, so that it is used the
+// next time the horse is ticked.
+func (h *HorseBehaviour) steer(_ *Ent, input RideInput) {
+	h.input = input
+}
+
+// Tick ticks the horse as an animal. An untamed horse that is ridden every
+// now and then tries to throw off its rider, unless it accepts the rider.
+func (h *HorseBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	h.removeClosedRiders(tx)
+	if _, ridden := RiderOf(e); ridden && !h.tamed && !h.Dead() {
+		if h.tameTicks++; h.tameTicks >= horseTameInterval {
+			h.tameTicks = 0
+			h.tryTame(e, tx)
+		}
+	} else {
+		h.tameTicks = 0
+	}
+	return h.AnimalBehaviour.Tick(e, tx)
+}
+
+// tryTame makes an untamed horse decide if it accepts its rider. The horse is
+// tamed if it does, and throws off its rider otherwise. The temper of the
+// horse grows with every rider thrown off.
+func (h *HorseBehaviour) tryTame(e *Ent, tx *world.Tx) {
+	if rand.IntN(horseMaxTemper) < h.temper {
+		h.tamed, e.data.Despawnable = true, false
+		e.updateState()
+		for _, v := range tx.Viewers(e.data.Pos) {
+			v.ViewEntityAction(e, TamingSucceededAction{})
+		}
+		return
+	}
+	h.temper = min(h.temper+5, horseMaxTemper)
+	ejectRiders(e, tx)
+	for _, v := range tx.Viewers(e.data.Pos) {
+		v.ViewEntityAction(e, TamingFailedAction{})
+	}
+}
+
+// ridden returns the velocity of the horse if it is controlled by its rider.
+// The horse faces the direction that its rider looks in and jumps once its
+// rider releases the jump button.
+func (h *HorseBehaviour) ridden(e *Ent, _ *world.Tx) (mgl64.Vec3, bool) {
+	if _, ridden := RiderOf(e); !ridden || !h.Controllable() {
+		h.input, h.jumpCharge = RideInput{}, 0
+		return mgl64.Vec3{}, false
+	}
+	input, vel, onGround := h.input, e.data.Vel, h.mc.OnGround()
+	e.data.Rot = cube.Rotation{input.Yaw, 0}
+
+	if onGround {
+		yaw := mgl64.DegToRad(input.Yaw)
+		forward, left := mgl64.Vec3{-math.Sin(yaw), 0, math.Cos(yaw)}, mgl64.Vec3{math.Cos(yaw), 0, math.Sin(yaw)}
+		if input.Forward < 0 {
+			// Horses walk backwards at a quarter of their speed.
+			input.Forward *= 0.25
+		}
+		dir := forward.Mul(input.Forward).Add(left.Mul(input.Sideways * 0.5))
+		if dir.Len() > 1 {
+			dir = dir.Normalize()
+		}
+		vel[0], vel[2] = dir[0]*h.Speed(), dir[2]*h.Speed()
+	}
+
+	switch {
+	case input.Jumping:
+		h.jumpCharge = min(h.jumpCharge+1, horseJumpChargeTicks)
+	case h.jumpCharge > 0:
+		if onGround {
+			vel[1] = h.conf.JumpStrength * float64(h.jumpCharge) / horseJumpChargeTicks
+		}
+		h.jumpCharge = 0
+	}
+	return vel, true
+}
+
+// interact saddles a tamed horse if a saddle is used on it, or feeds the
+// horse, healing it and making an untamed horse more likely to accept its
+// rider.
+func (h *HorseBehaviour) interact(e *Ent, _ item.User, held item.Stack, ctx *item.UseContext) bool {
+	if _, ok := held.Item().(item.Saddle); ok {
+		if !h.tamed || h.saddled || h.Baby() {
+			return false
+		}
+		h.saddled = true
+		ctx.SubtractFromCount(1)
+		e.updateState()
+		return true
+	}
+	var health float64
+	var temper int
+	switch held.Item().(type) {
+	case item.Wheat:
+		health, temper = 2, 3
+	case item.Sugar:
+		health, temper = 1, 3
+	case item.Apple:
+		health, temper = 3, 3
+	case block.HayBale:
+		health = 20
+	default:
+		return false
+	}
+	return h.feed(health, temper, ctx)
+}
+
+// Interact feeds the horse. Untamed horses may not breed, so feeding them
+// golden carrots or golden apples only heals them and makes them more likely
+// to accept their rider.
+func (h *HorseBehaviour) Interact(e *Ent, user item.User, held item.Stack, ctx *item.UseContext) bool {
+	if h.tamed || h.Dead() || held.Empty() || !h.Food(held.Item()) {
+		return h.AnimalBehaviour.Interact(e, user, held, ctx)
+	}
+	if _, ok := held.Item().(item.GoldenApple); ok {
+		return h.feed(10, 10, ctx)
+	}
+	return h.feed(4, 5, ctx)
+}
+
+// feed heals the horse by the health passed and adds the temper passed to an
+// untamed horse. False is returned if the food had no effect.
+func (h *HorseBehaviour) feed(health float64, temper int, ctx *item.UseContext) bool {
+	if h.tamed || h.temper >= horseMaxTemper {
+		temper = 0
+	}
+	if h.Heal(health) <= 0 && temper == 0 {
+		return false
+	}
+	h.temper = min(h.temper+temper, horseMaxTemper)
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// offspring returns the configuration of the baby of the horse and its
+// partner. The baby has the coat of one of its parents, and its speed, jump
+// strength and health are close to the average of those of its parents.
+func (h *HorseBehaviour) offspring(_, partner *Ent) world.EntityConfig {
+	conf := HorseBehaviourConfig{Colour: h.conf.Colour, Markings: h.conf.Markings, Baby: true}
+	p, ok := partner.Behaviour().(*HorseBehaviour)
+	if !ok {
+		p = h
+	}
+	if rand.IntN(2) == 0 {
+		conf.Colour = p.conf.Colour
+	}
+	if rand.IntN(2) == 0 {
+		conf.Markings = p.conf.Markings
+	}
+	conf.JumpStrength = (h.conf.JumpStrength + p.conf.JumpStrength + randomHorseJumpStrength()) / 3
+	conf.Speed = (h.conf.Speed + p.conf.Speed + randomHorseSpeed()) / 3
+	conf.MaxHealth = math.Round((h.conf.MaxHealth + p.conf.MaxHealth + randomHorseHealth()) / 3)
+	return conf
+}
+
+// dropSaddle drops the saddle of the horse when it dies.
+func (h *HorseBehaviour) dropSaddle(e *Ent, _ *world.Tx, _ world.DamageSource) {
+	if h.saddled {
+		h.dropLoot(e, []MobDrop{{Item: item.Saddle{}, Min: 1, Max: 1}}, 0)
+	}
+}
+
+// randomHorseJumpStrength returns a random jump strength of a horse between
+// 0.4 and 1, most likely close to 0.7.
+func randomHorseJumpStrength() float64 {
+	return 0.4 + rand.Float64()*0.2 + rand.Float64()*0.2 + rand.Float64()*0.2
+}
+
+// randomHorseSpeed returns a random movement speed of a horse between 0.1125
+// and 0.3375, most likely close to 0.225.
+func randomHorseSpeed() float64 {
+	return 0.1125 + rand.Float64()*0.075 + rand.Float64()*0.075 + rand.Float64()*0.075
+}
+
+// randomHorseHealth returns a random maximum health of a horse between 15 and
+// 30.
+func randomHorseHealth() float64 {
+	return float64(15 + rand.IntN(8) + rand.IntN(9))
+}
+
+// HorseType is a world.EntityType implementation for horses.
+var HorseType horseType
+
+type horseType struct{}
+
+func (horseType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (horseType) EncodeEntity() string           { return "minecraft:horse" }
+func (horseType) MobCategory() world.MobCategory { return world.MobCategoryCreature }
+func (horseType) BBox(e world.Entity) cube.BBox {
+	return animalBBox(e, 1.3964844, 1.6)
+}
+
+func (horseType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	h := HorseBehaviourConfig{
+		Colour:       int(nbtconv.Int32(m, "Variant")),
+		Markings:     int(nbtconv.Int32(m, "MarkVariant")),
+		Tamed:        nbtconv.Bool(m, "IsTamed"),
+		Saddled:      nbtconv.Bool(m, "Saddled"),
+		JumpStrength: float64(nbtconv.Float32(m, "JumpStrength")),
+		Speed:        float64(nbtconv.Float32(m, "MovementSpeed")),
+		MaxHealth:    float64(nbtconv.Float32(m, "MaxHealth")),
+	}.New()
+	h.temper = int(nbtconv.Int32(m, "Temper"))
+	decodeAnimalNBT(m, h.AnimalBehaviour)
+	data.Data = h
+}
+
+func (horseType) EncodeNBT(data *world.EntityData) map[string]any {
+	h := data.Data.(*HorseBehaviour)
+	m := encodeAnimalNBT(h.AnimalBehaviour)
+	m["Variant"], m["MarkVariant"] = int32(h.conf.Colour), int32(h.conf.Markings)
+	m["IsTamed"], m["Saddled"] = boolByte(h.tamed), boolByte(h.saddled)
+	m["Temper"] = int32(h.temper)
+	m["JumpStrength"], m["MovementSpeed"] = float32(h.conf.JumpStrength), float32(h.conf.Speed)
+	m["MaxHealth"] = float32(h.conf.MaxHealth)
+	return m
+}
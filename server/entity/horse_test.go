@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestHorseTaming(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := range 8 {
+			for z := range 8 {
+				tx.SetBlock(cube.Pos{x, 63, z}, block.Stone{}, nil)
+			}
+		}
+		e := tx.AddEntity(NewHorse(world.EntitySpawnOpts{Position: mgl64.Vec3{4, 64, 4}})).(*Ent)
+		h := e.Behaviour().(*HorseBehaviour)
+		rider := tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{2, 64, 2}})).(*Ent)
+
+		if InteractEntity(e, nil, item.NewStack(item.Saddle{}, 1), &item.UseContext{}) {
+			t.Fatalf("saddled an untamed horse")
+		}
+		if !rider.Mount(e) {
+			t.Fatalf("could not mount untamed horse")
+		}
+		for i := range int64(horseTameInterval) {
+			e.Tick(tx, i)
+		}
+		if _, riding := rider.Riding(); riding || h.Tamed() {
+			t.Fatalf("horse with no temper did not throw off its rider")
+		}
+		if h.Temper() != 5 {
+			t.Errorf("temper after throwing off rider = %v, want 5", h.Temper())
+		}
+
+		ctx := &item.UseContext{}
+		if !InteractEntity(e, nil, item.NewStack(item.GoldenApple{}, 1), ctx) || ctx.CountSub != 1 || h.Temper() != 15 {
+			t.Fatalf("feeding untamed horse a golden apple: temper = %v, want 15", h.Temper())
+		}
+		if h.InLove() {
+			t.Errorf("untamed horse fell in love, want only tamed horses to breed")
+		}
+
+		h.temper = horseMaxTemper
+		rider.Mount(e)
+		for i := range int64(horseTameInterval) {
+			e.Tick(tx, i)
+		}
+		if _, riding := rider.Riding(); !riding || !h.Tamed() {
+			t.Fatalf("horse with maximum temper did not accept its rider")
+		}
+		if h.Controllable() {
+			t.Fatalf("horse without saddle is controllable")
+		}
+		ctx = &item.UseContext{}
+		if !InteractEntity(e, nil, item.NewStack(item.Saddle{}, 1), ctx) || ctx.CountSub != 1 || !h.Saddled() {
+			t.Fatalf("could not saddle tamed horse")
+		}
+		if InteractEntity(e, nil, item.NewStack(item.Saddle{}, 1), &item.UseContext{}) {
+			t.Errorf("saddled horse twice")
+		}
+	})
+}
+
+func TestHorseRiderControl(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := range 24 {
+			for z := range 24 {
+				tx.SetBlock(cube.Pos{x, 63, z}, block.Stone{}, nil)
+			}
+		}
+		conf := HorseBehaviourConfig{Tamed: true, Saddled: true, JumpStrength: 0.8, Speed: 0.3}
+		e := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{4, 64, 4}}.New(HorseType, conf)).(*Ent)
+		rider := tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{2, 64, 2}})).(*Ent)
+		if !rider.Mount(e) {
+			t.Fatalf("could not mount tamed horse")
+		}
+		// Let the horse land on the ground first.
+		for i := range int64(5) {
+			e.Tick(tx, i)
+		}
+
+		start := e.Position()
+		SteerEntity(rider, e, RideInput{Forward: 1, Yaw: -90})
+		for i := range int64(20) {
+			e.Tick(tx, i)
+		}
+		if moved := e.Position().Sub(start); moved[0] < 2 || moved[2] > 0.01 || moved[2] < -0.01 {
+			t.Fatalf("horse ridden towards yaw -90 moved %v, want it to move along the X axis", moved)
+		}
+		if yaw := e.Rotation().Yaw(); yaw != -90 {
+			t.Errorf("horse yaw = %v, want it to face the direction of its rider", yaw)
+		}
+
+		SteerEntity(rider, e, RideInput{Jumping: true, Yaw: -90})
+		for i := range int64(horseJumpChargeTicks) {
+			e.Tick(tx, i)
+		}
+		ground := e.Position()[1]
+		SteerEntity(rider, e, RideInput{Yaw: -90})
+		e.Tick(tx, 0)
+		e.Tick(tx, 1)
+		if y := e.Position()[1]; y <= ground+0.5 {
+			t.Errorf("horse at y %v after jumping from %v, want it to jump", y, ground)
+		}
+	})
+}
+
+func TestHorseNBT(t *testing.T) {
+	conf := HorseBehaviourConfig{Colour: 4, Markings: 2, Tamed: true, Saddled: true, JumpStrength: 0.9, Speed: 0.25, MaxHealth: 28}
+	h := conf.New()
+	h.temper = 40
+
+	data := &world.EntityData{}
+	HorseType.DecodeNBT(HorseType.EncodeNBT(&world.EntityData{Data: h}), data)
+	d := data.Data.(*HorseBehaviour)
+	if d.Variant() != 4 || d.MarkVariant() != 2 || !d.Tamed() || !d.Saddled() || d.Temper() != 40 {
+		t.Errorf("decoded horse = variant %v, markings %v, tamed %v, saddled %v, temper %v, want 4, 2, true, true, 40", d.Variant(), d.MarkVariant(), d.Tamed(), d.Saddled(), d.Temper())
+	}
+	if !mgl64.FloatEqualThreshold(d.JumpStrength(), 0.9, 1e-6) || !mgl64.FloatEqualThreshold(d.Speed(), 0.25, 1e-6) || d.MaxHealth() != 28 {
+		t.Errorf("decoded horse stats = jump %v, speed %v, health %v, want 0.9, 0.25, 28", d.JumpStrength(), d.Speed(), d.MaxHealth())
+	}
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// hostileTargets returns the target goals of hostile mobs, which target the
+// entity that last attacked them or otherwise the nearest player.
+func hostileTargets() []PrioritisedGoal {
+	return []PrioritisedGoal{
+		{Priority: 1, Goal: &HurtByTargetGoal{}},
+		{Priority: 2, Goal: &NearestTargetGoal{}},
+	}
+}
+
+// night checks if it is currently night in the world of the transaction
+// passed.
+func night(tx *world.Tx) bool {
+	t := tx.World().Time() % world.TimeFull
+	return t >= world.TimeSleep && t < world.TimeWake
+}
+
+// brightness returns the light level at the position passed, taking the time
+// of day into account. Positions lit by the sky are 11 levels darker at night.
+func brightness(tx *world.Tx, pos cube.Pos) uint8 {
+	light := tx.Light(pos)
+	if night(tx) && light == tx.SkyLight(pos) {
+		return light - min(light, 11)
+	}
+	return light
+}
+
+// isBaby checks if the entity passed is a mob that is a baby.
+func isBaby(e world.Entity) bool {
+	if ent, ok := e.(*Ent); ok {
+		if b, ok := ent.Behaviour().(interface{ Baby() bool }); ok {
+			return b.Baby()
+		}
+	}
+	return false
+}
+
+// decodeMobNBT decodes the health of a mob from the NBT map passed.
+func decodeMobNBT(m map[string]any, b *MobBehaviour) {
+	if health, ok := m["Health"].(float32); ok {
+		b.SetHealth(float64(health))
+	}
+}
+
+// encodeMobNBT encodes the health of a mob to a new NBT map.
+func encodeMobNBT(b *MobBehaviour) map[string]any {
+	return map[string]any{"Health": float32(b.Health())}
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	_ "github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestCreeperExplodesNearTarget(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := range 6 {
+			tx.SetBlock(cube.Pos{x, 63, 0}, block.Stone{}, nil)
+		}
+		victim := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{2.5, 64, 0.5}}.New(testMobType{}, MobBehaviourConfig{})).(*Ent)
+		creeper := tx.AddEntity(NewCreeper(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}})).(*Ent)
+		c := creeper.Behaviour().(*CreeperBehaviour)
+		(&Mob{e: creeper, b: c.MobBehaviour, tx: tx}).SetTarget(victim)
+
+		ticks := 0
+		for ; ticks < 60; ticks++ {
+			if _, ok := creeper.H().Entity(tx); !ok {
+				break
+			}
+			creeper.Tick(tx, int64(ticks))
+			if ticks == 5 {
+				if _, primed := c.Primed(); !primed {
+					t.Fatalf("creeper not primed with its target 2 blocks away")
+				}
+			}
+		}
+		if ticks < 30 || ticks >= 60 {
+			t.Fatalf("creeper exploded after %v ticks, want it to explode after its fuse of 30 ticks", ticks)
+		}
+		if vb := victim.Behaviour().(*MobBehaviour); vb.Health() >= vb.MaxHealth() {
+			t.Errorf("victim health = %v, want it hurt by the explosion", vb.Health())
+		}
+	})
+}
+
+func TestSkeletonShootsArrowAtTarget(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := range 12 {
+			tx.SetBlock(cube.Pos{x, 63, 0}, block.Stone{}, nil)
+		}
+		victim := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{10.5, 64, 0.5}}.New(testMobType{}, MobBehaviourConfig{})).(*Ent)
+		skeleton := tx.AddEntity(NewSkeleton(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}})).(*Ent)
+		b := skeleton.Behaviour().(*MobBehaviour)
+		if held, _ := b.HeldItems(); held.Empty() {
+			t.Fatalf("skeleton holds no bow")
+		}
+		(&Mob{e: skeleton, b: b, tx: tx}).SetTarget(victim)
+
+		var arrow *Ent
+		for i := range int64(60) {
+			skeleton.Tick(tx, i)
+			for e := range tx.Entities() {
+				if e.H().Type() == ArrowType {
+					arrow = e.(*Ent)
+				}
+			}
+			if arrow != nil {
+				break
+			}
+		}
+		if arrow == nil {
+			t.Fatalf("skeleton did not shoot an arrow at its target")
+		}
+		if vel := arrow.Velocity(); vel[0] <= 0 {
+			t.Errorf("arrow velocity = %v, want it to fly towards the target", vel)
+		}
+	})
+}
+
+func TestZombieBurnsInDaylight(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	w.StopRaining()
+
+	for _, tc := range []struct {
+		time int
+		burn bool
+	}{{time: 6000, burn: true}, {time: 18000, burn: false}} {
+		w.SetTime(tc.time)
+		mustDo(t, w, func(tx *world.Tx) {
+			tx.SetBlock(cube.Pos{0, 63, 0}, block.Stone{}, nil)
+			zombie := tx.AddEntity(NewZombie(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}})).(*Ent)
+			b := zombie.Behaviour().(*MobBehaviour)
+			for i := range int64(40) {
+				zombie.Tick(tx, i)
+			}
+			if burning := zombie.OnFireDuration() > 0; burning != tc.burn {
+				t.Errorf("zombie burning at time %v = %v, want %v", tc.time, burning, tc.burn)
+			}
+			if hurt := b.Health() < b.MaxHealth(); hurt != tc.burn {
+				t.Errorf("zombie hurt at time %v = %v, want %v", tc.time, hurt, tc.burn)
+			}
+			_ = zombie.Close()
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// behaviourInteractable represents a Behaviour of an entity that users may interact with by using an item on it,
+// such as an animal that may be fed.
+type behaviourInteractable interface {
+	// Interact is called when a user uses the item it holds on the entity. Interact returns true if the
+	// interaction had an effect, in which case changes to the item are applied through the UseContext passed.
+	Interact(e *Ent, user item.User, held item.Stack, ctx *item.UseContext) bool
+}
+
+// InteractEntity makes user interact with the entity e using the item it holds. False is returned if the entity
+// cannot be interacted with or if the interaction had no effect.
+func InteractEntity(e world.Entity, user item.User, held item.Stack, ctx *item.UseContext) bool {
+	if ent, ok := e.(*Ent); ok {
+		if i, ok := ent.Behaviour().(behaviourInteractable); ok {
+			return i.Interact(ent, user, held, ctx)
+		}
+	}
+	return false
+}
@@ -69,18 +69,31 @@ func (s *lightningState) dealDamage(e *Ent, tx *world.Tx) {
 	pos := e.Position()
 	bb := e.H().Type().BBox(e).GrowVec3(mgl64.Vec3{3, 6, 3}).Translate(pos.Add(mgl64.Vec3{0, 3}))
 	for e := range tx.EntitiesWithin(bb) {
-		// Only damage entities that weren't already dead.
-		if l, ok := e.(Living); ok && l.Health() > 0 {
-			if s.Damage > 0 {
-				l.Hurt(s.Damage, LightningDamageSource{})
-			}
-			if f, ok := e.(Flammable); ok && f.OnFireDuration() < s.EntityFireDuration {
-				f.SetOnFire(s.EntityFireDuration)
-			}
+		if !alive(e) {
+			continue
+		}
+		if s.Damage > 0 {
+			HurtEntity(e, s.Damage, LightningDamageSource{})
+		}
+		if f, ok := e.(Flammable); ok && f.OnFireDuration() < s.EntityFireDuration {
+			f.SetOnFire(s.EntityFireDuration)
 		}
 	}
 }
 
+// alive checks if the entity passed is a living entity or mob that isn't
+// already dead.
+func alive(e world.Entity) bool {
+	if l, ok := e.(Living); ok {
+		return l.Health() > 0
+	}
+	if ent, ok := e.(*Ent); ok {
+		m, ok := ent.Behaviour().(interface{ Dead() bool })
+		return ok && !m.Dead()
+	}
+	return false
+}
+
 // spreadFire attempts to place fire at the position of the lightning and does
 // 4 additional attempts to spread it around that position.
 func (s *lightningState) spreadFire(tx *world.Tx, pos cube.Pos) {
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewMinecart creates a new minecart entity of the type passed. The minecart is placed on the rail at the
+// position passed, if there is one.
+func NewMinecart(opts world.EntitySpawnOpts, t item.MinecartType) *world.EntityHandle {
+	return opts.New(minecartEntityType(t), MinecartBehaviourConfig{Type: t})
+}
+
+// MinecartType is a world.EntityType implementation for rideable minecarts.
+var MinecartType = minecartType{t: item.MinecartTypeRideable()}
+
+// ChestMinecartType is a world.EntityType implementation for minecarts with a chest.
+var ChestMinecartType = minecartType{t: item.MinecartTypeChest()}
+
+// HopperMinecartType is a world.EntityType implementation for minecarts with a hopper.
+var HopperMinecartType = minecartType{t: item.MinecartTypeHopper()}
+
+// TNTMinecartType is a world.EntityType implementation for minecarts with TNT.
+var TNTMinecartType = minecartType{t: item.MinecartTypeTNT()}
+
+// minecartEntityType returns the world.EntityType of minecarts of the type passed.
+func minecartEntityType(t item.MinecartType) minecartType {
+	return minecartType{t: t}
+}
+
+type minecartType struct {
+	t item.MinecartType
+}
+
+func (minecartType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (m minecartType) EncodeEntity() string { return "minecraft:" + m.t.String() }
+func (minecartType) NetworkOffset() float64 { return 0.35 }
+func (minecartType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.49, 0, -0.49, 0.49, 0.7, 0.49)
+}
+
+func (m minecartType) DecodeNBT(data map[string]any, d *world.EntityData) {
+	conf := MinecartBehaviourConfig{Type: m.t}
+	if _, ok := data["Fuse"].(int16); ok {
+		conf.Fuse = nbtconv.TickDuration[int16](data, "Fuse")
+	}
+	b := conf.New()
+	if b.inventory != nil {
+		nbtconv.InvFromNBT(b.inventory, nbtconv.Slice(data, "Items"))
+	}
+	d.Data = b
+}
+
+func (minecartType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*MinecartBehaviour)
+	m := map[string]any{}
+	if b.inventory != nil {
+		m["Items"] = nbtconv.InvToNBT(b.inventory)
+	}
+	if fuse, primed := b.Primed(); primed {
+		m["Fuse"] = int16(min(fuse/(time.Second/20), math.MaxInt16))
+	}
+	return m
+}
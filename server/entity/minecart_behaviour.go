@@ -0,0 +1,432 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// minecartMaxSpeed is the maximum speed in blocks/tick of a minecart on rails.
+	minecartMaxSpeed = 0.4
+	// minecartSlopeAcceleration is the speed in blocks/tick added every tick to a minecart riding down a slope.
+	minecartSlopeAcceleration = 0.0078125
+	// minecartBoost is the speed in blocks/tick added every tick to a minecart riding over a powered rail.
+	minecartBoost = 0.06
+	// minecartTNTFuse is the fuse of a TNT minecart primed by an activator rail.
+	minecartTNTFuse = time.Second * 4
+)
+
+// MinecartBehaviourConfig holds optional parameters for a MinecartBehaviour.
+type MinecartBehaviourConfig struct {
+	// Type is the type of the minecart, which specifies what the minecart carries.
+	Type item.MinecartType
+	// Fuse is the time left until a TNT minecart explodes. If Fuse is 0, the TNT minecart is not primed. Fuse is
+	// ignored for minecarts that do not carry TNT.
+	Fuse time.Duration
+}
+
+func (conf MinecartBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a MinecartBehaviour using the parameters in conf.
+func (conf MinecartBehaviourConfig) New() *MinecartBehaviour {
+	m := &MinecartBehaviour{
+		BaseBehaviour: NewBaseBehaviour(),
+		conf:          conf,
+		mc:            &MovementComputer{Gravity: 0.04, Drag: 0.05, DragBeforeGravity: true},
+		riderSeats:    newRiderSeats(1),
+		hurtDirection: 1,
+	}
+	switch conf.Type {
+	case item.MinecartTypeChest():
+		m.initInventory(27)
+	case item.MinecartTypeHopper():
+		m.initInventory(5)
+	case item.MinecartTypeTNT():
+		m.fuse, m.primed = conf.Fuse, conf.Fuse > 0
+	}
+	return m
+}
+
+// MinecartBehaviour implements the behaviour of minecarts. Minecarts follow the rails they are on, keeping their
+// momentum, and fall like other entities when they are not on a rail.
+type MinecartBehaviour struct {
+	BaseBehaviour
+
+	conf MinecartBehaviourConfig
+	mc   *MovementComputer
+
+	riderSeats
+	containerInventory
+
+	damage                   float64
+	hurtTicks, hurtDirection int
+
+	primed bool
+	fuse   time.Duration
+
+	hopperLocked     bool
+	transferCooldown int
+}
+
+// Type returns the type of the minecart.
+func (m *MinecartBehaviour) Type() item.MinecartType {
+	return m.conf.Type
+}
+
+// Seats returns the single seat of the minecart.
+func (m *MinecartBehaviour) Seats() []Seat {
+	return []Seat{{}}
+}
+
+// canBeRidden only returns true for minecarts that do not carry anything.
+func (m *MinecartBehaviour) canBeRidden() bool {
+	return m.conf.Type == item.MinecartTypeRideable()
+}
+
+// Primed returns the time left until a primed TNT minecart explodes, and false if the minecart is not primed.
+func (m *MinecartBehaviour) Primed() (time.Duration, bool) {
+	return m.fuse, m.primed
+}
+
+// Wobble returns the number of ticks that the minecart keeps wobbling after being hit, and the direction in which
+// it wobbles.
+func (m *MinecartBehaviour) Wobble() (ticks, direction int) {
+	return m.hurtTicks, m.hurtDirection
+}
+
+// Tick moves the minecart along the rail it is on, or makes it fall if it is not on a rail. TNT minecarts that
+// are primed explode once their fuse runs out, and hopper minecarts pick up items.
+func (m *MinecartBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if m.hurtTicks > 0 {
+		if m.hurtTicks--; m.hurtTicks == 0 {
+			e.updateState()
+		}
+	}
+	if m.damage > 0 {
+		m.damage--
+	}
+	m.removeClosedRiders(tx)
+	if m.primed {
+		if m.fuse -= time.Second / 20; m.fuse <= 0 {
+			m.explode(e, tx)
+			return nil
+		}
+	}
+
+	pos, vel, rot := e.data.Pos, m.push(e, tx, e.data.Vel), e.data.Rot
+	var mv *Movement
+	if railPos, rail, ok := minecartRailAt(pos, tx); ok {
+		newPos, newVel := m.moveOnRail(e, tx, railPos, rail, pos, vel)
+		mv = &Movement{v: tx.Viewers(pos), e: e,
+			pos: newPos, vel: newVel, dpos: newPos.Sub(pos), dvel: newVel.Sub(e.data.Vel),
+			rot: rot, onGround: true,
+		}
+	} else {
+		mv = m.mc.TickMovement(e, pos, vel, rot, tx)
+	}
+	if horizontal := (mgl64.Vec3{mv.vel[0], 0, mv.vel[2]}); horizontal.Len() > 0.001 {
+		mv.rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(mv.vel[2], mv.vel[0])), 0}
+	}
+	e.data.Pos, e.data.Vel, e.data.Rot = mv.pos, mv.vel, mv.rot
+
+	if m.conf.Type == item.MinecartTypeHopper() && !m.hopperLocked {
+		m.tickHopper(e, tx)
+	}
+	return mv
+}
+
+// moveOnRail moves the minecart at pos with velocity vel along the rail at railPos. The new position and velocity
+// of the minecart are returned.
+func (m *MinecartBehaviour) moveOnRail(e *Ent, tx *world.Tx, railPos cube.Pos, rail block.MinecartRail, pos, vel mgl64.Vec3) (mgl64.Vec3, mgl64.Vec3) {
+	shape := rail.RailShape()
+	a, b := shape.Connections()
+	start := railPos.Vec3Middle().Add(directionVec(a).Mul(0.5))
+	track := directionVec(b).Sub(directionVec(a)).Mul(0.5)
+
+	if up, ok := shape.Ascending(); ok {
+		vel = vel.Sub(directionVec(up).Mul(minecartSlopeAcceleration))
+	}
+	horizontal := mgl64.Vec3{vel[0], 0, vel[2]}
+	speed, dir := horizontal.Len(), track.Normalize()
+	if horizontal.Dot(dir) < 0 {
+		dir = dir.Mul(-1)
+	}
+
+	switch r := rail.(type) {
+	case block.PoweredRail:
+		switch {
+		case !r.Powered:
+			if speed *= 0.5; speed < 0.03 {
+				speed = 0
+			}
+		case speed > 0.01:
+			speed += minecartBoost
+		default:
+			// A minecart standing still on a powered rail is pushed away from a solid block at one of the ends of
+			// the rail, so that powered rails may be used to launch minecarts.
+			for _, d := range [...]cube.Direction{a, b} {
+				side := railPos.Side(d.Face())
+				if tx.Block(side).Model().FaceSolid(side, d.Opposite().Face(), tx) {
+					dir, speed = directionVec(d.Opposite()), 0.02
+				}
+			}
+		}
+	case block.ActivatorRail:
+		m.activate(e, tx, r.Powered)
+	}
+	if m.riderCount() > 0 {
+		speed *= 0.997
+	} else {
+		speed *= 0.96
+	}
+	speed = min(speed, minecartMaxSpeed)
+	vel = dir.Mul(speed)
+
+	// Snap the minecart onto the line of the rail before moving it along the rail.
+	rel := pos.Sub(start)
+	rel[1] = 0
+	snapped := start.Add(track.Mul(mgl64.Clamp(rel.Dot(track)/track.Dot(track), 0, 1)))
+	newPos := snapped.Add(vel)
+
+	next := cube.Pos{int(math.Floor(newPos[0])), railPos[1], int(math.Floor(newPos[2]))}
+	if next[0] == railPos[0] && next[2] == railPos[2] {
+		newPos[1] = railHeight(railPos, shape, newPos)
+		return newPos, vel
+	}
+	for _, p := range [...]cube.Pos{next, next.Side(cube.FaceUp), next.Side(cube.FaceDown)} {
+		if r, ok := tx.Block(p).(block.MinecartRail); ok {
+			newPos[1] = railHeight(p, r.RailShape(), newPos)
+			return newPos, vel
+		}
+	}
+	if minecartBlocked(next, tx) {
+		// The minecart ran into a block at the end of the track.
+		snapped[1] = railHeight(railPos, shape, snapped)
+		return snapped, mgl64.Vec3{}
+	}
+	// The minecart leaves the rail: It keeps its height for this tick and starts falling in the next one.
+	newPos[1] = railHeight(railPos, shape, newPos)
+	return newPos, vel
+}
+
+// activate handles the minecart riding over an activator rail. Powered activator rails eject riders and prime TNT
+// minecarts. Hopper minecarts are unable to pick up items while on a powered activator rail.
+func (m *MinecartBehaviour) activate(e *Ent, tx *world.Tx, powered bool) {
+	if m.conf.Type == item.MinecartTypeHopper() {
+		m.hopperLocked = powered
+	}
+	if !powered {
+		return
+	}
+	ejectRiders(e, tx)
+	if m.conf.Type == item.MinecartTypeTNT() && !m.primed {
+		m.primed, m.fuse = true, minecartTNTFuse
+		tx.PlaySound(e.Position(), sound.TNT{})
+		e.updateState()
+	}
+}
+
+// push adds velocity to the minecart for every living entity that walks into it, other than its rider.
+func (m *MinecartBehaviour) push(e *Ent, tx *world.Tx, vel mgl64.Vec3) mgl64.Vec3 {
+	box := e.H().Type().BBox(e).Translate(e.data.Pos).Grow(0.2)
+	for other := range tx.EntitiesWithin(box.Grow(2)) {
+		if _, ok := other.(Living); !ok || other.H() == m.riders[0] {
+			continue
+		}
+		if !other.H().Type().BBox(other).Translate(other.Position()).IntersectsWith(box) {
+			continue
+		}
+		d := e.data.Pos.Sub(other.Position())
+		if d[1] = 0; d.Len() < 0.01 {
+			continue
+		}
+		vel = vel.Add(d.Normalize().Mul(0.05))
+	}
+	return vel
+}
+
+// tickHopper makes a hopper minecart pull an item out of the container above it or pick up an item entity close
+// to it.
+func (m *MinecartBehaviour) tickHopper(e *Ent, tx *world.Tx) {
+	if m.transferCooldown > 0 {
+		m.transferCooldown--
+		return
+	}
+	above := cube.PosFromVec3(e.data.Pos).Side(cube.FaceUp)
+	if c, ok := tx.Block(above).(block.Container); ok {
+		inv := c.Inventory(tx, above)
+		for slot, st := range inv.Slots() {
+			if st.Empty() {
+				continue
+			}
+			if _, err := m.inventory.AddItem(st.Grow(1 - st.Count())); err != nil {
+				continue
+			}
+			_ = inv.SetItem(slot, st.Grow(-1))
+			m.transferCooldown = 4
+			return
+		}
+	}
+	box := e.H().Type().BBox(e).Translate(e.data.Pos).GrowVec3(mgl64.Vec3{0.25, 0, 0.25})
+	for other := range tx.EntitiesWithin(box.Grow(2)) {
+		ent, ok := other.(*Ent)
+		if !ok || !ent.H().Type().BBox(ent).Translate(ent.Position()).IntersectsWith(box) {
+			continue
+		}
+		i, ok := ent.Behaviour().(*ItemBehaviour)
+		if !ok {
+			continue
+		}
+		n, _ := m.inventory.AddItem(i.Item())
+		if n == 0 {
+			continue
+		}
+		_ = ent.Close()
+		if n < i.Item().Count() {
+			tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: ent.Position()}, i.Item().Grow(-n)))
+		}
+		m.transferCooldown = 4
+		return
+	}
+}
+
+// Hurt damages the minecart, making it wobble. The minecart breaks once it has taken enough damage in a short
+// time, or immediately if hit by a player in creative mode. TNT minecarts explode when damaged by fire or explosions.
+func (m *MinecartBehaviour) Hurt(e *Ent, damage float64, src world.DamageSource) (float64, bool) {
+	damage = max(damage, 0)
+	if _, ok := src.(VoidDamageSource); ok {
+		ejectRiders(e, e.tx)
+		_ = e.Close()
+		return damage, true
+	}
+	if _, ok := src.(ExplosionDamageSource); (ok || src.Fire()) && m.conf.Type == item.MinecartTypeTNT() {
+		m.explode(e, e.tx)
+		return damage, true
+	}
+	creative := false
+	if s, ok := src.(AttackDamageSource); ok {
+		if g, ok := s.Attacker.(interface{ GameMode() world.GameMode }); ok {
+			creative = g.GameMode().CreativeInventory()
+		}
+	}
+	m.hurtTicks, m.hurtDirection = 10, -m.hurtDirection
+	m.damage += damage * 10
+	e.updateState()
+
+	if creative || m.damage > 40 {
+		m.destroy(e, e.tx, !creative)
+	}
+	return damage, true
+}
+
+// Explode breaks the minecart when it is hit by an explosion. TNT minecarts explode themselves instead.
+func (m *MinecartBehaviour) Explode(e *Ent, _ world.ExplosionSource, impact float64) {
+	if impact <= 0 {
+		return
+	}
+	if m.conf.Type == item.MinecartTypeTNT() {
+		m.explode(e, e.tx)
+		return
+	}
+	m.destroy(e, e.tx, true)
+}
+
+// destroy breaks the minecart, ejecting its rider. If drop is true, the minecart drops itself, the block it carries
+// and the contents of its inventory.
+func (m *MinecartBehaviour) destroy(e *Ent, tx *world.Tx, drop bool) {
+	if _, ok := e.H().Entity(tx); !ok {
+		return
+	}
+	ejectRiders(e, tx)
+	_ = e.Close()
+	if !drop {
+		return
+	}
+	drops := []item.Stack{item.NewStack(item.Minecart{Type: item.MinecartTypeRideable()}, 1)}
+	switch m.conf.Type {
+	case item.MinecartTypeChest():
+		drops = append(drops, item.NewStack(block.NewChest(), 1))
+	case item.MinecartTypeHopper():
+		drops = append(drops, item.NewStack(block.NewHopper(), 1))
+	case item.MinecartTypeTNT():
+		drops = append(drops, item.NewStack(block.TNT{}, 1))
+	}
+	if m.inventory != nil {
+		drops = append(drops, m.inventory.Clear()...)
+	}
+	for _, it := range drops {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: e.data.Pos.Add(mgl64.Vec3{0, 0.5})}, it))
+	}
+}
+
+// explode removes a TNT minecart and creates an explosion at its position. The explosion is larger if the minecart
+// moves faster.
+func (m *MinecartBehaviour) explode(e *Ent, tx *world.Tx) {
+	if _, ok := e.H().Entity(tx); !ok {
+		return
+	}
+	ejectRiders(e, tx)
+	_ = e.Close()
+	speed := mgl64.Vec3{e.data.Vel[0], 0, e.data.Vel[2]}.Len()
+	block.ExplosionConfig{ItemDropChance: 1}.Explode(tx, world.EntityExplosionSource{
+		Entity:        e,
+		ExplosionSize: 4 + min(speed*10, 1.5),
+	})
+}
+
+// minecartRailAt returns the rail that a minecart at pos is on, if any. The rail below pos is also taken into
+// account, so that minecarts at the top end of a slope stay on the rail.
+func minecartRailAt(pos mgl64.Vec3, tx *world.Tx) (cube.Pos, block.MinecartRail, bool) {
+	p := cube.PosFromVec3(pos)
+	for _, p := range [...]cube.Pos{p, p.Side(cube.FaceDown)} {
+		if r, ok := tx.Block(p).(block.MinecartRail); ok {
+			return p, r, true
+		}
+	}
+	return cube.Pos{}, nil, false
+}
+
+// minecartBlocked checks if a minecart is unable to move into pos because of a block there.
+func minecartBlocked(pos cube.Pos, tx *world.Tx) bool {
+	for _, box := range tx.Block(pos).Model().BBox(pos, tx) {
+		if box.Max()[1] > 0.1 {
+			return true
+		}
+	}
+	return false
+}
+
+// railHeight returns the height of a minecart at the horizontal position of at on the rail at pos with the shape s.
+func railHeight(pos cube.Pos, s block.RailShape, at mgl64.Vec3) float64 {
+	y := float64(pos[1])
+	up, ok := s.Ascending()
+	if !ok {
+		return y
+	}
+	fx := mgl64.Clamp(at[0]-float64(pos[0]), 0, 1)
+	fz := mgl64.Clamp(at[2]-float64(pos[2]), 0, 1)
+	switch up {
+	case cube.East:
+		return y + fx
+	case cube.West:
+		return y + 1 - fx
+	case cube.South:
+		return y + fz
+	default:
+		return y + 1 - fz
+	}
+}
+
+// directionVec returns the horizontal unit vector pointing in the direction d.
+func directionVec(d cube.Direction) mgl64.Vec3 {
+	return cube.Pos{}.Side(d.Face()).Vec3()
+}
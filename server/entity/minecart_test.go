@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestMinecartFollowsRails(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := range 12 {
+			tx.SetBlock(cube.Pos{x, 63, 0}, block.Stone{}, nil)
+		}
+		for x := range 4 {
+			tx.SetBlock(cube.Pos{x, 64, 0}, block.Rail{Shape: block.RailShapeEastWest()}, nil)
+		}
+		// Curve south at the end of the straight track.
+		tx.SetBlock(cube.Pos{4, 64, 0}, block.Rail{Shape: block.RailShapeSouthWest()}, nil)
+		tx.SetBlock(cube.Pos{4, 63, 1}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{4, 64, 1}, block.Rail{Shape: block.RailShapeNorthSouth()}, nil)
+		tx.SetBlock(cube.Pos{4, 64, 2}, block.Stone{}, nil)
+
+		e := tx.AddEntity(NewMinecart(world.EntitySpawnOpts{
+			Position: mgl64.Vec3{0.5, 64, 0.5},
+			Velocity: mgl64.Vec3{0.3, 0, 0.1},
+		}, item.MinecartTypeRideable())).(*Ent)
+		for i := range int64(40) {
+			e.Tick(tx, i)
+		}
+		pos := e.Position()
+		if pos[0] < 4 || pos[0] > 5 || pos[2] < 1 || pos[2] > 2 || pos[1] != 64 {
+			t.Fatalf("minecart ended at %v, want it stopped on the rail at %v", pos, cube.Pos{4, 64, 1})
+		}
+		if v := e.Velocity(); v.Len() != 0 {
+			t.Errorf("minecart velocity against a block = %v, want zero", v)
+		}
+	})
+}
+
+func TestMinecartRidesUpSlopeAndBoosts(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := range 8 {
+			tx.SetBlock(cube.Pos{x, 63, 0}, block.Stone{}, nil)
+		}
+		tx.SetBlock(cube.Pos{0, 64, 0}, block.PoweredRail{Shape: block.RailShapeEastWest(), Powered: true}, nil)
+		tx.SetBlock(cube.Pos{1, 64, 0}, block.Rail{Shape: block.RailShapeAscendingEast()}, nil)
+		tx.SetBlock(cube.Pos{2, 64, 0}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{2, 65, 0}, block.Rail{Shape: block.RailShapeEastWest()}, nil)
+
+		e := tx.AddEntity(NewMinecart(world.EntitySpawnOpts{
+			Position: mgl64.Vec3{0.5, 64, 0.5},
+			Velocity: mgl64.Vec3{0.1, 0, 0},
+		}, item.MinecartTypeRideable())).(*Ent)
+		e.Tick(tx, 0)
+		if v := e.Velocity()[0]; v <= 0.1 {
+			t.Errorf("minecart speed on powered rail = %v, want it to be boosted", v)
+		}
+		for i := range int64(10) {
+			e.Tick(tx, i+1)
+		}
+		if pos := e.Position(); pos[0] < 2 || pos[1] != 65 {
+			t.Fatalf("minecart ended at %v, want it on the rail at the top of the slope", pos)
+		}
+	})
+}
@@ -0,0 +1,202 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Mob is passed to the goals of a mob with a MobBehaviour while they are
+// ticked. It provides access to the entity and the transaction it is ticked
+// in, and controls the movement, rotation and target of the mob. A Mob is
+// only valid during the tick it was passed to a goal in and must not be
+// stored.
+type Mob struct {
+	e  *Ent
+	b  *MobBehaviour
+	tx *world.Tx
+}
+
+// Ent returns the entity of the mob.
+func (m *Mob) Ent() *Ent {
+	return m.e
+}
+
+// Tx returns the transaction that the mob is ticked in.
+func (m *Mob) Tx() *world.Tx {
+	return m.tx
+}
+
+// Behaviour returns the MobBehaviour of the mob.
+func (m *Mob) Behaviour() *MobBehaviour {
+	return m.b
+}
+
+// Position returns the current position of the mob.
+func (m *Mob) Position() mgl64.Vec3 {
+	return m.e.data.Pos
+}
+
+// OnGround checks if the mob is currently on the ground.
+func (m *Mob) OnGround() bool {
+	return m.b.mc.OnGround()
+}
+
+// InWater checks if the mob is currently in water.
+func (m *Mob) InWater() bool {
+	return m.b.inWater(m.e, m.tx)
+}
+
+// Target returns the entity that the mob currently targets, if any. False is
+// returned if the mob has no target or if its target is no longer in the
+// same world.
+func (m *Mob) Target() (world.Entity, bool) {
+	if m.b.target == nil {
+		return nil, false
+	}
+	return m.b.target.Entity(m.tx)
+}
+
+// SetTarget changes the target of the mob. Passing nil clears the target.
+func (m *Mob) SetTarget(e world.Entity) {
+	if e == nil {
+		m.b.target = nil
+		return
+	}
+	m.b.target = e.H()
+}
+
+// LastAttacker returns the entity that last attacked the mob and the time,
+// in terms of the age of the mob, at which it did. False is returned if the
+// mob was never attacked or if its attacker is no longer in the same world.
+func (m *Mob) LastAttacker() (world.Entity, time.Duration, bool) {
+	if m.b.attacker == nil {
+		return nil, 0, false
+	}
+	e, ok := m.b.attacker.Entity(m.tx)
+	return e, m.b.attackedAt, ok
+}
+
+// MoveTo makes the mob walk towards the position passed at a multiple of its
+// base speed. A path to the position is found over the following ticks. The
+// mob stops moving once it reaches the position or, if the position cannot
+// be reached, the closest position it can reach. It also stops when it gets
+// stuck or when StopMoving is called. MoveTo returns false if the mob cannot
+// move at all.
+func (m *Mob) MoveTo(pos mgl64.Vec3, speed float64) bool {
+	if speed <= 0 || m.b.Dead() {
+		return false
+	}
+	m.b.nav.moveTo(m.Position(), pos, speed)
+	return true
+}
+
+// StopMoving stops the mob from walking towards the position it was moving
+// to.
+func (m *Mob) StopMoving() {
+	m.b.nav.stop()
+}
+
+// Moving checks if the mob is currently walking towards a position.
+func (m *Mob) Moving() bool {
+	return m.b.nav.moving
+}
+
+// Destination returns the position that the mob is walking towards. False is
+// returned if the mob is not moving.
+func (m *Mob) Destination() (mgl64.Vec3, bool) {
+	return m.b.nav.dst, m.b.nav.moving
+}
+
+// LookAt makes the mob look at the position passed for the current tick.
+// The mob otherwise looks in the direction it is walking in.
+func (m *Mob) LookAt(pos mgl64.Vec3) {
+	m.b.look = mobLook{pos: pos, ticks: 1}
+}
+
+// Jump makes the mob jump if it is on the ground, or swim up if it is in
+// water.
+func (m *Mob) Jump() {
+	m.b.jump = true
+}
+
+// Attack makes the mob attack the entity passed, dealing the attack damage
+// of the mob and knocking the entity back. Attack returns false if the
+// entity could not be hurt.
+func (m *Mob) Attack(e world.Entity) bool {
+	for _, v := range m.tx.Viewers(m.e.data.Pos) {
+		v.ViewEntityAction(m.e, SwingArmAction{})
+	}
+	if _, vulnerable, ok := HurtEntity(e, m.b.conf.AttackDamage, AttackDamageSource{Attacker: m.e}); !ok || !vulnerable {
+		return false
+	}
+	if l, ok := e.(Living); ok {
+		l.KnockBack(m.e.data.Pos, 0.4, 0.4)
+	}
+	return true
+}
+
+// SetHeldItems changes the items held by the mob in its main hand and its
+// off hand.
+func (m *Mob) SetHeldItems(mainHand, offHand item.Stack) {
+	m.b.mainHand, m.b.offHand = mainHand, offHand
+	for _, v := range m.tx.Viewers(m.e.data.Pos) {
+		v.ViewEntityItems(m.e)
+	}
+}
+
+// SetUsingItem changes whether the mob is using the item in its main hand,
+// for example to charge a bow.
+func (m *Mob) SetUsingItem(using bool) {
+	if m.b.usingItem != using {
+		m.b.usingItem = using
+		m.e.updateState()
+	}
+}
+
+// EyePosition returns the position of the eyes of the mob.
+func (m *Mob) EyePosition() mgl64.Vec3 {
+	return eyePosition(m.e)
+}
+
+// CanSee checks if the mob can see the entity passed, that is, if there are
+// no blocks between the eyes of the mob and those of the entity.
+func (m *Mob) CanSee(e world.Entity) bool {
+	start, end := m.EyePosition(), eyePosition(e)
+	if start.ApproxEqual(end) {
+		return true
+	}
+	visible := true
+	trace.TraverseBlocks(start, end, func(pos cube.Pos) bool {
+		_, hit := trace.BlockIntercept(pos, m.tx, m.tx.Block(pos), start, end)
+		visible = !hit
+		return visible
+	})
+	return visible
+}
+
+// Distance returns the distance between the mob and the entity passed.
+func (m *Mob) Distance(e world.Entity) float64 {
+	return m.Position().Sub(e.Position()).Len()
+}
+
+// eyePosition returns the position of the eyes of the entity passed. The eyes
+// of entities that do not implement Eyed, such as mobs, are assumed to be at
+// 85% of the height of their bounding box.
+func eyePosition(e world.Entity) mgl64.Vec3 {
+	if _, ok := e.(Eyed); ok {
+		return EyePosition(e)
+	}
+	return e.Position().Add(mgl64.Vec3{0, e.H().Type().BBox(e).Height() * 0.85})
+}
+
+// horizontalDistance returns the distance between two positions, ignoring
+// the Y axis.
+func horizontalDistance(a, b mgl64.Vec3) float64 {
+	return math.Hypot(a[0]-b[0], a[2]-b[2])
+}
@@ -0,0 +1,630 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/pathfind"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// MobBehaviourConfig holds optional parameters for a MobBehaviour.
+type MobBehaviourConfig struct {
+	// Gravity is the amount of Y velocity subtracted every tick. Gravity
+	// defaults to 0.08.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is
+	// multiplied with (1-Drag) every tick. Drag defaults to 0.02.
+	Drag float64
+	// MaxHealth is the maximum health of the mob. The mob spawns with full
+	// health. MaxHealth defaults to 20.
+	MaxHealth float64
+	// Speed is the base movement speed of the mob in blocks per tick, before
+	// friction is applied. Goals move the mob at a multiple of this speed.
+	// Speed defaults to 0.2, which is roughly the walking speed of a zombie.
+	Speed float64
+	// AttackDamage is the damage dealt by the mob when it attacks another
+	// entity using Mob.Attack. AttackDamage defaults to 2.
+	AttackDamage float64
+	// BurnsInDaylight specifies if the mob catches fire when it is exposed to
+	// the sky during the day, like zombies and skeletons.
+	BurnsInDaylight bool
+	// Climber specifies if the mob can climb up walls that it walks into,
+	// like spiders.
+	Climber bool
+	// SlowFalling specifies if the mob falls slowly and takes no fall damage,
+	// like chickens flapping their wings.
+	SlowFalling bool
+	// Baby specifies if the mob is a baby. Clients show babies at a smaller
+	// size.
+	Baby bool
+	// MainHand is the item that the mob holds in its main hand when it is
+	// created.
+	MainHand item.Stack
+	// Drops holds the items dropped by the mob when it dies.
+	Drops []MobDrop
+	// Experience is the amount of experience dropped by the mob when it dies
+	// shortly after being attacked.
+	Experience int
+	// Pathfinding holds the parameters used to find paths for the mob. The
+	// Width and Height default to those of the bounding box of the mob.
+	Pathfinding pathfind.Config
+	// Goals returns the goals of a new mob, such as wandering around or
+	// attacking its target. Goals is called for every mob created, so that
+	// every mob has its own goals.
+	Goals func() []PrioritisedGoal
+	// Targets returns the target goals of a new mob, which select the entity
+	// that the mob targets. Targets is called for every mob created, so that
+	// every mob has its own goals.
+	Targets func() []PrioritisedGoal
+	// Tick is called for every tick that the mob is alive. Tick is called
+	// after the goals of the mob are ticked, but before the mob moves.
+	Tick func(e *Ent, tx *world.Tx)
+	// Ridden is called for every tick that the mob is alive, before its goals
+	// are ticked. If Ridden returns true, the mob is controlled by the entity
+	// riding it: The goals of the mob are not ticked and the mob moves with
+	// the velocity returned instead.
+	Ridden func(e *Ent, tx *world.Tx) (mgl64.Vec3, bool)
+	// Death is called when the mob dies. It may be used to drop items or
+	// experience.
+	Death func(e *Ent, tx *world.Tx, src world.DamageSource)
+}
+
+// MobDrop is an item dropped by a mob when it dies. A random amount of the
+// item between Min and Max, inclusive, is dropped. If Cooked is not nil, it
+// is dropped instead of Item if the mob dies while on fire.
+type MobDrop struct {
+	Item, Cooked world.Item
+	Min, Max     int
+}
+
+func (conf MobBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a MobBehaviour using the parameters in conf.
+func (conf MobBehaviourConfig) New() *MobBehaviour {
+	if conf.Gravity == 0 {
+		conf.Gravity = 0.08
+	}
+	if conf.Drag == 0 {
+		conf.Drag = 0.02
+	}
+	if conf.MaxHealth <= 0 {
+		conf.MaxHealth = 20
+	}
+	if conf.Speed == 0 {
+		conf.Speed = 0.2
+	}
+	if conf.AttackDamage == 0 {
+		conf.AttackDamage = 2
+	}
+	b := &MobBehaviour{
+		BaseBehaviour: NewBaseBehaviour(),
+		conf:          conf,
+		mc:            &MovementComputer{Gravity: conf.Gravity, Drag: conf.Drag},
+		health:        NewHealthManager(conf.MaxHealth, conf.MaxHealth),
+		goals:         NewGoalSelector(),
+		targets:       NewGoalSelector(),
+		mainHand:      conf.MainHand,
+	}
+	if conf.Goals != nil {
+		b.goals = NewGoalSelector(conf.Goals()...)
+	}
+	if conf.Targets != nil {
+		b.targets = NewGoalSelector(conf.Targets()...)
+	}
+	return b
+}
+
+// MobBehaviour implements Behaviour for mobs: living entities that act on
+// their own. What a mob does is decided by its goals, which are selected by
+// a GoalSelector every tick. A second GoalSelector holds the target goals of
+// the mob, which select the entity that the mob targets, for example to
+// attack it. Goals control the mob through a Mob value.
+type MobBehaviour struct {
+	BaseBehaviour
+
+	conf MobBehaviourConfig
+	mc   *MovementComputer
+
+	health         *HealthManager
+	goals, targets *GoalSelector
+
+	target *world.EntityHandle
+	riding *world.EntityHandle
+	nav    mobNavigation
+	look   mobLook
+	jump   bool
+
+	mainHand, offHand item.Stack
+	usingItem         bool
+
+	attacker     *world.EntityHandle
+	attackedAt   time.Duration
+	lastDamage   float64
+	immuneTicks  int
+	deathTicks   int
+	fallDistance float64
+
+	collidedHorizontally bool
+	climbing             bool
+}
+
+// mobNavigation holds the destination of a mob and the path that it follows
+// to reach it.
+type mobNavigation struct {
+	pf *pathfind.Pathfinder
+
+	moving    bool
+	searching bool
+	dst       mgl64.Vec3
+	speed     float64
+	path      []mgl64.Vec3
+
+	progress   mgl64.Vec3
+	stuckTicks int
+}
+
+// mobLook holds the position that a mob is looking at.
+type mobLook struct {
+	pos   mgl64.Vec3
+	ticks int
+}
+
+// Goals returns the GoalSelector holding the goals of the mob. Goals may be
+// added or removed while the mob is alive.
+func (b *MobBehaviour) Goals() *GoalSelector {
+	return b.goals
+}
+
+// Targets returns the GoalSelector holding the target goals of the mob.
+func (b *MobBehaviour) Targets() *GoalSelector {
+	return b.targets
+}
+
+// Health returns the current health of the mob.
+func (b *MobBehaviour) Health() float64 {
+	return b.health.Health()
+}
+
+// MaxHealth returns the maximum health of the mob.
+func (b *MobBehaviour) MaxHealth() float64 {
+	return b.health.MaxHealth()
+}
+
+// SetHealth sets the health of the mob, limited to its maximum health. It may
+// be used to restore the health of a mob when it is loaded from disk.
+func (b *MobBehaviour) SetHealth(health float64) {
+	b.health.AddHealth(health - b.health.Health())
+}
+
+// Heal heals the mob by the amount of health passed, returning the health
+// that was actually regenerated.
+func (b *MobBehaviour) Heal(health float64) float64 {
+	if b.Dead() || health <= 0 {
+		return 0
+	}
+	before := b.health.Health()
+	b.health.AddHealth(health)
+	return b.health.Health() - before
+}
+
+// Dead checks if the mob is dead.
+func (b *MobBehaviour) Dead() bool {
+	return b.health.Health() <= 0
+}
+
+// Baby checks if the mob is a baby.
+func (b *MobBehaviour) Baby() bool {
+	return b.conf.Baby
+}
+
+// Climbing checks if the mob is currently climbing up a wall.
+func (b *MobBehaviour) Climbing() bool {
+	return b.climbing
+}
+
+// HeldItems returns the items currently held by the mob in its main hand and
+// its off hand.
+func (b *MobBehaviour) HeldItems() (mainHand, offHand item.Stack) {
+	return b.mainHand, b.offHand
+}
+
+// UsingItem checks if the mob is currently using the item in its main hand.
+func (b *MobBehaviour) UsingItem() bool {
+	return b.usingItem
+}
+
+// Speed returns the base movement speed of the mob in blocks per tick.
+func (b *MobBehaviour) Speed() float64 {
+	return b.conf.Speed
+}
+
+// Tick selects and ticks the goals of the mob and moves it along the path it
+// is following.
+func (b *MobBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if b.Dead() {
+		if b.deathTicks++; b.deathTicks >= 20 {
+			_ = e.Close()
+			return nil
+		}
+		return b.move(e, tx, e.data.Vel)
+	}
+	if b.immuneTicks > 0 {
+		b.immuneTicks--
+	}
+	b.tickFire(e, tx)
+	if b.Dead() {
+		return b.move(e, tx, e.data.Vel)
+	}
+
+	if vehicle, ok := e.Riding(); ok {
+		b.ride(e, vehicle)
+		return nil
+	}
+	if b.conf.Ridden != nil {
+		if vel, ok := b.conf.Ridden(e, tx); ok {
+			b.nav.stop()
+			return b.move(e, tx, vel)
+		}
+	}
+
+	m := &Mob{e: e, b: b, tx: tx}
+	if _, ok := m.Target(); !ok {
+		b.target = nil
+	}
+	b.targets.Tick(m)
+	b.goals.Tick(m)
+	if b.conf.Tick != nil {
+		b.conf.Tick(e, tx)
+	}
+	return b.move(e, tx, b.travel(m))
+}
+
+// ride moves the mob to its seat on the entity that it is riding. Viewers
+// move riders along with the entity ridden themselves, so the movement is
+// not sent to them.
+func (b *MobBehaviour) ride(e *Ent, vehicle world.Entity) {
+	_, seat, _ := SeatOf(vehicle, e.H())
+	e.data.Pos, e.data.Vel = SeatPosition(vehicle, seat), mgl64.Vec3{}
+	b.fallDistance = 0
+	b.nav.stop()
+}
+
+// vehicle ...
+func (b *MobBehaviour) vehicle() *world.EntityHandle {
+	return b.riding
+}
+
+// setVehicle ...
+func (b *MobBehaviour) setVehicle(h *world.EntityHandle) {
+	b.riding = h
+}
+
+// travel computes the velocity of the mob for the current tick from the path
+// it is following and updates its rotation.
+func (b *MobBehaviour) travel(m *Mob) mgl64.Vec3 {
+	pos, vel := m.Position(), m.e.data.Vel
+	inWater := m.InWater()
+
+	var dir mgl64.Vec3
+	b.nav.update(m.e, m.tx, b.conf.Pathfinding)
+	if wp, ok := b.nav.next(pos); ok {
+		dir = wp.Sub(pos)
+		dir[1] = 0
+		if dir.Len() > 0 {
+			dir = dir.Normalize()
+		}
+		speed := b.conf.Speed * b.nav.speed
+		if !b.mc.OnGround() && !inWater {
+			speed *= 0.3
+		}
+		vel[0], vel[2] = dir[0]*speed, dir[2]*speed
+		if b.collidedHorizontally && (b.mc.OnGround() || inWater) && wp[1] > pos[1]-0.5 {
+			b.jump = true
+		}
+		b.nav.checkStuck(pos)
+	}
+
+	if inWater {
+		// Mobs slowly sink in water unless they jump, in which case they swim up.
+		vel[1] = vel[1]*0.8 + b.conf.Gravity*0.75
+		if b.jump {
+			vel[1] = max(vel[1], 0.12)
+		}
+	} else if b.jump && b.mc.OnGround() {
+		vel[1] = 0.42
+	}
+	b.jump = false
+
+	if b.conf.Climber && b.collidedHorizontally && !inWater {
+		vel[1] = 0.2
+	}
+
+	rot := m.e.data.Rot
+	switch {
+	case b.look.ticks > 0:
+		b.look.ticks--
+		rot = rotationTowards(eyePosition(m.e), b.look.pos)
+	case dir.Len() > 0:
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-dir[0], dir[2])), 0}
+	}
+	m.e.data.Rot = rot
+	return vel
+}
+
+// move moves the mob using the velocity passed and applies fall damage when
+// it lands.
+func (b *MobBehaviour) move(e *Ent, tx *world.Tx, vel mgl64.Vec3) *Movement {
+	if b.conf.SlowFalling && !b.mc.OnGround() && vel[1] < 0 {
+		vel[1] *= 0.6
+	}
+	m := b.mc.TickMovement(e, e.data.Pos, vel, e.data.Rot, tx)
+	e.data.Pos, e.data.Vel = m.pos, m.vel
+	b.collidedHorizontally = (vel[0] != 0 && m.vel[0] == 0) || (vel[2] != 0 && m.vel[2] == 0)
+	if climbing := b.conf.Climber && b.collidedHorizontally; climbing != b.climbing {
+		b.climbing = climbing
+		e.updateState()
+	}
+
+	if b.climbing {
+		b.fallDistance = 0
+	} else if b.mc.OnGround() || b.inWater(e, tx) {
+		if b.fallDistance > 3 && !b.Dead() && !b.conf.SlowFalling {
+			b.Hurt(e, math.Ceil(b.fallDistance-3), FallDamageSource{})
+		}
+		b.fallDistance = 0
+	} else if m.dpos[1] < 0 {
+		b.fallDistance -= m.dpos[1]
+	}
+	return m
+}
+
+// tickFire sets the mob on fire if it burns in daylight and hurts it while it
+// is on fire.
+func (b *MobBehaviour) tickFire(e *Ent, tx *world.Tx) {
+	pos := cube.PosFromVec3(e.data.Pos)
+	if b.conf.BurnsInDaylight && e.OnFireDuration() <= 0 && b.inDaylight(e, tx) {
+		e.SetOnFire(time.Second * 8)
+	}
+	if e.OnFireDuration() <= 0 {
+		return
+	}
+	if b.inWater(e, tx) || tx.RainingAt(pos) {
+		e.Extinguish()
+		return
+	}
+	if e.OnFireDuration()%time.Second == 0 {
+		b.Hurt(e, 1, block.FireDamageSource{})
+	}
+}
+
+// inDaylight checks if it is day and the head of the entity passed is exposed
+// to the sky.
+func (b *MobBehaviour) inDaylight(e *Ent, tx *world.Tx) bool {
+	if night(tx) {
+		return false
+	}
+	head := cube.PosFromVec3(e.data.Pos).Side(cube.FaceUp)
+	return tx.SkyLight(head) == 15 && !tx.RainingAt(head) && !b.inWater(e, tx)
+}
+
+// inWater checks if the entity passed is in water.
+func (b *MobBehaviour) inWater(e *Ent, tx *world.Tx) bool {
+	l, ok := tx.Liquid(cube.PosFromVec3(e.data.Pos))
+	if !ok {
+		return false
+	}
+	_, ok = l.(block.Water)
+	return ok
+}
+
+// Hurt hurts the mob for the damage passed. Mobs are immune to damage for a
+// short time after being hurt, unless the new damage is higher than the last.
+// Mobs attacked by another entity are knocked back and remember the attacker.
+func (b *MobBehaviour) Hurt(e *Ent, damage float64, src world.DamageSource) (float64, bool) {
+	if b.Dead() || damage <= 0 {
+		return 0, false
+	}
+	if b.immuneTicks > 0 {
+		if damage <= b.lastDamage {
+			return 0, false
+		}
+		damage, b.lastDamage = damage-b.lastDamage, damage
+	} else {
+		b.lastDamage, b.immuneTicks = damage, 10
+	}
+	b.health.AddHealth(-damage)
+	for _, v := range e.tx.Viewers(e.data.Pos) {
+		v.ViewEntityAction(e, HurtAction{})
+	}
+
+	var knockBackFrom world.Entity
+	switch s := src.(type) {
+	case AttackDamageSource:
+		b.attacker, knockBackFrom = s.Attacker.H(), s.Attacker
+	case ProjectileDamageSource:
+		knockBackFrom = s.Projectile
+		if s.Owner != nil {
+			b.attacker = s.Owner.H()
+		}
+	}
+	if knockBackFrom != nil {
+		b.attackedAt = e.Age()
+		b.KnockBack(e, knockBackFrom.Position(), 0.4, 0.4)
+	}
+	if b.Dead() {
+		b.die(e, src)
+	}
+	return damage, true
+}
+
+// KnockBack knocks the mob back with a given force and height, away from the
+// source position passed.
+func (b *MobBehaviour) KnockBack(e *Ent, src mgl64.Vec3, force, height float64) {
+	vel := e.data.Pos.Sub(src)
+	vel[1] = 0
+	if vel.Len() != 0 {
+		vel = vel.Normalize().Mul(force)
+	}
+	vel[1] = height
+	e.data.Vel = vel
+}
+
+// Explode hurts the mob and knocks it back when it is hit by an explosion.
+func (b *MobBehaviour) Explode(e *Ent, src world.ExplosionSource, impact float64) {
+	diff := e.data.Pos.Sub(src.Position())
+	b.Hurt(e, math.Floor((impact*impact+impact)*3.5*src.Size()*2+1), ExplosionDamageSource{Source: src})
+	if l := diff.Len(); l != 0 {
+		b.KnockBack(e, src.Position(), impact, diff[1]/l*impact)
+	}
+}
+
+// die stops all goals of the mob and shows its death to viewers.
+func (b *MobBehaviour) die(e *Ent, src world.DamageSource) {
+	m := &Mob{e: e, b: b, tx: e.tx}
+	b.targets.Stop(m)
+	b.goals.Stop(m)
+	b.target = nil
+	b.nav.stop()
+	for _, v := range e.tx.Viewers(e.data.Pos) {
+		v.ViewEntityAction(e, DeathAction{})
+	}
+	b.dropLoot(e, b.conf.Drops, b.conf.Experience)
+	if b.conf.Death != nil {
+		b.conf.Death(e, e.tx, src)
+	}
+}
+
+// dropLoot drops the items and experience passed when the mob dies.
+// Experience is only dropped if the mob was attacked shortly before dying.
+func (b *MobBehaviour) dropLoot(e *Ent, drops []MobDrop, experience int) {
+	pos := e.data.Pos
+	for _, drop := range drops {
+		n := drop.Min
+		if drop.Max > drop.Min {
+			n += rand.IntN(drop.Max - drop.Min + 1)
+		}
+		if n <= 0 {
+			continue
+		}
+		it := drop.Item
+		if drop.Cooked != nil && e.OnFireDuration() > 0 {
+			it = drop.Cooked
+		}
+		opts := world.EntitySpawnOpts{Position: pos.Add(mgl64.Vec3{0, 0.5}), Velocity: mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}}
+		e.tx.AddEntity(NewItem(opts, item.NewStack(it, n)))
+	}
+	if experience > 0 && b.attacker != nil && e.Age()-b.attackedAt < time.Second*5 {
+		for _, orb := range NewExperienceOrbs(pos, experience) {
+			e.tx.AddEntity(orb)
+		}
+	}
+}
+
+// moveTo makes the mob walk towards the destination passed. A new path is
+// only searched for if the destination is in a different block than the
+// current one. The mob keeps following its current path until it is found.
+func (nav *mobNavigation) moveTo(pos, dst mgl64.Vec3, speed float64) {
+	if !nav.moving {
+		nav.progress, nav.stuckTicks = pos, 0
+	}
+	if !nav.moving || cube.PosFromVec3(dst) != cube.PosFromVec3(nav.dst) {
+		nav.searching = true
+	}
+	nav.moving, nav.dst, nav.speed = true, dst, speed
+}
+
+// stop stops the mob from moving towards its destination.
+func (nav *mobNavigation) stop() {
+	nav.moving, nav.searching, nav.path = false, false, nil
+}
+
+// update continues the search for a path to the destination of the mob if
+// one is in progress, following the best path found so far.
+func (nav *mobNavigation) update(e *Ent, tx *world.Tx, conf pathfind.Config) {
+	if !nav.searching {
+		return
+	}
+	if nav.pf == nil {
+		box := e.H().Type().BBox(e)
+		if conf.Width == 0 {
+			conf.Width = box.Width()
+		}
+		if conf.Height == 0 {
+			conf.Height = box.Height()
+		}
+		nav.pf = conf.New()
+	}
+	path, done := nav.pf.Find(tx, e.data.Pos, nav.dst)
+	nav.searching = !done
+
+	pos := e.data.Pos
+	nav.path = nav.path[:0]
+	for _, n := range path.Nodes {
+		nav.path = append(nav.path, n.Pos)
+	}
+	if len(nav.path) == 0 {
+		return
+	}
+	// The path may start behind the mob if it moved since the search
+	// started, so it continues from the node closest to the mob.
+	start := 0
+	for i, wp := range nav.path {
+		if wp.Sub(pos).Len() < nav.path[start].Sub(pos).Len() {
+			start = i
+		}
+	}
+	if start+1 < len(nav.path) && nav.path[start+1].Sub(pos).Len() < nav.path[start+1].Sub(nav.path[start]).Len() {
+		start++
+	}
+	nav.path = nav.path[start:]
+}
+
+// next returns the waypoint that the mob should currently walk towards,
+// removing waypoints that were already reached. The mob stops moving once
+// it reached the last waypoint of a path that is no longer searched for.
+func (nav *mobNavigation) next(pos mgl64.Vec3) (mgl64.Vec3, bool) {
+	for len(nav.path) > 0 {
+		wp := nav.path[0]
+		if math.Abs(wp[0]-pos[0]) > 0.35 || math.Abs(wp[2]-pos[2]) > 0.35 || math.Abs(wp[1]-pos[1]) > 1 {
+			return wp, true
+		}
+		nav.path = nav.path[1:]
+	}
+	if !nav.searching {
+		nav.moving = false
+	}
+	return mgl64.Vec3{}, false
+}
+
+// checkStuck stops the navigation if the mob has not made any progress
+// towards its destination for a while.
+func (nav *mobNavigation) checkStuck(pos mgl64.Vec3) {
+	if pos.Sub(nav.progress).Len() > 1 {
+		nav.progress, nav.stuckTicks = pos, 0
+		return
+	}
+	if nav.stuckTicks++; nav.stuckTicks > 60 {
+		// The path the mob is following is probably blocked, so paths found
+		// earlier should not be reused.
+		nav.stop()
+		nav.pf.Reset()
+	}
+}
+
+// rotationTowards returns the rotation that an entity at from needs to look
+// at the position to.
+func rotationTowards(from, to mgl64.Vec3) cube.Rotation {
+	d := to.Sub(from)
+	return cube.Rotation{
+		mgl64.RadToDeg(math.Atan2(-d[0], d[2])),
+		mgl64.RadToDeg(-math.Atan2(d[1], math.Hypot(d[0], d[2]))),
+	}
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// monsterSpawns holds the monsters that spawn naturally in biomes with the
+// "monster" tag.
+var monsterSpawns = []world.MobSpawn{
+	{Type: ZombieType, Weight: 95, MinGroup: 4, MaxGroup: 4, New: NewZombie},
+	{Type: SkeletonType, Weight: 100, MinGroup: 4, MaxGroup: 4, New: NewSkeleton},
+	{Type: CreeperType, Weight: 100, MinGroup: 4, MaxGroup: 4, New: NewCreeper},
+	{Type: SpiderType, Weight: 100, MinGroup: 4, MaxGroup: 4, New: NewSpider},
+}
+
+// animalSpawns holds the animals that spawn naturally in biomes with the
+// "animal" tag.
+var animalSpawns = []world.MobSpawn{
+	{Type: SheepType, Weight: 12, MinGroup: 4, MaxGroup: 4, New: NewSheep},
+	{Type: PigType, Weight: 10, MinGroup: 4, MaxGroup: 4, New: NewPig},
+	{Type: ChickenType, Weight: 10, MinGroup: 4, MaxGroup: 4, New: NewChicken},
+	{Type: CowType, Weight: 8, MinGroup: 4, MaxGroup: 4, New: NewCow},
+}
+
+// MobSpawns returns the mobs that spawn naturally in the world.Biome passed.
+// Monsters spawn in biomes with the "monster" tag and animals spawn in biomes
+// with the "animal" tag. MobSpawns may be used as world.MobSpawning.Spawns.
+func MobSpawns(b world.Biome) []world.MobSpawn {
+	var spawns []world.MobSpawn
+	tags := b.Tags()
+	if slices.Contains(tags, "monster") {
+		spawns = append(spawns, monsterSpawns...)
+	}
+	if slices.Contains(tags, "animal") {
+		spawns = append(spawns, animalSpawns...)
+	}
+	return spawns
+}
@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/generator"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestNaturalMobSpawning(t *testing.T) {
+	w := newSpawnTestWorld(t)
+	w.SetDifficulty(world.DifficultyPeaceful)
+	w.SetTime(6000)
+	for range 401 {
+		w.AdvanceTick()
+	}
+	monsters, creatures := countMobs(t, w)
+	if monsters != 0 {
+		t.Errorf("monsters spawned on peaceful = %v, want 0", monsters)
+	}
+	if creatures == 0 {
+		t.Errorf("no animals spawned on grass in daylight")
+	}
+
+	w.SetDifficulty(world.DifficultyNormal)
+	w.SetTime(18000)
+	for range 20 {
+		w.AdvanceTick()
+	}
+	if monsters, _ = countMobs(t, w); monsters == 0 {
+		t.Errorf("no monsters spawned at night")
+	}
+}
+
+func TestNaturalMobSpawningCancelled(t *testing.T) {
+	w := newSpawnTestWorld(t)
+	h := &cancelMobSpawnHandler{}
+	w.Handle(h)
+	w.SetTime(18000)
+	for range 401 {
+		w.AdvanceTick()
+	}
+	if monsters, creatures := countMobs(t, w); monsters != 0 || creatures != 0 {
+		t.Errorf("mobs spawned with spawning cancelled: %v monsters, %v animals", monsters, creatures)
+	}
+	if h.calls == 0 {
+		t.Errorf("HandleMobSpawn never called")
+	}
+}
+
+// cancelMobSpawnHandler is a world.Handler that cancels all mobs spawning
+// naturally.
+type cancelMobSpawnHandler struct {
+	world.NopHandler
+	calls int
+}
+
+func (h *cancelMobSpawnHandler) HandleMobSpawn(ctx *world.Context, _ *mgl64.Vec3, _ *world.MobSpawn) {
+	h.calls++
+	ctx.Cancel()
+}
+
+// newSpawnTestWorld creates a flat plains world with natural mob spawning and
+// a loader at the origin.
+func newSpawnTestWorld(t *testing.T) *world.World {
+	world.DefaultBlockRegistry.Finalize()
+	w := world.Config{
+		Synchronous: true,
+		Entities:    DefaultRegistry,
+		Generator:   generator.NewFlat(biome.Plains{}, []world.Block{block.Grass{}, block.Dirt{}, block.Dirt{}, block.Bedrock{}}),
+		MobSpawning: world.MobSpawning{Spawns: MobSpawns},
+	}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	w.StopRaining()
+
+	l := world.NewLoader(4, w, world.NopViewer{})
+	mustDo(t, w, func(tx *world.Tx) {
+		l.Move(tx, mgl64.Vec3{0, -60, 0})
+		l.Load(tx, 81)
+	})
+	return w
+}
+
+// countMobs counts the monsters and creatures in the world passed.
+func countMobs(t *testing.T, w *world.World) (monsters, creatures int) {
+	mustDo(t, w, func(tx *world.Tx) {
+		for e := range tx.Entities() {
+			if mt, ok := e.H().Type().(world.MobEntityType); ok {
+				switch mt.MobCategory() {
+				case world.MobCategoryMonster:
+					monsters++
+				case world.MobCategoryCreature:
+					creatures++
+				}
+			}
+		}
+	})
+	return monsters, creatures
+}
+
+func TestNaturalMobDespawning(t *testing.T) {
+	w := newSpawnTestWorld(t)
+	w.SetDifficulty(world.DifficultyNormal)
+	w.SetTime(18000)
+	for range 20 {
+		w.AdvanceTick()
+	}
+	if monsters, _ := countMobs(t, w); monsters < 2 {
+		t.Fatalf("monsters spawned at night = %v, want at least 2", monsters)
+	}
+
+	var named *world.EntityHandle
+	mustDo(t, w, func(tx *world.Tx) {
+		for e := range tx.Entities() {
+			if mt, ok := e.H().Type().(world.MobEntityType); ok && mt.MobCategory() == world.MobCategoryMonster {
+				e.(*Ent).SetNameTag("Bob")
+				named = e.H()
+				break
+			}
+		}
+	})
+	w.SetDifficulty(world.DifficultyPeaceful)
+	w.AdvanceTick()
+	mustDo(t, w, func(tx *world.Tx) {
+		for e := range tx.Entities() {
+			if mt, ok := e.H().Type().(world.MobEntityType); ok && mt.MobCategory() == world.MobCategoryMonster && e.H() != named {
+				t.Errorf("naturally spawned monster %v did not despawn on peaceful", e.H().Type().EncodeEntity())
+			}
+		}
+		if _, ok := named.Entity(tx); !ok {
+			t.Errorf("named monster despawned on peaceful")
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestGoalSelectorPrioritisesControls(t *testing.T) {
+	wander := &testGoal{controls: GoalControlMove, start: true}
+	look := &testGoal{controls: GoalControlLook, start: true}
+	attack := &testGoal{controls: GoalControlMove | GoalControlLook}
+	s := NewGoalSelector(PrioritisedGoal{Priority: 5, Goal: wander}, PrioritisedGoal{Priority: 6, Goal: look})
+	s.Add(1, attack)
+
+	s.Tick(nil)
+	if running := s.Running(); !slices.Equal(running, []Goal{wander, look}) {
+		t.Fatalf("running goals = %v, want wander and look", running)
+	}
+
+	attack.start = true
+	s.Tick(nil)
+	if running := s.Running(); !slices.Equal(running, []Goal{attack}) {
+		t.Fatalf("running goals = %v, want only attack", running)
+	}
+	if wander.stopped != 1 || look.stopped != 1 {
+		t.Errorf("stopped wander %v times and look %v times, want both stopped once", wander.stopped, look.stopped)
+	}
+
+	// Lower priority goals may not start while a higher priority goal holds
+	// one of their controls.
+	s.Tick(nil)
+	if wander.started != 1 || attack.ticked != 2 {
+		t.Errorf("wander started %v times and attack ticked %v times, want 1 and 2", wander.started, attack.ticked)
+	}
+
+	attack.start, attack.cont = false, false
+	s.Remove(look)
+	s.Tick(nil)
+	if running := s.Running(); !slices.Equal(running, []Goal{wander}) {
+		t.Fatalf("running goals = %v, want only wander", running)
+	}
+	if goals := s.Goals(); len(goals) != 2 {
+		t.Errorf("goals after removal = %v, want 2 goals", goals)
+	}
+}
+
+func TestMeleeAttackGoalAttacksTarget(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := range 10 {
+			tx.SetBlock(cube.Pos{x, 63, 0}, block.Stone{}, nil)
+		}
+		victim := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{8.5, 64, 0.5}}.New(testMobType{}, MobBehaviourConfig{})).(*Ent)
+		attacker := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}}.New(testMobType{}, MobBehaviourConfig{
+			Goals: func() []PrioritisedGoal {
+				return []PrioritisedGoal{{Priority: 1, Goal: &MeleeAttackGoal{}}}
+			},
+			Targets: func() []PrioritisedGoal {
+				return []PrioritisedGoal{{Priority: 1, Goal: &NearestTargetGoal{
+					Target: func(e world.Entity) bool { return e.H() == victim.H() },
+					Chance: 1,
+				}}}
+			},
+		})).(*Ent)
+
+		vb := victim.Behaviour().(*MobBehaviour)
+		for i := range int64(100) {
+			attacker.Tick(tx, i)
+			victim.Tick(tx, i)
+			if vb.Health() < vb.MaxHealth() {
+				break
+			}
+		}
+		if vb.Health() != 18 {
+			t.Fatalf("victim health = %v, want 18 after being attacked", vb.Health())
+		}
+		if attacker.Position()[0] < 5 {
+			t.Errorf("attacker at %v, want it to have walked towards its target", attacker.Position())
+		}
+
+		// The victim should now retaliate using HurtByTargetGoal.
+		vb.Targets().Add(1, &HurtByTargetGoal{})
+		victim.Tick(tx, 100)
+		if target, ok := (&Mob{e: victim, b: vb, tx: tx}).Target(); !ok || target.H() != attacker.H() {
+			t.Errorf("victim target = %v, want its attacker", target)
+		}
+	})
+}
+
+func TestFloatGoalKeepsMobAfloat(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := range 2 {
+			tx.SetBlock(cube.Pos{x * 4, 55, 0}, block.Stone{}, nil)
+			for y := 56; y < 64; y++ {
+				tx.SetBlock(cube.Pos{x * 4, y, 0}, block.Water{Depth: 8, Still: true}, nil)
+			}
+		}
+		floating := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 60, 0.5}}.New(testMobType{}, MobBehaviourConfig{
+			Goals: func() []PrioritisedGoal { return []PrioritisedGoal{{Goal: &FloatGoal{}}} },
+		})).(*Ent)
+		sinking := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{4.5, 60, 0.5}}.New(testMobType{}, MobBehaviourConfig{})).(*Ent)
+		for i := range int64(100) {
+			floating.Tick(tx, i)
+			sinking.Tick(tx, i)
+		}
+		if y := floating.Position()[1]; y < 62 {
+			t.Errorf("floating mob at y %v, want it near the surface", y)
+		}
+		if y := sinking.Position()[1]; y != 56 {
+			t.Errorf("mob without float goal at y %v, want it at the bottom", y)
+		}
+	})
+}
+
+func TestMobWalksAroundWall(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	var h *world.EntityHandle
+	dst := mgl64.Vec3{8.5, 64, 1.5}
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := range 10 {
+			for z := range 6 {
+				tx.SetBlock(cube.Pos{x, 63, z}, block.Stone{}, nil)
+			}
+		}
+		for z := range 4 {
+			tx.SetBlock(cube.Pos{4, 64, z}, block.Stone{}, nil)
+			tx.SetBlock(cube.Pos{4, 65, z}, block.Stone{}, nil)
+		}
+		h = world.EntitySpawnOpts{Position: mgl64.Vec3{1.5, 64, 1.5}}.New(testMobType{}, MobBehaviourConfig{
+			Goals: func() []PrioritisedGoal {
+				return []PrioritisedGoal{{Goal: &testMoveGoal{dst: dst}}}
+			},
+		})
+		tx.AddEntity(h)
+	})
+	for i := range int64(200) {
+		mustDo(t, w, func(tx *world.Tx) {
+			e, _ := h.Entity(tx)
+			e.(*Ent).Tick(tx, i)
+		})
+		w.AdvanceTick()
+	}
+	mustDo(t, w, func(tx *world.Tx) {
+		e, _ := h.Entity(tx)
+		if pos := e.Position(); horizontalDistance(pos, dst) > 0.5 {
+			t.Errorf("mob at %v, want it to have walked around the wall to %v", pos, dst)
+		}
+	})
+}
+
+// testMoveGoal is a Goal that makes a mob move to a position once.
+type testMoveGoal struct {
+	dst     mgl64.Vec3
+	started bool
+}
+
+func (g *testMoveGoal) Controls() GoalControl   { return GoalControlMove }
+func (g *testMoveGoal) CanStart(*Mob) bool      { return !g.started }
+func (g *testMoveGoal) CanContinue(m *Mob) bool { return m.Moving() }
+func (g *testMoveGoal) Start(m *Mob)            { g.started = m.MoveTo(g.dst, 1) }
+func (g *testMoveGoal) Tick(*Mob)               {}
+func (g *testMoveGoal) Stop(*Mob)               {}
+
+// testGoal is a Goal that records the calls made to it.
+type testGoal struct {
+	controls    GoalControl
+	start, cont bool
+
+	started, ticked, stopped int
+}
+
+func (g *testGoal) Controls() GoalControl { return g.controls }
+func (g *testGoal) CanStart(*Mob) bool    { return g.start }
+func (g *testGoal) CanContinue(*Mob) bool { return g.start || g.cont }
+func (g *testGoal) Start(*Mob)            { g.started++ }
+func (g *testGoal) Tick(*Mob)             { g.ticked++ }
+func (g *testGoal) Stop(*Mob)             { g.stopped++ }
+
+// testMobType is a world.EntityType for mobs with a MobBehaviour, as a
+// plugin would register it.
+type testMobType struct{}
+
+func (testMobType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return Open(tx, handle, data)
+}
+
+func (testMobType) EncodeEntity() string { return "dragonfly:test_mob" }
+func (testMobType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+func (testMobType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (testMobType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
@@ -0,0 +1,80 @@
+package pathfind
+
+import (
+	"sync"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Budget limits the total number of nodes that all Pathfinders sharing it
+// may evaluate in a single tick of a world. Searches that run out of budget
+// continue during the next tick, so that many entities looking for paths at
+// the same time cannot stall a world. A Budget is safe for concurrent use by
+// multiple worlds.
+type Budget struct {
+	nodes int
+
+	mu     sync.Mutex
+	worlds map[*world.World]*budgetUsage
+}
+
+// budgetUsage holds the nodes evaluated in the current tick of a world.
+type budgetUsage struct {
+	tick     int64
+	used     int
+	lastUsed time.Time
+}
+
+// DefaultBudget is the Budget used by Pathfinders that have no Budget set in
+// their Config.
+var DefaultBudget = NewBudget(4096)
+
+// NewBudget creates a Budget that allows evaluating the number of nodes
+// passed in every tick of a world.
+func NewBudget(nodesPerTick int) *Budget {
+	return &Budget{nodes: nodesPerTick, worlds: make(map[*world.World]*budgetUsage)}
+}
+
+// Take takes at most n nodes from the budget left for the current tick of
+// the world of the transaction passed. The number of nodes that may be
+// evaluated is returned, which is 0 if the budget for the tick is spent.
+func (b *Budget) Take(tx *world.Tx, n int) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	w, tick := tx.World(), tx.CurrentTick()
+	u, ok := b.worlds[w]
+	if !ok {
+		b.prune()
+		u = &budgetUsage{tick: tick}
+		b.worlds[w] = u
+	}
+	if u.tick != tick {
+		u.tick, u.used = tick, 0
+	}
+	u.lastUsed = time.Now()
+	n = max(min(n, b.nodes-u.used), 0)
+	u.used += n
+	return n
+}
+
+// release returns n nodes taken from the budget in the current tick of the
+// world of the transaction passed.
+func (b *Budget) release(tx *world.Tx, n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if u, ok := b.worlds[tx.World()]; ok && u.tick == tx.CurrentTick() {
+		u.used = max(u.used-n, 0)
+	}
+}
+
+// prune removes the usage of worlds that have not used the budget in a
+// while, so that closed worlds are not kept around.
+func (b *Budget) prune() {
+	for w, u := range b.worlds {
+		if time.Since(u.lastUsed) > time.Minute {
+			delete(b.worlds, w)
+		}
+	}
+}
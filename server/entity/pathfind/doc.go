@@ -0,0 +1,7 @@
+// Package pathfind implements A* pathfinding over the blocks of a world for entities. Paths are found by a
+// Pathfinder, which evaluates block models to decide where an entity can stand, how high it can jump and how far it
+// may fall. Moving through water, lava, fire and other dangerous blocks has an additional cost, so that entities avoid
+// them where possible.
+// Searches are spread over multiple ticks if they evaluate more nodes than their budget allows, so that many entities
+// looking for paths at the same time do not stall the world.
+package pathfind
@@ -0,0 +1,291 @@
+package pathfind
+
+import (
+	"math"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NodeType describes how an entity moves through a node of a path.
+type NodeType uint8
+
+const (
+	// NodeBlocked is a node that an entity cannot move through.
+	NodeBlocked NodeType = iota
+	// NodeWalkable is a node in which an entity stands on the block below
+	// it.
+	NodeWalkable
+	// NodeWater is a node filled with water, through which an entity swims.
+	NodeWater
+	// NodeLava is a node filled with lava.
+	NodeLava
+	// NodeDamage is a node in which an entity takes damage, such as a node
+	// with fire or a lit campfire, or one on top of a magma block.
+	NodeDamage
+	// NodeDanger is a node next to a block that damages entities, such as
+	// fire, lava or cactus.
+	NodeDanger
+	// NodeDoorOpen is a node with an open door.
+	NodeDoorOpen
+	// NodeDoorClosed is a node with a closed door. Entities can only move
+	// through it if they can open doors.
+	NodeDoorClosed
+	// NodeFence is a node on top of a fence, wall or fence gate. Fences are
+	// too high for entities to jump onto.
+	NodeFence
+
+	nodeTypeCount
+)
+
+// Costs holds the additional costs of moving into nodes of specific
+// NodeTypes, on top of the distance moved. A negative cost means that an
+// entity cannot move into nodes of that type at all.
+type Costs map[NodeType]float64
+
+// defaultCosts holds the costs used for node types that have no cost set in
+// Config.Costs.
+var defaultCosts = [nodeTypeCount]float64{
+	NodeBlocked:    -1,
+	NodeWalkable:   0,
+	NodeWater:      8,
+	NodeLava:       -1,
+	NodeDamage:     16,
+	NodeDanger:     8,
+	NodeDoorOpen:   0,
+	NodeDoorClosed: -1,
+	NodeFence:      -1,
+}
+
+// cost returns the cost of moving into a node of the NodeType passed.
+func (conf Config) cost(t NodeType) float64 {
+	if c, ok := conf.Costs[t]; ok {
+		return c
+	}
+	if t == NodeDoorClosed && conf.CanOpenDoors {
+		return 0
+	}
+	return defaultCosts[t]
+}
+
+// cell holds the information about a single block needed to evaluate nodes.
+type cell struct {
+	boxes []cube.BBox
+	top   float64
+	kind  cellKind
+}
+
+// cellKind is the kind of block in a cell.
+type cellKind uint8
+
+const (
+	cellNormal cellKind = iota
+	cellWater
+	cellLava
+	cellFire
+	cellHurts
+	cellDoorOpen
+	cellDoorClosed
+	cellFence
+)
+
+// evaluator evaluates nodes for a search. It caches the blocks it looks up
+// during a single step of the search, as blocks may change between ticks.
+type evaluator struct {
+	conf  Config
+	tx    *world.Tx
+	cells map[cube.Pos]cell
+}
+
+// cell returns the cell at the position passed.
+func (ev *evaluator) cell(pos cube.Pos) cell {
+	if c, ok := ev.cells[pos]; ok {
+		return c
+	}
+	var c cell
+	if pos.OutOfBounds(ev.tx.Range()) {
+		c.boxes, c.top = []cube.BBox{cube.Box(0, 0, 0, 1, 1, 1).Translate(pos.Vec3())}, 1
+		ev.cells[pos] = c
+		return c
+	}
+	b := ev.tx.Block(pos)
+	switch m := b.Model().(type) {
+	case model.Solid:
+		c.boxes = []cube.BBox{cube.Box(0, 0, 0, 1, 1, 1)}
+	case model.Door:
+		c.kind = cellDoorOpen
+		if !m.Open {
+			c.kind = cellDoorClosed
+			if !ev.conf.CanOpenDoors {
+				c.boxes = m.BBox(pos, ev.tx)
+			}
+		}
+	case model.Fence, model.Wall, model.FenceGate:
+		c.kind, c.boxes = cellFence, m.BBox(pos, ev.tx)
+	default:
+		c.boxes = m.BBox(pos, ev.tx)
+	}
+	switch b := b.(type) {
+	case block.Fire:
+		c.kind = cellFire
+	case block.Campfire:
+		if !b.Extinguished {
+			c.kind = cellFire
+		}
+	case block.Magma, block.Cactus:
+		c.kind = cellHurts
+	}
+	if l, ok := ev.tx.Liquid(pos); ok && c.kind == cellNormal {
+		switch l.(type) {
+		case block.Water:
+			c.kind = cellWater
+		case block.Lava:
+			c.kind = cellLava
+		}
+	}
+	// Models may share the boxes they return, so they are copied rather than
+	// translated in place.
+	boxes := make([]cube.BBox, len(c.boxes))
+	for i, box := range c.boxes {
+		boxes[i] = box.Translate(pos.Vec3())
+		c.top = max(c.top, box.Max()[1])
+	}
+	c.boxes = boxes
+	ev.cells[pos] = c
+	return c
+}
+
+// entityBox returns the bounding box of the entity at the position passed,
+// extended upwards by the height passed.
+func (ev *evaluator) entityBox(x, z, minY, maxY float64) cube.BBox {
+	w := ev.conf.Width / 2
+	return cube.Box(x-w, minY, z-w, x+w, maxY+ev.conf.Height, z+w)
+}
+
+// clear checks if the box passed does not collide with any blocks.
+func (ev *evaluator) clear(box cube.BBox) bool {
+	minPos, maxPos := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	// Blocks such as fences have boxes extending into the block above them,
+	// so the blocks below the box are checked too.
+	for y := minPos[1] - 1; y <= maxPos[1]; y++ {
+		for x := minPos[0]; x <= maxPos[0]; x++ {
+			for z := minPos[2]; z <= maxPos[2]; z++ {
+				if cube.AnyIntersections(ev.cell(cube.Pos{x, y, z}).boxes, box) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// neighbour finds the node that an entity with its feet at from can move to
+// in the column at x and z. False is returned if the entity cannot move into
+// the column.
+func (ev *evaluator) neighbour(from mgl64.Vec3, x, z int) (mgl64.Vec3, NodeType, bool) {
+	centreX, centreZ := float64(x)+0.5, float64(z)+0.5
+	maxY := from[1] + ev.conf.JumpHeight
+	low := int(math.Floor(from[1])) - ev.conf.MaxFall - 1
+	for y := int(math.Floor(maxY)); y >= low; y-- {
+		pos := cube.Pos{x, y, z}
+		c := ev.cell(pos)
+		feet := float64(y) + c.top
+		switch {
+		case c.kind == cellWater || c.kind == cellLava:
+			// Entities swim in liquids rather than standing on the block
+			// below them.
+			feet = float64(y)
+		case len(c.boxes) == 0 || feet > maxY+epsilon:
+			// Nothing to stand on, or a block too high to jump on. The
+			// entity may still be able to move below it.
+			continue
+		}
+		// The entity needs room to move from its current position into the
+		// column: upwards before moving if it jumps, and downwards after
+		// moving if it falls.
+		top := max(feet, from[1])
+		if !ev.clear(ev.entityBox(centreX, centreZ, feet, top)) ||
+			!ev.clear(ev.entityBox(from[0], from[2], from[1], top)) {
+			return mgl64.Vec3{}, NodeBlocked, false
+		}
+		target := mgl64.Vec3{centreX, feet, centreZ}
+		return target, ev.nodeType(target), true
+	}
+	return mgl64.Vec3{}, NodeBlocked, false
+}
+
+// nodeType returns the NodeType of the node with its feet at the position
+// passed.
+func (ev *evaluator) nodeType(feet mgl64.Vec3) NodeType {
+	box := ev.entityBox(feet[0], feet[2], feet[1], feet[1])
+	minPos, maxPos := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+
+	t := NodeWalkable
+	switch ev.cell(cube.PosFromVec3(feet.Sub(mgl64.Vec3{0, epsilon}))).kind {
+	case cellFence:
+		t = NodeFence
+	case cellHurts:
+		t = NodeDamage
+	}
+	for y := minPos[1]; y <= maxPos[1]; y++ {
+		for x := minPos[0]; x <= maxPos[0]; x++ {
+			for z := minPos[2]; z <= maxPos[2]; z++ {
+				switch ev.cell(cube.Pos{x, y, z}).kind {
+				case cellLava:
+					return NodeLava
+				case cellFire:
+					t = worse(t, NodeDamage)
+				case cellDoorClosed:
+					t = worse(t, NodeDoorClosed)
+				case cellWater:
+					t = worse(t, NodeWater)
+				case cellDoorOpen:
+					t = worse(t, NodeDoorOpen)
+				}
+			}
+		}
+	}
+	if t != NodeWalkable {
+		return t
+	}
+	// Nodes next to damaging blocks are dangerous, even if the entity does
+	// not touch these blocks when standing in the node.
+	feetPos := cube.PosFromVec3(feet)
+	for x := feetPos[0] - 1; x <= feetPos[0]+1; x++ {
+		for z := feetPos[2] - 1; z <= feetPos[2]+1; z++ {
+			for y := feetPos[1] - 1; y <= feetPos[1]; y++ {
+				if k := ev.cell(cube.Pos{x, y, z}).kind; k == cellFire || k == cellLava || (k == cellHurts && y == feetPos[1]) {
+					return NodeDanger
+				}
+			}
+		}
+	}
+	return t
+}
+
+// worse returns the NodeType out of a and b that entities avoid most.
+func worse(a, b NodeType) NodeType {
+	if nodeTypeSeverity[b] > nodeTypeSeverity[a] {
+		return b
+	}
+	return a
+}
+
+// nodeTypeSeverity orders node types by how much entities avoid them.
+var nodeTypeSeverity = [nodeTypeCount]int{
+	NodeWalkable:   0,
+	NodeDoorOpen:   1,
+	NodeDanger:     2,
+	NodeWater:      3,
+	NodeDoorClosed: 4,
+	NodeFence:      5,
+	NodeDamage:     6,
+	NodeLava:       7,
+	NodeBlocked:    8,
+}
+
+// epsilon is the margin used when comparing heights.
+const epsilon = 1e-5
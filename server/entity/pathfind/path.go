@@ -0,0 +1,25 @@
+package pathfind
+
+import "github.com/go-gl/mathgl/mgl64"
+
+// Path is a path found by a Pathfinder.
+type Path struct {
+	// Nodes holds the nodes of the path in the order that an entity should
+	// move through them. The position that the path starts at is not
+	// included.
+	Nodes []Node
+	// Complete is true if the path ends at its destination. A path that is
+	// not complete ends at the node closest to the destination that could be
+	// reached, either because the destination cannot be reached or because
+	// the search for a path is still in progress.
+	Complete bool
+}
+
+// Node is a single node of a Path.
+type Node struct {
+	// Pos is the position of the feet of an entity in the node. Pos is in the
+	// horizontal centre of a block.
+	Pos mgl64.Vec3
+	// Type is the NodeType of the node.
+	Type NodeType
+}
@@ -0,0 +1,156 @@
+package pathfind
+
+import (
+	"context"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestFindPathAroundWall(t *testing.T) {
+	w := newTestWorld(t)
+	mustDo(t, w, func(tx *world.Tx) {
+		floor(tx, 0, 0, 10, 10)
+		// A wall of fences with a gap at z = 8. Fences are too high to jump
+		// over.
+		for z := range 8 {
+			tx.SetBlock(cube.Pos{5, 64, z}, block.WoodFence{}, nil)
+		}
+		path, done := Config{}.New().Find(tx, mgl64.Vec3{1.5, 64, 1.5}, mgl64.Vec3{8.5, 64, 1.5})
+		if !done || !path.Complete {
+			t.Fatalf("path around wall: done = %v, complete = %v, want both true", done, path.Complete)
+		}
+		through := false
+		for _, n := range path.Nodes {
+			if cube.PosFromVec3(n.Pos) == (cube.Pos{5, 64, 8}) {
+				through = true
+			}
+			if n.Pos[1] != 64 {
+				t.Errorf("node %v not on the floor", n.Pos)
+			}
+		}
+		if !through {
+			t.Errorf("path %v does not pass through the gap in the wall", path.Nodes)
+		}
+	})
+}
+
+func TestFindPathJumpsAndFalls(t *testing.T) {
+	w := newTestWorld(t)
+	mustDo(t, w, func(tx *world.Tx) {
+		floor(tx, 0, 0, 10, 1)
+		tx.SetBlock(cube.Pos{3, 64, 0}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{4, 64, 0}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{4, 65, 0}, block.Slab{Block: block.Stone{}}, nil)
+
+		path, done := Config{}.New().Find(tx, mgl64.Vec3{0.5, 64, 0.5}, mgl64.Vec3{8.5, 64, 0.5})
+		if !done || !path.Complete {
+			t.Fatalf("path over steps: done = %v, complete = %v, want both true", done, path.Complete)
+		}
+		heights := map[int]float64{}
+		for _, n := range path.Nodes {
+			heights[int(n.Pos[0])] = n.Pos[1]
+		}
+		if heights[3] != 65 || heights[4] != 65.5 || heights[5] != 64 {
+			t.Errorf("path heights = %v, want 65 on the stone, 65.5 on the slab and 64 after", heights)
+		}
+
+		// The same steps are too high for an entity that cannot jump as high.
+		path, _ = Config{JumpHeight: 0.5}.New().Find(tx, mgl64.Vec3{0.5, 64, 0.5}, mgl64.Vec3{8.5, 64, 0.5})
+		if path.Complete {
+			t.Errorf("path for entity with low jump height is complete, want it blocked")
+		}
+	})
+}
+
+func TestFindPathAvoidsLavaAndDoors(t *testing.T) {
+	w := newTestWorld(t)
+	mustDo(t, w, func(tx *world.Tx) {
+		floor(tx, 0, 0, 10, 3)
+		for z := range 3 {
+			tx.SetBlock(cube.Pos{5, 64, z}, block.Stone{}, nil)
+			tx.SetBlock(cube.Pos{5, 65, z}, block.Stone{}, nil)
+		}
+		tx.SetBlock(cube.Pos{5, 63, 1}, block.Lava{Depth: 8, Still: true}, nil)
+		tx.SetBlock(cube.Pos{5, 64, 1}, block.Air{}, nil)
+		tx.SetBlock(cube.Pos{5, 65, 1}, block.Air{}, nil)
+		from, to := mgl64.Vec3{1.5, 64, 1.5}, mgl64.Vec3{8.5, 64, 1.5}
+
+		// The only way through the wall is over lava, which is never taken.
+		if path, _ := (Config{}).New().Find(tx, from, to); path.Complete {
+			t.Fatalf("path over lava found, want no path")
+		}
+
+		tx.SetBlock(cube.Pos{5, 63, 1}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{5, 64, 1}, block.WoodDoor{}, nil)
+		tx.SetBlock(cube.Pos{5, 65, 1}, block.WoodDoor{Top: true}, nil)
+		if path, _ := (Config{}).New().Find(tx, from, to); path.Complete {
+			t.Errorf("path through closed door found for entity that cannot open doors")
+		}
+		if path, _ := (Config{CanOpenDoors: true}).New().Find(tx, from, to); !path.Complete {
+			t.Errorf("no path through closed door for entity that can open doors")
+		}
+	})
+}
+
+func TestFindSpreadsSearchOverTicks(t *testing.T) {
+	w := newTestWorld(t)
+	mustDo(t, w, func(tx *world.Tx) {
+		floor(tx, 0, 0, 20, 20)
+	})
+	p := Config{NodesPerTick: 4, Budget: NewBudget(8)}.New()
+	from, to := mgl64.Vec3{0.5, 64, 0.5}, mgl64.Vec3{18.5, 64, 18.5}
+
+	var (
+		path  Path
+		done  bool
+		ticks int
+	)
+	for ; !done && ticks < 100; ticks++ {
+		mustDo(t, w, func(tx *world.Tx) {
+			// A second Find in the same tick should not evaluate any more
+			// nodes than allowed in the tick.
+			path, done = p.Find(tx, from, to)
+			if !done {
+				path, done = p.Find(tx, from, to)
+			}
+		})
+		w.AdvanceTick()
+	}
+	if !done || !path.Complete {
+		t.Fatalf("search not done after %v ticks", ticks)
+	}
+	if ticks < 4 {
+		t.Errorf("search done after %v ticks, want it spread over more ticks", ticks)
+	}
+	mustDo(t, w, func(tx *world.Tx) {
+		if _, done := p.Find(tx, from, to); !done {
+			t.Errorf("path not cached after search finished")
+		}
+	})
+}
+
+func newTestWorld(t *testing.T) *world.World {
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	return w
+}
+
+func mustDo(t *testing.T, w *world.World, f func(tx *world.Tx)) {
+	t.Helper()
+	if err := w.Do(f).Wait(context.Background()); err != nil {
+		t.Fatalf("world task failed: %v", err)
+	}
+}
+
+// floor places a stone floor at y = 63 spanning the area passed.
+func floor(tx *world.Tx, x0, z0, x1, z1 int) {
+	for x := x0; x < x1; x++ {
+		for z := z0; z < z1; z++ {
+			tx.SetBlock(cube.Pos{x, 63, z}, block.Stone{}, nil)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package pathfind
+
+import (
+	"slices"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Config holds the parameters of a Pathfinder, describing the entity that
+// paths are found for and limiting the work done to find them.
+type Config struct {
+	// Width and Height are the width and height of the bounding box of the
+	// entity. Width defaults to 0.6 and Height defaults to 1.8.
+	Width, Height float64
+	// JumpHeight is the maximum height in blocks that the entity can move up
+	// from one node to the next. JumpHeight defaults to 1.125, which allows
+	// jumping onto full blocks but not onto fences and walls.
+	JumpHeight float64
+	// MaxFall is the maximum number of blocks that the entity may fall down
+	// from one node to the next. MaxFall defaults to 3.
+	MaxFall int
+	// CanOpenDoors specifies if the entity can open doors. If true, closed
+	// doors do not block the entity.
+	CanOpenDoors bool
+	// Costs holds the costs of moving through nodes of specific types, which
+	// override the default costs. By default, water and nodes next to
+	// damaging blocks have a cost of 8, damaging nodes such as fire have a
+	// cost of 16, and entities cannot move through lava, closed doors or on
+	// top of fences.
+	Costs Costs
+	// MaxDistance is the maximum distance in blocks from the start of a path
+	// that nodes are evaluated at. MaxDistance defaults to 32.
+	MaxDistance float64
+	// MaxNodes is the maximum number of nodes evaluated to find a single
+	// path. If no path is found after evaluating this number of nodes, a path
+	// to the closest node found is returned. MaxNodes defaults to 1024.
+	MaxNodes int
+	// NodesPerTick is the maximum number of nodes that the Pathfinder
+	// evaluates in a single tick. NodesPerTick defaults to 256.
+	NodesPerTick int
+	// Budget is the Budget shared with other Pathfinders, limiting the total
+	// number of nodes evaluated in a tick of a world. Budget defaults to
+	// DefaultBudget.
+	Budget *Budget
+	// CacheDuration is the duration that paths found are reused for when a
+	// path between the same blocks is requested again. CacheDuration
+	// defaults to 2 seconds.
+	CacheDuration time.Duration
+}
+
+// New creates a Pathfinder using the parameters in conf.
+func (conf Config) New() *Pathfinder {
+	if conf.Width <= 0 {
+		conf.Width = 0.6
+	}
+	if conf.Height <= 0 {
+		conf.Height = 1.8
+	}
+	if conf.JumpHeight <= 0 {
+		conf.JumpHeight = 1.125
+	}
+	if conf.MaxFall <= 0 {
+		conf.MaxFall = 3
+	}
+	if conf.MaxDistance <= 0 {
+		conf.MaxDistance = 32
+	}
+	if conf.MaxNodes <= 0 {
+		conf.MaxNodes = 1024
+	}
+	if conf.NodesPerTick <= 0 {
+		conf.NodesPerTick = 256
+	}
+	if conf.Budget == nil {
+		conf.Budget = DefaultBudget
+	}
+	if conf.CacheDuration <= 0 {
+		conf.CacheDuration = time.Second * 2
+	}
+	return &Pathfinder{conf: conf}
+}
+
+// Pathfinder finds paths for a single entity. A search for a path may be
+// spread over multiple ticks if it evaluates more nodes than allowed in a
+// single tick. Paths found are cached for a short time, so that an entity
+// repeatedly looking for a path to the same destination, for example one
+// that cannot be reached, does not search for it every tick.
+// A Pathfinder is not safe for concurrent use.
+type Pathfinder struct {
+	conf Config
+
+	search *search
+	cache  []cachedPath
+
+	tick int64
+	used int
+}
+
+// cachedPath is a path found by a Pathfinder that may be reused.
+type cachedPath struct {
+	from, to cube.Pos
+	path     Path
+	expiry   int64
+}
+
+// maxCachedPaths is the maximum number of paths cached by a Pathfinder.
+const maxCachedPaths = 4
+
+// Find finds a path from one position to another in the world of the
+// transaction passed. Find returns true if the search for the path is done,
+// in which case the path returned is either complete or, if the destination
+// cannot be reached, ends at the node closest to it. If the search is not
+// yet done, Find returns the best path found so far and false, and Find
+// should be called again in a later tick to continue the search.
+// Searches continue as long as Find is called with a destination in the same
+// block, even if the entity moved since the search started.
+func (p *Pathfinder) Find(tx *world.Tx, from, to mgl64.Vec3) (Path, bool) {
+	tick := tx.CurrentTick()
+	fromPos, toPos := cube.PosFromVec3(from), cube.PosFromVec3(to)
+	p.cache = slices.DeleteFunc(p.cache, func(c cachedPath) bool {
+		return c.expiry <= tick
+	})
+	for _, c := range p.cache {
+		if c.from == fromPos && c.to == toPos {
+			return c.path, true
+		}
+	}
+
+	if p.search == nil || p.search.goal != toPos {
+		p.search = newSearch(p.conf, from, to)
+	}
+	if p.tick != tick {
+		p.tick, p.used = tick, 0
+	}
+	if n := p.conf.Budget.Take(tx, p.conf.NodesPerTick-p.used); n > 0 {
+		used := p.search.step(tx, n)
+		p.used += used
+		if used < n {
+			// Return the part of the budget that was not needed to the
+			// other Pathfinders.
+			p.conf.Budget.release(tx, n-used)
+		}
+	}
+	path := p.search.path()
+	if !p.search.done {
+		return path, false
+	}
+	s := p.search
+	p.search = nil
+	if len(p.cache) == maxCachedPaths {
+		p.cache = p.cache[1:]
+	}
+	ticks := int64(p.conf.CacheDuration / (time.Second / 20))
+	p.cache = append(p.cache, cachedPath{from: cube.PosFromVec3(s.from), to: toPos, path: path, expiry: tick + ticks})
+	return path, true
+}
+
+// Reset stops the search currently in progress and clears all cached paths.
+// Reset may be used when the blocks around the entity changed, so that paths
+// found earlier are no longer valid.
+func (p *Pathfinder) Reset() {
+	p.search, p.cache = nil, nil
+}
@@ -0,0 +1,182 @@
+package pathfind
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// node is a node evaluated by a search.
+type node struct {
+	pos  mgl64.Vec3
+	key  cube.Pos
+	kind NodeType
+
+	g, h   float64
+	parent *node
+	index  int
+	closed bool
+}
+
+// search is an A* search for a path between two positions. A search may be
+// stepped over multiple ticks until it is done.
+type search struct {
+	conf     Config
+	from, to mgl64.Vec3
+	goal     cube.Pos
+
+	nodes   map[cube.Pos]*node
+	open    openSet
+	closest *node
+	found   *node
+
+	evaluated int
+	done      bool
+}
+
+// newSearch creates a search for a path from one position to another.
+func newSearch(conf Config, from, to mgl64.Vec3) *search {
+	start := &node{pos: from, key: cube.PosFromVec3(from), kind: NodeWalkable, h: from.Sub(to).Len()}
+	s := &search{
+		conf:    conf,
+		from:    from,
+		to:      to,
+		goal:    cube.PosFromVec3(to),
+		nodes:   map[cube.Pos]*node{start.key: start},
+		closest: start,
+	}
+	heap.Push(&s.open, start)
+	return s
+}
+
+// directions holds the horizontal offsets of the neighbours of a node. The
+// first four directions are straight, the last four diagonal.
+var directions = [8][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+
+// step evaluates at most n nodes of the search and returns the number of
+// nodes evaluated.
+func (s *search) step(tx *world.Tx, n int) int {
+	ev := &evaluator{conf: s.conf, tx: tx, cells: make(map[cube.Pos]cell)}
+	evaluated := 0
+	for evaluated < n && !s.done {
+		if s.open.Len() == 0 || s.evaluated >= s.conf.MaxNodes {
+			s.done = true
+			break
+		}
+		cur := heap.Pop(&s.open).(*node)
+		cur.closed = true
+		s.evaluated++
+		evaluated++
+		if cur.key == s.goal || cur.pos.Sub(s.to).Len() < 1 {
+			s.found, s.done = cur, true
+			break
+		}
+		s.expand(ev, cur)
+	}
+	return evaluated
+}
+
+// expand adds the neighbours of a node to the open set of the search.
+func (s *search) expand(ev *evaluator, cur *node) {
+	for i, d := range directions {
+		x, z := cur.key[0]+d[0], cur.key[2]+d[1]
+		pos, kind, ok := ev.neighbour(cur.pos, x, z)
+		cost := s.conf.cost(kind)
+		if !ok || cost < 0 || pos.Sub(s.from).Len() > s.conf.MaxDistance {
+			continue
+		}
+		if i >= 4 && !s.diagonal(ev, cur, pos, d) {
+			continue
+		}
+		key := cube.Pos{x, int(math.Floor(pos[1] + epsilon)), z}
+		g := cur.g + pos.Sub(cur.pos).Len() + cost
+		n, ok := s.nodes[key]
+		if ok && (n.closed || g >= n.g) {
+			continue
+		}
+		if !ok {
+			n = &node{key: key, h: pos.Sub(s.to).Len(), index: -1}
+			s.nodes[key] = n
+		}
+		n.pos, n.kind, n.g, n.parent = pos, kind, g, cur
+		if n.index >= 0 {
+			heap.Fix(&s.open, n.index)
+		} else {
+			heap.Push(&s.open, n)
+		}
+		if n.h < s.closest.h {
+			s.closest = n
+		}
+	}
+}
+
+// diagonal checks if an entity can move diagonally from a node to the
+// position passed. Diagonal moves are only allowed on level ground, when the
+// entity can also move into both straight neighbours in between without
+// cost, so that it does not cut corners.
+func (s *search) diagonal(ev *evaluator, cur *node, pos mgl64.Vec3, d [2]int) bool {
+	if math.Abs(pos[1]-cur.pos[1]) > epsilon {
+		return false
+	}
+	for _, c := range [2][2]int{{cur.key[0] + d[0], cur.key[2]}, {cur.key[0], cur.key[2] + d[1]}} {
+		p, kind, ok := ev.neighbour(cur.pos, c[0], c[1])
+		if !ok || s.conf.cost(kind) != 0 || math.Abs(p[1]-pos[1]) > epsilon {
+			return false
+		}
+	}
+	return true
+}
+
+// path returns the path found by the search. If the search did not reach its
+// destination, a path to the node closest to it is returned.
+func (s *search) path() Path {
+	end := s.found
+	if end == nil {
+		end = s.closest
+	}
+	var nodes []Node
+	for n := end; n.parent != nil; n = n.parent {
+		nodes = append(nodes, Node{Pos: n.pos, Type: n.kind})
+	}
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+	return Path{Nodes: nodes, Complete: s.found != nil}
+}
+
+// openSet is a priority queue of nodes that a search has yet to evaluate,
+// ordered by their estimated total cost. It implements heap.Interface.
+type openSet []*node
+
+func (o openSet) Len() int { return len(o) }
+
+func (o openSet) Less(i, j int) bool {
+	fi, fj := o[i].g+o[i].h, o[j].g+o[j].h
+	if fi == fj {
+		return o[i].h < o[j].h
+	}
+	return fi < fj
+}
+
+func (o openSet) Swap(i, j int) {
+	o[i], o[j] = o[j], o[i]
+	o[i].index, o[j].index = i, j
+}
+
+func (o *openSet) Push(x any) {
+	n := x.(*node)
+	n.index = len(*o)
+	*o = append(*o, n)
+}
+
+func (o *openSet) Pop() any {
+	old := *o
+	n := old[len(old)-1]
+	old[len(old)-1] = nil
+	n.index = -1
+	*o = old[:len(old)-1]
+	return n
+}
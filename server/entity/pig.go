@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewPig creates a new adult pig.
+func NewPig(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(PigType, pigConf)
+}
+
+var pigConf = AnimalBehaviourConfig{
+	Mob: MobBehaviourConfig{
+		MaxHealth:  10,
+		Speed:      0.25,
+		Drops:      []MobDrop{{Item: item.Porkchop{}, Cooked: item.Porkchop{Cooked: true}, Min: 1, Max: 3}},
+		Experience: 2,
+	},
+	Food: []world.Item{block.Carrot{}, block.Potato{}, item.Beetroot{}},
+}
+
+// PigType is a world.EntityType implementation for pigs.
+var PigType pigType
+
+type pigType struct{}
+
+func (pigType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (pigType) EncodeEntity() string           { return "minecraft:pig" }
+func (pigType) MobCategory() world.MobCategory { return world.MobCategoryCreature }
+func (pigType) BBox(e world.Entity) cube.BBox {
+	return animalBBox(e, 0.9, 0.9)
+}
+
+func (pigType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	a := pigConf.New()
+	decodeAnimalNBT(m, a)
+	data.Data = a
+}
+
+func (pigType) EncodeNBT(data *world.EntityData) map[string]any {
+	return encodeAnimalNBT(data.Data.(*AnimalBehaviour))
+}
@@ -12,20 +12,36 @@ import (
 var DefaultRegistry = conf.New([]world.EntityType{
 	AreaEffectCloudType,
 	ArrowType,
+	BoatType,
 	BottleOfEnchantingType,
+	ChestBoatType,
+	ChestMinecartType,
+	ChickenType,
+	CowType,
+	CreeperType,
 	EggType,
 	EndCrystalType,
 	EnderPearlType,
 	ExperienceOrbType,
 	FallingBlockType,
 	FireworkType,
+	HopperMinecartType,
+	HorseType,
 	ItemType,
 	LightningType,
 	LingeringPotionType,
+	MinecartType,
+	PigType,
+	SheepType,
+	SkeletonType,
 	SnowballType,
+	SpiderType,
 	SplashPotionType,
+	TNTMinecartType,
 	TNTType,
 	TextType,
+	VillagerType,
+	ZombieType,
 })
 
 var conf = world.EntityRegistryConfig{
@@ -49,10 +65,22 @@ var conf = world.EntityRegistryConfig{
 	SplashPotion: func(opts world.EntitySpawnOpts, t any, owner world.Entity) *world.EntityHandle {
 		return NewSplashPotion(opts, t.(potion.Potion), owner)
 	},
+	Minecart: func(opts world.EntitySpawnOpts, t any) *world.EntityHandle {
+		return NewMinecart(opts, t.(item.MinecartType))
+	},
+	Boat: func(opts world.EntitySpawnOpts, t any, chest bool) *world.EntityHandle {
+		if chest {
+			return NewChestBoat(opts, t.(item.BoatType))
+		}
+		return NewBoat(opts, t.(item.BoatType))
+	},
 	Arrow: func(opts world.EntitySpawnOpts, arrow world.ArrowSpawnConfig) *world.EntityHandle {
 		tip := arrow.Tip.(potion.Potion)
 		conf := arrowConf
-		conf.Damage, conf.Potion, conf.Owner = arrow.Damage, tip, arrow.Owner.H()
+		conf.Damage, conf.Potion = arrow.Damage, tip
+		if arrow.Owner != nil {
+			conf.Owner = arrow.Owner.H()
+		}
 		conf.KnockBackForceAddend = float64(arrow.PunchLevel) * enchantment.Punch.KnockBackMultiplier()
 		conf.DisablePickup = arrow.DisablePickup
 		if arrow.ObtainArrowOnPickup {
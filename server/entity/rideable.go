@@ -0,0 +1,275 @@
+package entity
+
+import (
+	"math"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Seat is a position on a rideable entity that one of its riders sits at.
+type Seat struct {
+	// Offset is the position of the rider relative to the entity ridden, before it is rotated by the yaw of that
+	// entity. The X axis points to the left of the entity ridden and the Z axis points forward.
+	Offset mgl64.Vec3
+	// MaxRotation is the maximum number of degrees that the rider may turn its head away from the direction that
+	// the entity ridden faces. If MaxRotation is 0, the rotation of the rider is not limited.
+	MaxRotation float64
+}
+
+// RideInput is the movement input of an entity controlling the entity it rides, such as a player steering a boat.
+type RideInput struct {
+	// Forward is the forward movement input, ranging from -1 (backwards) to 1 (forwards).
+	Forward float64
+	// Sideways is the sideways movement input, ranging from -1 (right) to 1 (left).
+	Sideways float64
+	// Yaw is the yaw of the rider in degrees.
+	Yaw float64
+	// Jumping specifies if the rider is holding its jump button.
+	Jumping bool
+}
+
+// behaviourRideable represents a Behaviour of an entity that other entities may ride, such as a minecart. The rider
+// in the first seat controls the entity.
+type behaviourRideable interface {
+	// Seats returns the seats of the entity. The position of a seat may depend on the number of riders.
+	Seats() []Seat
+	// Riders returns the handles of the entities riding the entity, one for every seat. Empty seats hold a nil
+	// handle.
+	Riders() []*world.EntityHandle
+	// setRider changes the entity riding in the seat passed. A nil handle empties the seat.
+	setRider(seat int, h *world.EntityHandle)
+	// canBeRidden checks if the entity may currently be ridden at all.
+	canBeRidden() bool
+}
+
+// behaviourSteerable represents a Behaviour of a rideable entity that the rider in its first seat may steer, such
+// as a boat.
+type behaviourSteerable interface {
+	// steer handles the movement input of the rider controlling the entity. It is called every time that the
+	// rider sends new input.
+	steer(e *Ent, input RideInput)
+}
+
+// behaviourRider represents a Behaviour of an entity that keeps track of the entity that it rides, such as a mob.
+type behaviourRider interface {
+	// vehicle returns the handle of the entity ridden, or nil if the entity is not riding.
+	vehicle() *world.EntityHandle
+	// setVehicle changes the entity ridden. A nil handle means the entity is no longer riding.
+	setVehicle(h *world.EntityHandle)
+}
+
+// riderSeats holds the riders of a rideable entity. It may be embedded by behaviours to implement the rider part
+// of behaviourRideable.
+type riderSeats struct {
+	riders []*world.EntityHandle
+}
+
+// newRiderSeats returns riderSeats with n empty seats.
+func newRiderSeats(n int) riderSeats {
+	return riderSeats{riders: make([]*world.EntityHandle, n)}
+}
+
+// Riders returns the handles of the entities riding the entity, one for every seat. Empty seats hold a nil handle.
+func (r *riderSeats) Riders() []*world.EntityHandle {
+	return slices.Clone(r.riders)
+}
+
+// setRider ...
+func (r *riderSeats) setRider(seat int, h *world.EntityHandle) {
+	r.riders[seat] = h
+}
+
+// riderCount returns the number of seats that are taken.
+func (r *riderSeats) riderCount() int {
+	n := 0
+	for _, h := range r.riders {
+		if h != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// removeClosedRiders empties the seats of riders that no longer exist in the transaction passed.
+func (r *riderSeats) removeClosedRiders(tx *world.Tx) {
+	for i, h := range r.riders {
+		if h == nil {
+			continue
+		}
+		if _, ok := h.Entity(tx); !ok {
+			r.riders[i] = nil
+		}
+	}
+}
+
+// Rideable checks if the entity passed may be ridden by other entities.
+func Rideable(e world.Entity) bool {
+	r, ok := rideable(e)
+	return ok && r.canBeRidden()
+}
+
+// RiderOf returns the handle of the entity controlling the entity passed, which is the rider in its first seat.
+// False is returned if the first seat is empty.
+func RiderOf(e world.Entity) (*world.EntityHandle, bool) {
+	if r, ok := rideable(e); ok {
+		if riders := r.Riders(); len(riders) > 0 && riders[0] != nil {
+			return riders[0], true
+		}
+	}
+	return nil, false
+}
+
+// RidersOf returns the handles of all entities riding the entity passed.
+func RidersOf(e world.Entity) []*world.EntityHandle {
+	r, ok := rideable(e)
+	if !ok {
+		return nil
+	}
+	return slices.DeleteFunc(r.Riders(), func(h *world.EntityHandle) bool {
+		return h == nil
+	})
+}
+
+// SeatOf returns the index of the seat that rider takes on the entity e and the Seat itself. False is returned if
+// rider is not riding e.
+func SeatOf(e world.Entity, rider *world.EntityHandle) (int, Seat, bool) {
+	r, ok := rideable(e)
+	if !ok {
+		return 0, Seat{}, false
+	}
+	i := slices.Index(r.Riders(), rider)
+	if i == -1 {
+		return 0, Seat{}, false
+	}
+	return i, r.Seats()[i], true
+}
+
+// SeatPosition returns the position of the seat passed of the entity e, taking the rotation of e into account.
+func SeatPosition(e world.Entity, seat Seat) mgl64.Vec3 {
+	yaw := mgl64.DegToRad(e.Rotation().Yaw())
+	sin, cos := math.Sin(yaw), math.Cos(yaw)
+	x, z := seat.Offset[0], seat.Offset[2]
+	return e.Position().Add(mgl64.Vec3{x*cos - z*sin, seat.Offset[1], x*sin + z*cos})
+}
+
+// MountEntity makes rider start riding the entity e in the first free seat and shows the link to viewers of the
+// rider. False is returned if e cannot be ridden or has no free seat. If the rider is an Ent that may ride other
+// entities, it keeps track of e. Other riders, such as players, should keep track of the entity they ride
+// themselves.
+func MountEntity(rider, e world.Entity, tx *world.Tx) bool {
+	r, ok := rideable(e)
+	if !ok || !r.canBeRidden() || rider.H() == e.H() {
+		return false
+	}
+	riders := r.Riders()
+	if slices.Contains(riders, rider.H()) {
+		return true
+	}
+	seat := slices.Index(riders, nil)
+	if seat == -1 {
+		return false
+	}
+	r.setRider(seat, rider.H())
+	if br, ok := riderBehaviour(rider); ok {
+		br.setVehicle(e.H())
+	}
+	for _, v := range tx.Viewers(rider.Position()) {
+		v.ViewEntityAction(rider, MountAction{Vehicle: e, Seat: seat})
+	}
+	updateRiderStates(e, tx)
+	return true
+}
+
+// DismountEntity makes rider stop riding the entity e and removes the link for viewers of the rider. Nothing
+// happens if rider was not riding e.
+func DismountEntity(rider, e world.Entity, tx *world.Tx) {
+	r, ok := rideable(e)
+	if !ok {
+		return
+	}
+	seat := slices.Index(r.Riders(), rider.H())
+	if seat == -1 {
+		return
+	}
+	r.setRider(seat, nil)
+	if br, ok := riderBehaviour(rider); ok && br.vehicle() == e.H() {
+		br.setVehicle(nil)
+	}
+	for _, v := range tx.Viewers(rider.Position()) {
+		v.ViewEntityAction(rider, DismountAction{Vehicle: e})
+		v.ViewEntityState(rider)
+	}
+	updateRiderStates(e, tx)
+}
+
+// SteerEntity passes the movement input of rider to the entity e that it rides. Only the rider in the first seat
+// of e may steer it, and only if e may be steered at all.
+func SteerEntity(rider, e world.Entity, input RideInput) {
+	if h, ok := RiderOf(e); !ok || h != rider.H() {
+		return
+	}
+	if ent, ok := e.(*Ent); ok {
+		if s, ok := ent.Behaviour().(behaviourSteerable); ok {
+			s.steer(ent, input)
+		}
+	}
+}
+
+// updateRiderStates shows the state of all riders of the entity e to their viewers, so that changes to the seats
+// of e are shown.
+func updateRiderStates(e world.Entity, tx *world.Tx) {
+	for _, h := range RidersOf(e) {
+		rider, ok := h.Entity(tx)
+		if !ok {
+			continue
+		}
+		for _, v := range tx.Viewers(rider.Position()) {
+			v.ViewEntityState(rider)
+		}
+	}
+}
+
+// ejectRiders makes all entities riding e stop riding it. Riders that keep track of the entity they ride
+// themselves are dismounted through their own Dismount method.
+func ejectRiders(e *Ent, tx *world.Tx) {
+	r, ok := rideable(e)
+	if !ok {
+		return
+	}
+	for seat, h := range r.Riders() {
+		if h == nil {
+			continue
+		}
+		rider, ok := h.Entity(tx)
+		if !ok {
+			r.setRider(seat, nil)
+			continue
+		}
+		if d, ok := rider.(interface{ Dismount() }); ok {
+			d.Dismount()
+		}
+		// Riders that do not track the entity they ride, or that were not riding e according to themselves,
+		// are removed from their seat directly.
+		DismountEntity(rider, e, tx)
+	}
+}
+
+// rideable returns the behaviourRideable of an entity, if it has one.
+func rideable(e world.Entity) (behaviourRideable, bool) {
+	if ent, ok := e.(*Ent); ok {
+		r, ok := ent.Behaviour().(behaviourRideable)
+		return r, ok
+	}
+	return nil, false
+}
+
+// riderBehaviour returns the behaviourRider of an entity, if it has one.
+func riderBehaviour(e world.Entity) (behaviourRider, bool) {
+	if ent, ok := e.(*Ent); ok {
+		r, ok := ent.Behaviour().(behaviourRider)
+		return r, ok
+	}
+	return nil, false
+}
@@ -0,0 +1,247 @@
+package entity
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewSheep creates a new adult sheep with a random natural wool colour. Most
+// sheep are white, but some are black, grey, light grey, brown or pink.
+func NewSheep(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(SheepType, SheepBehaviourConfig{Colour: randomSheepColour()})
+}
+
+// SheepBehaviourConfig holds optional parameters for a SheepBehaviour.
+type SheepBehaviourConfig struct {
+	// Colour is the colour of the wool of the sheep.
+	Colour item.Colour
+	// Sheared specifies if the sheep is sheared. Sheared sheep regrow their
+	// wool by eating grass.
+	Sheared bool
+	// Baby specifies if the sheep is a baby.
+	Baby bool
+}
+
+func (conf SheepBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a SheepBehaviour using the parameters in conf.
+func (conf SheepBehaviourConfig) New() *SheepBehaviour {
+	s := &SheepBehaviour{colour: conf.Colour, sheared: conf.Sheared}
+	animal := sheepConf
+	animal.Baby = conf.Baby
+	animal.Interact = s.interact
+	animal.Offspring = s.offspring
+	animal.Mob.Goals = func() []PrioritisedGoal {
+		return []PrioritisedGoal{{Priority: 4, Goal: &sheepEatGrassGoal{s: s}}}
+	}
+	animal.Mob.Death = s.dropWool
+	s.AnimalBehaviour = animal.New()
+	return s
+}
+
+var sheepConf = AnimalBehaviourConfig{
+	Mob: MobBehaviourConfig{
+		MaxHealth:  8,
+		Speed:      0.23,
+		Drops:      []MobDrop{{Item: item.Mutton{}, Cooked: item.Mutton{Cooked: true}, Min: 1, Max: 2}},
+		Experience: 2,
+	},
+	Food: []world.Item{item.Wheat{}},
+}
+
+// SheepBehaviour implements the behaviour of sheep. Sheep may be sheared
+// using shears to obtain their wool and dyed using dye to change its colour.
+type SheepBehaviour struct {
+	*AnimalBehaviour
+
+	colour  item.Colour
+	sheared bool
+}
+
+// Colour returns the colour of the wool of the sheep.
+func (s *SheepBehaviour) Colour() item.Colour {
+	return s.colour
+}
+
+// Sheared checks if the sheep is sheared.
+func (s *SheepBehaviour) Sheared() bool {
+	return s.sheared
+}
+
+// interact shears the sheep if shears are used on it, or dyes its wool if dye
+// is used on it.
+func (s *SheepBehaviour) interact(e *Ent, _ item.User, held item.Stack, ctx *item.UseContext) bool {
+	switch it := held.Item().(type) {
+	case item.Shears:
+		if s.sheared || s.Baby() {
+			return false
+		}
+		s.sheared = true
+		opts := world.EntitySpawnOpts{Position: e.data.Pos.Add(mgl64.Vec3{0, 1})}
+		e.tx.AddEntity(NewItem(opts, item.NewStack(block.Wool{Colour: s.colour}, 1+rand.IntN(3))))
+		e.tx.PlaySound(e.data.Pos, sound.Shear{})
+		ctx.DamageItem(1)
+	case item.Dye:
+		if s.colour == it.Colour {
+			return false
+		}
+		s.colour = it.Colour
+		ctx.SubtractFromCount(1)
+	default:
+		return false
+	}
+	e.updateState()
+	return true
+}
+
+// offspring returns the configuration of the baby of the sheep and its
+// partner. The baby has the wool colour of one of its parents.
+func (s *SheepBehaviour) offspring(_, partner *Ent) world.EntityConfig {
+	c := s.colour
+	if ps, ok := partner.Behaviour().(*SheepBehaviour); ok && rand.IntN(2) == 0 {
+		c = ps.colour
+	}
+	return SheepBehaviourConfig{Colour: c, Baby: true}
+}
+
+// dropWool drops the wool of the sheep when it dies, unless it is sheared.
+func (s *SheepBehaviour) dropWool(e *Ent, _ *world.Tx, _ world.DamageSource) {
+	if !s.sheared && !s.Baby() {
+		s.dropLoot(e, []MobDrop{{Item: block.Wool{Colour: s.colour}, Min: 1, Max: 1}}, 0)
+	}
+}
+
+// randomSheepColour returns a random natural wool colour of a sheep.
+func randomSheepColour() item.Colour {
+	switch n := rand.IntN(1000); {
+	case n < 50:
+		return item.ColourBlack()
+	case n < 100:
+		return item.ColourGrey()
+	case n < 150:
+		return item.ColourLightGrey()
+	case n < 180:
+		return item.ColourBrown()
+	case n < 182:
+		return item.ColourPink()
+	}
+	return item.ColourWhite()
+}
+
+// sheepEatGrassGoal is a Goal that makes a sheep eat grass every now and then,
+// regrowing its wool if it was sheared.
+type sheepEatGrassGoal struct {
+	s     *SheepBehaviour
+	ticks int
+}
+
+// Controls ...
+func (g *sheepEatGrassGoal) Controls() GoalControl {
+	return GoalControlMove | GoalControlLook | GoalControlJump
+}
+
+// CanStart ...
+func (g *sheepEatGrassGoal) CanStart(m *Mob) bool {
+	chance := 1000
+	if g.s.Baby() {
+		chance = 50
+	}
+	if rand.IntN(chance) != 0 {
+		return false
+	}
+	_, ok := g.grass(m)
+	return ok
+}
+
+// CanContinue ...
+func (g *sheepEatGrassGoal) CanContinue(*Mob) bool { return g.ticks > 0 }
+
+// Start ...
+func (g *sheepEatGrassGoal) Start(m *Mob) {
+	g.ticks = 40
+	m.StopMoving()
+	for _, v := range m.tx.Viewers(m.Position()) {
+		v.ViewEntityAction(m.e, EatGrassAction{})
+	}
+}
+
+// Tick ...
+func (g *sheepEatGrassGoal) Tick(m *Mob) {
+	if g.ticks--; g.ticks != 4 {
+		return
+	}
+	pos, ok := g.grass(m)
+	if !ok {
+		return
+	}
+	if _, short := m.tx.Block(pos).(block.ShortGrass); short {
+		m.tx.SetBlock(pos, nil, nil)
+	} else {
+		m.tx.SetBlock(pos, block.Dirt{}, nil)
+	}
+	s := g.s
+	s.sheared = false
+	if s.Baby() {
+		// Eating grass makes a baby grow up a minute faster.
+		if s.age = min(s.age+1200, 0); s.age == 0 {
+			s.grow(m.e)
+		}
+	}
+	m.e.updateState()
+}
+
+// Stop ...
+func (g *sheepEatGrassGoal) Stop(*Mob) { g.ticks = 0 }
+
+// grass returns the position of the grass that the sheep can eat: short grass
+// that it stands in or the grass block below it.
+func (g *sheepEatGrassGoal) grass(m *Mob) (cube.Pos, bool) {
+	pos := cube.PosFromVec3(m.Position())
+	if _, ok := m.tx.Block(pos).(block.ShortGrass); ok {
+		return pos, true
+	}
+	below := pos.Side(cube.FaceDown)
+	_, ok := m.tx.Block(below).(block.Grass)
+	return below, ok
+}
+
+// SheepType is a world.EntityType implementation for sheep.
+var SheepType sheepType
+
+type sheepType struct{}
+
+func (sheepType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (sheepType) EncodeEntity() string           { return "minecraft:sheep" }
+func (sheepType) MobCategory() world.MobCategory { return world.MobCategoryCreature }
+func (sheepType) BBox(e world.Entity) cube.BBox {
+	return animalBBox(e, 0.9, 1.3)
+}
+
+func (sheepType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := SheepBehaviourConfig{Sheared: nbtconv.Bool(m, "Sheared")}
+	if c := int(nbtconv.Uint8(m, "Color")); c < len(item.Colours()) {
+		conf.Colour = item.Colours()[c]
+	}
+	s := conf.New()
+	decodeAnimalNBT(m, s.AnimalBehaviour)
+	data.Data = s
+}
+
+func (sheepType) EncodeNBT(data *world.EntityData) map[string]any {
+	s := data.Data.(*SheepBehaviour)
+	m := encodeAnimalNBT(s.AnimalBehaviour)
+	m["Color"], m["Sheared"] = s.colour.Uint8(), boolByte(s.sheared)
+	return m
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewSkeleton creates a new skeleton holding a bow.
+func NewSkeleton(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(SkeletonType, skeletonConf)
+}
+
+var skeletonConf = MobBehaviourConfig{
+	Speed:           0.25,
+	BurnsInDaylight: true,
+	MainHand:        item.NewStack(item.Bow{}, 1),
+	Drops: []MobDrop{
+		{Item: item.Bone{}, Max: 2},
+		{Item: item.Arrow{}, Max: 2},
+	},
+	Experience: 5,
+	Goals: func() []PrioritisedGoal {
+		return []PrioritisedGoal{
+			{Priority: 0, Goal: &FloatGoal{}},
+			{Priority: 4, Goal: &BowAttackGoal{}},
+			{Priority: 5, Goal: &WanderGoal{}},
+			{Priority: 6, Goal: &LookAtPlayerGoal{}},
+		}
+	},
+	Targets: hostileTargets,
+}
+
+// SkeletonType is a world.EntityType implementation for skeletons.
+var SkeletonType skeletonType
+
+type skeletonType struct{}
+
+func (skeletonType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (skeletonType) EncodeEntity() string           { return "minecraft:skeleton" }
+func (skeletonType) MobCategory() world.MobCategory { return world.MobCategoryMonster }
+func (skeletonType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.99, 0.3)
+}
+
+func (skeletonType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	b := skeletonConf.New()
+	decodeMobNBT(m, b)
+	data.Data = b
+}
+
+func (skeletonType) EncodeNBT(data *world.EntityData) map[string]any {
+	return encodeMobNBT(data.Data.(*MobBehaviour))
+}
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewSpider creates a new spider.
+func NewSpider(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(SpiderType, spiderConf)
+}
+
+var spiderConf = MobBehaviourConfig{
+	MaxHealth: 16,
+	Speed:     0.3,
+	Climber:   true,
+	Drops: []MobDrop{
+		{Item: block.String{}, Max: 2},
+		{Item: item.SpiderEye{}, Max: 1},
+	},
+	Experience: 5,
+	Goals: func() []PrioritisedGoal {
+		return []PrioritisedGoal{
+			{Priority: 0, Goal: &FloatGoal{}},
+			{Priority: 1, Goal: &LeapAtTargetGoal{}},
+			{Priority: 2, Goal: &MeleeAttackGoal{}},
+			{Priority: 5, Goal: &WanderGoal{Speed: 0.8}},
+			{Priority: 6, Goal: &LookAtPlayerGoal{}},
+		}
+	},
+	Targets: func() []PrioritisedGoal {
+		return []PrioritisedGoal{
+			{Priority: 1, Goal: &HurtByTargetGoal{}},
+			{Priority: 2, Goal: spiderTargetGoal{&NearestTargetGoal{}}},
+		}
+	},
+}
+
+// spiderTargetGoal wraps a target Goal so that spiders only start targeting
+// entities in the dark. Spiders only attack during the day if they are
+// attacked first.
+type spiderTargetGoal struct {
+	Goal
+}
+
+// CanStart ...
+func (g spiderTargetGoal) CanStart(m *Mob) bool {
+	return brightness(m.Tx(), cube.PosFromVec3(m.Position())) < 8 && g.Goal.CanStart(m)
+}
+
+// SpiderType is a world.EntityType implementation for spiders.
+var SpiderType spiderType
+
+type spiderType struct{}
+
+func (spiderType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (spiderType) EncodeEntity() string           { return "minecraft:spider" }
+func (spiderType) MobCategory() world.MobCategory { return world.MobCategoryMonster }
+func (spiderType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.7, 0, -0.7, 0.7, 0.9, 0.7)
+}
+
+func (spiderType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	b := spiderConf.New()
+	decodeMobNBT(m, b)
+	data.Data = b
+}
+
+func (spiderType) EncodeNBT(data *world.EntityData) map[string]any {
+	return encodeMobNBT(data.Data.(*MobBehaviour))
+}
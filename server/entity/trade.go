@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"math"
+
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// TradeOffer is an offer of an entity that players may trade with, such as a villager. Players pay the Input and
+// SecondInput items to receive the Output item.
+type TradeOffer struct {
+	// Input is the first item that a player pays for the offer.
+	Input item.Stack
+	// SecondInput is the second item that a player pays for the offer. SecondInput is empty for most offers.
+	SecondInput item.Stack
+	// Output is the item that a player receives for the offer.
+	Output item.Stack
+	// MaxUses is the number of times that the offer may be used before the trader restocks. If MaxUses is 0, the
+	// offer may be used an unlimited number of times.
+	MaxUses int
+	// Uses is the number of times that the offer was used since the trader last restocked.
+	Uses int
+	// Experience is the trade experience that a villager gains when the offer is used. Villagers unlock new
+	// offers by gaining trade experience.
+	Experience int
+	// RewardExperience specifies if the player trading is rewarded with experience orbs when using the offer.
+	RewardExperience bool
+	// Level is the level that a villager must have for the offer to be used. Offers with a Level of 0 or 1 may
+	// always be used.
+	Level int
+}
+
+// Disabled checks if the offer was used its maximum number of times, so that it may not be used until the trader
+// restocks.
+func (o TradeOffer) Disabled() bool {
+	return o.MaxUses > 0 && o.Uses >= o.MaxUses
+}
+
+// TraderBehaviour is implemented by the Behaviour of entities that players may trade with, such as villagers.
+// Plugins may implement TraderBehaviour in their own Behaviour to create entities with custom trade offers.
+type TraderBehaviour interface {
+	// TradeOffers returns the trade offers of the entity that are currently shown to players.
+	TradeOffers() []TradeOffer
+	// TradeLevel returns the level of the entity and its trade experience. The trading UI shows the progress
+	// towards the next level.
+	TradeLevel() (level, experience int)
+	// StartTrading is called when trader opens the trading UI of the entity e. False is returned if the entity
+	// cannot trade with trader, for example because it is already trading with another player.
+	StartTrading(e *Ent, trader world.Entity) bool
+	// StopTrading is called when the player trading with the entity e closes the trading UI.
+	StopTrading(e *Ent)
+	// Trade is called when the player trading with the entity e uses the offer at the index passed in the
+	// slice returned by TradeOffers count times at once, before it pays the input items of the offer. False is
+	// returned if the offer may not be used count times, in which case Trade must not change any state and the
+	// trade is reverted.
+	Trade(e *Ent, trader world.Entity, index, count int) bool
+}
+
+// MaxTradeLevel is the highest level that villagers may reach by trading.
+const MaxTradeLevel = 5
+
+// villagerLevelExperience holds the trade experience that a villager needs to reach every level above the first.
+var villagerLevelExperience = [MaxTradeLevel - 1]int{10, 70, 150, 250}
+
+// TradeLevelExperience returns the trade experience that a villager needs to reach the level passed. Levels range
+// from 1 to MaxTradeLevel, and 0 is returned for the first level and for levels outside this range.
+func TradeLevelExperience(level int) int {
+	if level < 2 || level > MaxTradeLevel {
+		return 0
+	}
+	return villagerLevelExperience[level-2]
+}
+
+// decodeTradeOffers decodes the trade offers held in the NBT slice passed.
+func decodeTradeOffers(recipes []any) []TradeOffer {
+	offers := make([]TradeOffer, 0, len(recipes))
+	for _, r := range recipes {
+		m, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+		o := TradeOffer{
+			Input:            decodeTradeItem(m, "buyA"),
+			SecondInput:      decodeTradeItem(m, "buyB"),
+			Output:           decodeTradeItem(m, "sell"),
+			MaxUses:          int(nbtconv.Int32(m, "maxUses")),
+			Uses:             int(nbtconv.Int32(m, "uses")),
+			Experience:       int(nbtconv.Int32(m, "traderExp")),
+			RewardExperience: nbtconv.Bool(m, "rewardExp"),
+			Level:            int(nbtconv.Int32(m, "tier")) + 1,
+		}
+		if o.MaxUses == math.MaxInt32 {
+			o.MaxUses = 0
+		}
+		offers = append(offers, o)
+	}
+	return offers
+}
+
+// encodeTradeOffers encodes the trade offers passed to a slice which may be encoded as NBT.
+func encodeTradeOffers(offers []TradeOffer) []any {
+	recipes := make([]any, 0, len(offers))
+	for _, o := range offers {
+		recipes = append(recipes, EncodeTradeOffer(o))
+	}
+	return recipes
+}
+
+// EncodeTradeOffer encodes a TradeOffer to the NBT format used by villagers and the trading UI.
+func EncodeTradeOffer(o TradeOffer) map[string]any {
+	maxUses := int32(o.MaxUses)
+	if o.MaxUses <= 0 {
+		maxUses = math.MaxInt32
+	}
+	m := map[string]any{
+		"buyA":             item.WriteNBT(o.Input, true),
+		"buyCountA":        int32(o.Input.Count()),
+		"buyCountB":        int32(o.SecondInput.Count()),
+		"sell":             item.WriteNBT(o.Output, true),
+		"maxUses":          maxUses,
+		"uses":             int32(o.Uses),
+		"traderExp":        int32(o.Experience),
+		"rewardExp":        boolByte(o.RewardExperience),
+		"tier":             int32(max(o.Level-1, 0)),
+		"demand":           int32(0),
+		"priceMultiplierA": float32(0),
+		"priceMultiplierB": float32(0),
+	}
+	if !o.SecondInput.Empty() {
+		m["buyB"] = item.WriteNBT(o.SecondInput, true)
+	}
+	return m
+}
+
+// decodeTradeItem decodes the item stack under the key passed in the NBT map of a trade offer.
+func decodeTradeItem(m map[string]any, k string) item.Stack {
+	data, ok := m[k].(map[string]any)
+	if !ok {
+		return item.Stack{}
+	}
+	return item.ReadNBT(data, nil)
+}
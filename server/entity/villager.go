@@ -0,0 +1,499 @@
+package entity
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewVillager creates a new villager without a profession. The villager takes
+// the profession of the first unclaimed workstation that it finds.
+func NewVillager(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(VillagerType, VillagerBehaviourConfig{})
+}
+
+// VillagerBehaviourConfig holds optional parameters for a VillagerBehaviour.
+type VillagerBehaviourConfig struct {
+	// Profession is the profession of the villager. Villagers without a
+	// profession take the profession of the first unclaimed workstation that
+	// they find.
+	Profession VillagerProfession
+	// Level is the level of the villager, ranging from 1 to MaxTradeLevel.
+	// Villagers with a profession start with the trade offers of all levels
+	// up to Level. Level defaults to 1.
+	Level int
+	// Offers holds custom trade offers of the villager, for example to create
+	// an NPC shop. If Offers is not empty, the villager only trades these
+	// offers: it does not claim workstations and does not unlock new offers.
+	// Offers with a Level above the level of the villager may not be used
+	// until the villager gains enough experience from trading.
+	Offers []TradeOffer
+	// Traded is called after a player traded with the villager using the
+	// offer passed. If the offer was used multiple times at once, Traded is
+	// called once after all uses. Traded is not saved with the villager.
+	Traded func(e *Ent, trader world.Entity, offer TradeOffer)
+}
+
+func (conf VillagerBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a VillagerBehaviour using the parameters in conf.
+func (conf VillagerBehaviourConfig) New() *VillagerBehaviour {
+	conf.Level = min(max(conf.Level, 1), MaxTradeLevel)
+	v := &VillagerBehaviour{
+		conf:         conf,
+		profession:   conf.Profession,
+		level:        conf.Level,
+		experience:   TradeLevelExperience(conf.Level),
+		offers:       slices.Clone(conf.Offers),
+		custom:       len(conf.Offers) > 0,
+		restockTicks: villagerRestockTicks,
+	}
+	if !v.custom {
+		for level := 1; level <= v.level; level++ {
+			v.offers = append(v.offers, newVillagerOffers(v.profession, level)...)
+		}
+	}
+	mob := villagerConf
+	mob.Goals = func() []PrioritisedGoal {
+		return []PrioritisedGoal{
+			{Priority: 0, Goal: &FloatGoal{}},
+			{Priority: 1, Goal: &villagerTradeGoal{v: v}},
+			{Priority: 2, Goal: &FleeGoal{Avoid: func(e world.Entity) bool {
+				return e.H().Type() == ZombieType
+			}}},
+			{Priority: 3, Goal: &FleeGoal{}},
+			{Priority: 4, Goal: &villagerWorkstationGoal{v: v}},
+			{Priority: 5, Goal: &WanderGoal{}},
+			{Priority: 6, Goal: &LookAtPlayerGoal{}},
+		}
+	}
+	mob.Tick = v.tick
+	v.MobBehaviour = mob.New()
+	return v
+}
+
+var villagerConf = MobBehaviourConfig{Speed: 0.25}
+
+const (
+	// villagerRestockTicks is the minimum number of ticks between two
+	// restocks of a villager.
+	villagerRestockTicks = 6000
+	// villagerWorkstationDistance is the maximum horizontal distance in
+	// blocks between a villager and the workstations that it claims.
+	villagerWorkstationDistance = 16
+)
+
+// VillagerBehaviour implements the behaviour of villagers. Villagers take the
+// profession of the workstation that they claim, after which players may
+// trade with them. Trading gives villagers experience, which unlocks new
+// trade offers as they level up.
+type VillagerBehaviour struct {
+	*MobBehaviour
+	conf VillagerBehaviourConfig
+
+	profession        VillagerProfession
+	level, experience int
+	offers            []TradeOffer
+	custom            bool
+
+	workstation    cube.Pos
+	hasWorkstation bool
+	restockTicks   int
+	checkTicks     int
+
+	trading *world.EntityHandle
+}
+
+// Profession returns the profession of the villager.
+func (v *VillagerBehaviour) Profession() VillagerProfession {
+	return v.profession
+}
+
+// Workstation returns the position of the workstation claimed by the
+// villager. False is returned if the villager has no workstation.
+func (v *VillagerBehaviour) Workstation() (cube.Pos, bool) {
+	return v.workstation, v.hasWorkstation
+}
+
+// TradeOffers returns the trade offers of the villager.
+func (v *VillagerBehaviour) TradeOffers() []TradeOffer {
+	return slices.Clone(v.offers)
+}
+
+// TradeLevel returns the level of the villager and its trade experience.
+func (v *VillagerBehaviour) TradeLevel() (level, experience int) {
+	return v.level, v.experience
+}
+
+// TradingWith returns the handle of the entity that the villager is currently
+// trading with. False is returned if the villager is not trading.
+func (v *VillagerBehaviour) TradingWith() (*world.EntityHandle, bool) {
+	return v.trading, v.trading != nil
+}
+
+// StartTrading makes the villager start trading with the entity passed.
+// Villagers only trade if they have trade offers and do not trade with more
+// than one player at a time.
+func (v *VillagerBehaviour) StartTrading(e *Ent, trader world.Entity) bool {
+	if v.Dead() || len(v.offers) == 0 {
+		return false
+	}
+	if v.trading != nil && v.trading != trader.H() {
+		if _, ok := v.trading.Entity(e.tx); ok {
+			return false
+		}
+	}
+	v.trading = trader.H()
+	e.updateState()
+	return true
+}
+
+// StopTrading makes the villager stop trading.
+func (v *VillagerBehaviour) StopTrading(e *Ent) {
+	v.trading = nil
+	e.updateState()
+}
+
+// Trade uses the trade offer at the index passed count times, giving the
+// villager trade experience and levelling it up once it has enough
+// experience. If the offer rewards experience, experience orbs are dropped for
+// the trader. Nothing changes if the offer may not be used count times.
+func (v *VillagerBehaviour) Trade(e *Ent, trader world.Entity, index, count int) bool {
+	if v.Dead() || index < 0 || index >= len(v.offers) || v.trading != trader.H() || count < 1 {
+		return false
+	}
+	o := &v.offers[index]
+	if o.Disabled() || o.Level > v.level || (o.MaxUses > 0 && o.Uses+count > o.MaxUses) {
+		return false
+	}
+	o.Uses += count
+	v.experience += o.Experience * count
+
+	pos := e.data.Pos.Add(mgl64.Vec3{0, 0.5})
+	xp := 0
+	if o.RewardExperience {
+		for range count {
+			xp += 3 + rand.IntN(4)
+		}
+	}
+	for v.level < MaxTradeLevel && v.experience >= TradeLevelExperience(v.level+1) {
+		v.level++
+		if !v.custom {
+			v.offers = append(v.offers, newVillagerOffers(v.profession, v.level)...)
+		}
+		xp += 5
+		for _, viewer := range e.tx.Viewers(e.data.Pos) {
+			viewer.ViewEntityAction(e, VillagerHappyAction{})
+		}
+	}
+	for _, orb := range NewExperienceOrbs(pos, xp) {
+		e.tx.AddEntity(orb)
+	}
+	e.updateState()
+	if v.conf.Traded != nil {
+		v.conf.Traded(e, trader, v.offers[index])
+	}
+	return true
+}
+
+// tick checks if the workstation of the villager still exists and restocks
+// the trade offers of the villager.
+func (v *VillagerBehaviour) tick(e *Ent, tx *world.Tx) {
+	if v.checkTicks++; v.checkTicks >= 20 {
+		v.checkTicks = 0
+		if v.hasWorkstation && !v.profession.Workstation(tx.Block(v.workstation)) {
+			v.loseWorkstation(e)
+		}
+	}
+	if v.restockTicks > 0 {
+		v.restockTicks--
+	}
+	if v.custom && v.restockTicks == 0 {
+		v.restock()
+	}
+}
+
+// restockDue checks if any of the trade offers of the villager were used and
+// if enough time passed since the villager last restocked.
+func (v *VillagerBehaviour) restockDue() bool {
+	return v.restockTicks == 0 && slices.ContainsFunc(v.offers, func(o TradeOffer) bool {
+		return o.Uses > 0
+	})
+}
+
+// restock resets the uses of all trade offers of the villager.
+func (v *VillagerBehaviour) restock() {
+	for i := range v.offers {
+		v.offers[i].Uses = 0
+	}
+	v.restockTicks = villagerRestockTicks
+}
+
+// claim makes the villager claim the workstation at the position passed,
+// taking its profession if the villager does not yet have one.
+func (v *VillagerBehaviour) claim(e *Ent, pos cube.Pos) bool {
+	prof, ok := workstationProfession(e.tx.Block(pos))
+	if !ok || !v.canClaim(prof) || v.workstationClaimed(e, pos) {
+		return false
+	}
+	v.workstation, v.hasWorkstation = pos, true
+	if v.profession == ProfessionNone() {
+		v.profession = prof
+		v.offers = newVillagerOffers(prof, 1)
+	}
+	for _, viewer := range e.tx.Viewers(e.data.Pos) {
+		viewer.ViewEntityAction(e, VillagerHappyAction{})
+	}
+	e.updateState()
+	return true
+}
+
+// loseWorkstation makes the villager lose its workstation after it was
+// removed. Villagers that never traded also lose their profession.
+func (v *VillagerBehaviour) loseWorkstation(e *Ent) {
+	v.hasWorkstation = false
+	if v.experience > 0 || v.custom {
+		return
+	}
+	v.profession, v.level, v.offers = ProfessionNone(), 1, nil
+	e.updateState()
+}
+
+// canClaim checks if the villager may claim workstations of the profession
+// passed.
+func (v *VillagerBehaviour) canClaim(prof VillagerProfession) bool {
+	if v.custom || v.hasWorkstation {
+		return false
+	}
+	return v.profession == ProfessionNone() || v.profession == prof
+}
+
+// workstationClaimed checks if the workstation at the position passed is
+// claimed by another villager.
+func (v *VillagerBehaviour) workstationClaimed(e *Ent, pos cube.Pos) bool {
+	box := cube.Box(0, 0, 0, 1, 1, 1).Translate(pos.Vec3()).Grow(villagerWorkstationDistance * 2)
+	for other := range e.tx.EntitiesWithin(box) {
+		if ov, ok := villagerOf(other); ok && ov != v && ov.hasWorkstation && ov.workstation == pos {
+			return true
+		}
+	}
+	return false
+}
+
+// villagerOf returns the VillagerBehaviour of the entity passed, if it is a
+// villager.
+func villagerOf(e world.Entity) (*VillagerBehaviour, bool) {
+	if ent, ok := e.(*Ent); ok {
+		v, ok := ent.Behaviour().(*VillagerBehaviour)
+		return v, ok
+	}
+	return nil, false
+}
+
+// villagerTradeGoal is a Goal that makes a villager stand still and look at
+// the player that it is trading with.
+type villagerTradeGoal struct {
+	v *VillagerBehaviour
+}
+
+// Controls ...
+func (g *villagerTradeGoal) Controls() GoalControl {
+	return GoalControlMove | GoalControlLook | GoalControlJump
+}
+
+// CanStart ...
+func (g *villagerTradeGoal) CanStart(m *Mob) bool {
+	_, ok := g.trader(m)
+	return ok
+}
+
+// CanContinue ...
+func (g *villagerTradeGoal) CanContinue(m *Mob) bool {
+	return g.CanStart(m)
+}
+
+// Start ...
+func (g *villagerTradeGoal) Start(m *Mob) { m.StopMoving() }
+
+// Tick ...
+func (g *villagerTradeGoal) Tick(m *Mob) {
+	if trader, ok := g.trader(m); ok {
+		m.LookAt(eyePosition(trader))
+	}
+}
+
+// Stop ...
+func (g *villagerTradeGoal) Stop(*Mob) {}
+
+// trader returns the entity that the villager is trading with.
+func (g *villagerTradeGoal) trader(m *Mob) (world.Entity, bool) {
+	if g.v.trading == nil {
+		return nil, false
+	}
+	return g.v.trading.Entity(m.tx)
+}
+
+// villagerWorkstationGoal is a Goal that makes a villager walk to an
+// unclaimed workstation nearby to claim it, or to its own workstation to
+// restock its trade offers.
+type villagerWorkstationGoal struct {
+	v      *VillagerBehaviour
+	target cube.Pos
+	claim  bool
+	done   bool
+	ticks  int
+}
+
+// Controls ...
+func (g *villagerWorkstationGoal) Controls() GoalControl {
+	return GoalControlMove | GoalControlLook
+}
+
+// CanStart ...
+func (g *villagerWorkstationGoal) CanStart(m *Mob) bool {
+	v := g.v
+	if v.trading != nil || v.profession == ProfessionNitwit() {
+		return false
+	}
+	switch {
+	case v.hasWorkstation && v.restockDue():
+		if rand.IntN(20) != 0 {
+			return false
+		}
+		g.target, g.claim = v.workstation, false
+	case v.canClaim(v.profession):
+		if rand.IntN(40) != 0 {
+			return false
+		}
+		pos, ok := g.findWorkstation(m)
+		if !ok {
+			return false
+		}
+		g.target, g.claim = pos, true
+	default:
+		return false
+	}
+	if g.arrived(m) {
+		g.done, g.ticks = false, 0
+		return true
+	}
+	for _, face := range cube.HorizontalFaces() {
+		if dst, ok := groundBelow(m.tx, g.target.Side(face), 1); ok && m.MoveTo(dst, 1) {
+			g.done, g.ticks = false, 0
+			return true
+		}
+	}
+	return false
+}
+
+// CanContinue ...
+func (g *villagerWorkstationGoal) CanContinue(m *Mob) bool {
+	return !g.done && g.ticks < 400 && (m.Moving() || g.arrived(m))
+}
+
+// Start ...
+func (g *villagerWorkstationGoal) Start(*Mob) {}
+
+// Tick ...
+func (g *villagerWorkstationGoal) Tick(m *Mob) {
+	g.ticks++
+	m.LookAt(g.target.Vec3Centre())
+	if !g.arrived(m) {
+		return
+	}
+	g.done = true
+	m.StopMoving()
+	if g.claim {
+		g.v.claim(m.e, g.target)
+	} else if g.v.hasWorkstation && g.v.workstation == g.target {
+		g.v.restock()
+	}
+}
+
+// Stop ...
+func (g *villagerWorkstationGoal) Stop(m *Mob) { m.StopMoving() }
+
+// arrived checks if the villager is close enough to the target workstation
+// to use it.
+func (g *villagerWorkstationGoal) arrived(m *Mob) bool {
+	return m.Position().Sub(g.target.Vec3Centre()).Len() < 2
+}
+
+// findWorkstation finds the nearest unclaimed workstation around the
+// villager that it may claim.
+func (g *villagerWorkstationGoal) findWorkstation(m *Mob) (cube.Pos, bool) {
+	centre := cube.PosFromVec3(m.Position())
+	var (
+		nearest cube.Pos
+		dist    = -1.0
+	)
+	for y := -4; y <= 4; y++ {
+		for x := -villagerWorkstationDistance; x <= villagerWorkstationDistance; x++ {
+			for z := -villagerWorkstationDistance; z <= villagerWorkstationDistance; z++ {
+				pos := centre.Add(cube.Pos{x, y, z})
+				if pos.OutOfBounds(m.tx.Range()) {
+					continue
+				}
+				prof, ok := workstationProfession(m.tx.Block(pos))
+				if !ok || !g.v.canClaim(prof) {
+					continue
+				}
+				d := pos.Vec3Centre().Sub(m.Position()).Len()
+				if (dist < 0 || d < dist) && !g.v.workstationClaimed(m.e, pos) {
+					nearest, dist = pos, d
+				}
+			}
+		}
+	}
+	return nearest, dist >= 0
+}
+
+// VillagerType is a world.EntityType implementation for villagers.
+var VillagerType villagerType
+
+type villagerType struct{}
+
+func (villagerType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (villagerType) EncodeEntity() string { return "minecraft:villager_v2" }
+func (villagerType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.9, 0.3)
+}
+
+func (villagerType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	v := VillagerBehaviourConfig{}.New()
+	decodeMobNBT(m, v.MobBehaviour)
+	v.profession = villagerProfessionByName(nbtconv.String(m, "PreferredProfession"))
+	v.level = min(max(int(nbtconv.Int32(m, "TradeTier"))+1, 1), MaxTradeLevel)
+	v.experience = int(nbtconv.Int32(m, "TradeExperience"))
+	v.custom = nbtconv.Bool(m, "CustomOffers")
+	if offers, ok := m["Offers"].(map[string]any); ok {
+		v.offers = decodeTradeOffers(nbtconv.Slice(offers, "Recipes"))
+	}
+	if _, ok := m["Workstation"]; ok {
+		v.workstation, v.hasWorkstation = nbtconv.Pos(m, "Workstation"), true
+	}
+	data.Data = v
+}
+
+func (villagerType) EncodeNBT(data *world.EntityData) map[string]any {
+	v := data.Data.(*VillagerBehaviour)
+	m := encodeMobNBT(v.MobBehaviour)
+	m["PreferredProfession"] = v.profession.String()
+	m["TradeTier"] = int32(v.level - 1)
+	m["TradeExperience"] = int32(v.experience)
+	m["CustomOffers"] = boolByte(v.custom)
+	m["Offers"] = map[string]any{"Recipes": encodeTradeOffers(v.offers)}
+	if v.hasWorkstation {
+		m["Workstation"] = nbtconv.PosToInt32Slice(v.workstation)
+	}
+	return m
+}
@@ -0,0 +1,223 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// VillagerProfession is the profession of a villager. The profession of a villager decides the trade offers that
+// it has. Villagers without a profession take the profession of the first unclaimed workstation that they find.
+type VillagerProfession struct {
+	villagerProfession
+}
+
+type villagerProfession uint8
+
+// ProfessionNone is the profession of villagers that did not yet claim a workstation. Villagers without a
+// profession do not trade.
+func ProfessionNone() VillagerProfession {
+	return VillagerProfession{0}
+}
+
+// ProfessionFarmer is the profession of villagers that claimed a composter.
+func ProfessionFarmer() VillagerProfession {
+	return VillagerProfession{1}
+}
+
+// ProfessionFisherman is the profession of villagers that claimed a barrel.
+func ProfessionFisherman() VillagerProfession {
+	return VillagerProfession{2}
+}
+
+// ProfessionShepherd is the profession of villagers that claimed a loom.
+func ProfessionShepherd() VillagerProfession {
+	return VillagerProfession{3}
+}
+
+// ProfessionFletcher is the profession of villagers that claimed a fletching table.
+func ProfessionFletcher() VillagerProfession {
+	return VillagerProfession{4}
+}
+
+// ProfessionLibrarian is the profession of villagers that claimed a lectern.
+func ProfessionLibrarian() VillagerProfession {
+	return VillagerProfession{5}
+}
+
+// ProfessionCartographer is the profession of villagers that claimed a cartography table.
+func ProfessionCartographer() VillagerProfession {
+	return VillagerProfession{6}
+}
+
+// ProfessionCleric is the profession of villagers that claimed a brewing stand.
+func ProfessionCleric() VillagerProfession {
+	return VillagerProfession{7}
+}
+
+// ProfessionArmourer is the profession of villagers that claimed a blast furnace.
+func ProfessionArmourer() VillagerProfession {
+	return VillagerProfession{8}
+}
+
+// ProfessionWeaponsmith is the profession of villagers that claimed a grindstone.
+func ProfessionWeaponsmith() VillagerProfession {
+	return VillagerProfession{9}
+}
+
+// ProfessionToolsmith is the profession of villagers that claimed a smithing table.
+func ProfessionToolsmith() VillagerProfession {
+	return VillagerProfession{10}
+}
+
+// ProfessionButcher is the profession of villagers that claimed a smoker.
+func ProfessionButcher() VillagerProfession {
+	return VillagerProfession{11}
+}
+
+// ProfessionLeatherworker is the profession of villagers that claimed a cauldron.
+func ProfessionLeatherworker() VillagerProfession {
+	return VillagerProfession{12}
+}
+
+// ProfessionMason is the profession of villagers that claimed a stonecutter.
+func ProfessionMason() VillagerProfession {
+	return VillagerProfession{13}
+}
+
+// ProfessionNitwit is the profession of villagers that never claim a workstation and never trade.
+func ProfessionNitwit() VillagerProfession {
+	return VillagerProfession{14}
+}
+
+// VillagerProfessions returns all villager professions.
+func VillagerProfessions() []VillagerProfession {
+	return []VillagerProfession{
+		ProfessionNone(), ProfessionFarmer(), ProfessionFisherman(), ProfessionShepherd(), ProfessionFletcher(),
+		ProfessionLibrarian(), ProfessionCartographer(), ProfessionCleric(), ProfessionArmourer(),
+		ProfessionWeaponsmith(), ProfessionToolsmith(), ProfessionButcher(), ProfessionLeatherworker(),
+		ProfessionMason(), ProfessionNitwit(),
+	}
+}
+
+// Uint8 returns the profession as a uint8.
+func (p villagerProfession) Uint8() uint8 {
+	return uint8(p)
+}
+
+// Name returns the name of the profession, such as "Farmer".
+func (p villagerProfession) Name() string {
+	switch p {
+	case 1:
+		return "Farmer"
+	case 2:
+		return "Fisherman"
+	case 3:
+		return "Shepherd"
+	case 4:
+		return "Fletcher"
+	case 5:
+		return "Librarian"
+	case 6:
+		return "Cartographer"
+	case 7:
+		return "Cleric"
+	case 8:
+		return "Armorer"
+	case 9:
+		return "Weaponsmith"
+	case 10:
+		return "Toolsmith"
+	case 11:
+		return "Butcher"
+	case 12:
+		return "Leatherworker"
+	case 13:
+		return "Mason"
+	case 14:
+		return "Nitwit"
+	}
+	return "Villager"
+}
+
+// String returns the identifier of the profession used in NBT, such as "farmer".
+func (p villagerProfession) String() string {
+	switch p {
+	case 1:
+		return "farmer"
+	case 2:
+		return "fisherman"
+	case 3:
+		return "shepherd"
+	case 4:
+		return "fletcher"
+	case 5:
+		return "librarian"
+	case 6:
+		return "cartographer"
+	case 7:
+		return "cleric"
+	case 8:
+		return "armorer"
+	case 9:
+		return "weaponsmith"
+	case 10:
+		return "toolsmith"
+	case 11:
+		return "butcher"
+	case 12:
+		return "leatherworker"
+	case 13:
+		return "mason"
+	case 14:
+		return "nitwit"
+	}
+	return "none"
+}
+
+// Workstation checks if the block passed is the workstation of villagers with the profession. Villagers keep
+// their profession only as long as their workstation exists, unless they traded before.
+func (p villagerProfession) Workstation(b world.Block) bool {
+	prof, ok := workstationProfession(b)
+	return ok && prof.villagerProfession == p
+}
+
+// workstationProfession returns the VillagerProfession given to villagers that claim the block passed as their
+// workstation. False is returned if the block is not a workstation.
+func workstationProfession(b world.Block) (VillagerProfession, bool) {
+	switch b.(type) {
+	case block.Composter:
+		return ProfessionFarmer(), true
+	case block.Barrel:
+		return ProfessionFisherman(), true
+	case block.Loom:
+		return ProfessionShepherd(), true
+	case block.FletchingTable:
+		return ProfessionFletcher(), true
+	case block.Lectern:
+		return ProfessionLibrarian(), true
+	case block.BrewingStand:
+		return ProfessionCleric(), true
+	case block.BlastFurnace:
+		return ProfessionArmourer(), true
+	case block.Grindstone:
+		return ProfessionWeaponsmith(), true
+	case block.SmithingTable:
+		return ProfessionToolsmith(), true
+	case block.Smoker:
+		return ProfessionButcher(), true
+	case block.Stonecutter:
+		return ProfessionMason(), true
+	}
+	return VillagerProfession{}, false
+}
+
+// villagerProfessionByName returns the VillagerProfession with the identifier passed, as returned by
+// VillagerProfession.String. ProfessionNone is returned if no profession has the identifier.
+func villagerProfessionByName(name string) VillagerProfession {
+	for _, p := range VillagerProfessions() {
+		if p.String() == name {
+			return p
+		}
+	}
+	return ProfessionNone()
+}
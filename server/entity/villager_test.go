@@ -0,0 +1,176 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestVillagerClaimsWorkstation(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		ws := cube.Pos{2, 64, 0}
+		tx.SetBlock(ws, block.Composter{}, nil)
+
+		a := tx.AddEntity(NewVillager(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}})).(*Ent)
+		b := tx.AddEntity(NewVillager(world.EntitySpawnOpts{Position: mgl64.Vec3{4.5, 64, 0.5}})).(*Ent)
+		av, bv := a.Behaviour().(*VillagerBehaviour), b.Behaviour().(*VillagerBehaviour)
+		if av.StartTrading(a, b) {
+			t.Fatalf("villager without a profession started trading, want no trades")
+		}
+
+		if !av.claim(a, ws) {
+			t.Fatalf("villager could not claim unclaimed composter")
+		}
+		if p := av.Profession(); p != ProfessionFarmer() {
+			t.Fatalf("profession after claiming composter = %v, want %v", p, ProfessionFarmer())
+		}
+		if len(av.TradeOffers()) == 0 {
+			t.Fatalf("farmer has no trade offers after claiming composter")
+		}
+		if bv.claim(b, ws) {
+			t.Fatalf("villager claimed composter already claimed by another villager")
+		}
+
+		tx.SetBlock(ws, nil, nil)
+		for i := range int64(20) {
+			a.Tick(tx, i)
+		}
+		if _, ok := av.Workstation(); ok || av.Profession() != ProfessionNone() {
+			t.Errorf("villager kept profession %v after its workstation was removed, want none", av.Profession())
+		}
+	})
+}
+
+func TestVillagerTradeLevelsUp(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		trader := tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{2.5, 64, 0.5}}))
+		e := tx.AddEntity(NewVillager(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}})).(*Ent)
+		v := e.Behaviour().(*VillagerBehaviour)
+		v.profession, v.offers = ProfessionFletcher(), []TradeOffer{buyOffer(item.Stick{}, 32, 16, 2)}
+
+		if v.Trade(e, trader, 0, 1) {
+			t.Fatalf("villager traded before trading was started")
+		}
+		if !v.StartTrading(e, trader) {
+			t.Fatalf("villager with trade offers did not start trading")
+		}
+		if other := tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{4.5, 64, 0.5}})); v.StartTrading(e, other) {
+			t.Fatalf("villager started trading with a second entity")
+		}
+		for _, count := range []int{2, 3} {
+			if !v.Trade(e, trader, 0, count) {
+				t.Fatalf("trading %v times failed, want success", count)
+			}
+		}
+		if o := v.TradeOffers()[0]; o.Uses != 5 {
+			t.Fatalf("offer uses after 5 trades = %v, want 5", o.Uses)
+		}
+		if level, experience := v.TradeLevel(); level != 2 || experience != 10 {
+			t.Fatalf("level and experience after 5 trades = %v, %v, want 2, 10", level, experience)
+		}
+		if n := len(v.TradeOffers()); n != 3 {
+			t.Errorf("offer count after levelling up = %v, want 3", n)
+		}
+
+		v.StopTrading(e)
+		if _, ok := v.TradingWith(); ok {
+			t.Errorf("villager still trading after StopTrading")
+		}
+	})
+}
+
+func TestVillagerCustomOffers(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		tx.SetBlock(cube.Pos{1, 64, 0}, block.Lectern{}, nil)
+
+		var traded []TradeOffer
+		conf := VillagerBehaviourConfig{
+			Offers: []TradeOffer{
+				{Input: item.NewStack(item.Emerald{}, 5), Output: item.NewStack(item.Diamond{}, 1), MaxUses: 1},
+				{Input: item.NewStack(item.Emerald{}, 20), Output: item.NewStack(item.Elytra{}, 1), Level: 2},
+			},
+			Traded: func(_ *Ent, _ world.Entity, o TradeOffer) { traded = append(traded, o) },
+		}
+		trader := tx.AddEntity(NewCow(world.EntitySpawnOpts{Position: mgl64.Vec3{2.5, 64, 0.5}}))
+		e := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}}.New(VillagerType, conf)).(*Ent)
+		v := e.Behaviour().(*VillagerBehaviour)
+
+		if v.claim(e, cube.Pos{1, 64, 0}) || v.canClaim(ProfessionLibrarian()) {
+			t.Fatalf("villager with custom offers claimed a workstation")
+		}
+		if !v.StartTrading(e, trader) {
+			t.Fatalf("villager with custom offers did not start trading")
+		}
+		if v.Trade(e, trader, 0, 2) || len(traded) != 0 || v.TradeOffers()[0].Uses != 0 {
+			t.Fatalf("trading custom offer more times than its max uses changed the offer: traded = %v", traded)
+		}
+		if !v.Trade(e, trader, 0, 1) || len(traded) != 1 || traded[0].Uses != 1 {
+			t.Fatalf("trading custom offer: traded = %v, want one call with 1 use", traded)
+		}
+		if v.Trade(e, trader, 0, 1) {
+			t.Errorf("traded offer past its max uses")
+		}
+		if v.Trade(e, trader, 1, 1) {
+			t.Errorf("traded offer above the level of the villager")
+		}
+		if n := len(v.TradeOffers()); n != 2 {
+			t.Errorf("custom offer count = %v, want 2", n)
+		}
+	})
+}
+
+func TestVillagerNBT(t *testing.T) {
+	conf := VillagerBehaviourConfig{
+		Profession: ProfessionLibrarian(),
+		Level:      3,
+		Offers: []TradeOffer{
+			{Input: item.NewStack(item.Emerald{}, 3), SecondInput: item.NewStack(item.Book{}, 1), Output: item.NewStack(block.Bookshelf{}, 1), MaxUses: 12, Uses: 4, Experience: 10, RewardExperience: true, Level: 2},
+			{Input: item.NewStack(item.Paper{}, 24), Output: item.NewStack(item.Emerald{}, 1)},
+		},
+	}
+	v := conf.New()
+	v.workstation, v.hasWorkstation = cube.Pos{1, 2, 3}, true
+
+	data := &world.EntityData{}
+	VillagerType.DecodeNBT(VillagerType.EncodeNBT(&world.EntityData{Data: v}), data)
+	d := data.Data.(*VillagerBehaviour)
+
+	if d.Profession() != v.Profession() || !d.custom {
+		t.Errorf("decoded profession %v (custom %v), want %v (custom true)", d.Profession(), d.custom, v.Profession())
+	}
+	if dl, de := d.TradeLevel(); dl != 3 || de != TradeLevelExperience(3) {
+		t.Errorf("decoded level and experience = %v, %v, want 3, %v", dl, de, TradeLevelExperience(3))
+	}
+	if pos, ok := d.Workstation(); !ok || pos != v.workstation {
+		t.Errorf("decoded workstation = %v (%v), want %v", pos, ok, v.workstation)
+	}
+	offers := d.TradeOffers()
+	if len(offers) != len(conf.Offers) {
+		t.Fatalf("decoded %v offers, want %v", len(offers), len(conf.Offers))
+	}
+	for i, o := range offers {
+		want := conf.Offers[i]
+		if !o.Input.Equal(want.Input) || !o.SecondInput.Equal(want.SecondInput) || !o.Output.Equal(want.Output) {
+			t.Errorf("decoded offer %v items = %v + %v -> %v, want %v + %v -> %v", i, o.Input, o.SecondInput, o.Output, want.Input, want.SecondInput, want.Output)
+		}
+		if want.Level == 0 {
+			want.Level = 1
+		}
+		if o.MaxUses != want.MaxUses || o.Uses != want.Uses || o.Experience != want.Experience || o.RewardExperience != want.RewardExperience || o.Level != want.Level {
+			t.Errorf("decoded offer %v = %+v, want %+v", i, o, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package entity
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// villagerTrades holds the trade offers that villagers of a profession may unlock at every level. Villagers
+// unlock two random offers out of those of a level when they reach it.
+var villagerTrades = map[VillagerProfession][5][]TradeOffer{
+	ProfessionFarmer(): {
+		{buyOffer(item.Wheat{}, 20, 16, 2), buyOffer(block.Potato{}, 26, 16, 2), buyOffer(block.Carrot{}, 22, 16, 2), buyOffer(item.Beetroot{}, 15, 16, 2), sellOffer(1, item.Bread{}, 6, 16, 1)},
+		{buyOffer(block.Pumpkin{}, 6, 12, 10), sellOffer(1, item.PumpkinPie{}, 4, 12, 5), sellOffer(1, item.Apple{}, 4, 16, 5)},
+		{sellOffer(3, item.Cookie{}, 18, 12, 10), buyOffer(block.Melon{}, 4, 12, 20)},
+		{sellOffer(1, block.Cake{}, 1, 12, 15)},
+		{sellOffer(3, item.GoldenCarrot{}, 3, 12, 30), sellOffer(4, item.GlisteringMelonSlice{}, 3, 12, 30)},
+	},
+	ProfessionFisherman(): {
+		{buyOffer(block.String{}, 20, 16, 2), buyOffer(item.Coal{}, 10, 16, 2), sellOffer(1, item.Cod{Cooked: true}, 6, 16, 1)},
+		{buyOffer(item.Cod{}, 15, 16, 10), sellOffer(1, item.Salmon{Cooked: true}, 6, 16, 5)},
+		{buyOffer(item.Salmon{}, 13, 16, 20)},
+		{buyOffer(item.TropicalFish{}, 6, 12, 30)},
+		{buyOffer(item.Pufferfish{}, 4, 12, 30)},
+	},
+	ProfessionShepherd(): {
+		{buyOffer(block.Wool{Colour: item.ColourWhite()}, 18, 16, 2), buyOffer(block.Wool{Colour: item.ColourBrown()}, 18, 16, 2), buyOffer(block.Wool{Colour: item.ColourBlack()}, 18, 16, 2), sellOffer(2, item.Shears{}, 1, 12, 1)},
+		{buyOffer(item.Dye{Colour: item.ColourWhite()}, 12, 16, 10), buyOffer(item.Dye{Colour: item.ColourGrey()}, 12, 16, 10), sellOffer(1, block.Wool{Colour: item.ColourWhite()}, 1, 16, 5), sellOffer(1, block.Carpet{Colour: item.ColourWhite()}, 4, 16, 5)},
+		{buyOffer(item.Dye{Colour: item.ColourYellow()}, 12, 16, 20), buyOffer(item.Dye{Colour: item.ColourLightBlue()}, 12, 16, 20), sellOffer(3, block.Bed{Colour: item.ColourWhite()}, 1, 12, 10)},
+		{buyOffer(item.Dye{Colour: item.ColourPurple()}, 12, 16, 30), sellOffer(2, block.Carpet{Colour: item.ColourPink()}, 4, 12, 15)},
+		{sellOffer(2, block.Wool{Colour: item.ColourPink()}, 1, 12, 30)},
+	},
+	ProfessionFletcher(): {
+		{buyOffer(item.Stick{}, 32, 16, 2), sellOffer(1, item.Arrow{}, 16, 12, 1)},
+		{buyOffer(item.Flint{}, 26, 12, 10), sellOffer(2, item.Bow{}, 1, 12, 5)},
+		{buyOffer(block.String{}, 14, 16, 20), sellOffer(3, item.Crossbow{}, 1, 12, 10)},
+		{buyOffer(item.Feather{}, 24, 16, 30)},
+		{sellOffer(4, item.Arrow{}, 8, 12, 30)},
+	},
+	ProfessionLibrarian(): {
+		{buyOffer(item.Paper{}, 24, 16, 2), sellOffer(9, block.Bookshelf{}, 1, 12, 1)},
+		{buyOffer(item.Book{}, 4, 12, 10), sellOffer(1, block.Lantern{Type: block.NormalFire()}, 1, 12, 5)},
+		{buyOffer(item.InkSac{}, 5, 12, 20), sellOffer(1, block.Glass{}, 4, 12, 10)},
+		{buyOffer(item.BookAndQuill{}, 2, 12, 30), sellOffer(5, item.Clock{}, 1, 12, 15), sellOffer(4, item.Compass{}, 1, 12, 15)},
+		{sellOffer(20, item.BookAndQuill{}, 1, 12, 30), sellOffer(1, item.Paper{}, 8, 12, 30)},
+	},
+	ProfessionCartographer(): {
+		{buyOffer(item.Paper{}, 24, 16, 2)},
+		{buyOffer(block.GlassPane{}, 11, 16, 10)},
+		{buyOffer(item.Compass{}, 1, 12, 20)},
+		{sellOffer(7, block.ItemFrame{}, 1, 12, 15), sellOffer(2, item.Paper{}, 12, 12, 15)},
+		{sellOffer(8, item.Compass{}, 1, 12, 30)},
+	},
+	ProfessionCleric(): {
+		{buyOffer(item.RottenFlesh{}, 32, 16, 2), sellOffer(1, item.RedstoneWire{}, 2, 12, 1)},
+		{buyOffer(item.GoldIngot{}, 3, 12, 10), sellOffer(1, item.LapisLazuli{}, 1, 12, 5)},
+		{buyOffer(item.RabbitFoot{}, 2, 12, 20), sellOffer(4, block.Glowstone{}, 1, 12, 10)},
+		{buyOffer(item.Scute{}, 4, 12, 30), buyOffer(item.GlassBottle{}, 9, 12, 30), sellOffer(5, item.EnderPearl{}, 1, 12, 15)},
+		{buyOffer(block.NetherWart{}, 22, 12, 30), sellOffer(3, item.BottleOfEnchanting{}, 1, 12, 30)},
+	},
+	ProfessionArmourer(): {
+		{buyOffer(item.Coal{}, 15, 16, 2), sellOffer(7, item.Leggings{Tier: item.ArmourTierIron{}}, 1, 12, 1), sellOffer(4, item.Boots{Tier: item.ArmourTierIron{}}, 1, 12, 1), sellOffer(5, item.Helmet{Tier: item.ArmourTierIron{}}, 1, 12, 1), sellOffer(9, item.Chestplate{Tier: item.ArmourTierIron{}}, 1, 12, 1)},
+		{buyOffer(item.IronIngot{}, 4, 12, 10), sellOffer(1, item.Boots{Tier: item.ArmourTierChain{}}, 1, 12, 5), sellOffer(3, item.Leggings{Tier: item.ArmourTierChain{}}, 1, 12, 5)},
+		{buyOffer(item.Bucket{Content: item.LiquidBucketContent(block.Lava{Still: true, Depth: 8})}, 1, 12, 20), buyOffer(item.Diamond{}, 1, 12, 20), sellOffer(1, item.Helmet{Tier: item.ArmourTierChain{}}, 1, 12, 10), sellOffer(4, item.Chestplate{Tier: item.ArmourTierChain{}}, 1, 12, 10)},
+		{sellOffer(19, item.Leggings{Tier: item.ArmourTierDiamond{}}, 1, 3, 15), sellOffer(13, item.Boots{Tier: item.ArmourTierDiamond{}}, 1, 3, 15)},
+		{sellOffer(13, item.Helmet{Tier: item.ArmourTierDiamond{}}, 1, 3, 30), sellOffer(21, item.Chestplate{Tier: item.ArmourTierDiamond{}}, 1, 3, 30)},
+	},
+	ProfessionWeaponsmith(): {
+		{buyOffer(item.Coal{}, 15, 16, 2), sellOffer(3, item.Axe{Tier: item.ToolTierIron}, 1, 12, 1)},
+		{buyOffer(item.IronIngot{}, 4, 12, 10), sellOffer(2, item.Sword{Tier: item.ToolTierIron}, 1, 12, 5)},
+		{buyOffer(item.Flint{}, 24, 12, 20)},
+		{buyOffer(item.Diamond{}, 1, 12, 30), sellOffer(17, item.Axe{Tier: item.ToolTierDiamond}, 1, 3, 15)},
+		{sellOffer(13, item.Sword{Tier: item.ToolTierDiamond}, 1, 3, 30)},
+	},
+	ProfessionToolsmith(): {
+		{buyOffer(item.Coal{}, 15, 16, 2), sellOffer(1, item.Axe{Tier: item.ToolTierStone}, 1, 12, 1), sellOffer(1, item.Shovel{Tier: item.ToolTierStone}, 1, 12, 1), sellOffer(1, item.Pickaxe{Tier: item.ToolTierStone}, 1, 12, 1), sellOffer(1, item.Hoe{Tier: item.ToolTierStone}, 1, 12, 1)},
+		{buyOffer(item.IronIngot{}, 4, 12, 10)},
+		{buyOffer(item.Flint{}, 30, 12, 20), sellOffer(2, item.Shovel{Tier: item.ToolTierIron}, 1, 3, 10), sellOffer(3, item.Pickaxe{Tier: item.ToolTierIron}, 1, 3, 10)},
+		{buyOffer(item.Diamond{}, 1, 12, 30), sellOffer(4, item.Hoe{Tier: item.ToolTierDiamond}, 1, 3, 15)},
+		{sellOffer(18, item.Pickaxe{Tier: item.ToolTierDiamond}, 1, 3, 30)},
+	},
+	ProfessionButcher(): {
+		{buyOffer(item.Chicken{}, 14, 16, 2), buyOffer(item.Porkchop{}, 7, 16, 2), buyOffer(item.Rabbit{}, 4, 16, 2), sellOffer(1, item.RabbitStew{}, 1, 12, 1)},
+		{buyOffer(item.Coal{}, 15, 16, 2), sellOffer(1, item.Porkchop{Cooked: true}, 5, 16, 5), sellOffer(1, item.Chicken{Cooked: true}, 8, 16, 5)},
+		{buyOffer(item.Mutton{}, 7, 16, 20), buyOffer(item.Beef{}, 10, 16, 20)},
+		{buyOffer(block.DriedKelp{}, 10, 12, 30)},
+		{buyOffer(item.Beetroot{}, 15, 12, 30)},
+	},
+	ProfessionLeatherworker(): {
+		{buyOffer(item.Leather{}, 6, 16, 2), sellOffer(3, item.Leggings{Tier: item.ArmourTierLeather{}}, 1, 12, 1), sellOffer(7, item.Chestplate{Tier: item.ArmourTierLeather{}}, 1, 12, 1)},
+		{buyOffer(item.Flint{}, 26, 12, 10), sellOffer(5, item.Helmet{Tier: item.ArmourTierLeather{}}, 1, 12, 5), sellOffer(4, item.Boots{Tier: item.ArmourTierLeather{}}, 1, 12, 5)},
+		{buyOffer(item.RabbitHide{}, 9, 12, 20), sellOffer(7, item.Chestplate{Tier: item.ArmourTierLeather{}}, 1, 12, 10)},
+		{buyOffer(item.Scute{}, 4, 12, 30)},
+		{sellOffer(5, item.Helmet{Tier: item.ArmourTierLeather{}}, 1, 12, 30)},
+	},
+	ProfessionMason(): {
+		{buyOffer(item.ClayBall{}, 10, 16, 2), sellOffer(1, item.Brick{}, 10, 16, 1)},
+		{buyOffer(block.Stone{}, 20, 16, 10), sellOffer(1, block.StoneBricks{}, 4, 16, 5)},
+		{buyOffer(block.Granite{}, 16, 16, 20), sellOffer(1, block.Andesite{}, 4, 16, 10)},
+		{buyOffer(item.NetherQuartz{}, 12, 12, 30), sellOffer(1, block.Terracotta{}, 1, 12, 15)},
+		{sellOffer(1, block.Quartz{}, 1, 12, 30)},
+	},
+}
+
+// buyOffer returns a TradeOffer in which a villager buys count of the item passed for an emerald.
+func buyOffer(it world.Item, count, maxUses, experience int) TradeOffer {
+	return TradeOffer{
+		Input:            item.NewStack(it, count),
+		Output:           item.NewStack(item.Emerald{}, 1),
+		MaxUses:          maxUses,
+		Experience:       experience,
+		RewardExperience: true,
+	}
+}
+
+// sellOffer returns a TradeOffer in which a villager sells count of the item passed for emeralds.
+func sellOffer(emeralds int, it world.Item, count, maxUses, experience int) TradeOffer {
+	return TradeOffer{
+		Input:            item.NewStack(item.Emerald{}, emeralds),
+		Output:           item.NewStack(it, count),
+		MaxUses:          maxUses,
+		Experience:       experience,
+		RewardExperience: true,
+	}
+}
+
+// newVillagerOffers returns two random trade offers that a villager of the profession passed unlocks at the level
+// passed.
+func newVillagerOffers(p VillagerProfession, level int) []TradeOffer {
+	trades, ok := villagerTrades[p]
+	if !ok || level < 1 || level > len(trades) {
+		return nil
+	}
+	pool := trades[level-1]
+	offers := make([]TradeOffer, 0, 2)
+	for _, i := range rand.Perm(len(pool)) {
+		if len(offers) == 2 {
+			break
+		}
+		o := pool[i]
+		o.Level = level
+		offers = append(offers, o)
+	}
+	return offers
+}
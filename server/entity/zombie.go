@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewZombie creates a new zombie. One in twenty zombies created is a baby
+// zombie, which is smaller and faster than a regular zombie.
+func NewZombie(opts world.EntitySpawnOpts) *world.EntityHandle {
+	conf := zombieConf
+	conf.Baby = rand.IntN(20) == 0
+	return opts.New(ZombieType, zombieBehaviourConfig(conf))
+}
+
+var zombieConf = MobBehaviourConfig{
+	Speed:           0.23,
+	AttackDamage:    3,
+	BurnsInDaylight: true,
+	Drops:           []MobDrop{{Item: item.RottenFlesh{}, Max: 2}},
+	Experience:      5,
+	Goals: func() []PrioritisedGoal {
+		return []PrioritisedGoal{
+			{Priority: 0, Goal: &FloatGoal{}},
+			{Priority: 2, Goal: &MeleeAttackGoal{}},
+			{Priority: 5, Goal: &WanderGoal{}},
+			{Priority: 6, Goal: &LookAtPlayerGoal{}},
+		}
+	},
+	Targets: hostileTargets,
+}
+
+// zombieBehaviourConfig returns conf with the speed of baby zombies applied
+// if the zombie is a baby.
+func zombieBehaviourConfig(conf MobBehaviourConfig) MobBehaviourConfig {
+	if conf.Baby {
+		conf.Speed *= 1.5
+	}
+	return conf
+}
+
+// ZombieType is a world.EntityType implementation for zombies.
+var ZombieType zombieType
+
+type zombieType struct{}
+
+func (zombieType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (zombieType) EncodeEntity() string           { return "minecraft:zombie" }
+func (zombieType) MobCategory() world.MobCategory { return world.MobCategoryMonster }
+func (zombieType) BBox(e world.Entity) cube.BBox {
+	if isBaby(e) {
+		return cube.Box(-0.15, 0, -0.15, 0.15, 0.95, 0.15)
+	}
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.9, 0.3)
+}
+
+func (zombieType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := zombieConf
+	conf.Baby = nbtconv.Bool(m, "IsBaby")
+	b := zombieBehaviourConfig(conf).New()
+	decodeMobNBT(m, b)
+	data.Data = b
+}
+
+func (zombieType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*MobBehaviour)
+	m := encodeMobNBT(b)
+	m["IsBaby"] = boolByte(b.Baby())
+	return m
+}
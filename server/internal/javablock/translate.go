@@ -0,0 +1,396 @@
+package javablock
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/worldupgrader/blockupgrader"
+)
+
+// Translator translates Java Edition block states to blocks registered in a world.BlockRegistry. A Translator is
+// safe for concurrent use.
+type Translator struct {
+	states map[string][]state
+	water  world.Liquid
+}
+
+// state is a block state registered in a world.BlockRegistry.
+type state struct {
+	b          world.Block
+	properties map[string]any
+}
+
+// NewTranslator creates a Translator that translates Java Edition block states to the blocks registered in the
+// world.BlockRegistry passed. The registry must be finalised.
+func NewTranslator(br world.BlockRegistry) *Translator {
+	t := &Translator{states: make(map[string][]state)}
+	for _, b := range br.Blocks() {
+		name, properties := b.EncodeBlock()
+		t.states[name] = append(t.states[name], state{b: b, properties: properties})
+	}
+	if b, ok := br.BlockByName("minecraft:water", map[string]any{"liquid_depth": int32(0)}); ok {
+		t.water, _ = b.(world.Liquid)
+	}
+	return t
+}
+
+// TranslateState translates a Java Edition block state string, such as
+// "minecraft:oak_stairs[facing=east,half=top]", to a block and the liquid that the block is waterlogged with, if
+// any. False is returned if no block exists for the state.
+func (t *Translator) TranslateState(javaState string) (world.Block, world.Liquid, bool) {
+	return t.Translate(ParseState(javaState))
+}
+
+// Translate translates the name and properties of a Java Edition block state to a block and the liquid that the
+// block is waterlogged with, if any. False is returned if no block exists for the state.
+//
+// Properties of the state are translated to their Bedrock Edition counterparts where possible. If no block state
+// matches all properties, the state that matches most of them is used.
+func (t *Translator) Translate(name string, javaProperties map[string]string) (world.Block, world.Liquid, bool) {
+	properties := maps.Clone(javaProperties)
+	if properties == nil {
+		properties = make(map[string]string)
+	}
+	var liq world.Liquid
+	if properties["waterlogged"] == "true" {
+		liq = t.water
+	}
+	delete(properties, "waterlogged")
+
+	name = bedrockName(name, properties)
+	translated := translateProperties(name, properties)
+	states, ok := t.states[name]
+	if !ok {
+		// The name may also be one used by older versions of Bedrock Edition, which the block upgrader knows how to
+		// translate.
+		upgraded := blockupgrader.Upgrade(blockupgrader.BlockState{Name: name, Properties: translated})
+		if states, ok = t.states[upgraded.Name]; !ok {
+			return nil, nil, false
+		}
+		translated = upgraded.Properties
+	}
+	return bestMatch(states, translated), liq, true
+}
+
+// bestMatch returns the block of the state that has the most properties matching those passed. If multiple states
+// match equally well, the state with the most properties at their zero value is preferred, which is usually the
+// default state of the block.
+func bestMatch(states []state, properties map[string]any) world.Block {
+	best, bestScore := states[0].b, -1
+	for _, s := range states {
+		score := 0
+		for k, v := range s.properties {
+			// Blocks may encode boolean properties as either bools or bytes.
+			if b, ok := v.(bool); ok {
+				v = bit(b)
+			}
+			if want, ok := properties[k]; ok && want == v {
+				score += 64
+			} else if v == uint8(0) || v == int32(0) {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = s.b, score
+		}
+	}
+	return best
+}
+
+// ParseState parses a Java Edition block state string into its name and properties. Names without a namespace are
+// given the "minecraft" namespace.
+func ParseState(s string) (string, map[string]string) {
+	properties := make(map[string]string)
+	name, props, _ := strings.Cut(s, "[")
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	for _, prop := range strings.Split(strings.TrimSuffix(props, "]"), ",") {
+		if k, v, ok := strings.Cut(prop, "="); ok {
+			properties[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return name, properties
+}
+
+// javaNames maps the names of Java Edition blocks to their Bedrock Edition names where these differ.
+var javaNames = map[string]string{
+	"minecraft:attached_melon_stem":        "minecraft:melon_stem",
+	"minecraft:attached_pumpkin_stem":      "minecraft:pumpkin_stem",
+	"minecraft:bricks":                     "minecraft:brick_block",
+	"minecraft:cave_air":                   "minecraft:air",
+	"minecraft:chain":                      "minecraft:iron_chain",
+	"minecraft:cobblestone_stairs":         "minecraft:stone_stairs",
+	"minecraft:cobweb":                     "minecraft:web",
+	"minecraft:comparator":                 "minecraft:unpowered_comparator",
+	"minecraft:dead_bush":                  "minecraft:deadbush",
+	"minecraft:dirt_path":                  "minecraft:grass_path",
+	"minecraft:end_stone_bricks":           "minecraft:end_bricks",
+	"minecraft:glow_item_frame":            "minecraft:glow_frame",
+	"minecraft:grass":                      "minecraft:short_grass",
+	"minecraft:item_frame":                 "minecraft:frame",
+	"minecraft:jack_o_lantern":             "minecraft:lit_pumpkin",
+	"minecraft:kelp_plant":                 "minecraft:kelp",
+	"minecraft:lava_cauldron":              "minecraft:cauldron",
+	"minecraft:lily_pad":                   "minecraft:waterlily",
+	"minecraft:magma_block":                "minecraft:magma",
+	"minecraft:melon":                      "minecraft:melon_block",
+	"minecraft:moving_piston":              "minecraft:moving_block",
+	"minecraft:nether_bricks":              "minecraft:nether_brick",
+	"minecraft:nether_portal":              "minecraft:portal",
+	"minecraft:nether_quartz_ore":          "minecraft:quartz_ore",
+	"minecraft:note_block":                 "minecraft:noteblock",
+	"minecraft:oak_button":                 "minecraft:wooden_button",
+	"minecraft:oak_door":                   "minecraft:wooden_door",
+	"minecraft:oak_fence_gate":             "minecraft:fence_gate",
+	"minecraft:oak_pressure_plate":         "minecraft:wooden_pressure_plate",
+	"minecraft:oak_sign":                   "minecraft:standing_sign",
+	"minecraft:oak_trapdoor":               "minecraft:trapdoor",
+	"minecraft:oak_wall_sign":              "minecraft:wall_sign",
+	"minecraft:piston_head":                "minecraft:piston_arm_collision",
+	"minecraft:powder_snow_cauldron":       "minecraft:cauldron",
+	"minecraft:powered_rail":               "minecraft:golden_rail",
+	"minecraft:red_nether_bricks":          "minecraft:red_nether_brick",
+	"minecraft:redstone_wall_torch":        "minecraft:redstone_torch",
+	"minecraft:repeater":                   "minecraft:unpowered_repeater",
+	"minecraft:skeleton_wall_skull":        "minecraft:skeleton_skull",
+	"minecraft:slime_block":                "minecraft:slime",
+	"minecraft:snow":                       "minecraft:snow_layer",
+	"minecraft:snow_block":                 "minecraft:snow",
+	"minecraft:spawner":                    "minecraft:mob_spawner",
+	"minecraft:sticky_piston_head":         "minecraft:sticky_piston_arm_collision",
+	"minecraft:stone_slab":                 "minecraft:normal_stone_slab",
+	"minecraft:stone_stairs":               "minecraft:normal_stone_stairs",
+	"minecraft:sugar_cane":                 "minecraft:reeds",
+	"minecraft:tall_seagrass":              "minecraft:seagrass",
+	"minecraft:terracotta":                 "minecraft:hardened_clay",
+	"minecraft:tripwire":                   "minecraft:trip_wire",
+	"minecraft:void_air":                   "minecraft:air",
+	"minecraft:wall_torch":                 "minecraft:torch",
+	"minecraft:water_cauldron":             "minecraft:cauldron",
+	"minecraft:wither_skeleton_wall_skull": "minecraft:wither_skeleton_skull",
+}
+
+// bedrockName returns the Bedrock Edition name of a Java Edition block with the properties passed. Properties that
+// are expressed through the name of the block in Bedrock Edition, such as whether a furnace is lit, are removed from
+// the properties.
+func bedrockName(name string, properties map[string]string) string {
+	if n, ok := javaNames[name]; ok {
+		name = n
+	}
+	base := strings.TrimPrefix(name, "minecraft:")
+	switch {
+	case strings.HasSuffix(base, "_slab") && properties["type"] == "double":
+		delete(properties, "type")
+		return "minecraft:" + strings.TrimSuffix(base, "_slab") + "_double_slab"
+	case strings.HasSuffix(base, "_wall_banner"):
+		return "minecraft:wall_banner"
+	case strings.HasSuffix(base, "_banner"):
+		return "minecraft:standing_banner"
+	case strings.HasSuffix(base, "_bed"):
+		return "minecraft:bed"
+	case strings.HasSuffix(base, "_sign") && !strings.HasSuffix(base, "_wall_sign") && !strings.HasSuffix(base, "_hanging_sign") && base != "standing_sign" && base != "wall_sign":
+		return "minecraft:" + strings.TrimSuffix(base, "_sign") + "_standing_sign"
+	case strings.HasSuffix(base, "_wall_head"):
+		return "minecraft:" + strings.TrimSuffix(base, "_wall_head") + "_head"
+	case base == "light":
+		level := properties["level"]
+		delete(properties, "level")
+		if level == "" {
+			level = "15"
+		}
+		return "minecraft:light_block_" + level
+	}
+
+	lit := properties["lit"]
+	switch base {
+	case "furnace", "smoker", "blast_furnace", "redstone_lamp", "redstone_ore", "deepslate_redstone_ore":
+		delete(properties, "lit")
+		if lit == "true" {
+			return "minecraft:lit_" + base
+		}
+	case "redstone_torch":
+		delete(properties, "lit")
+		if lit == "false" {
+			return "minecraft:unlit_redstone_torch"
+		}
+	case "unpowered_repeater", "unpowered_comparator":
+		if properties["powered"] == "true" {
+			delete(properties, "powered")
+			return "minecraft:powered_" + strings.TrimPrefix(base, "unpowered_")
+		}
+	case "water", "lava":
+		if level := properties["level"]; level != "" && level != "0" {
+			return "minecraft:flowing_" + base
+		}
+	case "daylight_detector":
+		if properties["inverted"] == "true" {
+			delete(properties, "inverted")
+			return "minecraft:daylight_detector_inverted"
+		}
+	}
+	return name
+}
+
+// translateProperties translates the properties of a Java Edition block state to the properties of the Bedrock
+// Edition block passed. Properties may translate to multiple Bedrock Edition properties if their name depends on the
+// block, in which case only those that the block has are matched. Properties without a known translation are kept
+// with their name, with their values converted to the types used by Bedrock Edition.
+func translateProperties(name string, properties map[string]string) map[string]any {
+	m := make(map[string]any, len(properties))
+	switch properties["face"] {
+	case "floor":
+		if name == "minecraft:lever" {
+			m["lever_direction"] = "up_" + axisName(properties["facing"])
+		}
+		properties["facing"] = "up"
+	case "ceiling":
+		if name == "minecraft:lever" {
+			m["lever_direction"] = "down_" + axisName(properties["facing"])
+		}
+		properties["facing"] = "down"
+	case "wall":
+		if name == "minecraft:lever" {
+			m["lever_direction"] = properties["facing"]
+		}
+	}
+	delete(properties, "face")
+	if name == "minecraft:torch" || name == "minecraft:redstone_torch" || name == "minecraft:unlit_redstone_torch" {
+		if f, ok := properties["facing"]; ok {
+			m["torch_facing_direction"] = f
+			delete(properties, "facing")
+		} else {
+			m["torch_facing_direction"] = "top"
+		}
+	}
+	if name == "minecraft:piston" || name == "minecraft:sticky_piston" || strings.HasSuffix(name, "piston_arm_collision") {
+		// Bedrock Edition uses the opposite of horizontal facing directions for pistons.
+		if f, ok := map[string]string{"north": "south", "south": "north", "west": "east", "east": "west"}[properties["facing"]]; ok {
+			properties["facing"] = f
+		}
+	}
+
+	for k, v := range properties {
+		switch k {
+		case "facing":
+			translateFacing(m, v)
+		case "axis":
+			m["pillar_axis"] = v
+		case "half":
+			top := v == "top" || v == "upper"
+			m["upside_down_bit"], m["upper_block_bit"] = bit(top), bit(top)
+			m["minecraft:vertical_half"] = map[bool]string{true: "top", false: "bottom"}[top]
+		case "type":
+			m["minecraft:vertical_half"] = v
+		case "open":
+			m["open_bit"] = bit(v == "true")
+		case "powered":
+			m["powered_bit"], m["button_pressed_bit"], m["rail_data_bit"] = bit(v == "true"), bit(v == "true"), bit(v == "true")
+		case "age":
+			m["age"], m["growth"] = number(v), number(v)
+		case "rotation":
+			m["ground_sign_direction"] = number(v)
+		case "layers":
+			m["height"] = number(v) - 1
+		case "persistent":
+			m["persistent_bit"] = bit(v == "true")
+		case "hinge":
+			m["door_hinge_bit"] = bit(v == "right")
+		case "in_wall":
+			m["in_wall_bit"] = bit(v == "true")
+		case "moisture":
+			m["moisturized_amount"] = number(v)
+		case "bites":
+			m["bite_counter"] = number(v)
+		case "delay":
+			m["repeater_delay"] = number(v) - 1
+		case "power":
+			m["redstone_signal"] = number(v)
+		case "candles":
+			m["candles"] = number(v) - 1
+		case "pickles":
+			m["cluster_count"] = number(v) - 1
+		case "level":
+			m["liquid_depth"], m["composter_fill_level"], m["fill_level"] = number(v), number(v), number(v)*2
+		case "attached":
+			m["attached_bit"] = bit(v == "true")
+		case "triggered":
+			m["triggered_bit"] = bit(v == "true")
+		case "occupied":
+			m["occupied_bit"] = bit(v == "true")
+		case "part":
+			m["head_piece_bit"] = bit(v == "head")
+		case "mode":
+			m["output_subtract_bit"] = bit(v == "subtract")
+		case "up":
+			m["wall_post_bit"] = bit(v == "true")
+		case "north", "east", "south", "west":
+			if v == "low" || v == "tall" || v == "none" {
+				m["wall_connection_type_"+k] = strings.Replace(v, "low", "short", 1)
+			}
+		case "shape":
+			if d, ok := railDirections[v]; ok {
+				m["rail_direction"] = d
+			}
+		default:
+			switch v {
+			case "true", "false":
+				m[k] = bit(v == "true")
+			default:
+				if n, err := strconv.Atoi(v); err == nil {
+					m[k] = int32(n)
+				} else {
+					m[k] = v
+				}
+			}
+		}
+	}
+	return m
+}
+
+// translateFacing adds the Bedrock Edition properties that a Java Edition facing property may translate to. Which
+// property a block uses for its facing direction differs per block.
+func translateFacing(m map[string]any, facing string) {
+	m["minecraft:cardinal_direction"], m["minecraft:facing_direction"], m["minecraft:block_face"] = facing, facing, facing
+	if i := slices.Index([]string{"down", "up", "north", "south", "west", "east"}, facing); i >= 0 {
+		m["facing_direction"] = int32(i)
+	}
+	if i := slices.Index([]string{"south", "west", "north", "east"}, facing); i >= 0 {
+		m["direction"] = int32(i)
+	}
+	if i := slices.Index([]string{"east", "west", "south", "north"}, facing); i >= 0 {
+		m["weirdo_direction"] = int32(i)
+	}
+}
+
+// railDirections maps the shapes of Java Edition rails to the rail directions of Bedrock Edition.
+var railDirections = map[string]int32{
+	"north_south": 0, "east_west": 1,
+	"ascending_east": 2, "ascending_west": 3, "ascending_north": 4, "ascending_south": 5,
+	"south_east": 6, "south_west": 7, "north_west": 8, "north_east": 9,
+}
+
+// axisName returns the axis of a horizontal facing direction as used by levers.
+func axisName(facing string) string {
+	if facing == "east" || facing == "west" {
+		return "east_west"
+	}
+	return "north_south"
+}
+
+// bit converts a bool to a uint8 as used for boolean block properties.
+func bit(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// number parses an integer block property value.
+func number(v string) int32 {
+	n, _ := strconv.Atoi(v)
+	return int32(n)
+}
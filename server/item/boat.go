@@ -0,0 +1,42 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Boat is an item that may be placed to spawn a boat entity, which players and other entities may ride over
+// water.
+type Boat struct {
+	// Type is the type of the boat, which specifies the wood that the boat is made of.
+	Type BoatType
+	// Chest specifies if the boat carries a chest. Boats with a chest have room for only one rider.
+	Chest bool
+}
+
+// MaxCount ...
+func (Boat) MaxCount() int {
+	return 1
+}
+
+// UseOnBlock places a boat on the water clicked, or on top of the block clicked if it is not water. The boat faces
+// the same direction as the user.
+func (b Boat) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user User, ctx *UseContext) bool {
+	spawnPos := pos.Side(face).Vec3Middle()
+	if l, ok := tx.Liquid(pos); ok && l.LiquidType() == "water" {
+		spawnPos = pos.Vec3Middle().Add(mgl64.Vec3{0, 1})
+	}
+	opts := world.EntitySpawnOpts{Position: spawnPos, Rotation: cube.Rotation{user.Rotation().Yaw()}}
+	tx.AddEntity(tx.World().EntityRegistry().Config().Boat(opts, b.Type, b.Chest))
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (b Boat) EncodeItem() (name string, meta int16) {
+	if b.Chest {
+		return "minecraft:" + b.Type.String() + "_chest_boat", 0
+	}
+	return "minecraft:" + b.Type.String() + "_boat", 0
+}
@@ -0,0 +1,104 @@
+package item
+
+// BoatType represents a type of boat, which specifies the wood that the boat is made of.
+type BoatType struct {
+	boat
+}
+
+type boat uint8
+
+// BoatTypeOak is a boat made of oak wood.
+func BoatTypeOak() BoatType {
+	return BoatType{0}
+}
+
+// BoatTypeSpruce is a boat made of spruce wood.
+func BoatTypeSpruce() BoatType {
+	return BoatType{1}
+}
+
+// BoatTypeBirch is a boat made of birch wood.
+func BoatTypeBirch() BoatType {
+	return BoatType{2}
+}
+
+// BoatTypeJungle is a boat made of jungle wood.
+func BoatTypeJungle() BoatType {
+	return BoatType{3}
+}
+
+// BoatTypeAcacia is a boat made of acacia wood.
+func BoatTypeAcacia() BoatType {
+	return BoatType{4}
+}
+
+// BoatTypeDarkOak is a boat made of dark oak wood.
+func BoatTypeDarkOak() BoatType {
+	return BoatType{5}
+}
+
+// BoatTypeMangrove is a boat made of mangrove wood.
+func BoatTypeMangrove() BoatType {
+	return BoatType{6}
+}
+
+// BoatTypeCherry is a boat made of cherry wood.
+func BoatTypeCherry() BoatType {
+	return BoatType{8}
+}
+
+// BoatTypes returns all boat types.
+func BoatTypes() []BoatType {
+	return []BoatType{BoatTypeOak(), BoatTypeSpruce(), BoatTypeBirch(), BoatTypeJungle(), BoatTypeAcacia(), BoatTypeDarkOak(), BoatTypeMangrove(), BoatTypeCherry()}
+}
+
+// Uint8 returns the variant of the boat type, as used by boat entities.
+func (b boat) Uint8() uint8 {
+	return uint8(b)
+}
+
+// Name ...
+func (b boat) Name() string {
+	switch b {
+	case 0:
+		return "Oak Boat"
+	case 1:
+		return "Spruce Boat"
+	case 2:
+		return "Birch Boat"
+	case 3:
+		return "Jungle Boat"
+	case 4:
+		return "Acacia Boat"
+	case 5:
+		return "Dark Oak Boat"
+	case 6:
+		return "Mangrove Boat"
+	case 8:
+		return "Cherry Boat"
+	}
+	panic("unknown boat type")
+}
+
+// String ...
+func (b boat) String() string {
+	switch b {
+	case 0:
+		return "oak"
+	case 1:
+		return "spruce"
+	case 2:
+		return "birch"
+	case 3:
+		return "jungle"
+	case 4:
+		return "acacia"
+	case 5:
+		return "dark_oak"
+	case 6:
+		return "mangrove"
+	case 8:
+		return "cherry"
+	}
+	panic("unknown boat type")
+}
@@ -0,0 +1,40 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Minecart is an item that can be placed on rails to spawn a minecart entity.
+type Minecart struct {
+	// Type is the type of the minecart, which specifies what the minecart carries.
+	Type MinecartType
+}
+
+// minecartSupport represents a block that a minecart may be placed on, such as a rail.
+type minecartSupport interface {
+	SupportsMinecart() bool
+}
+
+// MaxCount ...
+func (Minecart) MaxCount() int {
+	return 1
+}
+
+// UseOnBlock places a minecart on the rail clicked. Minecarts cannot be placed on other blocks.
+func (m Minecart) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, tx *world.Tx, _ User, ctx *UseContext) bool {
+	support, ok := tx.Block(pos).(minecartSupport)
+	if !ok || !support.SupportsMinecart() {
+		return false
+	}
+	opts := world.EntitySpawnOpts{Position: pos.Vec3Middle()}
+	tx.AddEntity(tx.World().EntityRegistry().Config().Minecart(opts, m.Type))
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (m Minecart) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + m.Type.String(), 0
+}
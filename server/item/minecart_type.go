@@ -0,0 +1,68 @@
+package item
+
+// MinecartType represents a type of minecart, such as a plain rideable minecart or a minecart carrying a chest.
+type MinecartType struct {
+	minecart
+}
+
+type minecart uint8
+
+// MinecartTypeRideable is a plain minecart that may be ridden by players and other entities.
+func MinecartTypeRideable() MinecartType {
+	return MinecartType{0}
+}
+
+// MinecartTypeChest is a minecart carrying a chest that holds 27 stacks of items.
+func MinecartTypeChest() MinecartType {
+	return MinecartType{1}
+}
+
+// MinecartTypeHopper is a minecart carrying a hopper that picks up items from containers and the ground.
+func MinecartTypeHopper() MinecartType {
+	return MinecartType{2}
+}
+
+// MinecartTypeTNT is a minecart carrying TNT that explodes when activated.
+func MinecartTypeTNT() MinecartType {
+	return MinecartType{3}
+}
+
+// MinecartTypes returns all minecart types.
+func MinecartTypes() []MinecartType {
+	return []MinecartType{MinecartTypeRideable(), MinecartTypeChest(), MinecartTypeHopper(), MinecartTypeTNT()}
+}
+
+// Uint8 ...
+func (m minecart) Uint8() uint8 {
+	return uint8(m)
+}
+
+// Name ...
+func (m minecart) Name() string {
+	switch m {
+	case 0:
+		return "Minecart"
+	case 1:
+		return "Minecart with Chest"
+	case 2:
+		return "Minecart with Hopper"
+	case 3:
+		return "Minecart with TNT"
+	}
+	panic("unknown minecart type")
+}
+
+// String ...
+func (m minecart) String() string {
+	switch m {
+	case 0:
+		return "minecart"
+	case 1:
+		return "chest_minecart"
+	case 2:
+		return "hopper_minecart"
+	case 3:
+		return "tnt_minecart"
+	}
+	panic("unknown minecart type")
+}
@@ -111,6 +111,7 @@ func init() {
 	world.RegisterItem(RecoveryCompass{})
 	world.RegisterItem(ResinBrick{})
 	world.RegisterItem(RottenFlesh{})
+	world.RegisterItem(Saddle{})
 	world.RegisterItem(Salmon{Cooked: true})
 	world.RegisterItem(Salmon{})
 	world.RegisterItem(Scute{})
@@ -168,6 +169,13 @@ func init() {
 	for _, stew := range StewTypes() {
 		world.RegisterItem(SuspiciousStew{Type: stew})
 	}
+	for _, t := range MinecartTypes() {
+		world.RegisterItem(Minecart{Type: t})
+	}
+	for _, t := range BoatTypes() {
+		world.RegisterItem(Boat{Type: t})
+		world.RegisterItem(Boat{Type: t, Chest: true})
+	}
 	for _, sherd := range SherdTypes() {
 		world.RegisterItem(PotterySherd{Type: sherd})
 	}
@@ -0,0 +1,14 @@
+package item
+
+// Saddle is an item that may be put on a tamed horse so that the horse may be controlled by the player riding it.
+type Saddle struct{}
+
+// MaxCount ...
+func (Saddle) MaxCount() int {
+	return 1
+}
+
+// EncodeItem ...
+func (Saddle) EncodeItem() (name string, meta int16) {
+	return "minecraft:saddle", 0
+}
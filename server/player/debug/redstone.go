@@ -0,0 +1,164 @@
+package debug
+
+import (
+	"image/color"
+	"strconv"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var (
+	// RedstoneDirtyColour is the colour of the outline drawn around redstone nodes that are waiting to be
+	// evaluated by the redstone engine.
+	RedstoneDirtyColour = color.RGBA{R: 0xff, G: 0xd8, A: 0xff}
+	// RedstoneScheduledColour is the colour of the outline drawn around redstone nodes that have a scheduled
+	// block update pending.
+	RedstoneScheduledColour = color.RGBA{G: 0xd8, B: 0xff, A: 0xff}
+	// RedstoneEdgeColour is the colour of the edges drawn between redstone nodes.
+	RedstoneEdgeColour = color.RGBA{R: 0xc0, G: 0xc0, B: 0xc0, A: 0xff}
+)
+
+// RedstonePowerColour returns the colour used to draw a redstone node with the power passed. Unpowered nodes are
+// drawn in a dark red, which gets brighter as the power increases.
+func RedstonePowerColour(power int) color.RGBA {
+	power = world.ClampRedstonePower(power)
+	return color.RGBA{R: uint8(0x50 + power*0xaf/15), G: uint8(power * 0x30 / 15), A: 0xff}
+}
+
+// RedstoneOverlay draws a world.RedstoneGraph using debug shapes. Every node is drawn as a box coloured by its power,
+// with a label holding the power level, and every edge is drawn as an arrow, or a line if no power is lost over it.
+// Nodes that are dirty or have a scheduled update pending are highlighted with an outline.
+// Calling Draw again with a newer graph only updates the shapes that changed, so that the overlay may be redrawn
+// every time the redstone engine evaluates the graph. A RedstoneOverlay is not safe for concurrent use.
+type RedstoneOverlay struct {
+	nodes map[cube.Pos]*redstoneNodeShapes
+	edges map[[2]cube.Pos]Shape
+}
+
+// redstoneNodeShapes holds the shapes drawn for a single redstone node.
+type redstoneNodeShapes struct {
+	node      world.RedstoneNode
+	box       *Box
+	label     *Text
+	highlight *Box
+}
+
+// NewRedstoneOverlay creates an empty RedstoneOverlay.
+func NewRedstoneOverlay() *RedstoneOverlay {
+	return &RedstoneOverlay{nodes: make(map[cube.Pos]*redstoneNodeShapes), edges: make(map[[2]cube.Pos]Shape)}
+}
+
+// Draw draws the graph passed to the Renderer r. Shapes of nodes and edges no longer present in the graph are
+// removed, and shapes of nodes of which the state changed are updated.
+func (o *RedstoneOverlay) Draw(r Renderer, g world.RedstoneGraph) {
+	nodes := make(map[cube.Pos]struct{}, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes[n.Pos] = struct{}{}
+		o.drawNode(r, n)
+	}
+	for pos, s := range o.nodes {
+		if _, ok := nodes[pos]; !ok {
+			s.remove(r)
+			delete(o.nodes, pos)
+		}
+	}
+
+	edges := make(map[[2]cube.Pos]struct{}, len(g.Edges))
+	for _, e := range g.Edges {
+		key := [2]cube.Pos{e.From, e.To}
+		edges[key] = struct{}{}
+		if existing, ok := o.edges[key]; ok {
+			if _, arrow := existing.(*Arrow); arrow == (e.SignalLoss > 0) {
+				continue
+			}
+			r.RemoveDebugShape(existing)
+		}
+		o.edges[key] = redstoneEdgeShape(e)
+		r.AddDebugShape(o.edges[key])
+	}
+	for key, s := range o.edges {
+		if _, ok := edges[key]; !ok {
+			r.RemoveDebugShape(s)
+			delete(o.edges, key)
+		}
+	}
+}
+
+// Clear removes all shapes of the overlay from the Renderer r.
+func (o *RedstoneOverlay) Clear(r Renderer) {
+	for pos, s := range o.nodes {
+		s.remove(r)
+		delete(o.nodes, pos)
+	}
+	for key, s := range o.edges {
+		r.RemoveDebugShape(s)
+		delete(o.edges, key)
+	}
+}
+
+// drawNode draws the shapes of a single node, only updating them if the node changed since it was last drawn.
+func (o *RedstoneOverlay) drawNode(r Renderer, n world.RedstoneNode) {
+	s, ok := o.nodes[n.Pos]
+	if ok && s.node == n {
+		return
+	}
+	if !ok {
+		origin := n.Pos.Vec3()
+		s = &redstoneNodeShapes{
+			box:   &Box{Position: origin.Add(mgl64.Vec3{0.3, 0.3, 0.3}), Bounds: mgl64.Vec3{0.4, 0.4, 0.4}},
+			label: &Text{Position: origin.Add(mgl64.Vec3{0.5, 1.1, 0.5}), Scale: 0.5, DisableDepthTest: true},
+		}
+		o.nodes[n.Pos] = s
+	}
+	s.node = n
+	s.box.Colour = RedstonePowerColour(max(n.Power, n.Output))
+	s.label.Text = redstoneNodeLabel(n)
+	r.AddDebugShape(s.box)
+	r.AddDebugShape(s.label)
+
+	if !n.Dirty && !n.Scheduled {
+		if s.highlight != nil {
+			r.RemoveDebugShape(s.highlight)
+			s.highlight = nil
+		}
+		return
+	}
+	if s.highlight == nil {
+		s.highlight = &Box{Position: n.Pos.Vec3().Add(mgl64.Vec3{0.05, 0.05, 0.05}), Bounds: mgl64.Vec3{0.9, 0.9, 0.9}}
+	}
+	s.highlight.Colour = RedstoneScheduledColour
+	if n.Dirty {
+		s.highlight.Colour = RedstoneDirtyColour
+	}
+	r.AddDebugShape(s.highlight)
+}
+
+// remove removes all shapes drawn for a node from the Renderer r.
+func (s *redstoneNodeShapes) remove(r Renderer) {
+	r.RemoveDebugShape(s.box)
+	r.RemoveDebugShape(s.label)
+	if s.highlight != nil {
+		r.RemoveDebugShape(s.highlight)
+	}
+}
+
+// redstoneNodeLabel returns the label text drawn above a node, holding its power and, for sources, its output.
+func redstoneNodeLabel(n world.RedstoneNode) string {
+	label := strconv.Itoa(n.Power)
+	if n.Source && !n.Relayer {
+		label += " > " + strconv.Itoa(n.Output)
+	}
+	return label
+}
+
+// redstoneEdgeShape returns the shape drawn for an edge. Edges that lose power are drawn as arrows, pointing in the
+// direction power flows, while edges into non-relayers, which lose no power, are drawn as lines.
+func redstoneEdgeShape(e world.RedstoneEdge) Shape {
+	from, to := e.From.Vec3Centre(), e.To.Vec3Centre()
+	if e.SignalLoss > 0 {
+		return &Arrow{Colour: RedstoneEdgeColour, Position: from, EndPosition: to, HeadLength: 0.2, HeadRadius: 0.1}
+	}
+	return &Line{Colour: RedstoneEdgeColour, Position: from, EndPosition: to}
+}
@@ -63,6 +63,10 @@ type playerData struct {
 	sleeping bool
 	sleepPos cube.Pos
 
+	riding *world.EntityHandle
+
+	redstoneDebug *redstoneDebug
+
 	usingSince time.Time
 
 	glideTicks   int64
@@ -907,6 +911,7 @@ func (p *Player) kill(src world.DamageSource) {
 	p.Handler().HandleDeath(p, src, &keepInv)
 	p.StopSneaking()
 	p.StopSprinting()
+	p.Dismount()
 
 	pos := p.Position()
 	if !keepInv {
@@ -1131,6 +1136,7 @@ func (p *Player) StartSneaking() {
 		p.StopSprinting()
 	}
 	p.sneaking = true
+	p.Dismount()
 	p.updateState()
 }
 
@@ -1826,14 +1832,17 @@ func (p *Player) UseItemOnEntity(e world.Entity) bool {
 	if p.Handler().HandleItemUseOnEntity(ctx, e); ctx.Cancelled() {
 		return false
 	}
-	i, left := p.HeldItems()
-	usable, ok := i.Item().(item.UsableOnEntity)
-	if !ok {
+	if !p.Sneaking() && p.OpenTrade(e) {
 		return true
 	}
+	i, left := p.HeldItems()
 	useCtx := p.useContext()
-	if !usable.UseOnEntity(e, p.tx, p, useCtx) {
-		return true
+	if !entity.InteractEntity(e, p, i, useCtx) {
+		usable, ok := i.Item().(item.UsableOnEntity)
+		if !ok || !usable.UseOnEntity(e, p.tx, p, useCtx) {
+			p.rideOrOpen(e)
+			return true
+		}
 	}
 	p.SwingArm()
 	p.SetHeldItems(p.subtractItem(p.damageItem(i, useCtx.Damage), useCtx.CountSub), left)
@@ -1841,6 +1850,20 @@ func (p *Player) UseItemOnEntity(e world.Entity) bool {
 	return true
 }
 
+// rideOrOpen makes the player ride the entity passed or open the inventory it carries, if the item held had no
+// effect on it. Sneaking players open the inventory of entities that may also be ridden, such as chest boats.
+func (p *Player) rideOrOpen(e world.Entity) {
+	if p.Sneaking() {
+		if entity.Rideable(e) {
+			p.OpenEntityContainer(e)
+		}
+		return
+	}
+	if !p.Mount(e) {
+		p.OpenEntityContainer(e)
+	}
+}
+
 // AttackEntity uses the item held in the main hand of the player to attack the entity passed, provided it is
 // within range of the player.
 // The damage dealt to the entity will depend on the item held by the player and any effects the player may
@@ -2603,6 +2626,78 @@ func (p *Player) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
 	}
 }
 
+// OpenEntityContainer opens the inventory carried by an entity, such as a chest minecart. False is returned if the
+// entity does not carry an inventory, or if the player has no session connected to it.
+func (p *Player) OpenEntityContainer(e world.Entity) bool {
+	if p.session() == session.Nop {
+		return false
+	}
+	return p.session().OpenEntityContainer(e, p.tx)
+}
+
+// OpenTrade opens the trading UI of an entity that the player may trade with, such as a villager. False is
+// returned if the entity does not trade, if it is already trading with another player, or if the player has no
+// session connected to it.
+func (p *Player) OpenTrade(e world.Entity) bool {
+	if p.session() == session.Nop {
+		return false
+	}
+	return p.session().OpenTrade(e, p.tx)
+}
+
+// Mount makes the player start riding the entity passed, such as a minecart. If the player was already riding
+// another entity, it stops riding that entity first. The player takes the first free seat of the entity, and
+// controls the entity if that seat is the first one. False is returned if the entity cannot be ridden or has no free
+// seat.
+func (p *Player) Mount(e world.Entity) bool {
+	if vehicle, ok := p.Riding(); ok {
+		if vehicle.H() == e.H() {
+			return true
+		}
+		p.Dismount()
+	}
+	if !entity.Rideable(e) || !entity.MountEntity(p, e, p.tx) {
+		return false
+	}
+	p.riding = e.H()
+	p.updateState()
+	return true
+}
+
+// Dismount makes the player stop riding the entity it is currently riding. Dismount does nothing if the player is
+// not riding an entity.
+func (p *Player) Dismount() {
+	vehicle, ok := p.Riding()
+	p.riding = nil
+	if ok {
+		entity.DismountEntity(p, vehicle, p.tx)
+	}
+}
+
+// Riding returns the entity that the player is currently riding, such as a minecart. False is returned if the
+// player is not riding an entity.
+func (p *Player) Riding() (world.Entity, bool) {
+	if p.riding == nil {
+		return nil, false
+	}
+	e, ok := p.riding.Entity(p.tx)
+	if ok {
+		if _, _, riding := entity.SeatOf(e, p.handle); riding {
+			return e, true
+		}
+	}
+	p.riding = nil
+	return nil, false
+}
+
+// Steer passes the movement input of the player to the entity it rides, such as a boat or a horse. The input is
+// only used if the player controls the entity, which is the case if it sits in its first seat.
+func (p *Player) Steer(input entity.RideInput) {
+	if vehicle, ok := p.Riding(); ok {
+		entity.SteerEntity(p, vehicle, input)
+	}
+}
+
 // HideEntity hides a world.Entity from the Player so that it can under no circumstance see it. Hidden entities can be
 // made visible again through a call to ShowEntity.
 func (p *Player) HideEntity(e world.Entity) {
@@ -2703,6 +2798,9 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 		}
 	}
 
+	if p.redstoneDebug != nil {
+		p.redstoneDebug.tick(p.session(), tx, current)
+	}
 	p.session().SendDebugShapes(tx.World().Dimension())
 	p.session().SendHudUpdates()
 
@@ -3234,6 +3332,37 @@ func (p *Player) RemoveAllDebugShapes() {
 	p.session().RemoveAllDebugShapes()
 }
 
+// ShowRedstoneDebug shows the redstone network within radius blocks of pos to the player using debug shapes. Every
+// redstone component is drawn as a box coloured by its power, with edges between components drawn as arrows and lines.
+// Components waiting to be evaluated or with a scheduled update pending are outlined. The overlay is updated live as
+// the redstone engine evaluates the network, until HideRedstoneDebug is called. Calling ShowRedstoneDebug while an
+// overlay is already shown moves the overlay to the new position.
+func (p *Player) ShowRedstoneDebug(pos cube.Pos, radius int) {
+	if p.redstoneDebug == nil {
+		p.redstoneDebug = &redstoneDebug{overlay: debug.NewRedstoneOverlay()}
+	}
+	p.redstoneDebug.pos, p.redstoneDebug.radius, p.redstoneDebug.drawn = pos, radius, false
+}
+
+// HideRedstoneDebug hides the redstone debug overlay shown using ShowRedstoneDebug. HideRedstoneDebug does nothing if
+// no overlay is shown.
+func (p *Player) HideRedstoneDebug() {
+	if p.redstoneDebug == nil {
+		return
+	}
+	p.redstoneDebug.overlay.Clear(p.session())
+	p.redstoneDebug = nil
+}
+
+// RedstoneDebug returns the position and radius of the redstone debug overlay shown to the player. False is returned
+// if no overlay is shown.
+func (p *Player) RedstoneDebug() (pos cube.Pos, radius int, ok bool) {
+	if p.redstoneDebug == nil {
+		return cube.Pos{}, 0, false
+	}
+	return p.redstoneDebug.pos, p.redstoneDebug.radius, true
+}
+
 // LockInput applies an input lock to the player, disabling the specified input and immediately sending the
 // updated lock state to the client.
 func (p *Player) LockInput(l input.Lock) {
@@ -3359,6 +3488,7 @@ func (p *Player) close(msg string) {
 func (p *Player) quit(msg string) {
 	p.h.HandleQuit(p)
 	p.h = NopHandler{}
+	p.Dismount()
 
 	if s := p.s; s != nil {
 		s.Disconnect(msg)
@@ -0,0 +1,78 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player/debug"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// redstoneDebug holds the state of the redstone debug overlay shown to a player.
+type redstoneDebug struct {
+	pos     cube.Pos
+	radius  int
+	overlay *debug.RedstoneOverlay
+
+	drawn    bool
+	revision uint64
+}
+
+// redstoneDebugRefreshTicks is the interval in ticks at which the overlay is redrawn even if the redstone engine did
+// not evaluate anything, so that scheduled updates that finished without changing the network are no longer shown.
+const redstoneDebugRefreshTicks = 20
+
+// tick redraws the overlay if the redstone engine changed since it was last drawn.
+func (d *redstoneDebug) tick(r debug.Renderer, tx *world.Tx, current int64) {
+	revision := tx.Redstone().Revision()
+	if d.drawn && revision == d.revision && current%redstoneDebugRefreshTicks != 0 {
+		return
+	}
+	d.drawn, d.revision = true, revision
+	d.overlay.Draw(r, tx.Redstone().Graph(d.pos, d.radius))
+}
+
+// RedstoneDebugCommand is a cmd.Runnable that toggles the redstone debug overlay of the player running it, as shown
+// by Player.ShowRedstoneDebug. The overlay is centred around the player. If Radius is set while the overlay is already
+// shown, the overlay is moved to the player's position instead of being hidden.
+// RedstoneDebugCommand is not registered by default. It may be registered like this:
+//
+//	cmd.Register(cmd.New("redstonedebug", "Toggles the redstone debug overlay.", nil, player.RedstoneDebugCommand{}))
+type RedstoneDebugCommand struct {
+	// Radius is the radius in blocks around the player in which redstone components are shown. It defaults to
+	// DefaultRedstoneDebugRadius and may not exceed MaxRedstoneDebugRadius.
+	Radius cmd.Optional[int] `cmd:"radius"`
+}
+
+const (
+	// DefaultRedstoneDebugRadius is the radius used by RedstoneDebugCommand if no radius is passed.
+	DefaultRedstoneDebugRadius = 8
+	// MaxRedstoneDebugRadius is the maximum radius that may be passed to RedstoneDebugCommand.
+	MaxRedstoneDebugRadius = 24
+)
+
+// Run ...
+func (c RedstoneDebugCommand) Run(src cmd.Source, o *cmd.Output, _ *world.Tx) {
+	p := src.(*Player)
+	radius, set := c.Radius.Load()
+	if _, _, ok := p.RedstoneDebug(); ok && !set {
+		p.HideRedstoneDebug()
+		o.Print("Redstone debug overlay hidden.")
+		return
+	}
+	if !set {
+		radius = DefaultRedstoneDebugRadius
+	}
+	if radius < 1 || radius > MaxRedstoneDebugRadius {
+		o.Errorf("Radius must be between 1 and %v.", MaxRedstoneDebugRadius)
+		return
+	}
+	pos := cube.PosFromVec3(p.Position())
+	p.ShowRedstoneDebug(pos, radius)
+	o.Printf("Showing redstone components within %v blocks of %v.", radius, pos)
+}
+
+// Allow only allows players to run the command.
+func (RedstoneDebugCommand) Allow(src cmd.Source) bool {
+	_, ok := src.(*Player)
+	return ok
+}
@@ -606,6 +606,7 @@ func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *
 		SaveInterval:        srv.conf.SaveInterval,
 		ChunkUnloadInterval: srv.conf.ChunkUnloadInterval,
 		ChunkLoadWorkers:    srv.conf.ChunkLoadWorkers,
+		RedstoneBudget:      srv.conf.RedstoneBudget,
 		Entities:            srv.conf.Entities,
 		Blocks:              srv.conf.Blocks,
 		PortalDestination: func(dim world.Dimension) *world.World {
@@ -619,6 +620,9 @@ func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *
 			}
 		},
 	}
+	if dim == world.Overworld {
+		conf.MobSpawning = srv.conf.MobSpawning
+	}
 	w := conf.New()
 	logger.Info("Opened dimension.", "name", w.Name())
 	return w
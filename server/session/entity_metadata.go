@@ -107,6 +107,40 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 		m[protocol.EntityDataKeyFuseTime] = int32(t.Fuse().Milliseconds() / 50)
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagIgnited)
 	}
+	if c, ok := e.(climber); ok && c.Climbing() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagWallClimbing)
+	}
+	if c, ok := e.(charged); ok && c.Charged() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagPowered)
+	}
+	if l, ok := e.(inLove); ok && l.InLove() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagInLove)
+	}
+	if sh, ok := e.(sheared); ok && sh.Sheared() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSheared)
+	}
+	if c, ok := e.(coloured); ok {
+		m[protocol.EntityDataKeyColorIndex] = c.Colour().Uint8()
+	}
+	if p, ok := e.(primeable); ok {
+		if fuse, primed := p.Primed(); primed {
+			m[protocol.EntityDataKeyFuseTime] = int32(fuse.Milliseconds() / 50)
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagIgnited)
+		}
+	}
+	if w, ok := e.(wobbler); ok {
+		ticks, direction := w.Wobble()
+		m[protocol.EntityDataKeyHurt] = int32(ticks)
+		m[protocol.EntityDataKeyHurtDirection] = int32(direction)
+	}
+	if r, ok := e.(rider); ok {
+		if vehicle, riding := r.Riding(); riding {
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagRiding)
+			if _, seat, ok := entity.SeatOf(vehicle, r.H()); ok {
+				writeSeatMetadata(m, r, seat)
+			}
+		}
+	}
 	if nameTag, alwaysShow, ok := nameTagState(e); ok {
 		writeNameTagMetadata(m, nameTag, alwaysShow)
 	}
@@ -186,6 +220,29 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if mv, ok := e.(markVariable); ok {
 		m[protocol.EntityDataKeyMarkVariant] = mv.MarkVariant()
 	}
+	if p, ok := e.(professional); ok {
+		m[protocol.EntityDataKeyVariant] = int32(p.Profession().Uint8())
+	}
+	if sd, ok := e.(saddleable); ok && sd.Saddled() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSaddled)
+	}
+	if t, ok := e.(tameable); ok && t.Tamed() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagTamed)
+	}
+	if j, ok := e.(jumper); ok && j.Controllable() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagKeyboardControlled)
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagPowerJump)
+	}
+	if t, ok := e.(trader); ok {
+		level, experience := t.TradeLevel()
+		m[protocol.EntityDataKeyTradeTier] = int32(level - 1)
+		m[protocol.EntityDataKeyMaxTradeTier] = int32(entity.MaxTradeLevel - 1)
+		m[protocol.EntityDataKeyTradeExperience] = int32(experience)
+		m[protocol.EntityDataKeyTradeTarget] = int64(0)
+		if h, trading := t.TradingWith(); trading {
+			m[protocol.EntityDataKeyTradeTarget] = int64(s.handleRuntimeID(h))
+		}
+	}
 }
 
 // nameTagState returns the public name tag of an entity, whether that name tag is shown at all distances
@@ -222,6 +279,26 @@ func writeNameTagMetadata(m protocol.EntityMetadata, nameTag string, alwaysShow
 	}
 }
 
+// riderSitHeight is the distance between the network position of a rider, usually at its eyes, and the seat that
+// it sits on.
+const riderSitHeight = 0.4
+
+// writeSeatMetadata writes the position of the seat passed and the rotation limit of the rider e sitting on it to
+// the entity metadata passed. Seats without an offset are left to the client.
+func writeSeatMetadata(m protocol.EntityMetadata, e world.Entity, seat entity.Seat) {
+	if seat.Offset != (mgl64.Vec3{}) {
+		offset := seat.Offset
+		if y := entityOffset(e)[1]; y > 0 {
+			offset[1] += y - riderSitHeight
+		}
+		m[protocol.EntityDataKeySeatOffset] = vec64To32(offset)
+	}
+	if seat.MaxRotation > 0 {
+		m[protocol.EntityDataKeySeatLockPassengerRotation] = byte(1)
+		m[protocol.EntityDataKeySeatLockPassengerRotationDegrees] = float32(seat.MaxRotation)
+	}
+}
+
 type sneaker interface {
 	Sneaking() bool
 }
@@ -335,6 +412,39 @@ type tnt interface {
 	Fuse() time.Duration
 }
 
+type primeable interface {
+	Primed() (time.Duration, bool)
+}
+
+type climber interface {
+	Climbing() bool
+}
+
+type charged interface {
+	Charged() bool
+}
+
+type inLove interface {
+	InLove() bool
+}
+
+type sheared interface {
+	Sheared() bool
+}
+
+type coloured interface {
+	Colour() item.Colour
+}
+
+type wobbler interface {
+	Wobble() (ticks, direction int)
+}
+
+type rider interface {
+	world.Entity
+	Riding() (world.Entity, bool)
+}
+
 type living interface {
 	UUID() uuid.UUID
 	DeathPosition() (mgl64.Vec3, world.Dimension, bool)
@@ -347,3 +457,25 @@ type variable interface {
 type markVariable interface {
 	MarkVariant() int32
 }
+
+type professional interface {
+	Profession() entity.VillagerProfession
+}
+
+type saddleable interface {
+	Saddled() bool
+}
+
+type tameable interface {
+	Tamed() bool
+}
+
+type jumper interface {
+	Controllable() bool
+	JumpStrength() float64
+}
+
+type trader interface {
+	TradeLevel() (level, experience int)
+	TradingWith() (*world.EntityHandle, bool)
+}
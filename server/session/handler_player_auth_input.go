@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl32"
 	"github.com/go-gl/mathgl/mgl64"
@@ -21,6 +22,7 @@ func (h PlayerAuthInputHandler) Handle(p packet.Packet, s *Session, tx *world.Tx
 	if err := h.handleMovement(pk, s, c); err != nil {
 		return err
 	}
+	h.handleVehicleInput(pk, c)
 	return h.handleActions(pk, s, tx, c)
 }
 
@@ -66,6 +68,20 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 	return nil
 }
 
+// handleVehicleInput passes the movement input of the packet.PlayerAuthInput to the entity that the player rides,
+// if any, so that the player may steer it.
+func (h PlayerAuthInputHandler) handleVehicleInput(pk *packet.PlayerAuthInput, c Controllable) {
+	if _, riding := c.Riding(); !riding {
+		return
+	}
+	c.Steer(entity.RideInput{
+		Forward:  float64(pk.MoveVector.Y()),
+		Sideways: float64(pk.MoveVector.X()),
+		Yaw:      float64(pk.Yaw),
+		Jumping:  pk.InputData.Load(packet.InputFlagJumpDown),
+	})
+}
+
 // handleActions handles the actions with the world that are present in the PlayerAuthInput packet.
 func (h PlayerAuthInputHandler) handleActions(pk *packet.PlayerAuthInput, s *Session, tx *world.Tx, c Controllable) error {
 	if pk.InputData.Load(packet.InputFlagPerformItemInteraction) {
@@ -135,7 +151,10 @@ func (h PlayerAuthInputHandler) handleInputFlags(flags protocol.InputFlags, s *S
 		c.StopGliding()
 	}
 	if flags.Load(packet.InputFlagStartJumping) {
-		c.Jump()
+		// Riders use the jump input to make the entity they ride jump instead.
+		if _, riding := c.Riding(); !riding {
+			c.Jump()
+		}
 	}
 	if flags.Load(packet.InputFlagStartCrawling) {
 		c.StartCrawling()
@@ -0,0 +1,144 @@
+package session
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+const (
+	// tradeInputSlot is the slot index of the first item paid for a trade offer in the trading UI.
+	tradeInputSlot = 0x04
+	// tradeSecondInputSlot is the slot index of the second item paid for a trade offer in the trading UI.
+	tradeSecondInputSlot = 0x05
+)
+
+// handleTrade handles a CraftRecipe stack request action made using the trading UI. The network ID of the recipe
+// is that of the trade offer used.
+func (h *ItemStackRequestHandler) handleTrade(a *protocol.CraftRecipeStackRequestAction, s *Session, tx *world.Tx, c Controllable) error {
+	e, t, _ := s.openedTrader(tx)
+	offers := t.TradeOffers()
+	index := int(a.RecipeNetworkID) - 1
+	if index < 0 || index >= len(offers) {
+		return fmt.Errorf("trade offer with network id %v does not exist", a.RecipeNetworkID)
+	}
+	offer := offers[index]
+
+	timesTraded := int(a.NumberOfCrafts)
+	if timesTraded < 1 {
+		return fmt.Errorf("times traded must be at least 1")
+	}
+	if offer.MaxUses > 0 && offer.Uses+timesTraded > offer.MaxUses {
+		return fmt.Errorf("trade offer with network id %v may only be used %v more times", a.RecipeNetworkID, offer.MaxUses-offer.Uses)
+	}
+
+	inputs := [...]struct {
+		slot     protocol.StackRequestSlotInfo
+		expected item.Stack
+	}{
+		{slot: tradeSlot(protocol.ContainerTradeTwoIngredientOne, tradeInputSlot), expected: offer.Input},
+		{slot: tradeSlot(protocol.ContainerTradeTwoIngredientTwo, tradeSecondInputSlot), expected: offer.SecondInput},
+	}
+	var paid [len(inputs)]item.Stack
+	for i, input := range inputs {
+		if input.expected.Empty() {
+			continue
+		}
+		has, _ := h.itemInSlot(input.slot, s, tx)
+		if !has.Comparable(input.expected) {
+			return fmt.Errorf("input item %v is not the same as expected input %v", has, input.expected)
+		}
+		cost := input.expected.Count() * timesTraded
+		if has.Count() < cost {
+			return fmt.Errorf("input item count %v is less than the %v items required", has.Count(), cost)
+		}
+		paid[i] = has.Grow(-cost)
+	}
+
+	// All crafts are passed to the trader at once, so that it either accepts every one of them or changes no
+	// state at all. Rejecting the request only reverts the items.
+	level, _ := t.TradeLevel()
+	if !t.Trade(e, c, index, timesTraded) {
+		return fmt.Errorf("trade offer with network id %v could not be used %v times", a.RecipeNetworkID, timesTraded)
+	}
+	for i, input := range inputs {
+		if !input.expected.Empty() {
+			h.setItemInSlot(input.slot, paid[i], s, tx)
+		}
+	}
+	if newLevel, _ := t.TradeLevel(); newLevel != level {
+		// The trader unlocked new offers, so the trading UI needs to be updated.
+		s.sendTradeOffers(e, t, byte(s.openedWindowID.Load()))
+	}
+	return h.createResults(s, tx, repeatStacks([]item.Stack{offer.Output}, timesTraded)...)
+}
+
+// tradeSlot returns the stack request slot info of a slot in the trading UI.
+func tradeSlot(container byte, slot byte) protocol.StackRequestSlotInfo {
+	return protocol.StackRequestSlotInfo{
+		Container: protocol.FullContainerName{ContainerID: container},
+		Slot:      slot,
+	}
+}
+
+// openedTrader returns the entity that the session is currently trading with, if any.
+func (s *Session) openedTrader(tx *world.Tx) (*entity.Ent, entity.TraderBehaviour, bool) {
+	if !s.containerOpened.Load() || s.openedContainerID.Load() != protocol.ContainerTypeTrade {
+		return nil, nil, false
+	}
+	h := s.openedEntity.Load()
+	if h == nil {
+		return nil, nil, false
+	}
+	e, ok := h.Entity(tx)
+	if !ok {
+		return nil, nil, false
+	}
+	return entityTrader(e)
+}
+
+// sendTradeOffers sends the trade offers of the trader passed to the client, which opens the trading UI with the
+// window ID passed if it is not yet opened.
+func (s *Session) sendTradeOffers(e *entity.Ent, t entity.TraderBehaviour, windowID byte) {
+	offers := t.TradeOffers()
+	recipes := make([]any, 0, len(offers))
+	for i, o := range offers {
+		recipe := entity.EncodeTradeOffer(o)
+		recipe["netId"] = int32(i + 1)
+		recipes = append(recipes, recipe)
+	}
+	requirements := make([]any, 0, entity.MaxTradeLevel)
+	for level := 1; level <= entity.MaxTradeLevel; level++ {
+		requirements = append(requirements, map[string]any{strconv.Itoa(level - 1): int32(entity.TradeLevelExperience(level))})
+	}
+	serialisedOffers, err := nbt.Marshal(map[string]any{"Recipes": recipes, "TierExpRequirements": requirements})
+	if err != nil {
+		s.conf.Log.Error("send trade offers: encode offers: " + err.Error())
+		return
+	}
+
+	name := e.NameTag()
+	if p, ok := t.(interface {
+		Profession() entity.VillagerProfession
+	}); ok && name == "" {
+		name = p.Profession().Name()
+	}
+	level, _ := t.TradeLevel()
+	s.writePacket(&packet.UpdateTrade{
+		WindowID:         windowID,
+		WindowType:       protocol.ContainerTypeTrade,
+		Size:             int32(len(offers)),
+		TradeTier:        int32(level - 1),
+		VillagerUniqueID: int64(s.entityRuntimeID(e)),
+		EntityUniqueID:   selfEntityRuntimeID,
+		DisplayName:      name,
+		NewTradeUI:       true,
+		SerialisedOffers: serialisedOffers,
+	})
+}
@@ -70,6 +70,17 @@ func (s *Session) closeCurrentContainer(tx *world.Tx, clientRequested bool) {
 	if !s.closeWindow(clientRequested) {
 		return
 	}
+	if h := s.openedEntity.Swap(nil); h != nil {
+		if e, ok := h.Entity(tx); ok {
+			if c, ok := entityContainer(e); ok {
+				c.RemoveViewer(s)
+			}
+			if ent, t, ok := entityTrader(e); ok {
+				t.StopTrading(ent)
+			}
+		}
+		return
+	}
 
 	pos := *s.openedPos.Load()
 	b := tx.Block(pos)
@@ -328,6 +339,11 @@ func (s *Session) invByID(id int32, tx *world.Tx) (*inventory.Inventory, bool) {
 			if _, ok := tx.Block(*s.openedPos.Load()).(block.Grindstone); ok {
 				return s.ui, true
 			}
+		case protocol.ContainerTradeIngredientOne, protocol.ContainerTradeIngredientTwo,
+			protocol.ContainerTradeTwoIngredientOne, protocol.ContainerTradeTwoIngredientTwo:
+			if s.openedContainerID.Load() == protocol.ContainerTypeTrade {
+				return s.ui, true
+			}
 		case protocol.ContainerEnchantingInput, protocol.ContainerEnchantingMaterial:
 			if _, enchanting := tx.Block(*s.openedPos.Load()).(block.EnchantingTable); enchanting {
 				return s.ui, true
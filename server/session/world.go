@@ -75,6 +75,7 @@ func (s *Session) ViewEntity(e world.Entity) {
 
 	yaw, pitch := e.Rotation().Elem()
 	metadata := s.entityMetadata(e)
+	defer s.viewEntityLinks(e)
 
 	id := e.H().Type().EncodeEntity()
 	switch v := e.(type) {
@@ -157,9 +158,23 @@ func (s *Session) ViewEntity(e world.Entity) {
 		Yaw:             float32(yaw),
 		HeadYaw:         float32(yaw),
 		BodyYaw:         float32(yaw),
+		Attributes:      entityAttributes(e),
 	})
 }
 
+// entityAttributes returns the attributes of the entity passed that are sent when the entity is shown, such as the
+// jump strength of a horse.
+func entityAttributes(e world.Entity) []protocol.AttributeValue {
+	ent, ok := e.(*entity.Ent)
+	if !ok {
+		return nil
+	}
+	if j, ok := ent.Behaviour().(jumper); ok {
+		return []protocol.AttributeValue{{Name: "minecraft:horse.jump_strength", Value: float32(j.JumpStrength()), Max: 2}}
+	}
+	return nil
+}
+
 // ViewEntityGameMode ...
 func (s *Session) ViewEntityGameMode(e world.Entity) {
 	if s.entityHidden(e) {
@@ -300,7 +315,14 @@ func (s *Session) ViewEntityItems(e world.Entity) {
 		// Don't view the items of the entity if the entity is the Controllable entity of the session.
 		return
 	}
-	c, ok := e.(item.Carrier)
+	var carrier any = e
+	if ent, ok := e.(*entity.Ent); ok {
+		// Mobs hold items through their behaviour.
+		carrier = ent.Behaviour()
+	}
+	c, ok := carrier.(interface {
+		HeldItems() (mainHand, offHand item.Stack)
+	})
 	if !ok {
 		return
 	}
@@ -502,6 +524,11 @@ func (s *Session) ViewParticle(pos mgl64.Vec3, p world.Particle) {
 			EventType: packet.LevelEventParticleLegacyEvent | 10,
 			Position:  vec64To32(pos),
 		})
+	case particle.MobSpawn:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventParticlesMobBlockSpawn,
+			Position:  vec64To32(pos),
+		})
 	case particle.DustPlume:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventParticleLegacyEvent | 88,
@@ -574,6 +601,12 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 			Position:  vec64To32(pos),
 		})
 		return
+	case sound.ClickFail:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventSoundClickFail,
+			Position:  vec64To32(pos),
+		})
+		return
 	case sound.SignWaxed:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventWaxOn,
@@ -697,6 +730,8 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventFallSmall
 	case sound.Burp:
 		pk.SoundType = packet.SoundEventBurp
+	case sound.Shear:
+		pk.SoundType = packet.SoundEventShear
 	case sound.DoorOpen:
 		pk.SoundType, pk.ExtraData = packet.SoundEventDoorOpen, int32(s.br.BlockRuntimeID(so.Block))
 	case sound.DoorClose:
@@ -875,6 +910,10 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventPowerOff
 	case sound.LecternBookPlace:
 		pk.SoundType = packet.SoundEventLecternBookPlace
+	case sound.PistonExtend:
+		pk.SoundType = packet.SoundEventPistonOut
+	case sound.PistonRetract:
+		pk.SoundType = packet.SoundEventPistonIn
 	case sound.Totem:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventSoundTotemUsed,
@@ -1106,9 +1145,83 @@ func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 			EntityRuntimeID: s.entityRuntimeID(e),
 			EventType:       packet.ActorEventTalismanActivate,
 		})
+	case entity.LoveAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventLoveHearts,
+		})
+	case entity.EatGrassAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventEatGrass,
+		})
+	case entity.VillagerHappyAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventVillagerHappy,
+		})
+	case entity.TamingSucceededAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventTamingSucceeded,
+		})
+	case entity.TamingFailedAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventTamingFailed,
+		})
+	case entity.MountAction:
+		s.writeEntityLink(act.Vehicle.H(), e.H(), seatLinkType(act.Seat))
+	case entity.DismountAction:
+		s.writeEntityLink(act.Vehicle.H(), e.H(), protocol.EntityLinkRemove)
 	}
 }
 
+// viewEntityLinks shows the links between the entity passed and the entity it rides or the entity riding it, if
+// the session is already viewing the other entity.
+func (s *Session) viewEntityLinks(e world.Entity) {
+	if r, ok := e.(rider); ok {
+		if vehicle, ok := r.Riding(); ok && s.viewing(vehicle.H()) {
+			seat, _, _ := entity.SeatOf(vehicle, e.H())
+			s.writeEntityLink(vehicle.H(), e.H(), seatLinkType(seat))
+		}
+	}
+	for _, h := range entity.RidersOf(e) {
+		if s.viewing(h) {
+			seat, _, _ := entity.SeatOf(e, h)
+			s.writeEntityLink(e.H(), h, seatLinkType(seat))
+		}
+	}
+}
+
+// seatLinkType returns the type of link between a rider in the seat passed and the entity it rides. The rider in
+// the first seat controls the entity, while riders in other seats are passengers.
+func seatLinkType(seat int) byte {
+	if seat == 0 {
+		return protocol.EntityLinkRider
+	}
+	return protocol.EntityLinkPassenger
+}
+
+// writeEntityLink sends a link of the type passed between the ridden entity and its rider.
+func (s *Session) writeEntityLink(ridden, rider *world.EntityHandle, linkType byte) {
+	s.writePacket(&packet.SetActorLink{EntityLink: protocol.EntityLink{
+		RiddenEntityUniqueID: int64(s.handleRuntimeID(ridden)),
+		RiderEntityUniqueID:  int64(s.handleRuntimeID(rider)),
+		Type:                 linkType,
+		RiderInitiated:       true,
+	}})
+}
+
+// viewing checks if the session currently has a runtime ID for the entity passed, meaning the entity is shown to
+// the session.
+func (s *Session) viewing(h *world.EntityHandle) bool {
+	s.entityMutex.RLock()
+	defer s.entityMutex.RUnlock()
+	_, ok := s.entityRuntimeIDs[h]
+	return ok
+}
+
 // ViewEntityState ...
 func (s *Session) ViewEntityState(e world.Entity) {
 	s.writePacket(&packet.SetActorData{
@@ -1164,7 +1277,7 @@ func (s *Session) ViewEntityAnimation(e world.Entity, a world.EntityAnimation) {
 
 // OpenBlockContainer ...
 func (s *Session) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
-	if s.containerOpened.Load() && *s.openedPos.Load() == pos {
+	if s.containerOpened.Load() && s.openedEntity.Load() == nil && *s.openedPos.Load() == pos {
 		return
 	}
 	s.closeCurrentContainer(tx, false)
@@ -1244,6 +1357,10 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos, tx *world
 		containerType = protocol.ContainerTypeSmoker
 	case block.Hopper:
 		containerType = protocol.ContainerTypeHopper
+	case block.Dispenser:
+		containerType = protocol.ContainerTypeDispenser
+	case block.Dropper:
+		containerType = protocol.ContainerTypeDropper
 	}
 
 	s.openedContainerID.Store(uint32(containerType))
@@ -1256,6 +1373,104 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos, tx *world
 	s.sendInv(b.Inventory(tx, pos), uint32(nextID))
 }
 
+// OpenEntityContainer opens the inventory carried by the entity passed, such as the inventory of a chest minecart.
+// False is returned if the entity does not carry an inventory.
+func (s *Session) OpenEntityContainer(e world.Entity, tx *world.Tx) bool {
+	c, ok := entityContainer(e)
+	if !ok {
+		return false
+	}
+	inv, ok := c.Inventory()
+	if !ok {
+		return false
+	}
+	if s.containerOpened.Load() && s.openedEntity.Load() == e.H() {
+		return true
+	}
+	s.closeCurrentContainer(tx, false)
+	c.AddViewer(s)
+
+	nextID := s.nextWindowID()
+	// The position of the entity is stored as the opened position, so that no block container is considered to be
+	// opened while the entity container is.
+	pos := cube.PosFromVec3(e.Position())
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(inv)
+	s.openedPos.Store(&pos)
+	s.openedEntity.Store(e.H())
+
+	containerType := byte(protocol.ContainerTypeContainer)
+	switch e.H().Type() {
+	case entity.ChestMinecartType:
+		containerType = protocol.ContainerTypeCartChest
+	case entity.HopperMinecartType:
+		containerType = protocol.ContainerTypeCartHopper
+	case entity.ChestBoatType:
+		containerType = protocol.ContainerTypeChestBoat
+	}
+
+	s.openedContainerID.Store(uint32(containerType))
+	s.writePacket(&packet.ContainerOpen{
+		WindowID:                nextID,
+		ContainerType:           containerType,
+		ContainerPosition:       protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+		ContainerEntityUniqueID: int64(s.entityRuntimeID(e)),
+	})
+	s.sendInv(inv, uint32(nextID))
+	return true
+}
+
+// entityContainer returns the entity.ContainerBehaviour of the entity passed, if it has one.
+func entityContainer(e world.Entity) (entity.ContainerBehaviour, bool) {
+	if ent, ok := e.(*entity.Ent); ok {
+		c, ok := ent.Behaviour().(entity.ContainerBehaviour)
+		return c, ok
+	}
+	return nil, false
+}
+
+// OpenTrade opens the trading UI of the entity passed, such as a villager. False is returned if the entity does not
+// trade or if it is unable to trade with the player at the moment.
+func (s *Session) OpenTrade(e world.Entity, tx *world.Tx) bool {
+	ent, t, ok := entityTrader(e)
+	if !ok {
+		return false
+	}
+	if s.containerOpened.Load() && s.openedEntity.Load() == e.H() {
+		return true
+	}
+	c, ok := s.ent.Entity(tx)
+	if !ok {
+		return false
+	}
+	s.closeCurrentContainer(tx, false)
+	if !t.StartTrading(ent, c) {
+		return false
+	}
+
+	nextID := s.nextWindowID()
+	// Like with entity containers, the position of the entity is stored as the opened position.
+	pos := cube.PosFromVec3(e.Position())
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(inventory.New(1, nil))
+	s.openedPos.Store(&pos)
+	s.openedEntity.Store(e.H())
+	s.openedContainerID.Store(protocol.ContainerTypeTrade)
+
+	// The client opens the trading UI when it receives the trade offers, so no ContainerOpen packet is sent.
+	s.sendTradeOffers(ent, t, nextID)
+	return true
+}
+
+// entityTrader returns the entity.Ent and entity.TraderBehaviour of the entity passed, if it trades with players.
+func entityTrader(e world.Entity) (*entity.Ent, entity.TraderBehaviour, bool) {
+	if ent, ok := e.(*entity.Ent); ok {
+		t, ok := ent.Behaviour().(entity.TraderBehaviour)
+		return ent, t, ok
+	}
+	return nil, nil, false
+}
+
 // ViewSlotChange ...
 func (s *Session) ViewSlotChange(slot int, newItem item.Stack) {
 	if !s.containerOpened.Load() {
@@ -1287,6 +1502,16 @@ func (s *Session) ViewBlockAction(pos cube.Pos, a world.BlockAction) {
 			Position:  blockPos,
 			EventType: packet.BlockEventChangeChestState,
 		})
+	case block.PistonExtendAction:
+		// Pistons use an event type of 0, with event data 1 for extending and 0 for retracting.
+		s.writePacket(&packet.BlockEvent{
+			Position:  blockPos,
+			EventData: 1,
+		})
+	case block.PistonRetractAction:
+		s.writePacket(&packet.BlockEvent{
+			Position: blockPos,
+		})
 	case block.StartCrackAction:
 		if t.BreakTime <= 0 {
 			// An instant break has no cracking to animate, and encoding the crack speed would divide by zero.
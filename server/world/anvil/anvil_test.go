@@ -0,0 +1,200 @@
+package anvil_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/anvil"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+func TestConvert(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	writeRegion(t, filepath.Join(src, "region", "r.0.0.mca"), map[[2]int]map[string]any{
+		{0, 0}: testChunk(),
+		{1, 0}: {"DataVersion": int32(3953), "xPos": int32(1), "zPos": int32(0), "Status": "minecraft:features"},
+	})
+	writeNBT(t, filepath.Join(src, "level.dat"), map[string]any{"Data": map[string]any{
+		"LevelName": "Java World",
+		"SpawnX":    int32(8),
+		"SpawnY":    int32(70),
+		"SpawnZ":    int32(-8),
+		"DayTime":   int64(6000),
+	}})
+
+	db, err := mcdb.Open(dst)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+	report, err := anvil.Convert(src, db)
+	if err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+	if report.Chunks != 1 || report.SkippedChunks != 1 {
+		t.Errorf("expected 1 converted and 1 skipped chunk, got %v and %v", report.Chunks, report.SkippedChunks)
+	}
+	if !slices.Equal(report.UnmappedBlocks, []string{"minecraft:not_a_block"}) {
+		t.Errorf("expected a single unmapped block, got %v", report.UnmappedBlocks)
+	}
+	if !slices.Equal(report.UnmappedItems, []string{"minecraft:not_an_item"}) {
+		t.Errorf("expected a single unmapped item, got %v", report.UnmappedItems)
+	}
+	if !slices.Equal(report.UnsupportedBlockEntities, []string{"minecraft:beehive"}) {
+		t.Errorf("expected a single unsupported block entity, got %v", report.UnsupportedBlockEntities)
+	}
+	if s := db.Settings(); s.Name != "Java World" || s.Spawn != (cube.Pos{8, 70, -8}) || s.Time != 6000 {
+		t.Errorf("level.dat was not converted: got name %v, spawn %v and time %v", s.Name, s.Spawn, s.Time)
+	}
+
+	col, err := db.LoadColumn(world.ChunkPos{0, 0}, world.Overworld)
+	if err != nil {
+		t.Fatalf("load column: %v", err)
+	}
+	expected := []struct {
+		x, y, z uint8
+		b       world.Block
+	}{
+		{0, 0, 0, block.Stone{}},
+		{15, 0, 15, block.Stone{}},
+		{0, 1, 0, block.Chest{Facing: cube.North}},
+		{1, 1, 0, block.Chest{Facing: cube.North}},
+		{2, 1, 0, block.Air{}},
+		{3, 1, 0, block.Air{}},
+	}
+	for _, e := range expected {
+		rid := col.Chunk.Block(e.x, int16(e.y), e.z, 0)
+		if rid != world.DefaultBlockRegistry.BlockRuntimeID(e.b) {
+			b, _ := world.DefaultBlockRegistry.BlockByRuntimeID(rid)
+			t.Errorf("expected %#v at %v %v %v, got %#v", e.b, e.x, e.y, e.z, b)
+		}
+	}
+	if b, _ := world.BiomeByName("roofed_forest"); col.Chunk.Biome(4, 4, 4) != uint32(b.EncodeBiome()) {
+		t.Errorf("expected dark forest to be converted to roofed forest, got biome %v", col.Chunk.Biome(4, 4, 4))
+	}
+
+	blockEntities := make(map[cube.Pos]map[string]any)
+	for _, be := range col.BlockEntities {
+		blockEntities[be.Pos] = be.Data
+	}
+	if len(blockEntities) != 3 {
+		t.Fatalf("expected 3 block entities, got %v", len(blockEntities))
+	}
+	chest := blockEntities[cube.Pos{0, 1, 0}]
+	if nbtconv.String(chest, "id") != "Chest" || nbtconv.Int32(chest, "pairx") != 1 || nbtconv.Int32(chest, "pairz") != 0 {
+		t.Errorf("expected chest paired with 1 1 0, got %v", chest)
+	}
+	if items := nbtconv.Slice(chest, "Items"); len(items) != 1 || nbtconv.String(items[0].(map[string]any), "Name") != "minecraft:diamond" {
+		t.Errorf("expected chest to hold diamonds, got %v", items)
+	}
+	sign := blockEntities[cube.Pos{0, 2, 0}]
+	front, _ := sign["FrontText"].(map[string]any)
+	if nbtconv.String(front, "Text") != "Hello\nWorld" {
+		t.Errorf("expected sign text %q, got %q", "Hello\nWorld", nbtconv.String(front, "Text"))
+	}
+}
+
+// testChunk returns the NBT data of a Java Edition chunk at 0 0. Its bottom layer is filled with stone and it holds
+// a double chest with items, a sign and a beehive.
+func testChunk() map[string]any {
+	// The palette has 5 entries, so every index takes 4 bits and 16 indices fit in a long.
+	var states [256]int64
+	for i := range 4096 {
+		var index int64
+		switch {
+		case i < 256:
+			index = 1
+		case i == 256:
+			index = 2
+		case i == 257:
+			index = 3
+		case i == 258:
+			index = 4
+		}
+		states[i/16] |= index << (i % 16 * 4)
+	}
+	return map[string]any{
+		"DataVersion": int32(3953),
+		"xPos":        int32(0),
+		"zPos":        int32(0),
+		"Status":      "minecraft:full",
+		"sections": []any{map[string]any{
+			"Y": uint8(0),
+			"block_states": map[string]any{
+				"palette": []any{
+					map[string]any{"Name": "minecraft:air"},
+					map[string]any{"Name": "minecraft:stone"},
+					map[string]any{"Name": "minecraft:chest", "Properties": map[string]any{"facing": "north", "type": "left", "waterlogged": "false"}},
+					map[string]any{"Name": "minecraft:chest", "Properties": map[string]any{"facing": "north", "type": "right", "waterlogged": "false"}},
+					map[string]any{"Name": "minecraft:not_a_block"},
+				},
+				"data": states,
+			},
+			"biomes": map[string]any{"palette": []any{"minecraft:dark_forest"}},
+		}},
+		"block_entities": []any{
+			map[string]any{"id": "minecraft:chest", "x": int32(0), "y": int32(1), "z": int32(0), "Items": []any{
+				map[string]any{"id": "minecraft:diamond", "count": int32(3), "Slot": uint8(0)},
+				map[string]any{"id": "minecraft:not_an_item", "count": int32(1), "Slot": uint8(1)},
+			}},
+			map[string]any{"id": "minecraft:chest", "x": int32(1), "y": int32(1), "z": int32(0)},
+			map[string]any{"id": "minecraft:sign", "x": int32(0), "y": int32(2), "z": int32(0),
+				"front_text": map[string]any{"messages": []any{`"Hello"`, `{"text":"Wo","extra":["rld"]}`, `""`, `""`}, "color": "black"},
+				"back_text":  map[string]any{"messages": []any{`""`, `""`, `""`, `""`}, "color": "black"},
+			},
+			map[string]any{"id": "minecraft:beehive", "x": int32(0), "y": int32(3), "z": int32(0)},
+		},
+	}
+}
+
+// writeRegion writes a region file holding the chunks passed, compressed using zlib, to the path passed.
+func writeRegion(t *testing.T, path string, chunks map[[2]int]map[string]any) {
+	var header [2048]uint32
+	var sectors bytes.Buffer
+	for pos, data := range chunks {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		if err := nbt.NewEncoderWithEncoding(zw, nbt.BigEndian).Encode(data); err != nil {
+			t.Fatalf("encode chunk: %v", err)
+		}
+		_ = zw.Close()
+
+		offset := 2 + sectors.Len()/4096
+		_ = binary.Write(&sectors, binary.BigEndian, uint32(buf.Len()+1))
+		sectors.WriteByte(2)
+		sectors.Write(buf.Bytes())
+		sectors.Write(make([]byte, 4096-sectors.Len()%4096))
+		header[pos[1]*32+pos[0]] = uint32(offset<<8 | (2 + sectors.Len()/4096 - offset))
+	}
+	var f bytes.Buffer
+	_ = binary.Write(&f, binary.BigEndian, header)
+	f.Write(sectors.Bytes())
+	_ = os.MkdirAll(filepath.Dir(path), 0777)
+	if err := os.WriteFile(path, f.Bytes(), 0666); err != nil {
+		t.Fatalf("write region: %v", err)
+	}
+}
+
+// writeNBT writes the NBT data passed to a gzip compressed file at the path passed.
+func writeNBT(t *testing.T, path string, data map[string]any) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := nbt.NewEncoderWithEncoding(gz, nbt.BigEndian).Encode(data); err != nil {
+		t.Fatalf("encode nbt: %v", err)
+	}
+	_ = gz.Close()
+	if err := os.WriteFile(path, buf.Bytes(), 0666); err != nil {
+		t.Fatalf("write nbt: %v", err)
+	}
+}
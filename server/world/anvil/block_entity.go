@@ -0,0 +1,150 @@
+package anvil
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// containerIDs maps the IDs of Java Edition container block entities to the IDs of their Bedrock Edition
+// counterparts. The contents of these containers are converted.
+var containerIDs = map[string]string{
+	"minecraft:barrel":        "Barrel",
+	"minecraft:chest":         "Chest",
+	"minecraft:hopper":        "Hopper",
+	"minecraft:trapped_chest": "Chest",
+}
+
+// blockEntity converts the NBT data of a Java Edition block entity to a chunk.BlockEntity. Signs and containers are
+// converted, other block entities are reported as unsupported and false is returned for them.
+func (c *converter) blockEntity(data map[string]any, chests map[cube.Pos]cube.Direction) (chunk.BlockEntity, bool) {
+	id := nbtconv.String(data, "id")
+	pos := cube.Pos{int(nbtconv.Int32(data, "x")), int(nbtconv.Int32(data, "y")), int(nbtconv.Int32(data, "z"))}
+
+	var m map[string]any
+	switch id {
+	case "minecraft:sign":
+		m = c.sign(data)
+	default:
+		bedrockID, ok := containerIDs[id]
+		if !ok {
+			c.mu.Lock()
+			c.report.unsupportedBlockEntities[id] = struct{}{}
+			c.mu.Unlock()
+			return chunk.BlockEntity{}, false
+		}
+		m = map[string]any{"id": bedrockID, "Items": c.items(nbtconv.Slice(data, "Items"))}
+		if name := nbtconv.String(data, "CustomName"); name != "" {
+			m["CustomName"] = text(name)
+		}
+		if d, ok := chests[pos]; ok {
+			pair := pos.Side(d.Face())
+			m["pairx"], m["pairz"] = int32(pair[0]), int32(pair[2])
+		}
+	}
+	return chunk.BlockEntity{Pos: pos, Data: m}, true
+}
+
+// sign converts the NBT data of a Java Edition sign to that of a Bedrock Edition sign. Both the format used since
+// Java Edition 1.20, with text on both sides, and the older format are supported.
+func (c *converter) sign(data map[string]any) map[string]any {
+	m := map[string]any{"id": "Sign", "IsWaxed": nbtconv.Uint8(data, "is_waxed")}
+	if front, ok := data["front_text"].(map[string]any); ok {
+		back, _ := data["back_text"].(map[string]any)
+		m["FrontText"], m["BackText"] = signText(front), signText(back)
+		return m
+	}
+	m["FrontText"] = signText(map[string]any{
+		"messages":         []any{data["Text1"], data["Text2"], data["Text3"], data["Text4"]},
+		"color":            data["Color"],
+		"has_glowing_text": data["GlowingText"],
+	})
+	m["BackText"] = signText(nil)
+	return m
+}
+
+// signText converts the text on one side of a Java Edition sign to the format used by Bedrock Edition.
+func signText(data map[string]any) map[string]any {
+	lines := make([]string, 0, 4)
+	for _, msg := range nbtconv.Slice(data, "messages") {
+		lines = append(lines, text(msg))
+	}
+	colour := item.ColourBlack()
+	for _, c := range item.Colours() {
+		if c.String() == nbtconv.String(data, "color") {
+			colour = c
+		}
+	}
+	return map[string]any{
+		"Text":           strings.TrimRight(strings.Join(lines, "\n"), "\n"),
+		"SignTextColor":  nbtconv.Int32FromRGBA(colour.SignRGBA()),
+		"IgnoreLighting": nbtconv.Uint8(data, "has_glowing_text"),
+		"TextOwner":      "",
+	}
+}
+
+// text converts a Java Edition text component to plain text. Text components are either stored as JSON or, since
+// Java Edition 1.21.5, as NBT. Formatting of the text is not preserved.
+func text(v any) string {
+	switch v := v.(type) {
+	case string:
+		var component any
+		if err := json.Unmarshal([]byte(v), &component); err != nil {
+			// Not JSON, so the string holds the text itself.
+			return v
+		}
+		if s, ok := component.(string); ok {
+			return s
+		}
+		return text(component)
+	case map[string]any:
+		s, _ := v["text"].(string)
+		extra, _ := v["extra"].([]any)
+		for _, e := range extra {
+			s += text(e)
+		}
+		return s
+	case []any:
+		var s string
+		for _, e := range v {
+			s += text(e)
+		}
+		return s
+	}
+	return ""
+}
+
+// items converts the items of a Java Edition container to Bedrock Edition items. Items that have no Bedrock Edition
+// counterpart with the same name are left out and reported. Item components, such as enchantments, are not
+// converted.
+func (c *converter) items(data []any) []any {
+	items := make([]any, 0, len(data))
+	for _, v := range data {
+		m, _ := v.(map[string]any)
+		name := nbtconv.String(m, "id")
+		count := int(nbtconv.Int32(m, "count"))
+		if count == 0 {
+			// Item counts were stored as bytes before Java Edition 1.20.5.
+			count = int(nbtconv.Uint8(m, "Count"))
+		}
+		it, ok := world.ItemByName(name, 0)
+		if !ok {
+			c.mu.Lock()
+			c.report.unmappedItems[name] = struct{}{}
+			c.mu.Unlock()
+			continue
+		}
+		if count <= 0 {
+			continue
+		}
+		itemData := item.WriteNBT(item.NewStack(it, count), true)
+		itemData["Slot"] = nbtconv.Uint8(m, "Slot")
+		items = append(items, itemData)
+	}
+	return items
+}
@@ -0,0 +1,284 @@
+package anvil
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// minDataVersion is the lowest data version of chunks that may be converted. It is the data version of Java Edition
+// 1.18, which introduced the current chunk format.
+const minDataVersion = 2860
+
+// errNotGenerated is returned when converting a chunk that has not yet been fully generated by Java Edition.
+var errNotGenerated = errors.New("chunk not fully generated")
+
+// blockEntry is a Java Edition block state translated to the runtime IDs of a block and a liquid.
+type blockEntry struct {
+	rid    uint32
+	liquid uint32
+	// waterlogged specifies if liquid should be placed in the second layer.
+	waterlogged bool
+	// pair holds the direction of the other half of a double chest, if paired is true.
+	pair   cube.Direction
+	paired bool
+}
+
+// column converts the NBT data of a Java Edition chunk to a chunk.Column for the dimension passed.
+func (c *converter) column(data map[string]any, dim world.Dimension) (world.ChunkPos, *chunk.Column, error) {
+	pos := world.ChunkPos{nbtconv.Int32(data, "xPos"), nbtconv.Int32(data, "zPos")}
+	if v := nbtconv.Int32(data, "DataVersion"); v < minDataVersion {
+		return pos, nil, fmt.Errorf("chunk data version %v is not supported, the world must be opened in Java Edition 1.18 or newer first", v)
+	}
+	if status := strings.TrimPrefix(nbtconv.String(data, "Status"), "minecraft:"); status != "full" {
+		return pos, nil, errNotGenerated
+	}
+
+	col := &chunk.Column{Chunk: chunk.New(c.conf.Blocks, dim.Range())}
+	r := dim.Range()
+	chests := make(map[cube.Pos]cube.Direction)
+	for _, v := range nbtconv.Slice(data, "sections") {
+		section, _ := v.(map[string]any)
+		baseY := int(int8(nbtconv.Uint8(section, "Y"))) << 4
+		if baseY < r[0] || baseY > r[1] {
+			// Java Edition worlds may hold sections outside the range of the dimension, such as the sections
+			// holding the light above the highest blocks.
+			continue
+		}
+		blockStates, _ := section["block_states"].(map[string]any)
+		if err := c.blocks(col, pos, baseY, blockStates, chests); err != nil {
+			return pos, nil, err
+		}
+		biomes, _ := section["biomes"].(map[string]any)
+		if err := c.biomes(col, baseY, biomes, dim); err != nil {
+			return pos, nil, err
+		}
+	}
+	for _, v := range nbtconv.Slice(data, "block_entities") {
+		be, _ := v.(map[string]any)
+		if blockEntity, ok := c.blockEntity(be, chests); ok {
+			col.BlockEntities = append(col.BlockEntities, blockEntity)
+		}
+	}
+	return pos, col, nil
+}
+
+// blocks converts the block states of a section with the base y passed and writes them to the column. The positions
+// of chests that are part of a double chest are added to chests.
+func (c *converter) blocks(col *chunk.Column, pos world.ChunkPos, baseY int, data map[string]any, chests map[cube.Pos]cube.Direction) error {
+	palette := nbtconv.Slice(data, "palette")
+	if len(palette) == 0 {
+		return nil
+	}
+	entries := make([]blockEntry, len(palette))
+	for i, v := range palette {
+		state, _ := v.(map[string]any)
+		properties := make(map[string]string)
+		if props, ok := state["Properties"].(map[string]any); ok {
+			for k, v := range props {
+				properties[k], _ = v.(string)
+			}
+		}
+		entries[i] = c.block(nbtconv.String(state, "Name"), properties)
+	}
+	if len(entries) == 1 && entries[0].rid == c.air && !entries[0].waterlogged {
+		return nil
+	}
+
+	indices, err := unpack(data["data"], max(4, bits.Len(uint(len(entries)-1))), 4096, len(entries))
+	if err != nil {
+		return fmt.Errorf("section %v: block states: %w", baseY>>4, err)
+	}
+	for i := range 4096 {
+		e := entries[indices[i]]
+		x, y, z := uint8(i&15), int16(baseY+i>>8), uint8(i>>4&15)
+		col.Chunk.SetBlock(x, y, z, 0, e.rid)
+		if e.waterlogged {
+			col.Chunk.SetBlock(x, y, z, 1, e.liquid)
+		}
+		if e.paired {
+			chests[cube.Pos{int(pos[0])<<4 | int(x), int(y), int(pos[1])<<4 | int(z)}] = e.pair
+		}
+	}
+	return nil
+}
+
+// block translates a Java Edition block state to a blockEntry. Translations are cached so that every state is only
+// translated once per conversion. States that cannot be translated are converted to air.
+func (c *converter) block(name string, properties map[string]string) blockEntry {
+	key := stateString(name, properties)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.blockCache[key]; ok {
+		return e
+	}
+	var e blockEntry
+	b, liq, ok := c.t.Translate(name, properties)
+	if !ok {
+		c.report.unmappedBlocks[key] = struct{}{}
+		e.rid = c.air
+	} else {
+		e.rid = c.conf.Blocks.BlockRuntimeID(b)
+		if liq != nil {
+			e.liquid, e.waterlogged = c.conf.Blocks.BlockRuntimeID(liq), true
+		}
+	}
+	if t := properties["type"]; (name == "minecraft:chest" || name == "minecraft:trapped_chest") && t != "single" {
+		if facing, ok := directionByName(properties["facing"]); ok {
+			// The other half of a double chest is to the right of the left half when looking at the front of
+			// the chest, and vice versa.
+			e.pair, e.paired = facing.RotateRight(), true
+			if t == "right" {
+				e.pair = facing.RotateLeft()
+			}
+		}
+	}
+	c.blockCache[key] = e
+	return e
+}
+
+// biomes converts the biomes of a section with the base y passed and writes them to the column. Java Edition stores
+// a biome for every 4x4x4 area of blocks.
+func (c *converter) biomes(col *chunk.Column, baseY int, data map[string]any, dim world.Dimension) error {
+	palette := nbtconv.Slice(data, "palette")
+	if len(palette) == 0 {
+		return nil
+	}
+	ids := make([]uint32, len(palette))
+	for i, v := range palette {
+		name, _ := v.(string)
+		ids[i] = c.biome(name, dim)
+	}
+	indices, err := unpack(data["data"], bits.Len(uint(len(ids)-1)), 64, len(ids))
+	if err != nil {
+		return fmt.Errorf("section %v: biomes: %w", baseY>>4, err)
+	}
+	for i := range 4096 {
+		x, y, z := i&15, i>>8, i>>4&15
+		col.Chunk.SetBiome(uint8(x), int16(baseY+y), uint8(z), ids[indices[(y>>2)<<4|(z>>2)<<2|x>>2]])
+	}
+	return nil
+}
+
+// biome translates the name of a Java Edition biome to the ID of a biome. Biomes that cannot be translated are
+// converted to the default biome of the dimension passed.
+func (c *converter) biome(name string, dim world.Dimension) uint32 {
+	name = strings.TrimPrefix(name, "minecraft:")
+	if n, ok := javaBiomes[name]; ok {
+		name = n
+	}
+	if b, ok := world.BiomeByName(name); ok {
+		return uint32(b.EncodeBiome())
+	}
+	c.mu.Lock()
+	c.report.unmappedBiomes["minecraft:"+name] = struct{}{}
+	c.mu.Unlock()
+
+	var def world.Biome = biome.Plains{}
+	switch dim {
+	case world.Nether:
+		def = biome.NetherWastes{}
+	case world.End:
+		def = biome.End{}
+	}
+	return uint32(def.EncodeBiome())
+}
+
+// javaBiomes maps the names of Java Edition biomes to the names of their Bedrock Edition counterparts where these
+// differ.
+var javaBiomes = map[string]string{
+	"badlands":                 "mesa",
+	"dark_forest":              "roofed_forest",
+	"end_barrens":              "the_end",
+	"end_highlands":            "the_end",
+	"end_midlands":             "the_end",
+	"eroded_badlands":          "mesa_bryce",
+	"ice_spikes":               "ice_plains_spikes",
+	"mushroom_fields":          "mushroom_island",
+	"nether_wastes":            "hell",
+	"old_growth_birch_forest":  "birch_forest_mutated",
+	"old_growth_pine_taiga":    "mega_taiga",
+	"old_growth_spruce_taiga":  "redwood_taiga_mutated",
+	"small_end_islands":        "the_end",
+	"snowy_beach":              "cold_beach",
+	"snowy_plains":             "ice_plains",
+	"snowy_taiga":              "cold_taiga",
+	"soul_sand_valley":         "soulsand_valley",
+	"sparse_jungle":            "jungle_edge",
+	"stony_shore":              "stone_beach",
+	"swamp":                    "swampland",
+	"the_void":                 "plains",
+	"windswept_forest":         "extreme_hills_plus_trees",
+	"windswept_gravelly_hills": "extreme_hills_mutated",
+	"windswept_hills":          "extreme_hills",
+	"windswept_savanna":        "savanna_mutated",
+	"wooded_badlands":          "mesa_plateau_stone",
+}
+
+// unpack unpacks n indices of bitsPerIndex bits from the long array passed, as used by Java Edition for block
+// states and biomes. Indices do not span multiple longs. If bitsPerIndex is 0, all indices are 0. An error is
+// returned if the array is too short or if an index is not within the range of a palette with the length passed.
+func unpack(v any, bitsPerIndex, n, paletteLen int) ([]uint16, error) {
+	indices := make([]uint16, n)
+	if bitsPerIndex == 0 {
+		return indices, nil
+	}
+	longs, perLong := int64s(v), 64/bitsPerIndex
+	if len(longs) < (n+perLong-1)/perLong {
+		return nil, fmt.Errorf("expected %v longs for %v bits per index, got %v", (n+perLong-1)/perLong, bitsPerIndex, len(longs))
+	}
+	mask := uint64(1)<<bitsPerIndex - 1
+	for i := range indices {
+		index := uint16(uint64(longs[i/perLong]) >> ((i % perLong) * bitsPerIndex) & mask)
+		if int(index) >= paletteLen {
+			return nil, fmt.Errorf("palette index %v out of range", index)
+		}
+		indices[i] = index
+	}
+	return indices, nil
+}
+
+// int64s converts a long array decoded from NBT to a []int64.
+func int64s(v any) []int64 {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Array || val.Type().Elem().Kind() != reflect.Int64 {
+		return nil
+	}
+	s := make([]int64, val.Len())
+	reflect.Copy(reflect.ValueOf(s), val)
+	return s
+}
+
+// stateString formats the name and properties of a Java Edition block state as a block state string, such as
+// "minecraft:chest[facing=north,type=single,waterlogged=false]". Properties are sorted by name.
+func stateString(name string, properties map[string]string) string {
+	if len(properties) == 0 {
+		return name
+	}
+	props := make([]string, 0, len(properties))
+	for k, v := range properties {
+		props = append(props, k+"="+v)
+	}
+	slices.Sort(props)
+	return name + "[" + strings.Join(props, ",") + "]"
+}
+
+// directionByName returns the cube.Direction with the name passed, such as "north".
+func directionByName(name string) (cube.Direction, bool) {
+	for _, d := range cube.Directions() {
+		if d.String() == name {
+			return d, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,282 @@
+package anvil
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/javablock"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// Config holds the optional parameters of a conversion.
+type Config struct {
+	// Log is the Logger that will be used to log errors and debug messages to.
+	// If set to nil, Log is set to slog.Default().
+	Log *slog.Logger
+	// Blocks is the BlockRegistry that Java Edition block states are translated to. If nil,
+	// world.DefaultBlockRegistry is used. It must be the same registry as the one used by the mcdb.DB that
+	// columns are stored in.
+	Blocks world.BlockRegistry
+	// Workers is the number of region files that are converted at the same time. If 0 or lower, Workers is set to
+	// the number of CPUs.
+	Workers int
+}
+
+// Report holds the results of a conversion. Unmapped blocks, biomes and items are replaced with air, the default
+// biome of the dimension and nothing respectively.
+type Report struct {
+	// Chunks is the number of chunks that were converted and stored.
+	Chunks int
+	// SkippedChunks is the number of chunks that were not converted, either because they were not fully generated
+	// or because their data could not be read.
+	SkippedChunks int
+	// UnmappedBlocks holds the sorted Java Edition block states that could not be translated.
+	UnmappedBlocks []string
+	// UnmappedBiomes holds the sorted names of Java Edition biomes that could not be translated.
+	UnmappedBiomes []string
+	// UnmappedItems holds the sorted names of Java Edition items in containers that could not be translated.
+	UnmappedItems []string
+	// UnsupportedBlockEntities holds the sorted IDs of Java Edition block entities that were not converted.
+	UnsupportedBlockEntities []string
+}
+
+// dimensions maps the directories holding the region files of a Java Edition world to the dimension of the chunks
+// in them.
+var dimensions = []struct {
+	dir string
+	dim world.Dimension
+}{
+	{dir: "region", dim: world.Overworld},
+	{dir: filepath.Join("DIM-1", "region"), dim: world.Nether},
+	{dir: filepath.Join("DIM1", "region"), dim: world.End},
+}
+
+// Convert converts the Java Edition world in the directory src using the default Config. See Config.Convert for
+// more information.
+func Convert(src string, db *mcdb.DB) (Report, error) {
+	var conf Config
+	return conf.Convert(src, db)
+}
+
+// Convert converts the Java Edition world in the directory src, writing its chunks to the mcdb.DB passed using
+// mcdb.DB.StoreColumn, so that the world may be opened by dragonfly. Only worlds saved by Java Edition 1.18 or
+// newer are supported. The chunk sections, biomes and the block entities of signs and containers are converted.
+// Heightmaps are not converted, as they are computed from the blocks of a chunk when it is loaded. If the world
+// holds a level.dat, its name, spawn position, time and weather are also converted and saved to the settings of
+// the mcdb.DB.
+//
+// Chunks that could not be read are skipped and logged. An error is returned only if src is not a Java Edition
+// world or if a chunk could not be stored.
+func (conf Config) Convert(src string, db *mcdb.DB) (Report, error) {
+	if conf.Log == nil {
+		conf.Log = slog.Default()
+	}
+	conf.Log = conf.Log.With("src", src)
+	if conf.Blocks == nil {
+		conf.Blocks = world.DefaultBlockRegistry
+	}
+	conf.Blocks.Finalize()
+	if conf.Workers <= 0 {
+		conf.Workers = runtime.NumCPU()
+	}
+	c := &converter{
+		conf:       conf,
+		db:         db,
+		t:          javablock.NewTranslator(conf.Blocks),
+		blockCache: make(map[string]blockEntry),
+		report: report{
+			unmappedBlocks:           make(map[string]struct{}),
+			unmappedBiomes:           make(map[string]struct{}),
+			unmappedItems:            make(map[string]struct{}),
+			unsupportedBlockEntities: make(map[string]struct{}),
+		},
+	}
+	c.air = conf.Blocks.BlockRuntimeID(conf.Blocks.Air())
+
+	var regions int
+	for _, d := range dimensions {
+		files, err := filepath.Glob(filepath.Join(src, d.dir, "r.*.*.mca"))
+		if err != nil {
+			return Report{}, fmt.Errorf("convert: %w", err)
+		}
+		regions += len(files)
+		for _, f := range files {
+			c.queue(f, d.dim)
+		}
+	}
+	if regions == 0 {
+		return Report{}, fmt.Errorf("convert: %v holds no Java Edition region files", src)
+	}
+	if err := c.wait(); err != nil {
+		return c.report.result(), err
+	}
+	if err := c.convertSettings(filepath.Join(src, "level.dat")); err != nil {
+		return c.report.result(), err
+	}
+	return c.report.result(), nil
+}
+
+// converter converts the regions of a Java Edition world.
+type converter struct {
+	conf Config
+	db   *mcdb.DB
+	t    *javablock.Translator
+	air  uint32
+
+	wg  sync.WaitGroup
+	sem chan struct{}
+
+	mu         sync.Mutex
+	blockCache map[string]blockEntry
+	report     report
+	err        error
+}
+
+// report holds the results of a conversion while it is in progress.
+type report struct {
+	chunks, skippedChunks    int
+	unmappedBlocks           map[string]struct{}
+	unmappedBiomes           map[string]struct{}
+	unmappedItems            map[string]struct{}
+	unsupportedBlockEntities map[string]struct{}
+}
+
+// result converts the report to a Report.
+func (r report) result() Report {
+	return Report{
+		Chunks:                   r.chunks,
+		SkippedChunks:            r.skippedChunks,
+		UnmappedBlocks:           slices.Sorted(maps.Keys(r.unmappedBlocks)),
+		UnmappedBiomes:           slices.Sorted(maps.Keys(r.unmappedBiomes)),
+		UnmappedItems:            slices.Sorted(maps.Keys(r.unmappedItems)),
+		UnsupportedBlockEntities: slices.Sorted(maps.Keys(r.unsupportedBlockEntities)),
+	}
+}
+
+// queue starts converting the region file at the path passed as soon as fewer than Config.Workers regions are
+// being converted.
+func (c *converter) queue(path string, dim world.Dimension) {
+	if c.sem == nil {
+		c.sem = make(chan struct{}, c.conf.Workers)
+	}
+	c.sem <- struct{}{}
+	c.wg.Go(func() {
+		defer func() { <-c.sem }()
+		if err := c.region(path, dim); err != nil {
+			c.mu.Lock()
+			c.err = errors.Join(c.err, err)
+			c.mu.Unlock()
+		}
+	})
+}
+
+// wait waits until all queued regions are converted and returns the errors that occurred.
+func (c *converter) wait() error {
+	c.wg.Wait()
+	return c.err
+}
+
+// region converts all chunks in the region file at the path passed.
+func (c *converter) region(path string, dim world.Dimension) error {
+	log := c.conf.Log.With("region", filepath.Base(path), "dimension", fmt.Sprint(dim))
+	r, err := OpenRegion(path)
+	if err != nil {
+		log.Error("read region: " + err.Error())
+		return nil
+	}
+	defer r.Close()
+
+	for z := range regionSize {
+		for x := range regionSize {
+			data, err := r.Chunk(x, z)
+			if err != nil {
+				log.Error("read chunk: " + err.Error())
+				c.skip()
+				continue
+			}
+			if data == nil {
+				continue
+			}
+			pos, col, err := c.column(data, dim)
+			if errors.Is(err, errNotGenerated) {
+				c.skip()
+				continue
+			} else if err != nil {
+				log.Error("convert chunk: "+err.Error(), "X", pos[0], "Z", pos[1])
+				c.skip()
+				continue
+			}
+			if err := c.db.StoreColumn(pos, dim, col); err != nil {
+				return fmt.Errorf("convert: store column %v: %w", pos, err)
+			}
+			c.mu.Lock()
+			c.report.chunks++
+			c.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// skip records that a chunk was skipped.
+func (c *converter) skip() {
+	c.mu.Lock()
+	c.report.skippedChunks++
+	c.mu.Unlock()
+}
+
+// convertSettings reads the Java Edition level.dat at the path passed and applies its settings to the settings of
+// the mcdb.DB. Nothing happens if the file does not exist.
+func (c *converter) convertSettings(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("convert: read level.dat: %w", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("convert: read level.dat: %w", err)
+	}
+	defer gz.Close()
+
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(gz, nbt.BigEndian).Decode(&m); err != nil {
+		return fmt.Errorf("convert: read level.dat: decode nbt: %w", err)
+	}
+	data, _ := m["Data"].(map[string]any)
+
+	s := c.db.Settings()
+	s.Lock()
+	defer s.Unlock()
+	if name := strings.TrimSpace(nbtconv.String(data, "LevelName")); name != "" {
+		s.Name = name
+	}
+	s.Spawn = cube.Pos{int(nbtconv.Int32(data, "SpawnX")), int(nbtconv.Int32(data, "SpawnY")), int(nbtconv.Int32(data, "SpawnZ"))}
+	if spawn, ok := data["spawn"].(map[string]any); ok {
+		// Java Edition 1.21.9 and newer store the spawn position in a compound tag.
+		if pos, ok := spawn["pos"].([3]int32); ok {
+			s.Spawn = cube.Pos{int(pos[0]), int(pos[1]), int(pos[2])}
+		}
+	}
+	s.Time = nbtconv.Int64(data, "DayTime")
+	s.CurrentTick = nbtconv.Int64(data, "Time")
+	s.Raining, s.Thundering = nbtconv.Bool(data, "raining"), nbtconv.Bool(data, "thundering")
+	s.RainTime = int64(nbtconv.Int32(data, "rainTime"))
+	s.ThunderTime = int64(nbtconv.Int32(data, "thunderTime"))
+	c.db.SaveSettings(s)
+	return nil
+}
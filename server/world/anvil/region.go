@@ -0,0 +1,124 @@
+package anvil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+const (
+	// sectorSize is the size of a sector in a region file. Chunks are stored in whole sectors.
+	sectorSize = 4096
+	// regionSize is the width and length of a region in chunks.
+	regionSize = 32
+)
+
+// Compression types of chunks stored in a region file.
+const (
+	compressionGzip         = 1
+	compressionZlib         = 2
+	compressionNone         = 3
+	compressionLZ4          = 4
+	compressionExternalFlag = 128
+)
+
+// Region is a region file (.mca) of a Java Edition world. A region holds up to 32x32 chunks. A Region may be
+// opened using OpenRegion.
+type Region struct {
+	f    *os.File
+	path string
+	// locations holds the location of every chunk in the region: the offset of its first sector in the upper 24
+	// bits and the number of sectors it spans in the lower 8 bits.
+	locations [regionSize * regionSize]uint32
+}
+
+// OpenRegion opens the region file at the path passed. The Region must be closed using Region.Close after use.
+func OpenRegion(path string) (*Region, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open region: %w", err)
+	}
+	r := &Region{f: f, path: path}
+	if err := binary.Read(io.NewSectionReader(f, 0, sectorSize), binary.BigEndian, &r.locations); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("open region %v: read header: %w", filepath.Base(path), err)
+	}
+	return r, nil
+}
+
+// Chunk reads the NBT data of the chunk at the x and z passed, which are relative to the region and must be in the
+// range 0-31. If the region holds no chunk at the position, nil and no error is returned.
+func (r *Region) Chunk(x, z int) (map[string]any, error) {
+	loc := r.locations[z*regionSize+x]
+	if loc == 0 {
+		return nil, nil
+	}
+	offset, sectors := int64(loc>>8)*sectorSize, int64(loc&0xff)*sectorSize
+
+	header := make([]byte, 5)
+	if _, err := r.f.ReadAt(header, offset); err != nil {
+		return nil, fmt.Errorf("read chunk %v %v: %w", x, z, err)
+	}
+	length, compression := int64(binary.BigEndian.Uint32(header)), header[4]
+	if length < 1 || length+4 > sectors {
+		return nil, fmt.Errorf("read chunk %v %v: invalid length %v", x, z, length)
+	}
+
+	var data io.Reader = io.NewSectionReader(r.f, offset+5, length-1)
+	if compression&compressionExternalFlag != 0 {
+		// Chunks too large to fit in a region file are stored in a separate file next to it.
+		global := r.globalChunk(x, z)
+		external, err := os.ReadFile(filepath.Join(filepath.Dir(r.path), fmt.Sprintf("c.%v.%v.mcc", global[0], global[1])))
+		if err != nil {
+			return nil, fmt.Errorf("read chunk %v %v: %w", x, z, err)
+		}
+		data, compression = bytes.NewReader(external), compression&^compressionExternalFlag
+	}
+
+	switch compression {
+	case compressionGzip:
+		gz, err := gzip.NewReader(data)
+		if err != nil {
+			return nil, fmt.Errorf("read chunk %v %v: %w", x, z, err)
+		}
+		defer gz.Close()
+		data = gz
+	case compressionZlib:
+		zr, err := zlib.NewReader(data)
+		if err != nil {
+			return nil, fmt.Errorf("read chunk %v %v: %w", x, z, err)
+		}
+		defer zr.Close()
+		data = zr
+	case compressionNone:
+	case compressionLZ4:
+		return nil, fmt.Errorf("read chunk %v %v: LZ4 compressed chunks are not supported", x, z)
+	default:
+		return nil, fmt.Errorf("read chunk %v %v: unsupported compression type %v", x, z, compression)
+	}
+
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(data, nbt.BigEndian).Decode(&m); err != nil {
+		return nil, fmt.Errorf("read chunk %v %v: decode nbt: %w", x, z, err)
+	}
+	return m, nil
+}
+
+// globalChunk returns the world position of a chunk in the region, derived from the name of the region file.
+func (r *Region) globalChunk(x, z int) [2]int {
+	var rx, rz int
+	_, _ = fmt.Sscanf(filepath.Base(r.path), "r.%d.%d.mca", &rx, &rz)
+	return [2]int{rx*regionSize + x, rz*regionSize + z}
+}
+
+// Close closes the region file.
+func (r *Region) Close() error {
+	return r.f.Close()
+}
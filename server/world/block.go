@@ -159,6 +159,15 @@ type NeighbourUpdateTicker interface {
 	NeighbourUpdateTick(pos, changedNeighbour cube.Pos, tx *Tx)
 }
 
+// NeighbourStateObserver represents a block that is notified when the block state of a block adjacent to it
+// changes, such as an observer. Unlike a NeighbourUpdateTicker, it is not notified of updates that did not change the
+// block state of the neighbour, for example when only its block entity data changed.
+type NeighbourStateObserver interface {
+	// NeighbourStateChange handles the block state of a neighbouring block changing. The position of that block and
+	// the position of this block is passed.
+	NeighbourStateChange(pos, changedNeighbour cube.Pos, tx *Tx)
+}
+
 // NBTer represents either an item or a block which may decode NBT data and encode to NBT data. Typically,
 // this is done to store additional data.
 type NBTer interface {
@@ -69,6 +69,29 @@ func (chunk *Chunk) Clone() *Chunk {
 	return clone
 }
 
+// Share returns a copy of the Chunk that shares the block storages of its sub chunks with the Chunk. Block
+// storages are copied only once the copy changes them, so that sharing a Chunk is cheap. Light, biomes and the
+// height map are copied directly. The Chunk itself must not be changed after calling Share, but may be shared any
+// number of times, also simultaneously from multiple goroutines.
+func (chunk *Chunk) Share() *Chunk {
+	clone := &Chunk{
+		r:                    chunk.r,
+		br:                   chunk.br,
+		air:                  chunk.air,
+		recalculateHeightMap: chunk.recalculateHeightMap,
+		heightMap:            slices.Clone(chunk.heightMap),
+		sub:                  make([]*SubChunk, len(chunk.sub)),
+		biomes:               make([]*PalettedStorage, len(chunk.biomes)),
+	}
+	for i, sub := range chunk.sub {
+		clone.sub[i] = sub.share()
+	}
+	for i, biomes := range chunk.biomes {
+		clone.biomes[i] = biomes.Clone()
+	}
+	return clone
+}
+
 // Equals returns if the chunk passed is equal to the current one
 func (chunk *Chunk) Equals(c *Chunk) bool {
 	if !chunk.recalculateHeightMap && !c.recalculateHeightMap && !slices.Equal(c.heightMap, chunk.heightMap) {
@@ -5,8 +5,11 @@ import "slices"
 // SubChunk is a cube of blocks located in a chunk. It has a size of 16x16x16 blocks and forms part of a stack
 // that forms a Chunk.
 type SubChunk struct {
-	air        uint32
-	storages   []*PalettedStorage
+	air      uint32
+	storages []*PalettedStorage
+	// shared is true if storages are shared with another SubChunk. The storages are then copied before they are
+	// first changed.
+	shared     bool
 	blockLight []uint8
 	skyLight   []uint8
 }
@@ -45,6 +48,31 @@ func (sub *SubChunk) Clone() *SubChunk {
 	return clone
 }
 
+// share returns a copy of the SubChunk that shares its block storages with the SubChunk until the copy is first
+// changed. The light of the SubChunk is copied directly.
+func (sub *SubChunk) share() *SubChunk {
+	return &SubChunk{
+		air:        sub.air,
+		storages:   sub.storages,
+		shared:     true,
+		blockLight: cloneLight(sub.blockLight),
+		skyLight:   cloneLight(sub.skyLight),
+	}
+}
+
+// own copies the block storages of the SubChunk if they are shared with another SubChunk, so that they may be
+// changed.
+func (sub *SubChunk) own() {
+	if !sub.shared {
+		return
+	}
+	storages := make([]*PalettedStorage, len(sub.storages))
+	for i, storage := range sub.storages {
+		storages[i] = storage.Clone()
+	}
+	sub.storages, sub.shared = storages, false
+}
+
 func cloneLight(light []uint8) []uint8 {
 	if len(light) == 0 {
 		return slices.Clone(light)
@@ -68,6 +96,7 @@ func (sub *SubChunk) Empty() bool {
 // Layer returns a certain block storage/layer from a sub chunk. If no storage at the layer exists, the layer
 // is created, as well as all layers between the current highest layer and the new highest layer.
 func (sub *SubChunk) Layer(layer uint8) *PalettedStorage {
+	sub.own()
 	for uint8(len(sub.storages)) <= layer {
 		// Keep appending to storages until the requested layer is achieved. Makes working with new layers
 		// much easier.
@@ -76,7 +105,8 @@ func (sub *SubChunk) Layer(layer uint8) *PalettedStorage {
 	return sub.storages[layer]
 }
 
-// Layers returns all layers in the sub chunk. This method may also return an empty slice.
+// Layers returns all layers in the sub chunk. This method may also return an empty slice. The layers returned may
+// be shared with other sub chunks and must not be changed: Layer or SetBlock should be used to change blocks.
 func (sub *SubChunk) Layers() []*PalettedStorage {
 	return sub.storages
 }
@@ -136,6 +166,10 @@ func (sub *SubChunk) SkyLight(x, y, z byte) uint8 {
 // compactForRuntimeCache performs cheap in-memory compaction on the sub chunk. Unlike compact, it does not scan
 // multi-value storages for unused palette entries unless they are uniform and can be detected from packed words.
 func (sub *SubChunk) compactForRuntimeCache() {
+	if sub.shared {
+		// The storages are owned by another sub chunk, which is responsible for compacting them.
+		return
+	}
 	storages := sub.storages[:0]
 	for _, storage := range sub.storages {
 		storage.compactForRuntimeCache()
@@ -151,6 +185,10 @@ func (sub *SubChunk) compactForRuntimeCache() {
 // Compact cleans the garbage from all block storages that sub chunk contains, so that they may be
 // cleanly written to a database.
 func (sub *SubChunk) compact() {
+	if sub.shared {
+		// The storages are owned by another sub chunk, which is responsible for compacting them.
+		return
+	}
 	newStorages := make([]*PalettedStorage, 0, len(sub.storages))
 	for _, storage := range sub.storages {
 		storage.compact()
@@ -71,6 +71,17 @@ type Config struct {
 	// be added to the World.
 	Entities EntityRegistry
 
+	// RedstoneBudget limits the work the redstone engine of the World may
+	// perform, so that lag machines cannot slow down the World. Networks
+	// exceeding the budget are reported through Handler and may be throttled
+	// or frozen. By default, no limits are imposed.
+	RedstoneBudget RedstoneBudget
+
+	// MobSpawning holds the parameters for the natural spawning of mobs in the
+	// World, such as the mobs that spawn in every Biome. By default, no mobs
+	// spawn naturally.
+	MobSpawning MobSpawning
+
 	// Blocks is the BlockRegistry used by the World.
 	// If left nil, DefaultBlockRegistry is used. For a non-default registry,
 	// use NewBlockRegistry(), register blocks/states, and call Finalize().
@@ -120,6 +131,12 @@ func (conf Config) New() *World {
 	if conf.RandomTickSpeed == 0 {
 		conf.RandomTickSpeed = 3
 	}
+	if conf.RedstoneBudget.Window <= 0 {
+		conf.RedstoneBudget.Window = time.Second
+	}
+	if conf.RedstoneBudget.Penalty == 0 {
+		conf.RedstoneBudget.Penalty = time.Second * 5
+	}
 	if conf.Blocks == nil {
 		conf.Blocks = DefaultBlockRegistry
 	}
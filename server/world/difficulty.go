@@ -14,6 +14,9 @@ type Difficulty interface {
 	// FireSpreadIncrease returns a number that increases the rate at which fire
 	// spreads.
 	FireSpreadIncrease() int
+	// MonstersSpawn specifies if hostile mobs spawn naturally with this
+	// difficulty.
+	MonstersSpawn() bool
 }
 
 var (
@@ -93,6 +96,7 @@ type difficultyPeaceful struct{}
 func (difficultyPeaceful) FoodRegenerates() bool          { return true }
 func (difficultyPeaceful) StarvationHealthLimit() float64 { return 20 }
 func (difficultyPeaceful) FireSpreadIncrease() int        { return 0 }
+func (difficultyPeaceful) MonstersSpawn() bool            { return false }
 
 // difficultyEasy difficulty has mobs deal less damage to players than normal
 // and starvation won't occur if a player has less than 5 hearts of health.
@@ -101,6 +105,7 @@ type difficultyEasy struct{}
 func (difficultyEasy) FoodRegenerates() bool          { return false }
 func (difficultyEasy) StarvationHealthLimit() float64 { return 10 }
 func (difficultyEasy) FireSpreadIncrease() int        { return 7 }
+func (difficultyEasy) MonstersSpawn() bool            { return true }
 
 // difficultyNormal difficulty has mobs that deal normal damage to players.
 // Starvation will occur until the player is down to a single heart.
@@ -109,6 +114,7 @@ type difficultyNormal struct{}
 func (difficultyNormal) FoodRegenerates() bool          { return false }
 func (difficultyNormal) StarvationHealthLimit() float64 { return 2 }
 func (difficultyNormal) FireSpreadIncrease() int        { return 14 }
+func (difficultyNormal) MonstersSpawn() bool            { return true }
 
 // difficultyHard difficulty has mobs that deal above average damage to
 // players. Starvation will kill players with too little food and monsters will
@@ -118,3 +124,4 @@ type difficultyHard struct{}
 func (difficultyHard) FoodRegenerates() bool          { return false }
 func (difficultyHard) StarvationHealthLimit() float64 { return -1 }
 func (difficultyHard) FireSpreadIncrease() int        { return 21 }
+func (difficultyHard) MonstersSpawn() bool            { return true }
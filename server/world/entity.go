@@ -134,6 +134,31 @@ func entityFromData(t EntityType, id int64, data map[string]any) *EntityHandle {
 	return handle
 }
 
+// DecodeNBT decodes an entity from the NBT data passed, such as data
+// previously returned by EntityHandle.EncodeNBT. The EntityType is looked up
+// using the "identifier" field of the data. The EntityHandle returned is
+// given a new UUID. If the EntityType could not be found, false is returned.
+func (reg EntityRegistry) DecodeNBT(data map[string]any) (*EntityHandle, bool) {
+	name, _ := data["identifier"].(string)
+	t, ok := reg.Lookup(name)
+	if !ok {
+		return nil, false
+	}
+	id := uuid.New()
+	return entityFromData(t, int64(binary.LittleEndian.Uint64(id[8:])), data), true
+}
+
+// EncodeNBT encodes the EntityHandle, including the data specific to its
+// EntityType, into a map that can be encoded as NBT. The "identifier" field
+// of the map holds the name of the EntityType. EncodeNBT must only be called
+// from a transaction of the World that the entity is in.
+func (e *EntityHandle) EncodeNBT() map[string]any {
+	data := e.encodeNBT()
+	maps.Copy(data, e.t.EncodeNBT(&e.data))
+	data["identifier"] = e.t.EncodeEntity()
+	return data
+}
+
 // Type returns the EntityType of the EntityHandle.
 func (e *EntityHandle) Type() EntityType {
 	return e.t
@@ -390,8 +415,8 @@ func (e *EntityHandle) setAndUnlockWorldAt(w *World, pos mgl64.Vec3) {
 	e.cond.Broadcast()
 }
 
-// decodeNBT decodes the position, velocity, rotation, age, on-fire duration and
-// name tag of an entity.
+// decodeNBT decodes the position, velocity, rotation, age, on-fire duration,
+// name tag and persistence of an entity.
 func (e *EntityHandle) decodeNBT(m map[string]any) {
 	e.data.Pos = readVec3(m, "Pos")
 	e.data.Vel = readVec3(m, "Motion")
@@ -399,19 +424,26 @@ func (e *EntityHandle) decodeNBT(m map[string]any) {
 	e.data.Age = time.Duration(readInt16(m, "Age")) * (time.Second / 20)
 	e.data.FireDuration = time.Duration(readInt16(m, "Fire")) * time.Second / 20
 	e.data.Name, _ = m["NameTag"].(string)
+	persistent, ok := m["Persistent"].(uint8)
+	e.data.Despawnable = ok && persistent == 0
 }
 
-// encodeNBT encodes the position, velocity, rotation, age, on-fire duration and
-// name tag of an entity.
+// encodeNBT encodes the position, velocity, rotation, age, on-fire duration,
+// name tag and persistence of an entity.
 func (e *EntityHandle) encodeNBT() map[string]any {
+	persistent := uint8(1)
+	if e.data.Despawnable {
+		persistent = 0
+	}
 	return map[string]any{
-		"Pos":     []float32{float32(e.data.Pos[0]), float32(e.data.Pos[1]), float32(e.data.Pos[2])},
-		"Motion":  []float32{float32(e.data.Vel[0]), float32(e.data.Vel[1]), float32(e.data.Vel[2])},
-		"Yaw":     float32(e.data.Rot[0]),
-		"Pitch":   float32(e.data.Rot[1]),
-		"Fire":    int16(e.data.FireDuration.Seconds() * 20),
-		"Age":     int16(min(e.data.Age/(time.Second/20), math.MaxInt16)),
-		"NameTag": e.data.Name,
+		"Pos":        []float32{float32(e.data.Pos[0]), float32(e.data.Pos[1]), float32(e.data.Pos[2])},
+		"Motion":     []float32{float32(e.data.Vel[0]), float32(e.data.Vel[1]), float32(e.data.Vel[2])},
+		"Yaw":        float32(e.data.Rot[0]),
+		"Pitch":      float32(e.data.Rot[1]),
+		"Fire":       int16(e.data.FireDuration.Seconds() * 20),
+		"Age":        int16(min(e.data.Age/(time.Second/20), math.MaxInt16)),
+		"NameTag":    e.data.Name,
+		"Persistent": persistent,
 	}
 }
 
@@ -423,6 +455,9 @@ type EntityData struct {
 	AlwaysShowNameTag bool
 	FireDuration      time.Duration
 	Age               time.Duration
+	// Despawnable specifies if the entity despawns once it is far away from
+	// all Loaders. It is set for mobs spawned naturally through MobSpawning.
+	Despawnable bool
 
 	Data any
 }
@@ -501,6 +536,8 @@ type EntityRegistryConfig struct {
 	EnderPearl         func(opts EntitySpawnOpts, owner Entity) *EntityHandle
 	Firework           func(opts EntitySpawnOpts, firework Item, owner Entity, sidewaysVelocityMultiplier, upwardsAcceleration float64, attached bool) *EntityHandle
 	LingeringPotion    func(opts EntitySpawnOpts, t any, owner Entity) *EntityHandle
+	Minecart           func(opts EntitySpawnOpts, t any) *EntityHandle
+	Boat               func(opts EntitySpawnOpts, t any, chest bool) *EntityHandle
 	Snowball           func(opts EntitySpawnOpts, owner Entity) *EntityHandle
 	SplashPotion       func(opts EntitySpawnOpts, t any, owner Entity) *EntityHandle
 	Lightning          func(opts EntitySpawnOpts) *EntityHandle
@@ -0,0 +1,175 @@
+package generator
+
+import (
+	"math/rand/v2"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// PlacedFeature describes how a Feature is distributed through the chunks decorated by a Decorator.
+type PlacedFeature struct {
+	// Feature is the Feature placed.
+	Feature Feature
+	// Count is the number of attempts made to place the Feature in a chunk.
+	Count int
+	// Rarity, if larger than 1, makes the Feature attempted in only one out of Rarity chunks on average.
+	Rarity int
+	// Placement selects the y at which an attempt is made for a random x and z in the chunk.
+	Placement Placement
+	// Biome, if non-nil, is called with the biome at the position of an attempt. The Feature is only placed if it
+	// returns true.
+	Biome func(b world.Biome) bool
+}
+
+// Placement selects the y at which a Feature is placed for a world x and z within the chunk decorated. If no
+// suitable y exists, false is returned and the attempt is skipped.
+type Placement func(d *Decoration, x, z int, r *rand.Rand) (y int, ok bool)
+
+// Surface returns a Placement that places Features directly on top of the highest block of a column.
+func Surface() Placement {
+	return func(d *Decoration, x, z int, _ *rand.Rand) (int, bool) {
+		y, ok := d.HighestBlock(x, z)
+		return y + 1, ok && y+1 <= d.Range().Max()
+	}
+}
+
+// UniformHeight returns a Placement that places Features at a y picked uniformly between min and max, inclusive.
+func UniformHeight(min, max int) Placement {
+	return func(_ *Decoration, _, _ int, r *rand.Rand) (int, bool) {
+		return min + r.IntN(max-min+1), true
+	}
+}
+
+// InBiomes returns a function that may be used as PlacedFeature.Biome to restrict a Feature to the biomes passed.
+func InBiomes(biomes ...world.Biome) func(b world.Biome) bool {
+	ids := make(map[int]struct{}, len(biomes))
+	for _, b := range biomes {
+		ids[b.EncodeBiome()] = struct{}{}
+	}
+	return func(b world.Biome) bool {
+		_, ok := ids[b.EncodeBiome()]
+		return ok
+	}
+}
+
+// Decorator places Features in chunks after their terrain has been generated. Every chunk is decorated with its own
+// random source derived from the seed and the position of the chunk, so that decoration is deterministic.
+//
+// Features may extend into the chunks directly neighbouring the chunk that they are placed from. When a chunk is
+// decorated, the Features of its neighbours are placed as well, using the random sources of those neighbours and
+// their undecorated terrain, and only the parts that fall into the chunk are kept. The result of decorating a chunk
+// therefore does not depend on the order in which chunks are generated. The parts of Features that extend out of a
+// chunk are cached for a limited number of recently decorated chunks, so that the terrain of neighbours rarely has
+// to be generated again. Decorator is safe for concurrent use. A Decorator may be constructed using NewDecorator.
+type Decorator struct {
+	seed     int64
+	br       world.BlockRegistry
+	terrain  func(pos world.ChunkPos, c *chunk.Chunk)
+	features []PlacedFeature
+
+	mu     sync.Mutex
+	spills map[world.ChunkPos]map[world.ChunkPos][]pendingWrite
+	// order holds the positions in spills in the order that they were added, so that the oldest is evicted once
+	// maxCachedSpills is reached.
+	order []world.ChunkPos
+}
+
+// maxCachedSpills is the maximum number of chunks for which a Decorator caches the parts of Features that extend
+// into neighbouring chunks.
+const maxCachedSpills = 1024
+
+// NewDecorator creates a Decorator that places the features passed, in order, using the seed passed. br is used to
+// resolve the blocks of Features to runtime IDs. terrain is called to generate the undecorated terrain of
+// neighbouring chunks that Features are placed from. It must produce the same terrain that the chunk passed to
+// Decorate holds.
+func NewDecorator(seed int64, br world.BlockRegistry, terrain func(pos world.ChunkPos, c *chunk.Chunk), features ...PlacedFeature) *Decorator {
+	return &Decorator{
+		seed:     seed,
+		br:       br,
+		terrain:  terrain,
+		features: features,
+		spills:   make(map[world.ChunkPos]map[world.ChunkPos][]pendingWrite),
+	}
+}
+
+// Decorate places all Features of the Decorator in the chunk at pos, followed by the parts of the Features of its
+// neighbours that extend into it. Decorate should be called from Generator.GenerateChunk once the terrain of the
+// chunk has been generated.
+func (dec *Decorator) Decorate(pos world.ChunkPos, c *chunk.Chunk) {
+	d := dec.place(pos, c)
+	dec.cache(pos, d.spill)
+
+	for dx := int32(-1); dx <= 1; dx++ {
+		for dz := int32(-1); dz <= 1; dz++ {
+			if dx == 0 && dz == 0 {
+				continue
+			}
+			for _, w := range dec.spillOf(world.ChunkPos{pos[0] + dx, pos[1] + dz}, c.Range())[pos] {
+				d.ModifyBlock(w.pos, w.modify)
+			}
+		}
+	}
+}
+
+// place places the Features of the Decorator in the chunk at pos and returns the Decoration holding the writes that
+// fall outside the chunk.
+func (dec *Decorator) place(pos world.ChunkPos, c *chunk.Chunk) *Decoration {
+	d := &Decoration{pos: pos, c: c, br: dec.br, spill: make(map[world.ChunkPos][]pendingWrite)}
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	for i, f := range dec.features {
+		r := chunkSource(dec.seed, pos[0], pos[1], saltFeatures<<16|uint64(i))
+		if f.Rarity > 1 && r.IntN(f.Rarity) != 0 {
+			continue
+		}
+		for range f.Count {
+			x, z := baseX+r.IntN(16), baseZ+r.IntN(16)
+			y, ok := f.Placement(d, x, z, r)
+			if !ok {
+				continue
+			}
+			at := cube.Pos{x, y, z}
+			if f.Biome != nil {
+				if b, ok := d.Biome(at); !ok || !f.Biome(b) {
+					continue
+				}
+			}
+			f.Feature.Place(d, at, r)
+		}
+	}
+	return d
+}
+
+// spillOf returns the writes of the Features of the chunk at pos that fall outside of it, grouped by the chunk that
+// they fall in. If these are not cached, the terrain of the chunk is generated and its Features are placed in it.
+func (dec *Decorator) spillOf(pos world.ChunkPos, r cube.Range) map[world.ChunkPos][]pendingWrite {
+	dec.mu.Lock()
+	spill, ok := dec.spills[pos]
+	dec.mu.Unlock()
+	if ok {
+		return spill
+	}
+	c := chunk.New(dec.br, r)
+	dec.terrain(pos, c)
+	spill = dec.place(pos, c).spill
+	dec.cache(pos, spill)
+	return spill
+}
+
+// cache stores the writes of the Features of the chunk at pos that fall outside of it, evicting the oldest entry if
+// the cache is full.
+func (dec *Decorator) cache(pos world.ChunkPos, spill map[world.ChunkPos][]pendingWrite) {
+	dec.mu.Lock()
+	defer dec.mu.Unlock()
+	if _, ok := dec.spills[pos]; ok {
+		return
+	}
+	if len(dec.order) >= maxCachedSpills {
+		delete(dec.spills, dec.order[0])
+		dec.order = dec.order[1:]
+	}
+	dec.spills[pos] = spill
+	dec.order = append(dec.order, pos)
+}
@@ -0,0 +1,77 @@
+package generator_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/generator"
+)
+
+// pillar is a Feature that places a line of stone along the x axis over the last 4 blocks of a chunk and the first 4
+// blocks of the neighbouring chunk.
+type pillar struct{}
+
+func (pillar) Place(d *generator.Decoration, pos cube.Pos, _ *rand.Rand) bool {
+	for x := 12; x < 20; x++ {
+		d.SetBlock(cube.Pos{int(d.Chunk()[0])<<4 + x, 10, pos[2]}, block.Stone{})
+	}
+	return true
+}
+
+// emptyTerrain is the terrain function of Decorators in tests, which leaves chunks empty.
+func emptyTerrain(world.ChunkPos, *chunk.Chunk) {}
+
+// decorate decorates an empty chunk at pos using the Decorator passed.
+func decorate(dec *generator.Decorator, pos world.ChunkPos) *chunk.Chunk {
+	c := chunk.New(world.DefaultBlockRegistry, world.Overworld.Range())
+	dec.Decorate(pos, c)
+	return c
+}
+
+func TestDecoratorSpill(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	stone := world.BlockRuntimeID(block.Stone{})
+	features := []generator.PlacedFeature{{Feature: pillar{}, Count: 1, Placement: generator.UniformHeight(10, 10)}}
+
+	// The write spilled from chunk (0, 0) must be applied to chunk (1, 0), regardless of which of the two chunks is
+	// decorated first.
+	dec := generator.NewDecorator(5, world.DefaultBlockRegistry, emptyTerrain, features...)
+	decorate(dec, world.ChunkPos{0, 0})
+	after := decorate(dec, world.ChunkPos{1, 0})
+	dec = generator.NewDecorator(5, world.DefaultBlockRegistry, emptyTerrain, features...)
+	before := decorate(dec, world.ChunkPos{1, 0})
+	decorate(dec, world.ChunkPos{0, 0})
+
+	for _, c := range []*chunk.Chunk{after, before} {
+		found := false
+		for z := range uint8(16) {
+			if c.Block(0, 10, z, 0) == stone && c.Block(3, 10, z, 0) == stone {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected write spilled from chunk (0, 0) to be applied to chunk (1, 0)")
+		}
+	}
+	if !after.Equals(before) {
+		t.Fatalf("chunk (1, 0) decorated differently depending on the order of decoration")
+	}
+}
+
+func TestDecoratorDeterministic(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	features := []generator.PlacedFeature{{
+		Feature:   generator.OreVein{Size: 12, Replace: map[world.Block]world.Block{block.Air{}: block.Stone{}}},
+		Count:     8,
+		Placement: generator.UniformHeight(0, 40),
+	}}
+	a := decorate(generator.NewDecorator(11, world.DefaultBlockRegistry, emptyTerrain, features...), world.ChunkPos{3, -4})
+	b := decorate(generator.NewDecorator(11, world.DefaultBlockRegistry, emptyTerrain, features...), world.ChunkPos{3, -4})
+	if !a.Equals(b) {
+		t.Fatalf("chunk decorated differently by two decorators with the same seed")
+	}
+}
@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Density noise is sampled on a coarse grid and interpolated in between, which is considerably faster than sampling
+// the noise for every block and produces smooth terrain and cave walls.
+const (
+	densityCellWidth  = 4
+	densityCellHeight = 8
+	densityGridWidth  = 16/densityCellWidth + 1
+)
+
+// densityGrid holds density samples for a chunk, taken every densityCellWidth blocks horizontally and every
+// densityCellHeight blocks vertically.
+type densityGrid struct {
+	samples []float64
+	height  int
+}
+
+// newDensityGrid samples the density function f on a coarse grid covering the chunk at pos. f is called with world
+// coordinates.
+func newDensityGrid(pos world.ChunkPos, r cube.Range, f func(x, y, z float64) float64) densityGrid {
+	g := densityGrid{height: r.Height()/densityCellHeight + 2}
+	g.samples = make([]float64, densityGridWidth*densityGridWidth*g.height)
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	for cx := range densityGridWidth {
+		for cz := range densityGridWidth {
+			for cy := range g.height {
+				x, y, z := baseX+cx*densityCellWidth, r.Min()+cy*densityCellHeight, baseZ+cz*densityCellWidth
+				g.samples[(cx*densityGridWidth+cz)*g.height+cy] = f(float64(x), float64(y), float64(z))
+			}
+		}
+	}
+	return g
+}
+
+// at returns the interpolated density at a position in the chunk. y is relative to the bottom of the world.
+func (g densityGrid) at(x, y, z int) float64 {
+	cx, cy, cz := x/densityCellWidth, y/densityCellHeight, z/densityCellWidth
+	tx := float64(x%densityCellWidth) / densityCellWidth
+	ty := float64(y%densityCellHeight) / densityCellHeight
+	tz := float64(z%densityCellWidth) / densityCellWidth
+
+	s := func(dx, dy, dz int) float64 {
+		return g.samples[((cx+dx)*densityGridWidth+cz+dz)*g.height+cy+dy]
+	}
+	return lerp(tx,
+		lerp(tz, lerp(ty, s(0, 0, 0), s(0, 1, 0)), lerp(ty, s(0, 0, 1), s(0, 1, 1))),
+		lerp(tz, lerp(ty, s(1, 0, 0), s(1, 1, 0)), lerp(ty, s(1, 0, 1), s(1, 1, 1))),
+	)
+}
@@ -0,0 +1,191 @@
+package generator
+
+import (
+	"math"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/portal"
+)
+
+// End is a noise based generator that produces vanilla-style End terrain: a central island of end stone surrounded
+// by obsidian pillars, a void ring and, from 1000 blocks out, a field of smaller outer islands. The obsidian arrival
+// platform is generated as part of the terrain so that the End may also be used as the spawn dimension. Generation is
+// fully deterministic for a seed. End is safe for concurrent use. An End may be constructed by calling NewEnd.
+type End struct {
+	shape, detail, islands octaveNoise
+	pillars                [10]endPillar
+
+	air, endStone, obsidian, bedrock, ironBars, fire uint32
+}
+
+// endPillar is one of the obsidian pillars placed in a ring around the centre of the main island.
+type endPillar struct {
+	x, z, radius, height int
+	caged                bool
+}
+
+// endIslandRadius is the approximate radius of the main End island. Beyond endOuterIslands blocks from the centre,
+// outer islands are generated.
+const (
+	endIslandRadius = 80
+	endOuterIslands = 1000
+)
+
+// NewEnd creates a new End generator that generates terrain using the seed passed.
+func NewEnd(seed int64) *End {
+	return NewEndWithRegistry(seed, world.DefaultBlockRegistry)
+}
+
+// NewEndWithRegistry creates a new End generator using the block registry passed to resolve blocks to runtime IDs.
+// Use this constructor when the generator is used in a World with a non-default block registry.
+func NewEndWithRegistry(seed int64, br world.BlockRegistry) *End {
+	e := &End{
+		shape:    newOctaveNoise(noiseSource(seed, saltTerrain), 3, 48),
+		detail:   newOctaveNoise(noiseSource(seed, saltSurface), 3, 16),
+		islands:  newOctaveNoise(noiseSource(seed, saltIslands), 3, 180),
+		air:      br.AirRuntimeID(),
+		endStone: br.BlockRuntimeID(block.EndStone{}),
+		obsidian: br.BlockRuntimeID(block.Obsidian{}),
+		bedrock:  br.BlockRuntimeID(block.Bedrock{InfiniteBurning: true}),
+		ironBars: br.BlockRuntimeID(block.IronBars{}),
+		fire:     br.BlockRuntimeID(block.Fire{Type: block.NormalFire()}),
+	}
+	// The pillar sizes are shuffled using the seed, while the positions are always the same, like in vanilla.
+	sizes := noiseSource(seed, saltPillars).Perm(len(e.pillars))
+	for i := range e.pillars {
+		angle := 2 * (-math.Pi + math.Pi/10*float64(i))
+		k := sizes[i]
+		e.pillars[i] = endPillar{
+			x:      int(math.Floor(42 * math.Cos(angle))),
+			z:      int(math.Floor(42 * math.Sin(angle))),
+			radius: 2 + k/3,
+			height: 76 + k*3,
+			caged:  k == 1 || k == 2,
+		}
+	}
+	return e
+}
+
+// GenerateChunk ...
+func (e *End) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	r := c.Range()
+	biomeID := uint32(biome.End{}.EncodeBiome())
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	for x := range uint8(16) {
+		for z := range uint8(16) {
+			for y := int16(r.Min()); y <= int16(r.Max()); y++ {
+				c.SetBiome(x, y, z, biomeID)
+			}
+			minY, maxY, ok := e.column(float64(baseX+int(x)), float64(baseZ+int(z)))
+			if !ok {
+				continue
+			}
+			for y := max(minY, r.Min()); y <= min(maxY, r.Max()); y++ {
+				c.SetBlock(x, int16(y), z, 0, e.endStone)
+			}
+		}
+	}
+	for _, p := range e.pillars {
+		e.placePillar(c, baseX, baseZ, p)
+	}
+	e.placePlatform(c, baseX, baseZ)
+}
+
+// column returns the lowest and highest y of end stone at the world x and z passed. If no end stone should be
+// placed in the column, false is returned.
+func (e *End) column(x, z float64) (minY, maxY int, ok bool) {
+	d := math.Hypot(x, z)
+	detail := e.detail.at2(x, z)
+	if d < endOuterIslands {
+		radius := endIslandRadius + e.shape.at2(x, z)*10
+		if d >= radius {
+			return 0, 0, false
+		}
+		t := 1 - d/radius
+		top := 57 + t*4 + detail*2
+		bottom := 57 - (math.Pow(t, 0.7)*55 + math.Abs(detail)*4)
+		return int(math.Round(bottom)), int(math.Round(top)), top > bottom
+	}
+	// Outer islands are formed where the island noise exceeds a threshold. The further the noise is above the
+	// threshold, the thicker the island.
+	v := e.islands.at2(x, z)*1.8 - 0.45
+	if v <= 0 {
+		return 0, 0, false
+	}
+	t := math.Min(v*4, 1)
+	height := 60 + e.shape.at2(x, z)*12
+	top := height + t*3 + detail*1.5
+	bottom := height - t*(18+math.Abs(detail)*6)
+	return int(math.Round(bottom)), int(math.Round(top)), top > bottom
+}
+
+// placePillar places the part of the pillar passed that lies within the chunk at baseX, baseZ. The pillar is topped
+// by burning bedrock and, if caged, surrounded by iron bars at the top. The area just above the pillar is cleared.
+func (e *End) placePillar(c *chunk.Chunk, baseX, baseZ int, p endPillar) {
+	cage := 0
+	if p.caged {
+		cage = 2
+	}
+	if p.x+p.radius+cage < baseX || p.x-p.radius-cage > baseX+15 || p.z+p.radius+cage < baseZ || p.z-p.radius-cage > baseZ+15 {
+		return
+	}
+	r := c.Range()
+	for x := range 16 {
+		for z := range 16 {
+			dx, dz := baseX+x-p.x, baseZ+z-p.z
+			if dx*dx+dz*dz <= p.radius*p.radius+1 {
+				for y := r.Min(); y <= min(p.height+10, r.Max()); y++ {
+					if y < p.height {
+						c.SetBlock(uint8(x), int16(y), uint8(z), 0, e.obsidian)
+					} else if y > 65 {
+						c.SetBlock(uint8(x), int16(y), uint8(z), 0, e.air)
+					}
+				}
+			}
+			if dx == 0 && dz == 0 {
+				c.SetBlock(uint8(x), int16(p.height), uint8(z), 0, e.bedrock)
+				c.SetBlock(uint8(x), int16(p.height+1), uint8(z), 0, e.fire)
+			}
+			if !p.caged || abs(dx) > 2 || abs(dz) > 2 {
+				continue
+			}
+			for dy := range 4 {
+				if abs(dx) == 2 || abs(dz) == 2 || dy == 3 {
+					c.SetBlock(uint8(x), int16(p.height+dy), uint8(z), 0, e.ironBars)
+				}
+			}
+		}
+	}
+}
+
+// placePlatform places the part of the obsidian arrival platform that lies within the chunk at baseX, baseZ. The
+// platform matches the one built by portal.GenerateEndSpawnPlatform.
+func (e *End) placePlatform(c *chunk.Chunk, baseX, baseZ int) {
+	spawn := e.spawn()
+	for dx := -2; dx <= 2; dx++ {
+		for dz := -2; dz <= 2; dz++ {
+			x, z := spawn[0]+dx-baseX, spawn[2]+dz-baseZ
+			if x < 0 || x >= 16 || z < 0 || z >= 16 {
+				continue
+			}
+			c.SetBlock(uint8(x), int16(spawn[1]-1), uint8(z), 0, e.obsidian)
+			for dy := range 3 {
+				c.SetBlock(uint8(x), int16(spawn[1]+dy), uint8(z), 0, e.air)
+			}
+		}
+	}
+}
+
+// spawn returns the block position of the End arrival platform, directly above its centre.
+func (e *End) spawn() cube.Pos {
+	return cube.PosFromVec3(portal.EndSpawnPosition(true))
+}
+
+// DefaultSpawn returns the position of the obsidian arrival platform.
+func (e *End) DefaultSpawn(world.Dimension) cube.Pos {
+	return e.spawn()
+}
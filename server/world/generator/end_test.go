@@ -0,0 +1,64 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/generator"
+)
+
+func TestEndMainIsland(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	c := generateIn(generator.NewEnd(9), world.End, world.ChunkPos{0, 0})
+	if b, _ := world.BlockByRuntimeID(c.Block(8, 50, 8, 0)); b != (block.EndStone{}) {
+		t.Fatalf("expected end stone near the centre of the main island, got %#v", b)
+	}
+	far := generateIn(generator.NewEnd(9), world.End, world.ChunkPos{20, 20})
+	for y := int16(0); y <= 255; y++ {
+		if far.Block(3, y, 3, 0) != world.DefaultBlockRegistry.AirRuntimeID() {
+			t.Fatalf("expected only air in the void between the main island and outer islands")
+		}
+	}
+}
+
+func TestEndSpawnPlatform(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	g := generator.NewEnd(9)
+	spawn := g.DefaultSpawn(world.End)
+	c := generateIn(g, world.End, world.ChunkPos{int32(spawn[0] >> 4), int32(spawn[2] >> 4)})
+
+	for dx := -2; dx <= 2; dx++ {
+		for dz := -2; dz <= 2; dz++ {
+			x, z := uint8((spawn[0]+dx)&15), uint8((spawn[2]+dz)&15)
+			if (spawn[0]+dx)>>4 != spawn[0]>>4 || (spawn[2]+dz)>>4 != spawn[2]>>4 {
+				continue
+			}
+			if b, _ := world.BlockByRuntimeID(c.Block(x, int16(spawn[1]-1), z, 0)); b != (block.Obsidian{}) {
+				t.Fatalf("expected obsidian platform below spawn %v, got %#v", spawn, b)
+			}
+		}
+	}
+}
+
+func TestEndPillars(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	g := generator.NewEnd(9)
+	// The first pillar is always centred at (42, 0).
+	c := generateIn(g, world.End, world.ChunkPos{2, 0})
+	x, z := uint8(42&15), uint8(0)
+
+	top := int16(-1)
+	for y := int16(255); y >= 0; y-- {
+		if b, _ := world.BlockByRuntimeID(c.Block(x, y, z, 0)); b == (block.Bedrock{InfiniteBurning: true}) {
+			top = y
+			break
+		}
+	}
+	if top < 76 {
+		t.Fatalf("expected burning bedrock on top of the pillar at y >= 76, got %v", top)
+	}
+	if b, _ := world.BlockByRuntimeID(c.Block(x, top-1, z, 0)); b != (block.Obsidian{}) {
+		t.Fatalf("expected obsidian below the pillar top, got %#v", b)
+	}
+}
@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// Feature is a structure or decoration, such as a tree, an ore vein or a lake, that is placed in a chunk after its
+// terrain has been generated. Features are placed through a Decoration, which allows them to extend into
+// neighbouring chunks.
+type Feature interface {
+	// Place attempts to place the Feature at a position in the Decoration passed. All randomness must be taken from
+	// the rand.Rand passed so that the Feature is placed deterministically. Place returns false if the Feature
+	// could not be placed at the position, for example because the ground below was unsuitable.
+	Place(d *Decoration, pos cube.Pos, r *rand.Rand) bool
+}
+
+// Decoration is the chunk that Features are being placed in by a Decorator. Blocks may be read only within the
+// chunk, but may also be written in the chunks directly neighbouring it: these writes are applied by the Decorator
+// when the neighbouring chunk is decorated. Writes further away are discarded.
+type Decoration struct {
+	pos world.ChunkPos
+	c   *chunk.Chunk
+	br  world.BlockRegistry
+
+	spill map[world.ChunkPos][]pendingWrite
+}
+
+// pendingWrite is a write of a Decoration that falls outside the chunk being decorated.
+type pendingWrite struct {
+	pos    cube.Pos
+	modify func(b world.Block) (world.Block, bool)
+}
+
+// Chunk returns the position of the chunk being decorated.
+func (d *Decoration) Chunk() world.ChunkPos {
+	return d.pos
+}
+
+// Range returns the vertical range of the chunk being decorated.
+func (d *Decoration) Range() cube.Range {
+	return d.c.Range()
+}
+
+// Block returns the block at a position in the world. If the position is not within the chunk being decorated or
+// outside the range of the world, Block returns false.
+func (d *Decoration) Block(pos cube.Pos) (world.Block, bool) {
+	x, z, ok := d.local(pos)
+	if !ok {
+		return nil, false
+	}
+	return d.br.BlockByRuntimeIDOrAir(d.c.Block(x, int16(pos[1]), z, 0)), true
+}
+
+// Biome returns the biome at a position in the world. If the position is not within the chunk being decorated or
+// outside the range of the world, Biome returns false.
+func (d *Decoration) Biome(pos cube.Pos) (world.Biome, bool) {
+	x, z, ok := d.local(pos)
+	if !ok {
+		return nil, false
+	}
+	return world.BiomeByID(int(d.c.Biome(x, int16(pos[1]), z)))
+}
+
+// HighestBlock returns the y of the highest non-air block at a world x and z within the chunk being decorated. If
+// the x and z are not within the chunk, HighestBlock returns false.
+func (d *Decoration) HighestBlock(x, z int) (int, bool) {
+	if x>>4 != int(d.pos[0]) || z>>4 != int(d.pos[1]) {
+		return 0, false
+	}
+	return int(d.c.HighestBlock(uint8(x&15), uint8(z&15))), true
+}
+
+// SetBlock sets the block at a position in the world, regardless of the block currently there.
+func (d *Decoration) SetBlock(pos cube.Pos, b world.Block) {
+	d.ModifyBlock(pos, func(world.Block) (world.Block, bool) {
+		return b, true
+	})
+}
+
+// PlaceBlock sets the block at a position in the world if the block currently there is air or may be replaced by
+// the block passed, such as short grass.
+func (d *Decoration) PlaceBlock(pos cube.Pos, b world.Block) {
+	d.ModifyBlock(pos, func(existing world.Block) (world.Block, bool) {
+		if _, ok := existing.(block.Air); ok {
+			return b, true
+		}
+		if r, ok := existing.(block.Replaceable); ok && r.ReplaceableBy(b) {
+			return b, true
+		}
+		return nil, false
+	})
+}
+
+// ModifyBlock calls modify with the block currently at a position in the world and sets the block returned if
+// modify returns true. For positions in a chunk neighbouring the chunk being decorated, modify is called once that
+// chunk is decorated. Positions further away are ignored.
+func (d *Decoration) ModifyBlock(pos cube.Pos, modify func(b world.Block) (world.Block, bool)) {
+	if pos.OutOfBounds(d.c.Range()) {
+		return
+	}
+	x, z, ok := d.local(pos)
+	if !ok {
+		cpos := world.ChunkPos{int32(pos[0] >> 4), int32(pos[2] >> 4)}
+		if abs(int(cpos[0]-d.pos[0])) > 1 || abs(int(cpos[1]-d.pos[1])) > 1 {
+			// Only the direct neighbours read the writes spilled into them, so anything further away would never
+			// be applied.
+			return
+		}
+		d.spill[cpos] = append(d.spill[cpos], pendingWrite{pos: pos, modify: modify})
+		return
+	}
+	if b, ok := modify(d.br.BlockByRuntimeIDOrAir(d.c.Block(x, int16(pos[1]), z, 0))); ok {
+		d.c.SetBlock(x, int16(pos[1]), z, 0, d.br.BlockRuntimeID(b))
+	}
+}
+
+// local returns the chunk-local x and z of a position in the world. If the position is not within the chunk or
+// outside the range of the world, local returns false.
+func (d *Decoration) local(pos cube.Pos) (x, z uint8, ok bool) {
+	if pos[0]>>4 != int(d.pos[0]) || pos[2]>>4 != int(d.pos[1]) || pos.OutOfBounds(d.c.Range()) {
+		return 0, 0, false
+	}
+	return uint8(pos[0] & 15), uint8(pos[2] & 15), true
+}
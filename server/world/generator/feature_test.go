@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+func TestDecorationSpillsOnlyToNeighbours(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	pos := world.ChunkPos{2, -3}
+	d := &Decoration{
+		pos:   pos,
+		c:     chunk.New(world.DefaultBlockRegistry, world.Overworld.Range()),
+		br:    world.DefaultBlockRegistry,
+		spill: make(map[world.ChunkPos][]pendingWrite),
+	}
+	base := cube.Pos{int(pos[0]) << 4, 10, int(pos[1]) << 4}
+	d.SetBlock(base.Add(cube.Pos{-1, 0, 16}), block.Stone{})
+	d.SetBlock(base.Add(cube.Pos{32, 0, 0}), block.Stone{})
+	d.SetBlock(base.Add(cube.Pos{0, 0, -17}), block.Stone{})
+
+	if len(d.spill) != 1 || len(d.spill[world.ChunkPos{1, -2}]) != 1 {
+		t.Fatalf("spilled writes = %v, want only one write for chunk (1, -2)", d.spill)
+	}
+}
@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// FlowerPatch is a Feature that scatters plants over the grass around a position. Besides flowers, other plants,
+// such as short grass, may be placed by a FlowerPatch.
+type FlowerPatch struct {
+	// Flowers holds the plants that are picked from randomly for every plant placed.
+	Flowers []world.Block
+	// Tries is the number of positions tried. If zero, 64 positions are tried.
+	Tries int
+	// Spread is the maximum horizontal distance of a plant from the centre of the patch. If zero, plants are
+	// spread up to 7 blocks away.
+	Spread int
+}
+
+// Place ...
+func (f FlowerPatch) Place(d *Decoration, pos cube.Pos, r *rand.Rand) bool {
+	if len(f.Flowers) == 0 {
+		return false
+	}
+	tries, spread := f.Tries, f.Spread
+	if tries == 0 {
+		tries = 64
+	}
+	if spread == 0 {
+		spread = 7
+	}
+	placed := false
+	for range tries {
+		at := pos.Add(cube.Pos{r.IntN(spread+1) - r.IntN(spread+1), r.IntN(4) - r.IntN(4), r.IntN(spread+1) - r.IntN(spread+1)})
+		// Plants are only placed if both the position and the block below it are within the chunk, so that no
+		// plants end up floating or on unsuitable ground.
+		if b, ok := d.Block(at); !ok || b != (block.Air{}) {
+			continue
+		}
+		if below, ok := d.Block(at.Side(cube.FaceDown)); !ok || below != (block.Grass{}) {
+			continue
+		}
+		d.SetBlock(at, f.Flowers[r.IntN(len(f.Flowers))])
+		placed = true
+	}
+	return placed
+}
@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Lake is a Feature that carves a small, irregularly shaped lake of up to 16x8x16 blocks into the terrain. The
+// bottom half of the lake is filled with Liquid, while the top half is cleared. The position that a Lake is placed
+// at is the centre of the lake, directly above the surface of the liquid.
+type Lake struct {
+	// Liquid is the liquid that the lake is filled with, such as still water or still lava.
+	Liquid world.Liquid
+}
+
+// lakeWidth and lakeHeight are the dimensions of the area that a lake is carved out of.
+const (
+	lakeWidth  = 16
+	lakeHeight = 8
+)
+
+// Place ...
+func (l Lake) Place(d *Decoration, pos cube.Pos, r *rand.Rand) bool {
+	origin := pos.Sub(cube.Pos{lakeWidth / 2, lakeHeight / 2, lakeWidth / 2})
+	if origin[1] <= d.Range().Min()+4 || origin[1]+lakeHeight > d.Range().Max() {
+		return false
+	}
+	var lake [lakeWidth][lakeWidth][lakeHeight]bool
+	in := func(x, y, z int) bool {
+		return x >= 0 && x < lakeWidth && y >= 0 && y < lakeHeight && z >= 0 && z < lakeWidth && lake[x][z][y]
+	}
+	// The shape of the lake is the union of a number of random ellipsoids.
+	for range 4 + r.IntN(4) {
+		sx, sy, sz := r.Float64()*6+3, r.Float64()*4+2, r.Float64()*6+3
+		cx := r.Float64()*(lakeWidth-sx-2) + 1 + sx/2
+		cy := r.Float64()*(lakeHeight-sy-4) + 2 + sy/2
+		cz := r.Float64()*(lakeWidth-sz-2) + 1 + sz/2
+		for x := 1; x < lakeWidth-1; x++ {
+			for z := 1; z < lakeWidth-1; z++ {
+				for y := 1; y < lakeHeight-1; y++ {
+					dx, dy, dz := (float64(x)-cx)/(sx/2), (float64(y)-cy)/(sy/2), (float64(z)-cz)/(sz/2)
+					if dx*dx+dy*dy+dz*dz < 1 {
+						lake[x][z][y] = true
+					}
+				}
+			}
+		}
+	}
+	// The lake is not placed if liquid could flow out of it or if it would cut into other liquids. Blocks outside
+	// the chunk are not known and are assumed to be suitable.
+	for x := range lakeWidth {
+		for z := range lakeWidth {
+			for y := range lakeHeight {
+				if lake[x][z][y] || !(in(x-1, y, z) || in(x+1, y, z) || in(x, y-1, z) || in(x, y+1, z) || in(x, y, z-1) || in(x, y, z+1)) {
+					continue
+				}
+				b, ok := d.Block(origin.Add(cube.Pos{x, y, z}))
+				if !ok {
+					continue
+				}
+				_, liquid := b.(world.Liquid)
+				if y >= lakeHeight/2 && liquid {
+					return false
+				}
+				if y < lakeHeight/2 && !liquid && !lakeWall(b) {
+					return false
+				}
+				if y < lakeHeight/2 && liquid && b != l.Liquid {
+					return false
+				}
+			}
+		}
+	}
+	for x := range lakeWidth {
+		for z := range lakeWidth {
+			for y := range lakeHeight {
+				if !lake[x][z][y] {
+					continue
+				}
+				at := origin.Add(cube.Pos{x, y, z})
+				if y < lakeHeight/2 {
+					d.SetBlock(at, l.Liquid)
+				} else {
+					d.SetBlock(at, block.Air{})
+				}
+			}
+		}
+	}
+	return true
+}
+
+// lakeWall checks if a block may form the wall of a lake, which is the case for all blocks that liquid cannot flow
+// through.
+func lakeWall(b world.Block) bool {
+	switch b.(type) {
+	case block.Air, block.Replaceable:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,317 @@
+package generator
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// NetherLavaLevel is the y level up to which the Nether generator fills open space with lava.
+const NetherLavaLevel = 31
+
+// Nether is a noise based generator that produces vanilla-style Nether terrain: a cavernous layout between a
+// bedrock floor and ceiling, seas of lava and the nether wastes, soul sand valley and basalt deltas biomes. Glowstone
+// hangs from the ceilings and quartz, nether gold and ancient debris are distributed through the netherrack.
+// Generation is fully deterministic for a seed. Nether is safe for concurrent use, so it may be used in a World with
+// more than one chunk load worker. A Nether may be constructed by calling NewNether.
+type Nether struct {
+	seed int64
+	br   world.BlockRegistry
+
+	terrain, surface, temperature, humidity octaveNoise
+
+	b         netherBlocks
+	decorator *Decorator
+}
+
+// netherBlocks holds the runtime IDs of all blocks placed by the Nether generator.
+type netherBlocks struct {
+	air, bedrock, netherrack, lava, gravel, soulSand, soulSoil, basalt, blackstone, magma, glowstone uint32
+}
+
+// NewNether creates a new Nether generator that generates terrain using the seed passed.
+func NewNether(seed int64) *Nether {
+	return NewNetherWithRegistry(seed, world.DefaultBlockRegistry)
+}
+
+// NewNetherWithRegistry creates a new Nether generator using the block registry passed to resolve blocks to runtime
+// IDs. Use this constructor when the generator is used in a World with a non-default block registry.
+func NewNetherWithRegistry(seed int64, br world.BlockRegistry) *Nether {
+	rid := br.BlockRuntimeID
+	n := &Nether{
+		seed:        seed,
+		br:          br,
+		terrain:     newOctaveNoise(noiseSource(seed, saltTerrain), 4, 96),
+		surface:     newOctaveNoise(noiseSource(seed, saltSurface), 3, 24),
+		temperature: newOctaveNoise(noiseSource(seed, saltTemperature), 3, 320),
+		humidity:    newOctaveNoise(noiseSource(seed, saltHumidity), 3, 320),
+		b: netherBlocks{
+			air:        br.AirRuntimeID(),
+			bedrock:    rid(block.Bedrock{}),
+			netherrack: rid(block.Netherrack{}),
+			lava:       rid(block.Lava{Still: true, Depth: 8}),
+			gravel:     rid(block.Gravel{}),
+			soulSand:   rid(block.SoulSand{}),
+			soulSoil:   rid(block.SoulSoil{}),
+			basalt:     rid(block.Basalt{Axis: cube.Y}),
+			blackstone: rid(block.Blackstone{Type: block.NormalBlackstone()}),
+			magma:      rid(block.Magma{}),
+			glowstone:  rid(block.Glowstone{}),
+		},
+	}
+	n.decorator = NewDecorator(seed, br, n.generateTerrain, netherFeatures()...)
+	return n
+}
+
+// netherFeatures returns the features placed by the Nether generator after the terrain of a chunk has been
+// generated.
+func netherFeatures() []PlacedFeature {
+	vein := func(ore world.Block, size, count, minY, maxY int) PlacedFeature {
+		replace := map[world.Block]world.Block{block.Netherrack{}: ore}
+		return PlacedFeature{Feature: OreVein{Size: size, Replace: replace}, Count: count, Placement: UniformHeight(minY, maxY)}
+	}
+	return []PlacedFeature{
+		vein(block.NetherQuartzOre{}, 14, 16, 10, 117),
+		vein(block.NetherGoldOre{}, 10, 10, 10, 117),
+		vein(block.AncientDebris{}, 2, 2, 8, 24),
+	}
+}
+
+// GenerateChunk ...
+func (n *Nether) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	n.generateTerrain(pos, c)
+	n.decorator.Decorate(pos, c)
+}
+
+// generateTerrain generates the terrain of the chunk at pos, including the blocks placed on its floors, but without
+// the Features placed by the decorator.
+func (n *Nether) generateTerrain(pos world.ChunkPos, c *chunk.Chunk) {
+	r := c.Range()
+	density := newDensityGrid(pos, r, n.density)
+	rng := chunkSource(n.seed, pos[0], pos[1], saltBedrock)
+
+	minY, maxY := int16(r.Min()), int16(r.Max())
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	for x := range uint8(16) {
+		for z := range uint8(16) {
+			fx, fz := float64(baseX+int(x)), float64(baseZ+int(z))
+			b := n.biome(fx, fz)
+			biomeID := uint32(b.EncodeBiome())
+			floor, ceiling := minY+int16(1+rng.IntN(4)), maxY-int16(rng.IntN(4))
+			surface := n.surface.at2(fx, fz)
+
+			// depth is the number of solid blocks directly above the current block, counting from the last open
+			// space. Floors are only decorated if they are below open space.
+			depth := -1
+			for y := maxY; y >= minY; y-- {
+				c.SetBiome(x, y, z, biomeID)
+				if y < floor || y >= ceiling {
+					c.SetBlock(x, y, z, 0, n.b.bedrock)
+					depth = -1
+					continue
+				}
+				if density.at(int(x), int(y-minY), int(z)) <= 0 {
+					if y <= NetherLavaLevel {
+						c.SetBlock(x, y, z, 0, n.b.lava)
+					}
+					depth = 0
+					continue
+				}
+				if depth >= 0 {
+					depth++
+				}
+				c.SetBlock(x, y, z, 0, n.floorBlock(b, depth, int(y), surface))
+			}
+		}
+	}
+	n.decorate(pos, c, chunkSource(n.seed, pos[0], pos[1], saltDecoration))
+}
+
+// density returns the terrain density at a position in the Nether. Positive densities are solid. The density is
+// biased towards solid blocks near the floor and ceiling, which leaves a large open cavern in between.
+func (n *Nether) density(x, y, z float64) float64 {
+	d := n.terrain.at(x, y*2, z) * 1.8
+	if y < 24 {
+		d += (24 - y) / 8
+	}
+	if y > 100 {
+		d += (y - 100) / 12
+	}
+	return d - 0.2
+}
+
+// biome returns the biome at the world x and z passed.
+func (n *Nether) biome(x, z float64) world.Biome {
+	temperature, humidity := n.temperature.at2(x, z)*1.8, n.humidity.at2(x, z)*1.8
+	switch {
+	case humidity < -0.35:
+		return biome.SoulSandValley{}
+	case temperature > 0.35:
+		return biome.BasaltDeltas{}
+	}
+	return biome.NetherWastes{}
+}
+
+// floorBlock returns the block placed at a depth below the open space above. depth is -1 if the block is not below
+// any open space. surface is the surface noise of the column, used to mix blocks in a biome.
+func (n *Nether) floorBlock(b world.Biome, depth, y int, surface float64) uint32 {
+	if depth < 0 {
+		return n.b.netherrack
+	}
+	switch b.(type) {
+	case biome.SoulSandValley:
+		if depth <= 4 {
+			if surface > 0 {
+				return n.b.soulSand
+			}
+			return n.b.soulSoil
+		}
+	case biome.BasaltDeltas:
+		if depth <= 3 {
+			if y >= NetherLavaLevel-1 && y <= NetherLavaLevel+2 && surface > 0.25 {
+				return n.b.magma
+			}
+			if surface > -0.2 {
+				return n.b.basalt
+			}
+			return n.b.blackstone
+		}
+	default:
+		// Gravel and soul sand gather on the shores of the lava seas.
+		if depth <= 3 && y >= NetherLavaLevel-2 && y <= NetherLavaLevel+4 {
+			switch {
+			case surface > 0.3:
+				return n.b.gravel
+			case surface < -0.3:
+				return n.b.soulSand
+			}
+		}
+	}
+	return n.b.netherrack
+}
+
+// decorate places glowstone clusters and, in basalt deltas, basalt columns and lava pools in a chunk.
+func (n *Nether) decorate(pos world.ChunkPos, c *chunk.Chunk, rng *rand.Rand) {
+	r := c.Range()
+	for range 4 {
+		// Glowstone clusters hang from the first ceiling found above a random height.
+		x, z := rng.IntN(16), rng.IntN(16)
+		for y := int16(NetherLavaLevel + rng.IntN(r.Max()-NetherLavaLevel-16)); y < int16(r.Max()-4); y++ {
+			if c.Block(uint8(x), y, uint8(z), 0) == n.b.air && c.Block(uint8(x), y+1, uint8(z), 0) == n.b.netherrack {
+				n.glowstone(c, x, int(y), z, rng)
+				break
+			}
+		}
+	}
+
+	baseX, baseZ := float64(int(pos[0])<<4), float64(int(pos[1])<<4)
+	for range 12 {
+		x, z := rng.IntN(16), rng.IntN(16)
+		if _, ok := n.biome(baseX+float64(x), baseZ+float64(z)).(biome.BasaltDeltas); !ok {
+			continue
+		}
+		for y := int16(NetherLavaLevel + 1); y < int16(r.Max()-8); y++ {
+			below := c.Block(uint8(x), y-1, uint8(z), 0)
+			if c.Block(uint8(x), y, uint8(z), 0) != n.b.air || (below != n.b.basalt && below != n.b.blackstone) {
+				continue
+			}
+			if rng.IntN(3) == 0 {
+				n.lavaPool(c, x, y-1, z)
+			} else {
+				for dy := range int16(1 + rng.IntN(5)) {
+					if c.Block(uint8(x), y+dy, uint8(z), 0) != n.b.air {
+						break
+					}
+					c.SetBlock(uint8(x), y+dy, uint8(z), 0, n.b.basalt)
+				}
+			}
+			break
+		}
+	}
+}
+
+// glowstone grows a glowstone cluster downwards from the ceiling above x, y, z. Blocks of the cluster that fall
+// outside the chunk are discarded.
+func (n *Nether) glowstone(c *chunk.Chunk, x, y, z int, rng *rand.Rand) {
+	c.SetBlock(uint8(x), int16(y), uint8(z), 0, n.b.glowstone)
+	for range 60 {
+		px, py, pz := x+rng.IntN(7)-3, y-rng.IntN(8), z+rng.IntN(7)-3
+		if px < 0 || px >= 16 || pz < 0 || pz >= 16 || py <= c.Range().Min() {
+			continue
+		}
+		if c.Block(uint8(px), int16(py), uint8(pz), 0) != n.b.air {
+			continue
+		}
+		neighbours := 0
+		for _, face := range cube.Faces() {
+			side := cube.Pos{px, py, pz}.Side(face)
+			if side[0] < 0 || side[0] >= 16 || side[2] < 0 || side[2] >= 16 {
+				continue
+			}
+			if c.Block(uint8(side[0]), int16(side[1]), uint8(side[2]), 0) == n.b.glowstone {
+				neighbours++
+			}
+		}
+		if neighbours == 1 {
+			c.SetBlock(uint8(px), int16(py), uint8(pz), 0, n.b.glowstone)
+		}
+	}
+}
+
+// lavaPool replaces the floor block at x, y, z with lava if it is enclosed by solid blocks on all horizontal sides
+// and below, so that the lava does not flow out.
+func (n *Nether) lavaPool(c *chunk.Chunk, x int, y int16, z int) {
+	if x == 0 || x == 15 || z == 0 || z == 15 {
+		return
+	}
+	for _, p := range [...][3]int{{x - 1, int(y), z}, {x + 1, int(y), z}, {x, int(y), z - 1}, {x, int(y), z + 1}, {x, int(y) - 1, z}} {
+		if rid := c.Block(uint8(p[0]), int16(p[1]), uint8(p[2]), 0); rid == n.b.air || rid == n.b.lava {
+			return
+		}
+	}
+	c.SetBlock(uint8(x), y, uint8(z), 0, n.b.lava)
+}
+
+// DefaultSpawn returns a position on the floor of an open cavern above the lava sea, close to the origin of the
+// world.
+func (n *Nether) DefaultSpawn(dim world.Dimension) cube.Pos {
+	if pos, ok := findSpawn(n, n.br, dim, func(rid uint32) bool {
+		return rid != n.b.air && rid != n.b.lava && rid != n.b.bedrock
+	}); ok {
+		return pos
+	}
+	return cube.Pos{0, NetherLavaLevel + 2, 0}
+}
+
+// findSpawn generates chunks spiralling outwards from the origin of the world and returns the first position above
+// a solid block for which two blocks of air are available. Positions below NetherLavaLevel are never returned.
+func findSpawn(g world.Generator, br world.BlockRegistry, dim world.Dimension, solid func(rid uint32) bool) (cube.Pos, bool) {
+	r := dim.Range()
+	air := br.AirRuntimeID()
+	for radius := int32(0); radius <= 8; radius++ {
+		for cx := -radius; cx <= radius; cx++ {
+			for cz := -radius; cz <= radius; cz++ {
+				if max(abs(int(cx)), abs(int(cz))) != int(radius) {
+					continue
+				}
+				c := chunk.New(br, r)
+				g.GenerateChunk(world.ChunkPos{cx, cz}, c)
+				for y := int16(math.Max(NetherLavaLevel+1, float64(r.Min()+1))); y < int16(r.Max()-2); y++ {
+					for x := range uint8(16) {
+						for z := range uint8(16) {
+							if solid(c.Block(x, y-1, z, 0)) && c.Block(x, y, z, 0) == air && c.Block(x, y+1, z, 0) == air {
+								return cube.Pos{int(cx)<<4 | int(x), int(y), int(cz)<<4 | int(z)}, true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return cube.Pos{}, false
+}
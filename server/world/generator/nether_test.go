@@ -0,0 +1,59 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/generator"
+)
+
+func TestNetherDeterministic(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	a, b := generator.NewNether(42), generator.NewNether(42)
+
+	for _, pos := range []world.ChunkPos{{0, 0}, {-3, 7}, {40, -15}} {
+		if !generateIn(a, world.Nether, pos).Equals(generateIn(b, world.Nether, pos)) {
+			t.Fatalf("chunk %v generated differently by two generators with the same seed", pos)
+		}
+	}
+	if generateIn(a, world.Nether, world.ChunkPos{5, 5}).Equals(generateIn(generator.NewNether(43), world.Nether, world.ChunkPos{5, 5})) {
+		t.Fatalf("expected chunks of generators with different seeds to differ")
+	}
+}
+
+func TestNetherBedrock(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	c := generateIn(generator.NewNether(3), world.Nether, world.ChunkPos{2, -2})
+	bedrock := world.BlockRuntimeID(block.Bedrock{})
+	for x := range uint8(16) {
+		for z := range uint8(16) {
+			if c.Block(x, 0, z, 0) != bedrock || c.Block(x, 127, z, 0) != bedrock {
+				t.Fatalf("expected bedrock at the floor and ceiling of column %v, %v", x, z)
+			}
+		}
+	}
+}
+
+func TestNetherDefaultSpawn(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	for _, seed := range []int64{0, 1, 1337} {
+		g := generator.NewNether(seed)
+		spawn := g.DefaultSpawn(world.Nether)
+		if spawn[1] <= generator.NetherLavaLevel {
+			t.Fatalf("seed %v: expected spawn above the lava sea, got %v", seed, spawn)
+		}
+
+		c := generateIn(g, world.Nether, world.ChunkPos{int32(spawn[0] >> 4), int32(spawn[2] >> 4)})
+		x, z := uint8(spawn[0]&15), uint8(spawn[2]&15)
+		below, _ := world.BlockByRuntimeID(c.Block(x, int16(spawn[1]-1), z, 0))
+		if _, ok := below.(block.Lava); ok || below == (block.Air{}) {
+			t.Fatalf("seed %v: expected solid ground below spawn %v, got %#v", seed, spawn, below)
+		}
+		for y := spawn[1]; y <= spawn[1]+1; y++ {
+			if at, _ := world.BlockByRuntimeID(c.Block(x, int16(y), z, 0)); at != (block.Air{}) {
+				t.Fatalf("seed %v: expected air at spawn %v, got %#v", seed, spawn, at)
+			}
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// Salts used to derive independent noise layers and per-chunk random sources from a generator seed.
+const (
+	saltContinentalness uint64 = iota + 1
+	saltErosion
+	saltRidges
+	saltDetail
+	saltTemperature
+	saltHumidity
+	saltRiver
+	saltCheese
+	saltSpaghettiA
+	saltSpaghettiB
+	saltBedrock
+	saltDecoration
+	saltTerrain
+	saltSurface
+	saltIslands
+	saltPillars
+	saltFeatures
+)
+
+// perlin is a seeded implementation of Ken Perlin's improved gradient noise. A perlin value is immutable once
+// created, so it may be sampled from multiple goroutines at the same time.
+type perlin struct {
+	// p is the permutation table, repeated twice so that lookups never need to wrap around.
+	p [512]uint8
+	// ox, oy and oz are random offsets applied to every sample, so that two noise instances with the same
+	// permutation do not produce the same values at the origin.
+	ox, oy, oz float64
+}
+
+// newPerlin creates a perlin noise instance with a permutation table shuffled by the rand.Rand passed.
+func newPerlin(r *rand.Rand) *perlin {
+	n := &perlin{ox: r.Float64() * 256, oy: r.Float64() * 256, oz: r.Float64() * 256}
+	for i := range 256 {
+		n.p[i] = uint8(i)
+	}
+	r.Shuffle(256, func(i, j int) {
+		n.p[i], n.p[j] = n.p[j], n.p[i]
+	})
+	copy(n.p[256:], n.p[:256])
+	return n
+}
+
+// at samples the noise at the coordinates passed. The value returned is roughly in the range [-1, 1].
+func (n *perlin) at(x, y, z float64) float64 {
+	x, y, z = x+n.ox, y+n.oy, z+n.oz
+	fx, fy, fz := math.Floor(x), math.Floor(y), math.Floor(z)
+	xi, yi, zi := int(fx)&255, int(fy)&255, int(fz)&255
+	x, y, z = x-fx, y-fy, z-fz
+	u, v, w := fade(x), fade(y), fade(z)
+
+	a := int(n.p[xi]) + yi
+	aa, ab := int(n.p[a])+zi, int(n.p[a+1])+zi
+	b := int(n.p[xi+1]) + yi
+	ba, bb := int(n.p[b])+zi, int(n.p[b+1])+zi
+
+	return lerp(w,
+		lerp(v,
+			lerp(u, grad(n.p[aa], x, y, z), grad(n.p[ba], x-1, y, z)),
+			lerp(u, grad(n.p[ab], x, y-1, z), grad(n.p[bb], x-1, y-1, z)),
+		),
+		lerp(v,
+			lerp(u, grad(n.p[aa+1], x, y, z-1), grad(n.p[ba+1], x-1, y, z-1)),
+			lerp(u, grad(n.p[ab+1], x, y-1, z-1), grad(n.p[bb+1], x-1, y-1, z-1)),
+		),
+	)
+}
+
+// fade is the quintic smoothing curve used by improved noise.
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+// lerp linearly interpolates between a and b using t.
+func lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
+
+// grad returns the dot product of one of twelve gradient vectors, selected using hash, with (x, y, z).
+func grad(hash uint8, x, y, z float64) float64 {
+	switch hash & 15 {
+	case 0, 12:
+		return x + y
+	case 1, 14:
+		return -x + y
+	case 2:
+		return x - y
+	case 3:
+		return -x - y
+	case 4:
+		return x + z
+	case 5:
+		return -x + z
+	case 6:
+		return x - z
+	case 7:
+		return -x - z
+	case 8:
+		return y + z
+	case 9, 13:
+		return -y + z
+	case 10:
+		return y - z
+	default:
+		return -y - z
+	}
+}
+
+// octaveNoise combines several octaves of perlin noise, each with double the frequency and half the amplitude of
+// the previous one. Like perlin, octaveNoise is safe for concurrent use.
+type octaveNoise struct {
+	octaves   []*perlin
+	frequency float64
+}
+
+// newOctaveNoise creates an octaveNoise with the number of octaves passed. scale is the size in blocks of the
+// features produced by the first octave.
+func newOctaveNoise(r *rand.Rand, octaves int, scale float64) octaveNoise {
+	o := octaveNoise{octaves: make([]*perlin, octaves), frequency: 1 / scale}
+	for i := range o.octaves {
+		o.octaves[i] = newPerlin(r)
+	}
+	return o
+}
+
+// at samples the combined octaves at the coordinates passed. The value returned is normalised to roughly the range
+// [-1, 1].
+func (o octaveNoise) at(x, y, z float64) float64 {
+	var sum, norm float64
+	amplitude, frequency := 1.0, o.frequency
+	for _, p := range o.octaves {
+		sum += p.at(x*frequency, y*frequency, z*frequency) * amplitude
+		norm += amplitude
+		amplitude /= 2
+		frequency *= 2
+	}
+	return sum / norm
+}
+
+// at2 samples the combined octaves on a horizontal plane.
+func (o octaveNoise) at2(x, z float64) float64 {
+	return o.at(x, 0, z)
+}
+
+// noiseSource returns a rand.Rand for a specific noise layer of a generator with the seed passed. Every layer uses a
+// different salt so that layers are uncorrelated while still being deterministic for a seed.
+func noiseSource(seed int64, salt uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(uint64(seed), salt))
+}
+
+// chunkSource returns a rand.Rand seeded deterministically for a chunk of a generator with the seed passed. salt may
+// be used to get independent random sequences for different generation stages of the same chunk.
+func chunkSource(seed int64, x, z int32, salt uint64) *rand.Rand {
+	h := uint64(x)*0x9e3779b97f4a7c15 ^ uint64(z)*0xc2b2ae3d27d4eb4f ^ salt*0x165667b19e3779f9
+	return rand.New(rand.NewPCG(uint64(seed)^h, h))
+}
+
+// clamp limits v to the range [lo, hi].
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
+
+// smoothstep performs smooth Hermite interpolation of v between the edges a and b.
+func smoothstep(a, b, v float64) float64 {
+	t := clamp((v-a)/(b-a), 0, 1)
+	return t * t * (3 - 2*t)
+}
+
+// abs returns the absolute value of v.
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
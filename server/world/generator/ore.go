@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// OreVein is a Feature that places a vein of ore as a random walk through the blocks around a position. Only blocks
+// found in Replace are replaced, so the vein takes the shape of the surrounding stone.
+type OreVein struct {
+	// Size is the number of steps of the random walk, which is the maximum number of blocks in the vein.
+	Size int
+	// Replace maps the blocks that the vein may replace to the ore blocks placed in their position, for example
+	// stone to stone coal ore and deepslate to deepslate coal ore.
+	Replace map[world.Block]world.Block
+}
+
+// Place ...
+func (v OreVein) Place(d *Decoration, pos cube.Pos, r *rand.Rand) bool {
+	for range v.Size {
+		d.ModifyBlock(pos, v.replace)
+		pos = pos.Side(cube.Face(r.IntN(6)))
+	}
+	return true
+}
+
+// replace returns the ore block that replaces the block passed, if any.
+func (v OreVein) replace(b world.Block) (world.Block, bool) {
+	ore, ok := v.Replace[b]
+	return ore, ok
+}
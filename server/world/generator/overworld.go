@@ -0,0 +1,453 @@
+package generator
+
+import (
+	"math"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// SeaLevel is the y level up to which the Overworld generator fills oceans, lakes and rivers with water.
+const SeaLevel = 62
+
+// lavaLevel is the y level, relative to the bottom of the world, up to which caves are flooded with lava.
+const lavaLevel = 9
+
+// Overworld is a noise based generator that produces vanilla-style Overworld terrain. The terrain consists of
+// continents and oceans, mountains, rivers and beaches, with biomes from the biome package chosen using temperature
+// and humidity noise. The terrain is carved by caves, with lava lakes at the bottom of the world. Once the terrain of
+// a chunk is generated, it is decorated with ores, lakes, trees, grass and flowers depending on the biome.
+// Generation is fully deterministic for a seed. Overworld is safe for concurrent use, so it may be used in a World
+// with more than one chunk load worker. An Overworld may be constructed by calling NewOverworld.
+type Overworld struct {
+	seed int64
+
+	continentalness, erosion, ridges, detail octaveNoise
+	temperature, humidity, river             octaveNoise
+	cheese, spaghettiA, spaghettiB           octaveNoise
+
+	b         overworldBlocks
+	decorator *Decorator
+}
+
+// overworldBlocks holds the runtime IDs of all blocks placed by the Overworld generator.
+type overworldBlocks struct {
+	bedrock, stone, deepslate, dirt, grass, sand, redSand, sandstone, gravel, snow, terracotta, clay uint32
+	water, lava                                                                                      uint32
+}
+
+// NewOverworld creates a new Overworld generator that generates terrain using the seed passed.
+func NewOverworld(seed int64) *Overworld {
+	return NewOverworldWithRegistry(seed, world.DefaultBlockRegistry)
+}
+
+// NewOverworldWithRegistry creates a new Overworld generator using the block registry passed to resolve blocks to
+// runtime IDs. Use this constructor when the generator is used in a World with a non-default block registry.
+func NewOverworldWithRegistry(seed int64, br world.BlockRegistry) *Overworld {
+	o := &Overworld{
+		seed:            seed,
+		continentalness: newOctaveNoise(noiseSource(seed, saltContinentalness), 5, 900),
+		erosion:         newOctaveNoise(noiseSource(seed, saltErosion), 4, 500),
+		ridges:          newOctaveNoise(noiseSource(seed, saltRidges), 4, 220),
+		detail:          newOctaveNoise(noiseSource(seed, saltDetail), 4, 70),
+		temperature:     newOctaveNoise(noiseSource(seed, saltTemperature), 3, 1100),
+		humidity:        newOctaveNoise(noiseSource(seed, saltHumidity), 3, 900),
+		river:           newOctaveNoise(noiseSource(seed, saltRiver), 3, 700),
+		cheese:          newOctaveNoise(noiseSource(seed, saltCheese), 3, 80),
+		spaghettiA:      newOctaveNoise(noiseSource(seed, saltSpaghettiA), 2, 90),
+		spaghettiB:      newOctaveNoise(noiseSource(seed, saltSpaghettiB), 2, 90),
+		b: overworldBlocks{
+			bedrock:    br.BlockRuntimeID(block.Bedrock{}),
+			stone:      br.BlockRuntimeID(block.Stone{}),
+			deepslate:  br.BlockRuntimeID(block.Deepslate{Type: block.NormalDeepslate(), Axis: cube.Y}),
+			dirt:       br.BlockRuntimeID(block.Dirt{}),
+			grass:      br.BlockRuntimeID(block.Grass{}),
+			sand:       br.BlockRuntimeID(block.Sand{}),
+			redSand:    br.BlockRuntimeID(block.Sand{Red: true}),
+			sandstone:  br.BlockRuntimeID(block.Sandstone{}),
+			gravel:     br.BlockRuntimeID(block.Gravel{}),
+			snow:       br.BlockRuntimeID(block.Snow{}),
+			terracotta: br.BlockRuntimeID(block.Terracotta{}),
+			clay:       br.BlockRuntimeID(block.Clay{}),
+			water:      br.BlockRuntimeID(block.Water{Still: true, Depth: 8}),
+			lava:       br.BlockRuntimeID(block.Lava{Still: true, Depth: 8}),
+		},
+	}
+	o.decorator = NewDecorator(seed, br, o.generateTerrain, overworldFeatures()...)
+	return o
+}
+
+// column holds the terrain properties computed for a single x/z column of the Overworld.
+type column struct {
+	// height is the y level of the highest terrain block in the column.
+	height int
+	// biome is the biome of the column.
+	biome world.Biome
+}
+
+// GenerateChunk ...
+func (o *Overworld) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	o.generateTerrain(pos, c)
+	o.decorator.Decorate(pos, c)
+}
+
+// generateTerrain generates the undecorated terrain of the chunk at pos.
+func (o *Overworld) generateTerrain(pos world.ChunkPos, c *chunk.Chunk) {
+	r := c.Range()
+	cols := o.columns(pos)
+	caves := o.caveDensity(pos, r)
+	rng := chunkSource(o.seed, pos[0], pos[1], saltBedrock)
+
+	minY := int16(r.Min())
+	for x := range uint8(16) {
+		for z := range uint8(16) {
+			col := cols[int(x)<<4|int(z)]
+			s := o.surface(col)
+			biomeID := uint32(col.biome.EncodeBiome())
+			bedrockDepth := int16(1 + rng.IntN(4))
+
+			for y := minY; y <= int16(r.Max()); y++ {
+				c.SetBiome(x, y, z, biomeID)
+				h := int16(col.height)
+				if y > h {
+					if y <= SeaLevel {
+						c.SetBlock(x, y, z, 0, o.b.water)
+					}
+					continue
+				}
+				if y-minY < bedrockDepth {
+					c.SetBlock(x, y, z, 0, o.b.bedrock)
+					continue
+				}
+				if o.carved(caves, x, y, y-minY, z, col) {
+					if y-minY <= lavaLevel {
+						c.SetBlock(x, y, z, 0, o.b.lava)
+					}
+					continue
+				}
+				c.SetBlock(x, y, z, 0, s.at(int(h-y), int(y), o.b))
+			}
+		}
+	}
+}
+
+// DefaultSpawn returns a position on dry land close to the origin of the world. The position returned is directly
+// above the highest terrain block of the column.
+func (o *Overworld) DefaultSpawn(world.Dimension) cube.Pos {
+	for radius := 0; radius <= 2048; radius += 16 {
+		for dx := -radius; dx <= radius; dx += 16 {
+			for dz := -radius; dz <= radius; dz += 16 {
+				if max(abs(dx), abs(dz)) != radius {
+					// Only check the edges of the current square, the inside was checked in earlier iterations.
+					continue
+				}
+				if col := o.column(dx, dz); o.dryLand(col) {
+					return cube.Pos{dx, col.height + 1, dz}
+				}
+			}
+		}
+	}
+	return cube.Pos{0, o.column(0, 0).height + 1, 0}
+}
+
+// dryLand checks if a column is suitable as a spawn position: above sea level and not in an ocean, river or beach.
+func (o *Overworld) dryLand(col column) bool {
+	if col.height <= SeaLevel+1 {
+		return false
+	}
+	switch col.biome.(type) {
+	case biome.Ocean, biome.DeepOcean, biome.FrozenOcean, biome.WarmOcean, biome.ColdOcean, biome.River,
+		biome.FrozenRiver, biome.Beach, biome.SnowyBeach, biome.StonyShore, biome.StonyPeaks, biome.SnowySlopes:
+		return false
+	}
+	return true
+}
+
+// columns computes the terrain properties of all columns in a chunk, indexed by x<<4|z.
+func (o *Overworld) columns(pos world.ChunkPos) [256]column {
+	var cols [256]column
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	for x := range 16 {
+		for z := range 16 {
+			cols[x<<4|z] = o.column(baseX+x, baseZ+z)
+		}
+	}
+	return cols
+}
+
+// column computes the terrain height and biome of the column at the world x and z passed.
+func (o *Overworld) column(x, z int) column {
+	fx, fz := float64(x), float64(z)
+
+	cont := clamp(o.continentalness.at2(fx, fz)*1.8, -1, 1)
+	erosion := clamp(o.erosion.at2(fx, fz)*1.8, -1, 1)
+	ridge := 1 - math.Abs(o.ridges.at2(fx, fz)*1.8)
+	ridge = clamp(ridge, 0, 1)
+
+	h := continentHeight(cont)
+	// Mountains rise from inland regions with low erosion. The ridge noise shapes them into chains of peaks.
+	mountains := smoothstep(0.05, 0.6, cont) * math.Pow((1-erosion)/2, 1.5)
+	h += mountains * (30 + 130*ridge*ridge)
+	// Hills and small bumps, dampened near the coast so that beaches stay flat.
+	h += o.detail.at2(fx, fz) * (2 + 14*(1-erosion)/2) * smoothstep(-0.15, 0.1, cont)
+
+	river := false
+	if rv := math.Abs(o.river.at2(fx, fz)); rv < 0.045 && cont > -0.18 && h < SeaLevel+35 {
+		t := smoothstep(0, 1, 1-rv/0.045)
+		h = lerp(t, h, SeaLevel-4)
+		river = t > 0.45
+	}
+
+	temperature := clamp(o.temperature.at2(fx, fz)*1.8, -1, 1)
+	humidity := clamp(o.humidity.at2(fx, fz)*1.8, -1, 1)
+
+	height := int(math.Floor(h))
+	return column{height: height, biome: o.biome(height, cont, erosion, temperature, humidity, river)}
+}
+
+// continentHeight maps a continentalness value to a base terrain height using a piecewise linear spline.
+func continentHeight(cont float64) float64 {
+	points := [...][2]float64{
+		{-1, 18}, {-0.5, 32}, {-0.25, 48}, {-0.12, 58}, {-0.05, 62}, {0, 64}, {0.15, 67}, {0.4, 74}, {1, 88},
+	}
+	if cont <= points[0][0] {
+		return points[0][1]
+	}
+	for i := 1; i < len(points); i++ {
+		if cont <= points[i][0] {
+			a, b := points[i-1], points[i]
+			return lerp((cont-a[0])/(b[0]-a[0]), a[1], b[1])
+		}
+	}
+	return points[len(points)-1][1]
+}
+
+// biome selects the biome of a column using its height and climate.
+func (o *Overworld) biome(height int, cont, erosion, temperature, humidity float64, river bool) world.Biome {
+	frozen, warm := temperature < -0.45, temperature > 0.45
+	switch {
+	case river && height < SeaLevel:
+		if frozen {
+			return biome.FrozenRiver{}
+		}
+		return biome.River{}
+	case height < SeaLevel-18:
+		if frozen {
+			return biome.FrozenOcean{}
+		}
+		return biome.DeepOcean{}
+	case height < SeaLevel-1:
+		switch {
+		case frozen:
+			return biome.FrozenOcean{}
+		case temperature < -0.2:
+			return biome.ColdOcean{}
+		case warm:
+			return biome.WarmOcean{}
+		}
+		return biome.Ocean{}
+	case height <= SeaLevel+2 && cont < 0.02:
+		switch {
+		case erosion < -0.35:
+			return biome.StonyShore{}
+		case frozen:
+			return biome.SnowyBeach{}
+		}
+		return biome.Beach{}
+	case height > SeaLevel+95:
+		if temperature < 0.1 {
+			return biome.SnowySlopes{}
+		}
+		return biome.StonyPeaks{}
+	case height > SeaLevel+55:
+		if frozen {
+			return biome.SnowySlopes{}
+		}
+		return biome.WindsweptHills{}
+	}
+
+	switch {
+	case frozen:
+		if humidity > 0 {
+			return biome.SnowyTaiga{}
+		}
+		return biome.SnowyPlains{}
+	case temperature < -0.15:
+		if humidity > -0.2 {
+			return biome.Taiga{}
+		}
+		return biome.Plains{}
+	case temperature < 0.2:
+		switch {
+		case humidity < -0.3:
+			return biome.Plains{}
+		case humidity < 0.05:
+			return biome.Forest{}
+		case humidity < 0.3:
+			return biome.BirchForest{}
+		case humidity > 0.5 && height < SeaLevel+6:
+			return biome.Swamp{}
+		}
+		return biome.DarkForest{}
+	case !warm:
+		switch {
+		case humidity < -0.2:
+			return biome.Savanna{}
+		case humidity < 0.35:
+			return biome.Plains{}
+		}
+		return biome.Jungle{}
+	}
+	switch {
+	case humidity < -0.1:
+		return biome.Desert{}
+	case humidity < 0.15:
+		return biome.Badlands{}
+	}
+	return biome.Jungle{}
+}
+
+// surfaceLayers describes the blocks that make up the top layers of a column.
+type surfaceLayers struct {
+	// top is the block placed at the surface of the column.
+	top uint32
+	// filler is the block placed below top, fillerDepth blocks deep.
+	filler      uint32
+	fillerDepth int
+	// under is an optional layer placed below the filler, underDepth blocks deep.
+	under      uint32
+	underDepth int
+}
+
+// surface returns the surface layers of a column, depending on its biome and height.
+func (o *Overworld) surface(col column) surfaceLayers {
+	b := o.b
+	if col.height < SeaLevel {
+		switch col.biome.(type) {
+		case biome.DeepOcean, biome.FrozenOcean, biome.ColdOcean:
+			return surfaceLayers{top: b.gravel, filler: b.gravel, fillerDepth: 3}
+		case biome.Swamp:
+			return surfaceLayers{top: b.clay, filler: b.dirt, fillerDepth: 2}
+		case biome.Desert, biome.Badlands, biome.WarmOcean, biome.Beach:
+			return surfaceLayers{top: b.sand, filler: b.sand, fillerDepth: 3, under: b.sandstone, underDepth: 3}
+		}
+		if col.height < SeaLevel-6 {
+			return surfaceLayers{top: b.gravel, filler: b.dirt, fillerDepth: 2}
+		}
+		return surfaceLayers{top: b.sand, filler: b.sand, fillerDepth: 2, under: b.sandstone, underDepth: 2}
+	}
+	switch col.biome.(type) {
+	case biome.Desert, biome.Beach, biome.SnowyBeach:
+		return surfaceLayers{top: b.sand, filler: b.sand, fillerDepth: 3, under: b.sandstone, underDepth: 3}
+	case biome.Badlands:
+		return surfaceLayers{top: b.redSand, filler: b.terracotta, fillerDepth: 12}
+	case biome.StonyShore, biome.StonyPeaks:
+		return surfaceLayers{top: b.stone, filler: b.stone}
+	case biome.SnowySlopes:
+		return surfaceLayers{top: b.snow, filler: b.snow, fillerDepth: 2}
+	case biome.WindsweptHills:
+		if col.height > SeaLevel+80 {
+			return surfaceLayers{top: b.stone, filler: b.stone}
+		}
+	}
+	return surfaceLayers{top: b.grass, filler: b.dirt, fillerDepth: 3}
+}
+
+// at returns the block at a depth below the surface of a column. y is the absolute y level of the block.
+func (s surfaceLayers) at(depth, y int, b overworldBlocks) uint32 {
+	switch {
+	case depth == 0:
+		return s.top
+	case depth <= s.fillerDepth:
+		return s.filler
+	case depth <= s.fillerDepth+s.underDepth:
+		return s.under
+	case y < 0:
+		return b.deepslate
+	}
+	return b.stone
+}
+
+// caveDensity samples the cave density for the chunk at pos. Positive densities are carved out.
+func (o *Overworld) caveDensity(pos world.ChunkPos, r cube.Range) densityGrid {
+	return newDensityGrid(pos, r, func(x, y, z float64) float64 {
+		// Cheese caves are large open caverns, spaghetti caves long winding tunnels where two noise fields are
+		// both close to zero.
+		cheese := o.cheese.at(x, y*1.6, z)*1.8 - 0.55
+		spaghetti := 0.08 - max(math.Abs(o.spaghettiA.at(x, y*1.4, z)), math.Abs(o.spaghettiB.at(x, y*1.4, z)))
+		return max(cheese, spaghetti)
+	})
+}
+
+// carved checks if the block at a position in the chunk is carved out by a cave. relY is the y level relative to
+// the bottom of the world. Caves never break through the floor of oceans and rivers, so that water does not drain
+// into them.
+func (o *Overworld) carved(caves densityGrid, x uint8, y, relY int16, z uint8, col column) bool {
+	if col.height < SeaLevel+2 && int(y) > col.height-6 {
+		return false
+	}
+	return caves.at(int(x), int(relY), int(z)) > 0
+}
+
+// overworldFeatures returns the features placed by the Overworld generator after the terrain of a chunk has been
+// generated.
+func overworldFeatures() []PlacedFeature {
+	stone, deepslate := block.Stone{}, block.Deepslate{Type: block.NormalDeepslate(), Axis: cube.Y}
+	s, d := block.StoneOre(), block.DeepslateOre()
+	in := func(b world.Block) map[world.Block]world.Block {
+		return map[world.Block]world.Block{stone: b, deepslate: b}
+	}
+	ores := func(stoneOre, deepslateOre world.Block) map[world.Block]world.Block {
+		return map[world.Block]world.Block{stone: stoneOre, deepslate: deepslateOre}
+	}
+	vein := func(replace map[world.Block]world.Block, size, count, minY, maxY int) PlacedFeature {
+		return PlacedFeature{Feature: OreVein{Size: size, Replace: replace}, Count: count, Placement: UniformHeight(minY, maxY)}
+	}
+	emerald := vein(ores(block.EmeraldOre{Type: s}, block.EmeraldOre{Type: d}), 3, 6, -16, 240)
+	emerald.Biome = InBiomes(biome.WindsweptHills{}, biome.StonyPeaks{}, biome.SnowySlopes{})
+
+	trees := func(f Feature, count, rarity int, biomes ...world.Biome) PlacedFeature {
+		return PlacedFeature{Feature: f, Count: count, Rarity: rarity, Placement: Surface(), Biome: InBiomes(biomes...)}
+	}
+	grassy := []world.Biome{
+		biome.Plains{}, biome.Forest{}, biome.BirchForest{}, biome.DarkForest{}, biome.Taiga{}, biome.Swamp{},
+		biome.Savanna{}, biome.Jungle{}, biome.WindsweptHills{},
+	}
+	return []PlacedFeature{
+		{Feature: Lake{Liquid: block.Lava{Still: true, Depth: 8}}, Count: 1, Rarity: 12, Placement: UniformHeight(-54, 40)},
+		{Feature: Lake{Liquid: block.Water{Still: true, Depth: 8}}, Count: 1, Rarity: 8, Placement: Surface(), Biome: InBiomes(grassy...)},
+		vein(in(block.Dirt{}), 33, 7, 0, 160),
+		vein(in(block.Gravel{}), 33, 6, -64, 160),
+		vein(in(block.Granite{}), 48, 2, 0, 60),
+		vein(in(block.Diorite{}), 48, 2, 0, 60),
+		vein(in(block.Andesite{}), 48, 2, 0, 60),
+		vein(ores(block.CoalOre{Type: s}, block.CoalOre{Type: d}), 17, 20, 0, 190),
+		vein(ores(block.IronOre{Type: s}, block.IronOre{Type: d}), 9, 12, -64, 72),
+		vein(ores(block.CopperOre{Type: s}, block.CopperOre{Type: d}), 10, 16, -16, 112),
+		vein(ores(block.GoldOre{Type: s}, block.GoldOre{Type: d}), 9, 4, -64, 32),
+		vein(ores(block.RedstoneOre{Type: s}, block.RedstoneOre{Type: d}), 8, 6, -64, 15),
+		vein(ores(block.LapisOre{Type: s}, block.LapisOre{Type: d}), 7, 2, -64, 64),
+		vein(ores(block.DiamondOre{Type: s}, block.DiamondOre{Type: d}), 7, 4, -64, 16),
+		emerald,
+		trees(OakTree{}, 6, 0, biome.Forest{}, biome.DarkForest{}, biome.Jungle{}),
+		trees(BirchTree{}, 2, 0, biome.Forest{}),
+		trees(BirchTree{}, 8, 0, biome.BirchForest{}),
+		trees(SpruceTree{}, 7, 0, biome.Taiga{}, biome.SnowyTaiga{}),
+		trees(OakTree{}, 2, 0, biome.Swamp{}, biome.Savanna{}, biome.DarkForest{}, biome.Jungle{}),
+		trees(SpruceTree{}, 1, 2, biome.WindsweptHills{}, biome.SnowyPlains{}),
+		trees(OakTree{}, 1, 4, biome.Plains{}),
+		{Feature: FlowerPatch{Flowers: []world.Block{block.ShortGrass{}}, Tries: 32}, Count: 3, Placement: Surface(), Biome: InBiomes(grassy...)},
+		{Feature: FlowerPatch{Flowers: []world.Block{
+			block.Flower{Type: block.Dandelion()}, block.Flower{Type: block.Poppy()},
+		}}, Count: 1, Placement: Surface(), Biome: InBiomes(grassy...)},
+		{Feature: FlowerPatch{Flowers: []world.Block{
+			block.Flower{Type: block.Dandelion()}, block.Flower{Type: block.Poppy()}, block.Flower{Type: block.AzureBluet()},
+			block.Flower{Type: block.OxeyeDaisy()}, block.Flower{Type: block.Cornflower()}, block.Flower{Type: block.RedTulip()},
+			block.Flower{Type: block.OrangeTulip()}, block.Flower{Type: block.WhiteTulip()}, block.Flower{Type: block.PinkTulip()},
+		}}, Count: 2, Placement: Surface(), Biome: InBiomes(biome.Plains{}, biome.Forest{})},
+		{Feature: FlowerPatch{Flowers: []world.Block{block.Flower{Type: block.BlueOrchid()}}}, Count: 1, Placement: Surface(), Biome: InBiomes(biome.Swamp{})},
+	}
+}
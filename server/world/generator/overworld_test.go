@@ -0,0 +1,98 @@
+package generator_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/generator"
+)
+
+// generate generates the chunk at pos using the generator g.
+func generate(g world.Generator, pos world.ChunkPos) *chunk.Chunk {
+	return generateIn(g, world.Overworld, pos)
+}
+
+// generateIn generates the chunk at pos in a dimension using the generator g.
+func generateIn(g world.Generator, dim world.Dimension, pos world.ChunkPos) *chunk.Chunk {
+	c := chunk.New(world.DefaultBlockRegistry, dim.Range())
+	g.GenerateChunk(pos, c)
+	return c
+}
+
+func TestOverworldDeterministic(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	a, b := generator.NewOverworld(42), generator.NewOverworld(42)
+
+	for _, pos := range []world.ChunkPos{{0, 0}, {-3, 7}, {120, -45}} {
+		if !generate(a, pos).Equals(generate(b, pos)) {
+			t.Fatalf("chunk %v generated differently by two generators with the same seed", pos)
+		}
+	}
+	if generate(a, world.ChunkPos{5, 5}).Equals(generate(generator.NewOverworld(43), world.ChunkPos{5, 5})) {
+		t.Fatalf("expected chunks of generators with different seeds to differ")
+	}
+}
+
+func TestOverworldConcurrent(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	// A square of adjacent chunks, so that trees and lakes extend from one chunk into the next.
+	var positions []world.ChunkPos
+	for x := int32(-2); x <= 2; x++ {
+		for z := int32(-2); z <= 2; z++ {
+			positions = append(positions, world.ChunkPos{x, z})
+		}
+	}
+	want := make([]*chunk.Chunk, len(positions))
+	g := generator.NewOverworld(7)
+	for i, pos := range positions {
+		want[i] = generate(g, pos)
+	}
+
+	// Generating the same chunks in reverse order must produce the same chunks.
+	g = generator.NewOverworld(7)
+	for i := len(positions) - 1; i >= 0; i-- {
+		if !want[i].Equals(generate(g, positions[i])) {
+			t.Fatalf("chunk %v generated in reverse order differs from sequential generation", positions[i])
+		}
+	}
+
+	g = generator.NewOverworld(7)
+	var wg sync.WaitGroup
+	got := make([]*chunk.Chunk, len(positions))
+	for i, pos := range positions {
+		wg.Go(func() {
+			got[i] = generate(g, pos)
+		})
+	}
+	wg.Wait()
+	for i, pos := range positions {
+		if !want[i].Equals(got[i]) {
+			t.Fatalf("chunk %v generated concurrently differs from sequential generation", pos)
+		}
+	}
+}
+
+func TestOverworldDefaultSpawn(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	for _, seed := range []int64{0, 1, 1337, -99} {
+		g := generator.NewOverworld(seed)
+		spawn := g.DefaultSpawn(world.Overworld)
+		if spawn[1] <= generator.SeaLevel {
+			t.Fatalf("seed %v: expected spawn above sea level, got %v", seed, spawn)
+		}
+
+		c := generate(g, world.ChunkPos{int32(spawn[0] >> 4), int32(spawn[2] >> 4)})
+		x, z := uint8(spawn[0]&15), uint8(spawn[2]&15)
+		below, _ := world.BlockByRuntimeID(c.Block(x, int16(spawn[1]-1), z, 0))
+		if _, ok := below.(block.Water); ok || below == (block.Air{}) {
+			t.Fatalf("seed %v: expected solid ground below spawn %v, got %#v", seed, spawn, below)
+		}
+		at, _ := world.BlockByRuntimeID(c.Block(x, int16(spawn[1]), z, 0))
+		if at != (block.Air{}) {
+			t.Fatalf("seed %v: expected air at spawn %v, got %#v", seed, spawn, at)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"cmp"
+	"maps"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// OakTree is a Feature that places a small oak tree with a rounded crown of leaves, 4 to 6 blocks tall.
+type OakTree struct{}
+
+// Place ...
+func (OakTree) Place(d *Decoration, pos cube.Pos, r *rand.Rand) bool {
+	return placeTree(d, pos, r, block.OakWood())
+}
+
+// BirchTree is a Feature that places a birch tree with a rounded crown of leaves, 5 to 7 blocks tall.
+type BirchTree struct{}
+
+// Place ...
+func (BirchTree) Place(d *Decoration, pos cube.Pos, r *rand.Rand) bool {
+	return placeTree(d, pos, r, block.BirchWood())
+}
+
+// SpruceTree is a Feature that places a spruce tree with a conical crown of leaves, 6 to 9 blocks tall.
+type SpruceTree struct{}
+
+// Place ...
+func (SpruceTree) Place(d *Decoration, pos cube.Pos, r *rand.Rand) bool {
+	return placeTree(d, pos, r, block.SpruceWood())
+}
+
+// placeTree places a tree of the wood type passed at pos. The tree is generated by block.NewTree, so that it has the
+// same shape as trees grown from saplings. Leaves are only placed in air and blocks that they may replace.
+func placeTree(d *Decoration, pos cube.Pos, r *rand.Rand, wood block.WoodType) bool {
+	t, ok := block.NewTree(wood, false, r)
+	if !ok || !treeFits(d, pos, t) {
+		return false
+	}
+	// The blocks are placed in a fixed order, so that the palettes of the chunks that the tree is placed in are
+	// always the same.
+	blocks := t.Blocks()
+	positions := slices.SortedFunc(maps.Keys(blocks), func(a, b cube.Pos) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]), cmp.Compare(a[2], b[2]))
+	})
+	for _, rel := range positions {
+		b := blocks[rel]
+		if _, leaves := b.(block.Leaves); leaves {
+			d.PlaceBlock(pos.Add(rel), b)
+			continue
+		}
+		d.SetBlock(pos.Add(rel), b)
+	}
+	return true
+}
+
+// treeFits checks if the tree passed may be placed at pos. The block below pos must be soil, the tree must be within
+// the range of the world and the space that its logs take up must be free. Blocks outside the chunk are assumed to be
+// free.
+func treeFits(d *Decoration, pos cube.Pos, t *block.Tree) bool {
+	switch b, _ := d.Block(pos.Side(cube.FaceDown)); b.(type) {
+	case block.Grass, block.Dirt, block.Podzol:
+	default:
+		return false
+	}
+	for rel, b := range t.Blocks() {
+		p := pos.Add(rel)
+		if p.OutOfBounds(d.Range()) {
+			return false
+		}
+		if _, log := b.(block.Log); !log {
+			continue
+		}
+		existing, ok := d.Block(p)
+		if !ok {
+			continue
+		}
+		if _, air := existing.(block.Air); air {
+			continue
+		}
+		if r, ok := existing.(block.Replaceable); !ok || !r.ReplaceableBy(b) {
+			return false
+		}
+	}
+	return true
+}
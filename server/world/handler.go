@@ -49,6 +49,11 @@ type Handler interface {
 	// Leaves decaying happens when there is no wood block neighbouring it.
 	// ctx.Cancel() may be called to prevent leaves from decaying.
 	HandleLeavesDecay(ctx *Context, pos cube.Pos)
+	// HandleTreeGrow handles a sapling at a position growing into a tree. The
+	// tree is the Structure that will be built in its place. For trees grown
+	// from four saplings, pos is the position of the north-west sapling.
+	// ctx.Cancel() may be called to prevent the tree from growing.
+	HandleTreeGrow(ctx *Context, pos cube.Pos, tree Structure)
 	// HandlePortalCreate handles an active portal being built. portalType is
 	// Nether or End, and positions contains every block changed to build it.
 	// ctx.Cancel() may be called to prevent the portal from being built.
@@ -71,6 +76,15 @@ type Handler interface {
 	// HandleRedstoneUpdate handles a redstone update proposed by the World redstone engine. ctx.Cancel() may be
 	// called to suppress the proposed redstone mutation and any propagation from that mutation.
 	HandleRedstoneUpdate(ctx *Context, update RedstoneUpdate)
+	// HandleRedstoneBudgetExceeded handles a redstone network exceeding the RedstoneBudget set in the Config of the
+	// World. The network may be located using the position and chunks in the event. ctx.Cancel() may be called to
+	// prevent the network from being throttled or frozen.
+	HandleRedstoneBudgetExceeded(ctx *Context, exceeded RedstoneBudgetExceeded)
+	// HandleMobSpawn handles a mob spawning naturally at a position. The
+	// position and the MobSpawn may be changed to spawn the mob elsewhere or to
+	// spawn a different mob. ctx.Cancel() may be called to prevent the mob from
+	// spawning.
+	HandleMobSpawn(ctx *Context, pos *mgl64.Vec3, spawn *MobSpawn)
 	// HandleClose handles the World being closed. HandleClose may be used as a
 	// moment to finish code running on other goroutines that operates on the
 	// World specifically. HandleClose is called directly before the World stops
@@ -94,11 +108,14 @@ func (NopHandler) HandleFireSpread(*Context, cube.Pos, cube.Pos)
 func (NopHandler) HandleBlockBurn(*Context, cube.Pos)                           {}
 func (NopHandler) HandleCropTrample(*Context, cube.Pos)                         {}
 func (NopHandler) HandleLeavesDecay(*Context, cube.Pos)                         {}
+func (NopHandler) HandleTreeGrow(*Context, cube.Pos, Structure)                 {}
 func (NopHandler) HandlePortalCreate(*Context, Dimension, []cube.Pos)           {}
 func (NopHandler) HandlePortalActivate(*Context, Dimension, []cube.Pos)         {}
 func (NopHandler) HandleEntitySpawn(*Tx, Entity)                                {}
 func (NopHandler) HandleEntityDespawn(*Tx, Entity)                              {}
 func (NopHandler) HandleExplosion(*Context, ExplosionSource, *[]Entity, *[]cube.Pos, *float64, *bool) {
 }
-func (NopHandler) HandleRedstoneUpdate(*Context, RedstoneUpdate) {}
-func (NopHandler) HandleClose(*Tx)                               {}
+func (NopHandler) HandleRedstoneUpdate(*Context, RedstoneUpdate)                 {}
+func (NopHandler) HandleRedstoneBudgetExceeded(*Context, RedstoneBudgetExceeded) {}
+func (NopHandler) HandleMobSpawn(*Context, *mgl64.Vec3, *MobSpawn)               {}
+func (NopHandler) HandleClose(*Tx)                                               {}
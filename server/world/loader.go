@@ -18,6 +18,7 @@ type Loader struct {
 
 	mu        sync.RWMutex
 	pos       ChunkPos
+	vec       mgl64.Vec3
 	loadQueue []ChunkPos
 	loaded    map[ChunkPos]*Column
 	pending   map[ChunkPos]struct{}
@@ -78,6 +79,7 @@ func (l *Loader) Move(tx *Tx, pos mgl64.Vec3) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	l.vec = pos
 	chunkPos := chunkPosFromVec3(pos)
 	if chunkPos == l.pos {
 		return
@@ -0,0 +1,184 @@
+package mcstructure
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/worldupgrader/blockupgrader"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// formatVersion is the version of the .mcstructure format that is read and written.
+const formatVersion = 1
+
+// maxVolume is the maximum number of blocks that a structure read may hold. Larger structures are rejected before
+// any memory is allocated for them.
+const maxVolume = 1 << 24
+
+// structureData is the NBT representation of a .mcstructure file.
+type structureData struct {
+	FormatVersion int32   `nbt:"format_version"`
+	Size          []int32 `nbt:"size"`
+	Structure     struct {
+		BlockIndices [][]int32              `nbt:"block_indices"`
+		Entities     []map[string]any       `nbt:"entities"`
+		Palette      map[string]paletteData `nbt:"palette"`
+	} `nbt:"structure"`
+	WorldOrigin []int32 `nbt:"structure_world_origin"`
+}
+
+// paletteData holds the block states and block entity data of a .mcstructure file.
+type paletteData struct {
+	BlockPalette      []world.BlockState      `nbt:"block_palette"`
+	BlockPositionData map[string]positionData `nbt:"block_position_data"`
+}
+
+// positionData holds additional data of a block in a .mcstructure file.
+type positionData struct {
+	BlockEntityData map[string]any `nbt:"block_entity_data,omitempty"`
+}
+
+// ReadFile reads a Structure from the .mcstructure file at the path passed. Blocks are looked up in the
+// world.DefaultBlockRegistry.
+func ReadFile(path string) (*Structure, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read mcstructure: %w", err)
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read reads a Structure in the .mcstructure format from the io.Reader passed. Blocks are looked up in the
+// world.DefaultBlockRegistry. Block states of older versions of the game are upgraded to the current version.
+func Read(r io.Reader) (*Structure, error) {
+	return ReadWithRegistry(r, world.DefaultBlockRegistry)
+}
+
+// ReadWithRegistry reads a Structure in the .mcstructure format from the io.Reader passed, looking up blocks in the
+// world.BlockRegistry passed. An error is returned if the data is invalid or if a block state in the palette of the
+// structure is not registered.
+func ReadWithRegistry(r io.Reader, br world.BlockRegistry) (*Structure, error) {
+	var data structureData
+	if err := nbt.NewDecoderWithEncoding(r, nbt.LittleEndian).Decode(&data); err != nil {
+		return nil, fmt.Errorf("read mcstructure: decode nbt: %w", err)
+	}
+	if data.FormatVersion != formatVersion {
+		return nil, fmt.Errorf("read mcstructure: unsupported format version %v", data.FormatVersion)
+	}
+	if len(data.Size) != 3 || data.Size[0] < 0 || data.Size[1] < 0 || data.Size[2] < 0 {
+		return nil, fmt.Errorf("read mcstructure: invalid size %v", data.Size)
+	}
+	volume, ok := structureVolume(data.Size)
+	if !ok {
+		return nil, fmt.Errorf("read mcstructure: size %v exceeds the maximum of %v blocks", data.Size, maxVolume)
+	}
+	if len(data.Structure.BlockIndices) > 2 {
+		return nil, fmt.Errorf("read mcstructure: expected at most 2 block layers, got %v", len(data.Structure.BlockIndices))
+	}
+	for layer, indices := range data.Structure.BlockIndices {
+		if len(indices) != volume {
+			return nil, fmt.Errorf("read mcstructure: layer %v holds %v blocks, expected %v", layer, len(indices), volume)
+		}
+	}
+	s := newStructure([3]int{int(data.Size[0]), int(data.Size[1]), int(data.Size[2])})
+	if len(data.WorldOrigin) == 3 {
+		s.origin = cube.Pos{int(data.WorldOrigin[0]), int(data.WorldOrigin[1]), int(data.WorldOrigin[2])}
+	}
+
+	palette := data.Structure.Palette["default"]
+	s.palette = make([]world.Block, len(palette.BlockPalette))
+	for i, state := range palette.BlockPalette {
+		upgraded := blockupgrader.Upgrade(blockupgrader.BlockState{Name: state.Name, Properties: state.Properties, Version: state.Version})
+		b, ok := br.BlockByName(upgraded.Name, upgraded.Properties)
+		if !ok {
+			return nil, fmt.Errorf("read mcstructure: unknown block state %v%v", upgraded.Name, upgraded.Properties)
+		}
+		s.palette[i] = b
+	}
+
+	for layer, indices := range data.Structure.BlockIndices {
+		for _, index := range indices {
+			if index >= int32(len(s.palette)) || index < -1 {
+				return nil, fmt.Errorf("read mcstructure: palette index %v out of range", index)
+			}
+		}
+		copy(s.blocks[layer], indices)
+	}
+
+	for k, pos := range palette.BlockPositionData {
+		i, err := strconv.Atoi(k)
+		if err != nil || i < 0 || i >= len(s.blocks[0]) {
+			return nil, fmt.Errorf("read mcstructure: invalid block position data index %q", k)
+		}
+		if pos.BlockEntityData != nil {
+			s.nbt[i] = pos.BlockEntityData
+		}
+	}
+	s.entities = data.Structure.Entities
+	return s, nil
+}
+
+// structureVolume returns the number of blocks in a structure of the size passed. False is returned if the volume
+// exceeds maxVolume.
+func structureVolume(size []int32) (int, bool) {
+	volume := int64(1)
+	for _, v := range size {
+		// volume is at most maxVolume and v at most math.MaxInt32 here, so the product cannot overflow.
+		if volume *= int64(v); volume > maxVolume {
+			return 0, false
+		}
+	}
+	return int(volume), true
+}
+
+// WriteFile writes the Structure in the .mcstructure format to the file at the path passed, creating the file if
+// it does not yet exist.
+func (s *Structure) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("write mcstructure: %w", err)
+	}
+	if err := s.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Write writes the Structure in the .mcstructure format to the io.Writer passed. The file written may be loaded
+// using a structure block in the game.
+func (s *Structure) Write(w io.Writer) error {
+	var data structureData
+	data.FormatVersion = formatVersion
+	data.Size = []int32{int32(s.dim[0]), int32(s.dim[1]), int32(s.dim[2])}
+	data.WorldOrigin = []int32{int32(s.origin[0]), int32(s.origin[1]), int32(s.origin[2])}
+	data.Structure.BlockIndices = [][]int32{s.blocks[0], s.blocks[1]}
+	data.Structure.Entities = s.entities
+	if data.Structure.Entities == nil {
+		data.Structure.Entities = []map[string]any{}
+	}
+
+	palette := paletteData{
+		BlockPalette:      make([]world.BlockState, len(s.palette)),
+		BlockPositionData: make(map[string]positionData, len(s.nbt)),
+	}
+	for i, b := range s.palette {
+		name, properties := b.EncodeBlock()
+		palette.BlockPalette[i] = world.BlockState{Name: name, Properties: properties, Version: chunk.CurrentBlockVersion}
+	}
+	for i, blockEntity := range s.nbt {
+		palette.BlockPositionData[strconv.Itoa(i)] = positionData{BlockEntityData: blockEntity}
+	}
+	data.Structure.Palette = map[string]paletteData{"default": palette}
+
+	if err := nbt.NewEncoderWithEncoding(w, nbt.LittleEndian).Encode(data); err != nil {
+		return fmt.Errorf("write mcstructure: encode nbt: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package mcstructure
+
+import (
+	"maps"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Structure is a world.Structure stored in the .mcstructure format used by structure blocks in Bedrock Edition. A
+// Structure holds two layers of blocks: the first layer holds the blocks themselves, while the second layer holds
+// liquids that the blocks are waterlogged with. Blocks with a block entity, such as chests, keep their NBT data and
+// the Structure additionally holds the entities that were present in it.
+//
+// A Structure may be placed in a world using world.Tx.BuildStructure, after which its entities may be added using
+// Structure.AddEntities. Blocks at positions in the Structure that hold a structure void are left untouched when
+// building it.
+type Structure struct {
+	dim    [3]int
+	origin cube.Pos
+
+	palette []world.Block
+	// blocks holds the indices of the blocks in the palette for both layers of the Structure, or -1 if a position
+	// in a layer holds no block. The indices are ordered by x, then y and then z.
+	blocks [2][]int32
+	// nbt holds the block entity data of blocks by their index in blocks.
+	nbt map[int]map[string]any
+	// entities holds the NBT data of the entities in the Structure. Their positions are relative to the world
+	// origin of the Structure.
+	entities []map[string]any
+}
+
+// Capture captures the blocks, liquids, block entities and entities within the area spanned by the positions a and
+// b, inclusive, into a new Structure. Players are not included. The Structure returned may be written to a
+// .mcstructure file using Structure.Write.
+func Capture(tx *world.Tx, a, b cube.Pos) *Structure {
+	origin := cube.Pos{min(a[0], b[0]), min(a[1], b[1]), min(a[2], b[2])}
+	end := cube.Pos{max(a[0], b[0]), max(a[1], b[1]), max(a[2], b[2])}
+	s := newStructure([3]int{end[0] - origin[0] + 1, end[1] - origin[1] + 1, end[2] - origin[2] + 1})
+	s.origin = origin
+
+	br := tx.World().BlockRegistry()
+	indices := make(map[uint32]int32)
+	paletteIndex := func(b world.Block) int32 {
+		rid := br.BlockRuntimeID(b)
+		if i, ok := indices[rid]; ok {
+			return i
+		}
+		i := int32(len(s.palette))
+		indices[rid] = i
+		s.palette = append(s.palette, b)
+		return i
+	}
+
+	for x := range s.dim[0] {
+		for y := range s.dim[1] {
+			for z := range s.dim[2] {
+				pos := origin.Add(cube.Pos{x, y, z})
+				if pos.OutOfBounds(tx.Range()) {
+					continue
+				}
+				i := s.index(x, y, z)
+				b := tx.Block(pos)
+				s.blocks[0][i] = paletteIndex(b)
+				if _, ok := b.(world.Liquid); !ok {
+					if liq, ok := tx.Liquid(pos); ok {
+						s.blocks[1][i] = paletteIndex(liq)
+					}
+				}
+				if nb, ok := b.(world.NBTer); ok && br.NBTBlock(br.BlockRuntimeID(b)) {
+					data := nb.EncodeNBT()
+					data["x"], data["y"], data["z"] = int32(pos[0]), int32(pos[1]), int32(pos[2])
+					s.nbt[i] = data
+				}
+			}
+		}
+	}
+
+	box := cube.Box(float64(origin[0]), float64(origin[1]), float64(origin[2]), float64(end[0]+1), float64(end[1]+1), float64(end[2]+1))
+	for e := range tx.EntitiesWithin(box) {
+		if e.H().Type().EncodeEntity() == "minecraft:player" {
+			continue
+		}
+		data := e.H().EncodeNBT()
+		data["Rotation"] = []float32{float32(e.Rotation().Yaw()), float32(e.Rotation().Pitch())}
+		s.entities = append(s.entities, data)
+	}
+	return s
+}
+
+// newStructure creates an empty Structure with the dimensions passed. All positions of the Structure are filled
+// with structure voids.
+func newStructure(dim [3]int) *Structure {
+	n := dim[0] * dim[1] * dim[2]
+	s := &Structure{dim: dim, blocks: [2][]int32{make([]int32, n), make([]int32, n)}, nbt: make(map[int]map[string]any)}
+	for i := range n {
+		s.blocks[0][i], s.blocks[1][i] = -1, -1
+	}
+	return s
+}
+
+// Dimensions returns the width, height and length of the Structure.
+func (s *Structure) Dimensions() [3]int {
+	return s.dim
+}
+
+// Origin returns the position in the world that the Structure was originally captured at.
+func (s *Structure) Origin() cube.Pos {
+	return s.origin
+}
+
+// At returns the block and liquid at a position in the Structure. If the position holds a block entity, the block
+// returned has its NBT data decoded into it. At returns a nil block for structure voids.
+func (s *Structure) At(x, y, z int, _ func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	i := s.index(x, y, z)
+
+	var b world.Block
+	if p := s.blocks[0][i]; p >= 0 {
+		b = s.palette[p]
+		if data, ok := s.nbt[i]; ok {
+			if nb, ok := b.(world.NBTer); ok {
+				// Decode from a copy: block entities may hold on to the map, and the Structure may be built
+				// more than once.
+				b = nb.DecodeNBT(maps.Clone(data)).(world.Block)
+			}
+		}
+	}
+	var liq world.Liquid
+	if p := s.blocks[1][i]; p >= 0 {
+		liq, _ = s.palette[p].(world.Liquid)
+	}
+	return b, liq
+}
+
+// AddEntities adds the entities of the Structure to the world of the transaction passed, as if the Structure was
+// built at pos using world.Tx.BuildStructure. Entities of a type not registered in the world's
+// world.EntityRegistry are skipped. The entities added are returned.
+func (s *Structure) AddEntities(tx *world.Tx, pos cube.Pos) []world.Entity {
+	reg := tx.World().EntityRegistry()
+	offset := pos.Sub(s.origin).Vec3()
+
+	entities := make([]world.Entity, 0, len(s.entities))
+	for _, e := range s.entities {
+		data := maps.Clone(e)
+		data["Pos"] = nbtconv.Vec3ToFloat32Slice(nbtconv.Vec3(data, "Pos").Add(offset))
+		if _, ok := data["Yaw"]; !ok {
+			// Structures saved by the game store the rotation of entities in a list rather than separately.
+			if yaw, pitch, ok := rotation(data); ok {
+				data["Yaw"], data["Pitch"] = yaw, pitch
+			}
+		}
+		if handle, ok := reg.DecodeNBT(data); ok {
+			entities = append(entities, tx.AddEntity(handle))
+		}
+	}
+	return entities
+}
+
+// rotation reads the yaw and pitch from the "Rotation" list in the NBT data of an entity. False is returned if the
+// data holds no valid rotation.
+func rotation(data map[string]any) (yaw, pitch float32, ok bool) {
+	switch v := data["Rotation"].(type) {
+	case []any:
+		if len(v) == 2 {
+			yaw, ok = v[0].(float32)
+			pitch, _ = v[1].(float32)
+			return yaw, pitch, ok
+		}
+	case []float32:
+		if len(v) == 2 {
+			return v[0], v[1], true
+		}
+	}
+	return 0, 0, false
+}
+
+// index returns the index of a position in the blocks of the Structure.
+func (s *Structure) index(x, y, z int) int {
+	return (x*s.dim[1]+y)*s.dim[2] + z
+}
@@ -0,0 +1,98 @@
+package mcstructure_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/mcstructure"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+func TestCaptureRoundTrip(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	defer w.Close()
+
+	slab := block.Slab{Block: block.Planks{Wood: block.OakWood()}}
+	water := block.Water{Depth: 8, Still: true}
+	var s *mcstructure.Structure
+	w.Do(func(tx *world.Tx) {
+		tx.SetBlock(cube.Pos{0, 0, 0}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{1, 0, 0}, slab, nil)
+		tx.SetLiquid(cube.Pos{1, 0, 0}, water)
+		tx.SetBlock(cube.Pos{0, 1, 1}, block.NewChest(), nil)
+		chest := tx.Block(cube.Pos{0, 1, 1}).(block.Chest)
+		_ = chest.Inventory(tx, cube.Pos{0, 1, 1}).SetItem(3, item.NewStack(item.Diamond{}, 5))
+		tx.AddEntity(entity.NewItem(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 1, 0.5}}, item.NewStack(item.Stick{}, 1)))
+
+		s = mcstructure.Capture(tx, cube.Pos{1, 1, 1}, cube.Pos{0, 0, 0})
+	})
+	if dim := s.Dimensions(); dim != [3]int{2, 2, 2} {
+		t.Fatalf("expected dimensions [2 2 2], got %v", dim)
+	}
+
+	var buf bytes.Buffer
+	if err := s.Write(&buf); err != nil {
+		t.Fatalf("write structure: %v", err)
+	}
+	s, err := mcstructure.Read(&buf)
+	if err != nil {
+		t.Fatalf("read structure: %v", err)
+	}
+	if origin := s.Origin(); origin != (cube.Pos{}) {
+		t.Errorf("expected origin %v, got %v", cube.Pos{}, origin)
+	}
+
+	paste := cube.Pos{10, 5, 10}
+	w.Do(func(tx *world.Tx) {
+		tx.BuildStructure(paste, s)
+		added := s.AddEntities(tx, paste)
+
+		if b := tx.Block(paste); b != (block.Stone{}) {
+			t.Errorf("expected stone at %v, got %#v", paste, b)
+		}
+		if b := tx.Block(paste.Add(cube.Pos{1, 0, 0})); b != slab {
+			t.Errorf("expected slab at %v, got %#v", paste.Add(cube.Pos{1, 0, 0}), b)
+		}
+		if liq, ok := tx.Liquid(paste.Add(cube.Pos{1, 0, 0})); !ok || liq != water {
+			t.Errorf("expected the slab to be waterlogged, got %#v", liq)
+		}
+		chest, ok := tx.Block(paste.Add(cube.Pos{0, 1, 1})).(block.Chest)
+		if !ok {
+			t.Fatalf("expected chest at %v", paste.Add(cube.Pos{0, 1, 1}))
+		}
+		if it, _ := chest.Inventory(tx, paste.Add(cube.Pos{0, 1, 1})).Item(3); it.Count() != 5 {
+			t.Errorf("expected 5 diamonds in the chest, got %v", it)
+		}
+		if len(added) != 1 {
+			t.Fatalf("expected 1 entity to be added, got %v", len(added))
+		}
+		if pos := added[0].Position(); pos != (mgl64.Vec3{10.5, 6, 10.5}) {
+			t.Errorf("expected entity at %v, got %v", mgl64.Vec3{10.5, 6, 10.5}, pos)
+		}
+	})
+}
+
+func TestReadRejectsOversizedStructure(t *testing.T) {
+	var buf bytes.Buffer
+	data := map[string]any{
+		"format_version": int32(1),
+		"size":           []int32{65536, 65536, 65536},
+		"structure": map[string]any{
+			"block_indices": [][]int32{{0}},
+			"entities":      []map[string]any{},
+			"palette":       map[string]any{},
+		},
+	}
+	if err := nbt.NewEncoderWithEncoding(&buf, nbt.LittleEndian).Encode(data); err != nil {
+		t.Fatalf("encode structure: %v", err)
+	}
+	if _, err := mcstructure.Read(&buf); err == nil {
+		t.Fatalf("expected structure with size [65536 65536 65536] to be rejected")
+	}
+}
@@ -0,0 +1,327 @@
+package world
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// MobCategory is a category of mobs that spawn naturally. Mobs of every
+// category spawn under their own conditions, and the number of mobs of a
+// category in a World is limited by the cap of the category set in
+// MobSpawning.
+type MobCategory uint8
+
+const (
+	// MobCategoryMonster is the category of hostile mobs such as zombies.
+	// Monsters spawn in the dark every tick, unless the Difficulty of the
+	// World prevents monsters from spawning.
+	MobCategoryMonster MobCategory = iota
+	// MobCategoryCreature is the category of passive animals such as cows.
+	// Creatures spawn on grass in light, and only once every 20 seconds.
+	MobCategoryCreature
+)
+
+// mobCategories holds all MobCategories in the order that they are spawned
+// in.
+var mobCategories = [...]MobCategory{MobCategoryMonster, MobCategoryCreature}
+
+// defaultCap returns the cap of the MobCategory used if none is set in
+// MobSpawning.Caps.
+func (c MobCategory) defaultCap() int {
+	if c == MobCategoryMonster {
+		return 70
+	}
+	return 10
+}
+
+// interval returns the number of ticks between two attempts to spawn mobs of
+// the MobCategory.
+func (c MobCategory) interval() int64 {
+	if c == MobCategoryMonster {
+		return 1
+	}
+	return 400
+}
+
+// MobEntityType is an EntityType of mobs that belong to a MobCategory.
+// Entities of a MobEntityType count towards the cap of their category.
+type MobEntityType interface {
+	EntityType
+	// MobCategory returns the MobCategory that mobs of the type belong to.
+	MobCategory() MobCategory
+}
+
+// MobSpawn describes a mob that may spawn naturally in a Biome.
+type MobSpawn struct {
+	// Type is the type of the mob. The MobCategory of Type decides the
+	// conditions that the mob spawns under.
+	Type MobEntityType
+	// Weight is the chance that the mob is chosen to spawn relative to the
+	// other mobs of the same MobCategory that may spawn in the Biome.
+	Weight int
+	// MinGroup and MaxGroup are the minimum and maximum number of mobs that
+	// spawn together in a group. Both default to 1.
+	MinGroup, MaxGroup int
+	// New creates a new mob of Type using the EntitySpawnOpts passed.
+	New func(opts EntitySpawnOpts) *EntityHandle
+}
+
+// MobSpawning holds the parameters for the natural spawning of mobs in a
+// World. Mobs spawn in loaded chunks within the simulation distance of
+// Loaders, but never within 24 blocks of a Loader. Every spawned mob is
+// passed to Handler.HandleMobSpawn first. A zero MobSpawning spawns no mobs.
+//
+// Mobs spawned naturally are marked as despawnable through
+// EntityData.Despawnable. They despawn immediately once they are more than
+// 128 blocks away from every Loader, and randomly once they are more than 32
+// blocks away and have lived for 30 seconds. Monsters also despawn while the
+// Difficulty of the World prevents them from spawning.
+type MobSpawning struct {
+	// Spawns returns the mobs that may spawn naturally in a Biome. If nil, no
+	// mobs spawn naturally.
+	Spawns func(b Biome) []MobSpawn
+	// Caps holds the maximum number of mobs of a MobCategory for every 289
+	// chunks, an area of 17x17 chunks, within the simulation distance of
+	// Loaders. No more mobs of a category spawn while the World has more mobs
+	// of the category than its cap allows. Caps of categories not in Caps
+	// default to 70 for monsters and 10 for creatures. A negative cap
+	// prevents mobs of the category from spawning at all.
+	Caps map[MobCategory]int
+}
+
+// limit returns the cap of the MobCategory passed for the number of chunks
+// passed.
+func (s MobSpawning) limit(c MobCategory, chunks int) int {
+	n, ok := s.Caps[c]
+	if !ok {
+		n = c.defaultCap()
+	}
+	return n * chunks / 289
+}
+
+const (
+	// mobSpawnMinDistance is the minimum distance between a Loader and a mob
+	// spawned naturally.
+	mobSpawnMinDistance = 24
+	// mobSpawnGroupRadius is the maximum distance on the X and Z axes between
+	// the first position of a group and the positions of the other mobs in
+	// the group.
+	mobSpawnGroupRadius = 5
+	// mobDespawnDistance is the distance to the closest Loader beyond which a
+	// despawnable mob despawns immediately.
+	mobDespawnDistance = 128
+	// mobIdleDistance is the distance to the closest Loader beyond which a
+	// despawnable mob older than mobIdleAge despawns with a chance of one in
+	// mobIdleChance every tick.
+	mobIdleDistance = 32
+	// mobIdleAge is the age that a despawnable mob must reach before it may
+	// despawn randomly.
+	mobIdleAge = time.Second * 30
+	// mobIdleChance is the inverse of the chance that a despawnable mob far
+	// enough away from Loaders despawns during a tick.
+	mobIdleChance = 800
+)
+
+// loaderPositions returns the positions of the loaders passed.
+func loaderPositions(loaders []*Loader) []mgl64.Vec3 {
+	positions := make([]mgl64.Vec3, 0, len(loaders))
+	for _, loader := range loaders {
+		loader.mu.RLock()
+		positions = append(positions, loader.vec)
+		loader.mu.RUnlock()
+	}
+	return positions
+}
+
+// despawnMobs closes despawnable mobs that are too far away from the Loaders
+// at the positions passed, or monsters that may not exist at the Difficulty
+// of the World.
+func (t ticker) despawnMobs(tx *Tx, loaders []mgl64.Vec3) {
+	w := tx.World()
+	if len(loaders) == 0 {
+		return
+	}
+	monsters := w.Difficulty().MonstersSpawn()
+
+	var despawn []*EntityHandle
+	for handle := range w.entities {
+		if !handle.data.Despawnable {
+			continue
+		}
+		if mt, ok := handle.Type().(MobEntityType); ok && mt.MobCategory() == MobCategoryMonster && !monsters {
+			despawn = append(despawn, handle)
+			continue
+		}
+		dist := math.MaxFloat64
+		for _, l := range loaders {
+			dist = min(dist, l.Sub(handle.data.Pos).Len())
+		}
+		if dist > mobDespawnDistance || (dist > mobIdleDistance && handle.data.Age >= mobIdleAge && w.r.IntN(mobIdleChance) == 0) {
+			despawn = append(despawn, handle)
+		}
+	}
+	for _, handle := range despawn {
+		_ = handle.mustEntity(tx).Close()
+	}
+}
+
+// spawnMobs spawns mobs naturally in loaded chunks within the simulation
+// distance of the loaders passed, which are at the positions passed. Mobs of
+// every MobCategory spawn in random chunks until the cap of the category is
+// reached.
+func (t ticker) spawnMobs(tx *Tx, loaders []*Loader, positions []mgl64.Vec3, tick int64) {
+	w := tx.World()
+	conf, r := w.conf.MobSpawning, int32(w.tickRange())
+	if conf.Spawns == nil || r == 0 || len(loaders) == 0 {
+		return
+	}
+	loaded := make([]ChunkPos, 0, len(loaders))
+	for _, loader := range loaders {
+		loader.mu.RLock()
+		loaded = append(loaded, loader.pos)
+		loader.mu.RUnlock()
+	}
+	chunks := make([]ChunkPos, 0, len(w.chunks))
+	for pos := range w.chunks {
+		if t.anyWithinDistance(pos, loaded, r) {
+			chunks = append(chunks, pos)
+		}
+	}
+
+	var counts [len(mobCategories)]int
+	for handle := range w.entities {
+		if mt, ok := handle.Type().(MobEntityType); ok && int(mt.MobCategory()) < len(counts) {
+			counts[mt.MobCategory()]++
+		}
+	}
+	monsters := w.Difficulty().MonstersSpawn()
+	for _, c := range mobCategories {
+		if tick%c.interval() != 0 || (c == MobCategoryMonster && !monsters) {
+			continue
+		}
+		limit := conf.limit(c, len(chunks))
+		for _, pos := range chunks {
+			if counts[c] >= limit {
+				break
+			}
+			counts[c] += t.spawnGroup(tx, c, pos, positions)
+		}
+	}
+}
+
+// spawnGroup attempts to spawn a group of mobs of a MobCategory around a
+// random position in the chunk passed. The number of mobs spawned is
+// returned.
+func (t ticker) spawnGroup(tx *Tx, c MobCategory, chunk ChunkPos, loaders []mgl64.Vec3) int {
+	w := tx.World()
+	x, z := int(chunk[0]<<4)+w.r.IntN(16), int(chunk[1]<<4)+w.r.IntN(16)
+	top := min(tx.highestBlock(x, z)+1, w.ra[1])
+	start := cube.Pos{x, w.ra[0] + w.r.IntN(top-w.ra[0]+1), z}
+	if tx.Block(start).Model().FaceSolid(start, cube.FaceUp, tx) {
+		return 0
+	}
+
+	var (
+		spawn  MobSpawn
+		chosen bool
+		group  int
+		n      int
+	)
+	for range 4 {
+		pos := start.Add(cube.Pos{w.r.IntN(mobSpawnGroupRadius*2+1) - mobSpawnGroupRadius, 0, w.r.IntN(mobSpawnGroupRadius*2+1) - mobSpawnGroupRadius})
+		vec := mgl64.Vec3{float64(pos[0]) + 0.5, float64(pos[1]), float64(pos[2]) + 0.5}
+		if !pos.OutOfBounds(w.ra) && !t.nearLoader(vec, loaders) && t.canSpawnAt(tx, c, pos) {
+			if !chosen {
+				if spawn, chosen = t.chooseSpawn(tx, c, pos); !chosen {
+					return n
+				}
+				group = spawn.MinGroup
+				if spawn.MaxGroup > spawn.MinGroup {
+					group += w.r.IntN(spawn.MaxGroup - spawn.MinGroup + 1)
+				}
+			}
+			s := spawn
+			ctx := tx.Event()
+			if w.Handler().HandleMobSpawn(ctx, &vec, &s); ctx.Cancelled() || s.New == nil {
+				continue
+			}
+			handle := s.New(EntitySpawnOpts{Position: vec, Rotation: cube.Rotation{w.r.Float64() * 360}})
+			handle.data.Despawnable = true
+			tx.AddEntity(handle)
+			if n++; n >= group {
+				break
+			}
+		}
+	}
+	return n
+}
+
+// chooseSpawn chooses a random MobSpawn of a MobCategory that may spawn in
+// the Biome at the position passed, taking into account the weight of every
+// MobSpawn.
+func (t ticker) chooseSpawn(tx *Tx, c MobCategory, pos cube.Pos) (MobSpawn, bool) {
+	var (
+		spawns []MobSpawn
+		total  int
+	)
+	for _, s := range tx.World().conf.MobSpawning.Spawns(tx.Biome(pos)) {
+		if s.Type != nil && s.Type.MobCategory() == c && s.Weight > 0 {
+			spawns, total = append(spawns, s), total+s.Weight
+		}
+	}
+	if total == 0 {
+		return MobSpawn{}, false
+	}
+	n := tx.World().r.IntN(total)
+	for _, s := range spawns {
+		if n -= s.Weight; n < 0 {
+			s.MinGroup = max(s.MinGroup, 1)
+			return s, true
+		}
+	}
+	return MobSpawn{}, false
+}
+
+// canSpawnAt checks if a mob of a MobCategory may spawn with its feet at the
+// position passed. Mobs need a solid block to stand on and two blocks of air
+// to spawn in. Monsters only spawn in the dark, while creatures only spawn on
+// grass in light.
+func (t ticker) canSpawnAt(tx *Tx, c MobCategory, pos cube.Pos) bool {
+	below := pos.Side(cube.FaceDown)
+	if !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+		return false
+	}
+	for _, p := range [...]cube.Pos{pos, pos.Side(cube.FaceUp)} {
+		if _, ok := tx.Liquid(p); ok || len(tx.Block(p).Model().BBox(p, tx)) != 0 {
+			return false
+		}
+	}
+	switch c {
+	case MobCategoryMonster:
+		if tx.blockLight(pos) > 0 {
+			return false
+		}
+		sky := tx.skyLight(pos)
+		if now := tx.World().Time() % TimeFull; now >= TimeSleep && now < TimeWake {
+			// The sky provides barely any light at night.
+			sky -= min(sky, 11)
+		}
+		return int(sky) <= tx.World().r.IntN(8)
+	case MobCategoryCreature:
+		name, _ := tx.Block(below).EncodeBlock()
+		return name == "minecraft:grass_block" && tx.light(pos) > 8
+	}
+	return false
+}
+
+// nearLoader checks if the position passed is within mobSpawnMinDistance of
+// any of the Loader positions passed.
+func (t ticker) nearLoader(pos mgl64.Vec3, loaders []mgl64.Vec3) bool {
+	return slices.ContainsFunc(loaders, func(l mgl64.Vec3) bool {
+		return l.Sub(pos).Len() < mobSpawnMinDistance
+	})
+}
@@ -92,6 +92,9 @@ type Lava struct{ particle }
 // DustPlume is a particle that shows up when an item is successfully inserted into a decorated pot.
 type DustPlume struct{ particle }
 
+// MobSpawn is a particle of smoke and flames that shows up around a mob spawner when it spawns an entity.
+type MobSpawn struct{ particle }
+
 // particle serves as a base for all particles in this package.
 type particle struct{}
 
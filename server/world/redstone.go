@@ -3,6 +3,7 @@ package world
 import (
 	"maps"
 	"slices"
+	"time"
 
 	"github.com/df-mc/dragonfly/server/block/cube"
 )
@@ -120,6 +121,23 @@ type redstoneEngine struct {
 	evaluating        map[cube.Pos]struct{}
 	suppressedSources map[cube.Pos]int
 	torchBurnout      map[cube.Pos]redstoneTorchBurnout
+	// revision is incremented every time positions are invalidated or evaluated, so that observers such as debug
+	// overlays know when to take a new snapshot of the graph.
+	revision uint64
+
+	// profile collects metrics while the engine is evaluating a graph. It is nil outside of evaluation.
+	profile *redstoneProfile
+	// stats holds the metrics of the last tick in which the engine evaluated positions.
+	stats RedstoneStats
+	// lastGraph and lastProfile hold the graph and profile of the last tick in which the engine evaluated
+	// positions, if the metrics of its chunks and networks have not yet been computed.
+	lastGraph   redstoneGraph
+	lastProfile *redstoneProfile
+	// chunkUpdates holds the number of updates per tick within each chunk, used to enforce
+	// RedstoneBudget.MaxChunkUpdates.
+	chunkUpdates map[ChunkPos][]redstoneTickUpdates
+	// limited holds the positions of networks that exceeded the RedstoneBudget and are throttled or frozen.
+	limited map[cube.Pos]*redstoneLimit
 }
 
 // redstoneDirty records why a position needs redstone evaluation.
@@ -168,11 +186,13 @@ type redstoneEdge struct {
 // newRedstoneEngine creates a redstone engine initialized at tick.
 func newRedstoneEngine(tick int64) *redstoneEngine {
 	return &redstoneEngine{
-		currentTick: tick,
-		dirty:       make(map[cube.Pos]redstoneDirty),
-		power:       make(map[cube.Pos]int),
-		output:      make(map[cube.Pos]int),
-		evaluating:  make(map[cube.Pos]struct{}),
+		currentTick:  tick,
+		dirty:        make(map[cube.Pos]redstoneDirty),
+		power:        make(map[cube.Pos]int),
+		output:       make(map[cube.Pos]int),
+		evaluating:   make(map[cube.Pos]struct{}),
+		chunkUpdates: make(map[ChunkPos][]redstoneTickUpdates),
+		limited:      make(map[cube.Pos]*redstoneLimit),
 	}
 }
 
@@ -210,6 +230,7 @@ func (e *redstoneEngine) invalidate(pos cube.Pos, d redstoneDirty, r cube.Range)
 	if pos.OutOfBounds(r) {
 		return
 	}
+	e.revision++
 	if existing, ok := e.dirty[pos]; ok {
 		e.dirty[pos] = mergeRedstoneDirty(existing, d)
 		return
@@ -258,6 +279,10 @@ func (e *redstoneEngine) removeChunk(chunkPos ChunkPos) {
 	maps.DeleteFunc(e.torchBurnout, func(pos cube.Pos, _ redstoneTorchBurnout) bool {
 		return chunkPosFromBlockPos(pos) == chunkPos
 	})
+	maps.DeleteFunc(e.limited, func(pos cube.Pos, _ *redstoneLimit) bool {
+		return chunkPosFromBlockPos(pos) == chunkPos
+	})
+	delete(e.chunkUpdates, chunkPos)
 }
 
 // forget clears cached redstone input and output power for pos.
@@ -270,19 +295,48 @@ func (e *redstoneEngine) forget(pos cube.Pos) {
 	delete(e.evaluating, pos)
 }
 
+// lastPower returns the input power last stored for pos.
+func (e *redstoneEngine) lastPower(pos cube.Pos) int {
+	if e == nil {
+		return 0
+	}
+	return e.power[pos]
+}
+
 // tick evaluates all dirty redstone positions for the current world tick.
 func (e *redstoneEngine) tick(tx *Tx, tick int64) {
-	if e == nil || len(e.dirty) == 0 {
+	if e == nil {
 		return
 	}
 	e.currentTick = tick
+	if len(e.limited) != 0 {
+		e.pruneLimits(tx, tick)
+	}
+	if len(e.dirty) == 0 {
+		return
+	}
+	e.revision++
 	dirty := maps.Clone(e.dirty)
 	clear(e.dirty)
+	deferred := e.deferLimited(dirty)
 
+	start := time.Now()
 	candidates := slices.Collect(maps.Keys(dirty))
 	slices.SortFunc(candidates, compareBlockPos)
 
-	graph := e.compile(tx, candidates)
+	graph := e.withoutLimited(tx, e.compile(tx, candidates))
+	prof := &redstoneProfile{compileTime: time.Since(start), deferred: deferred, updates: make(map[cube.Pos]int)}
+
+	e.profile = prof
+	e.evaluate(tx, graph, dirty)
+	e.profile = nil
+	prof.evaluateTime = time.Since(start) - prof.compileTime
+
+	e.finishProfile(tx, tick, graph, prof)
+}
+
+// evaluate computes the power of every node in the graph passed and applies it to sinks and sources.
+func (e *redstoneEngine) evaluate(tx *Tx, graph redstoneGraph, dirty map[cube.Pos]redstoneDirty) {
 	cancelledSources, checkedSources := e.updateGraphSources(tx, graph, dirty)
 	previousSuppressed := e.suppressedSources
 	e.suppressedSources = cancelledSources
@@ -1002,6 +1056,9 @@ func (e *redstoneEngine) redstonePower(source RedstonePowerSource, pos cube.Pos,
 
 // redstoneUpdateAllowed dispatches redstone callbacks and reports whether the update was cancelled.
 func (e *redstoneEngine) redstoneUpdateAllowed(tx *Tx, update RedstoneUpdate) bool {
+	if e.profile != nil {
+		e.profile.updates[update.Pos]++
+	}
 	ctx := tx.Event()
 	tx.World().Handler().HandleRedstoneUpdate(ctx, update)
 	return !ctx.Cancelled()
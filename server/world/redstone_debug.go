@@ -0,0 +1,124 @@
+package world
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// RedstoneGraph is a read-only snapshot of the redstone network compiled by the world's redstone engine. It is
+// intended for debugging contraptions and is obtained through RedstoneTransaction.Graph.
+type RedstoneGraph struct {
+	// Nodes holds every redstone-relevant block in the snapshot, ordered by Y, Z and then X.
+	Nodes []RedstoneNode
+	// Edges holds the relayer connections between nodes in the snapshot.
+	Edges []RedstoneEdge
+	// Revision is the revision of the redstone engine at the time the snapshot was taken. The revision changes every
+	// time positions are invalidated or evaluated, so a snapshot may be compared against
+	// RedstoneTransaction.Revision to check if it is outdated.
+	Revision uint64
+}
+
+// RedstoneNode is a single redstone-relevant block in a RedstoneGraph.
+type RedstoneNode struct {
+	// Pos is the position of the block.
+	Pos cube.Pos
+	// Source, Sink and Relayer report the redstone capabilities of the block. A sink is a block that reacts to power,
+	// either by changing its state or by performing an action.
+	Source, Sink, Relayer bool
+	// Power is the power the engine propagates to the block.
+	Power int
+	// Output is the cached output power of the block if it is a source.
+	Output int
+	// Dirty is true if the block is waiting to be evaluated during the next redstone phase.
+	Dirty bool
+	// Scheduled is true if a scheduled block update is pending at the position of the block.
+	Scheduled bool
+}
+
+// RedstoneEdge is a connection between two nodes in a RedstoneGraph, through which a relayer passes on power.
+type RedstoneEdge struct {
+	// From and To are the positions of the nodes connected by the edge. Power flows from From to To.
+	From, To cube.Pos
+	// SignalLoss is the amount of power lost when power crosses the edge.
+	SignalLoss int
+}
+
+// Graph compiles a snapshot of the redstone network within radius blocks of pos. Nodes outside the radius are not
+// included, but do contribute to the power of nodes that are. Graph does not evaluate or otherwise change the state
+// of the redstone engine.
+func (r RedstoneTransaction) Graph(pos cube.Pos, radius int) RedstoneGraph {
+	return r.tx.World().redstone.snapshot(r.tx, pos, max(radius, 0))
+}
+
+// Revision returns the current revision of the redstone engine. The revision changes every time positions are
+// invalidated or evaluated by the engine.
+func (r RedstoneTransaction) Revision() uint64 {
+	if e := r.tx.World().redstone; e != nil {
+		return e.revision
+	}
+	return 0
+}
+
+// snapshot compiles a RedstoneGraph of the redstone network within radius blocks of centre.
+func (e *redstoneEngine) snapshot(tx *Tx, centre cube.Pos, radius int) RedstoneGraph {
+	if e == nil {
+		return RedstoneGraph{}
+	}
+	within := func(pos cube.Pos) bool {
+		return abs(pos[0]-centre[0]) <= radius && abs(pos[1]-centre[1]) <= radius && abs(pos[2]-centre[2]) <= radius
+	}
+	scheduled := tx.World().scheduledUpdates.pending(within)
+
+	candidates := make([]cube.Pos, 0, len(scheduled))
+	for x := centre[0] - radius; x <= centre[0]+radius; x++ {
+		for y := max(centre[1]-radius, tx.Range()[0]); y <= min(centre[1]+radius, tx.Range()[1]); y++ {
+			for z := centre[2] - radius; z <= centre[2]+radius; z++ {
+				pos := cube.Pos{x, y, z}
+				if b, ok := tx.World().blockLoaded(pos); ok && isRedstoneRelevant(b) {
+					candidates = append(candidates, pos)
+				}
+			}
+		}
+	}
+	for pos := range e.dirty {
+		if within(pos) {
+			candidates = append(candidates, pos)
+		}
+	}
+	candidates = append(candidates, slices.Collect(maps.Keys(scheduled))...)
+	slices.SortFunc(candidates, compareBlockPos)
+
+	graph := e.compile(tx, candidates)
+	powers := e.graphPower(tx, graph)
+
+	g := RedstoneGraph{Revision: e.revision}
+	for i, node := range graph.nodes {
+		if !within(node.pos) {
+			continue
+		}
+		_, dirty := e.dirty[node.pos]
+		_, pending := scheduled[node.pos]
+		n := RedstoneNode{
+			Pos:       node.pos,
+			Source:    node.source,
+			Sink:      node.sink,
+			Power:     powers[i],
+			Output:    e.output[node.pos],
+			Dirty:     dirty,
+			Scheduled: pending,
+		}
+		if b, ok := tx.World().blockLoaded(node.pos); ok {
+			_, _, _, n.Relayer = classifyRedstoneBlock(b)
+		}
+		g.Nodes = append(g.Nodes, n)
+	}
+	for _, edge := range graph.edges {
+		from, to := graph.nodes[edge.from].pos, graph.nodes[edge.to].pos
+		if within(from) && within(to) {
+			g.Edges = append(g.Edges, RedstoneEdge{From: from, To: to, SignalLoss: edge.weight})
+		}
+	}
+	return g
+}
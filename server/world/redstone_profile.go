@@ -0,0 +1,452 @@
+package world
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// RedstoneBudget limits the work that the redstone engine of a World may perform, so that lag machines such as fast
+// clocks and huge wire grids cannot slow down the World. Redstone components are grouped into networks of components
+// that are connected or placed close to each other. Networks exceeding one of the limits are reported through
+// Handler.HandleRedstoneBudgetExceeded, after which the Action of the budget is applied to them.
+// A zero RedstoneBudget imposes no limits, in which case networks are only grouped when their metrics are requested
+// through RedstoneTransaction.Stats.
+type RedstoneBudget struct {
+	// MaxNetworkNodes is the maximum number of components of a single network that may be evaluated in one tick. A
+	// value of 0 means there is no limit.
+	MaxNetworkNodes int
+	// MaxNetworkUpdates is the maximum number of redstone updates that a single network may cause in one tick. A
+	// value of 0 means there is no limit.
+	MaxNetworkUpdates int
+	// MaxChunkUpdates is the maximum number of redstone updates that may happen within a single chunk during Window.
+	// Every network with components in a chunk exceeding this limit is considered to exceed the budget. A value of 0
+	// means there is no limit.
+	MaxChunkUpdates int
+	// Window is the period over which MaxChunkUpdates is counted. Window defaults to one second.
+	Window time.Duration
+	// Action is the action taken for networks that exceed the budget. By default, networks are only reported.
+	Action RedstoneBudgetAction
+	// Penalty is how long networks are throttled or frozen for after exceeding the budget. Penalty defaults to 5
+	// seconds. If negative, networks stay limited until released using RedstoneTransaction.Release.
+	Penalty time.Duration
+}
+
+// RedstoneBudgetAction is the action taken by the redstone engine for networks that exceed the RedstoneBudget of a
+// World.
+type RedstoneBudgetAction uint8
+
+const (
+	// RedstoneBudgetActionReport only reports networks that exceed the budget. The networks keep working normally.
+	RedstoneBudgetActionReport RedstoneBudgetAction = iota
+	// RedstoneBudgetActionThrottle postpones the evaluation of networks that exceed the budget until the penalty has
+	// passed. Updates of the network are kept and are processed once the penalty has passed, so that the network is
+	// slowed down rather than stopped.
+	RedstoneBudgetActionThrottle
+	// RedstoneBudgetActionFreeze stops evaluating networks that exceed the budget until the penalty has passed.
+	// Updates of the network are discarded, and the network is re-evaluated as a whole once the penalty has passed.
+	RedstoneBudgetActionFreeze
+)
+
+// RedstoneBudgetReason describes which limit of a RedstoneBudget was exceeded by a network.
+type RedstoneBudgetReason uint8
+
+const (
+	// RedstoneBudgetReasonNodes means the network exceeded RedstoneBudget.MaxNetworkNodes.
+	RedstoneBudgetReasonNodes RedstoneBudgetReason = iota
+	// RedstoneBudgetReasonUpdates means the network exceeded RedstoneBudget.MaxNetworkUpdates.
+	RedstoneBudgetReasonUpdates
+	// RedstoneBudgetReasonChunkUpdates means a chunk that the network has components in exceeded
+	// RedstoneBudget.MaxChunkUpdates.
+	RedstoneBudgetReasonChunkUpdates
+)
+
+// RedstoneBudgetExceeded is passed to Handler.HandleRedstoneBudgetExceeded when a redstone network exceeds the
+// RedstoneBudget of a World.
+type RedstoneBudgetExceeded struct {
+	// Network holds the metrics of the network that exceeded the budget. Network.Pos may be used to locate it.
+	Network RedstoneNetworkStats
+	// Reason is the limit that was exceeded.
+	Reason RedstoneBudgetReason
+	// Chunk is the chunk that exceeded RedstoneBudget.MaxChunkUpdates. It is only set if Reason is
+	// RedstoneBudgetReasonChunkUpdates.
+	Chunk ChunkPos
+	// Action is the action that will be taken for the network if the event is not cancelled.
+	Action RedstoneBudgetAction
+	// Penalty is how long the action applies to the network. It is negative if the network stays limited until
+	// it is released.
+	Penalty time.Duration
+}
+
+// RedstoneStats holds metrics of a single tick of the redstone engine of a World.
+type RedstoneStats struct {
+	// Tick is the world tick that the metrics were collected during.
+	Tick int64
+	// CompileTime is the time spent compiling the redstone graph.
+	CompileTime time.Duration
+	// EvaluateTime is the time spent computing power and updating components.
+	EvaluateTime time.Duration
+	// Nodes is the number of components evaluated.
+	Nodes int
+	// Updates is the number of redstone updates proposed, including updates cancelled by the Handler.
+	Updates int
+	// Deferred is the number of dirty positions that were not evaluated because their network was throttled or
+	// frozen.
+	Deferred int
+	// Networks holds the metrics of every network evaluated, ordered by position.
+	Networks []RedstoneNetworkStats
+	// Chunks holds the metrics of every chunk with components that were evaluated.
+	Chunks map[ChunkPos]RedstoneChunkStats
+}
+
+// RedstoneNetworkStats holds metrics of a single redstone network during one tick.
+type RedstoneNetworkStats struct {
+	// Pos is the position of the lowest component of the network, which may be used to locate it.
+	Pos cube.Pos
+	// Chunks holds the chunks that the network has components in.
+	Chunks []ChunkPos
+	// Nodes is the number of components of the network that were evaluated.
+	Nodes int
+	// Updates is the number of redstone updates the network caused.
+	Updates int
+}
+
+// RedstoneChunkStats holds metrics of the redstone components within a single chunk.
+type RedstoneChunkStats struct {
+	// Nodes is the number of components within the chunk that were evaluated during the tick.
+	Nodes int
+	// Updates is the number of redstone updates within the chunk during the tick.
+	Updates int
+	// WindowUpdates is the number of redstone updates within the chunk during the last RedstoneBudget.Window. It is
+	// only counted if RedstoneBudget.MaxChunkUpdates is set.
+	WindowUpdates int
+}
+
+// Stats returns the metrics of the last tick in which the redstone engine evaluated any positions.
+func (r RedstoneTransaction) Stats() RedstoneStats {
+	e := r.tx.World().redstone
+	if e == nil {
+		return RedstoneStats{}
+	}
+	if e.lastProfile != nil {
+		// The metrics of chunks and networks were not needed to enforce the budget, so they are computed now.
+		e.stats.Chunks = chunkStats(e.lastGraph, e.lastProfile)
+		for _, network := range redstoneNetworks(e.lastGraph) {
+			e.stats.Networks = append(e.stats.Networks, networkStats(e.lastGraph, network, e.lastProfile))
+		}
+		e.lastGraph, e.lastProfile = redstoneGraph{}, nil
+	}
+	stats := e.stats
+	stats.Networks = slices.Clone(stats.Networks)
+	stats.Chunks = maps.Clone(stats.Chunks)
+	return stats
+}
+
+// Limited reports if the network that the component at pos is part of is currently throttled or frozen for
+// exceeding the RedstoneBudget.
+func (r RedstoneTransaction) Limited(pos cube.Pos) bool {
+	e := r.tx.World().redstone
+	if e == nil {
+		return false
+	}
+	_, ok := e.limited[pos]
+	return ok
+}
+
+// Release stops throttling or freezing the network that the component at pos is part of and re-evaluates it.
+// False is returned if the network was not limited.
+func (r RedstoneTransaction) Release(pos cube.Pos) bool {
+	e := r.tx.World().redstone
+	if e == nil {
+		return false
+	}
+	l, ok := e.limited[pos]
+	if ok {
+		e.release(r.tx, l)
+	}
+	return ok
+}
+
+// redstoneProfile collects metrics while the redstone engine evaluates a graph.
+type redstoneProfile struct {
+	compileTime, evaluateTime time.Duration
+	deferred                  int
+	updates                   map[cube.Pos]int
+}
+
+// redstoneTickUpdates holds the number of redstone updates within a chunk during one tick.
+type redstoneTickUpdates struct {
+	tick    int64
+	updates int
+}
+
+// redstoneLimit is a throttle or freeze applied to a network that exceeded the RedstoneBudget.
+type redstoneLimit struct {
+	action    RedstoneBudgetAction
+	until     int64
+	positions []cube.Pos
+}
+
+// expired checks if the limit no longer applies at the tick passed.
+func (l *redstoneLimit) expired(tick int64) bool {
+	return l.until >= 0 && tick >= l.until
+}
+
+// pruneLimits releases all limits that expired at the tick passed.
+func (e *redstoneEngine) pruneLimits(tx *Tx, tick int64) {
+	var expired []*redstoneLimit
+	for _, l := range e.limited {
+		if l.expired(tick) && !slices.Contains(expired, l) {
+			expired = append(expired, l)
+		}
+	}
+	for _, l := range expired {
+		e.release(tx, l)
+	}
+}
+
+// release removes the limit passed and invalidates the positions it applied to, so that the network is evaluated
+// again.
+func (e *redstoneEngine) release(tx *Tx, l *redstoneLimit) {
+	d := redstoneDirty{cause: RedstoneUpdateCauseCompilerRebuild}
+	for _, pos := range l.positions {
+		if e.limited[pos] != l {
+			continue
+		}
+		delete(e.limited, pos)
+		e.invalidate(pos, d, tx.Range())
+	}
+}
+
+// deferLimited removes the positions of limited networks from dirty. Positions of throttled networks are kept dirty
+// until the limit expires, while positions of frozen networks are discarded. The number of removed positions is
+// returned.
+func (e *redstoneEngine) deferLimited(dirty map[cube.Pos]redstoneDirty) int {
+	if len(e.limited) == 0 {
+		return 0
+	}
+	n := 0
+	for pos, d := range dirty {
+		l, ok := e.limited[pos]
+		if !ok {
+			continue
+		}
+		delete(dirty, pos)
+		if l.action == RedstoneBudgetActionThrottle {
+			e.dirty[pos] = d
+		}
+		n++
+	}
+	return n
+}
+
+// withoutLimited removes the nodes of limited networks from a compiled graph. Such nodes may be pulled into the graph
+// by neighbouring components that are not limited.
+func (e *redstoneEngine) withoutLimited(tx *Tx, graph redstoneGraph) redstoneGraph {
+	if len(e.limited) == 0 {
+		return graph
+	}
+	nodes := slices.DeleteFunc(slices.Clone(graph.nodes), func(node redstoneNode) bool {
+		_, ok := e.limited[node.pos]
+		return ok
+	})
+	if len(nodes) == len(graph.nodes) {
+		return graph
+	}
+	return redstoneGraph{nodes: nodes, edges: e.compileEdges(tx, nodes)}
+}
+
+// finishProfile stores the metrics collected while evaluating graph and enforces the RedstoneBudget of the World. If
+// the budget imposes no limits, the metrics of chunks and networks are not computed until requested.
+func (e *redstoneEngine) finishProfile(tx *Tx, tick int64, graph redstoneGraph, prof *redstoneProfile) {
+	budget := tx.World().conf.RedstoneBudget
+	e.stats = RedstoneStats{
+		Tick:         tick,
+		CompileTime:  prof.compileTime,
+		EvaluateTime: prof.evaluateTime,
+		Nodes:        len(graph.nodes),
+		Deferred:     prof.deferred,
+	}
+	for _, node := range graph.nodes {
+		e.stats.Updates += prof.updates[node.pos]
+	}
+	if !budget.limited() {
+		e.lastGraph, e.lastProfile = graph, prof
+		return
+	}
+	e.lastGraph, e.lastProfile = redstoneGraph{}, nil
+
+	e.stats.Chunks = chunkStats(graph, prof)
+	if budget.MaxChunkUpdates > 0 {
+		e.countWindowUpdates(tick, int64(max(budget.Window/(time.Second/20), 1)), e.stats.Chunks)
+	}
+	for _, network := range redstoneNetworks(graph) {
+		n := networkStats(graph, network, prof)
+		e.stats.Networks = append(e.stats.Networks, n)
+
+		if exceeded, ok := budget.exceeded(n, e.stats.Chunks); ok {
+			e.limit(tx, graph, network, exceeded)
+		}
+	}
+}
+
+// chunkStats returns the metrics of every chunk with nodes in the graph passed.
+func chunkStats(graph redstoneGraph, prof *redstoneProfile) map[ChunkPos]RedstoneChunkStats {
+	chunks := make(map[ChunkPos]RedstoneChunkStats)
+	for _, node := range graph.nodes {
+		chunk := chunkPosFromBlockPos(node.pos)
+		c := chunks[chunk]
+		c.Nodes++
+		c.Updates += prof.updates[node.pos]
+		chunks[chunk] = c
+	}
+	return chunks
+}
+
+// networkStats returns the metrics of a network of nodes in the graph passed.
+func networkStats(graph redstoneGraph, network []int, prof *redstoneProfile) RedstoneNetworkStats {
+	n := RedstoneNetworkStats{Pos: graph.nodes[network[0]].pos, Nodes: len(network)}
+	for _, i := range network {
+		pos := graph.nodes[i].pos
+		n.Updates += prof.updates[pos]
+		if chunk := chunkPosFromBlockPos(pos); !slices.Contains(n.Chunks, chunk) {
+			n.Chunks = append(n.Chunks, chunk)
+		}
+	}
+	return n
+}
+
+// countWindowUpdates records the updates of the chunks passed for the tick passed and sets their WindowUpdates to
+// the number of updates during the last window ticks.
+func (e *redstoneEngine) countWindowUpdates(tick, window int64, chunks map[ChunkPos]RedstoneChunkStats) {
+	for chunk, c := range chunks {
+		history := slices.DeleteFunc(e.chunkUpdates[chunk], func(u redstoneTickUpdates) bool {
+			return u.tick <= tick-window
+		})
+		if c.Updates > 0 {
+			history = append(history, redstoneTickUpdates{tick: tick, updates: c.Updates})
+		}
+		for _, u := range history {
+			c.WindowUpdates += u.updates
+		}
+		if len(history) == 0 {
+			delete(e.chunkUpdates, chunk)
+		} else {
+			e.chunkUpdates[chunk] = history
+		}
+		chunks[chunk] = c
+	}
+}
+
+// limited checks if the budget imposes any limits on networks.
+func (budget RedstoneBudget) limited() bool {
+	return budget.MaxNetworkNodes > 0 || budget.MaxNetworkUpdates > 0 || budget.MaxChunkUpdates > 0
+}
+
+// exceeded checks if the network passed exceeds the budget. If so, the event to report is returned.
+func (budget RedstoneBudget) exceeded(n RedstoneNetworkStats, chunks map[ChunkPos]RedstoneChunkStats) (RedstoneBudgetExceeded, bool) {
+	exceeded := RedstoneBudgetExceeded{Network: n, Action: budget.Action, Penalty: budget.Penalty}
+	switch {
+	case budget.MaxNetworkNodes > 0 && n.Nodes > budget.MaxNetworkNodes:
+		exceeded.Reason = RedstoneBudgetReasonNodes
+		return exceeded, true
+	case budget.MaxNetworkUpdates > 0 && n.Updates > budget.MaxNetworkUpdates:
+		exceeded.Reason = RedstoneBudgetReasonUpdates
+		return exceeded, true
+	}
+	if budget.MaxChunkUpdates > 0 {
+		for _, chunk := range n.Chunks {
+			if chunks[chunk].WindowUpdates > budget.MaxChunkUpdates {
+				exceeded.Reason, exceeded.Chunk = RedstoneBudgetReasonChunkUpdates, chunk
+				return exceeded, true
+			}
+		}
+	}
+	return exceeded, false
+}
+
+// limit reports a network exceeding the budget to the Handler of the World and, if not cancelled, throttles or
+// freezes the network.
+func (e *redstoneEngine) limit(tx *Tx, graph redstoneGraph, network []int, exceeded RedstoneBudgetExceeded) {
+	ctx := tx.Event()
+	tx.World().Handler().HandleRedstoneBudgetExceeded(ctx, exceeded)
+	if ctx.Cancelled() || exceeded.Action == RedstoneBudgetActionReport {
+		return
+	}
+	l := &redstoneLimit{action: exceeded.Action, until: -1, positions: make([]cube.Pos, 0, len(network))}
+	if exceeded.Penalty >= 0 {
+		l.until = e.currentTick + int64(max(exceeded.Penalty/(time.Second/20), 1))
+	}
+	for _, i := range network {
+		pos := graph.nodes[i].pos
+		l.positions = append(l.positions, pos)
+		e.limited[pos] = l
+	}
+}
+
+// redstoneNetworkOffsets holds the offsets of all positions within a Manhattan distance of two. Components this
+// close to each other are able to interact directly or through a single conducting block, so they are grouped into
+// the same network.
+var redstoneNetworkOffsets = func() (offsets []cube.Pos) {
+	for x := -2; x <= 2; x++ {
+		for y := -2; y <= 2; y++ {
+			for z := -2; z <= 2; z++ {
+				if d := abs(x) + abs(y) + abs(z); d > 0 && d <= 2 {
+					offsets = append(offsets, cube.Pos{x, y, z})
+				}
+			}
+		}
+	}
+	return offsets
+}()
+
+// redstoneNetworks splits the nodes of a graph into networks of nodes that are connected by an edge or close enough
+// to each other to interact. The indices of the nodes of every network are returned, ordered by position, and the
+// networks themselves are ordered by the position of their first node.
+func redstoneNetworks(graph redstoneGraph) [][]int {
+	parent := make([]int, len(graph.nodes))
+	index := make(map[cube.Pos]int, len(graph.nodes))
+	for i, node := range graph.nodes {
+		parent[i] = i
+		index[node.pos] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(a, b int) {
+		if a, b = find(a), find(b); a != b {
+			// Always keep the lowest index as root, so that networks are ordered by their first node.
+			parent[max(a, b)] = min(a, b)
+		}
+	}
+	for _, edge := range graph.edges {
+		union(edge.from, edge.to)
+	}
+	for i, node := range graph.nodes {
+		for _, offset := range redstoneNetworkOffsets {
+			if j, ok := index[node.pos.Add(offset)]; ok {
+				union(i, j)
+			}
+		}
+	}
+
+	var networks [][]int
+	roots := make(map[int]int)
+	for i := range graph.nodes {
+		root := find(i)
+		n, ok := roots[root]
+		if !ok {
+			n = len(networks)
+			roots[root] = n
+			networks = append(networks, nil)
+		}
+		networks[n] = append(networks[n], i)
+	}
+	return networks
+}
@@ -28,13 +28,16 @@ func (minimalRedstoneTestHandler) HandleFireSpread(*Context, cube.Pos, cube.Pos)
 func (minimalRedstoneTestHandler) HandleBlockBurn(*Context, cube.Pos)                           {}
 func (minimalRedstoneTestHandler) HandleCropTrample(*Context, cube.Pos)                         {}
 func (minimalRedstoneTestHandler) HandleLeavesDecay(*Context, cube.Pos)                         {}
+func (minimalRedstoneTestHandler) HandleTreeGrow(*Context, cube.Pos, Structure)                 {}
 func (minimalRedstoneTestHandler) HandlePortalCreate(*Context, Dimension, []cube.Pos)           {}
 func (minimalRedstoneTestHandler) HandlePortalActivate(*Context, Dimension, []cube.Pos)         {}
 func (minimalRedstoneTestHandler) HandleEntitySpawn(*Tx, Entity)                                {}
 func (minimalRedstoneTestHandler) HandleEntityDespawn(*Tx, Entity)                              {}
 func (minimalRedstoneTestHandler) HandleExplosion(*Context, ExplosionSource, *[]Entity, *[]cube.Pos, *float64, *bool) {
 }
-func (minimalRedstoneTestHandler) HandleClose(*Tx) {}
+func (minimalRedstoneTestHandler) HandleRedstoneBudgetExceeded(*Context, RedstoneBudgetExceeded) {}
+func (minimalRedstoneTestHandler) HandleMobSpawn(*Context, *mgl64.Vec3, *MobSpawn)               {}
+func (minimalRedstoneTestHandler) HandleClose(*Tx)                                               {}
 
 func TestClampRedstonePower(t *testing.T) {
 	tests := []struct {
@@ -448,6 +451,161 @@ func TestRedstoneRelayerToSinkDoesNotLosePower(t *testing.T) {
 	}
 }
 
+func TestRedstoneGraphSnapshotDoesNotEvaluate(t *testing.T) {
+	sourcePos, relayerPos, sinkPos := cube.Pos{0, 64, 0}, cube.Pos{1, 64, 0}, cube.Pos{2, 64, 0}
+	w := Config{Synchronous: true, Blocks: redstoneSignalLossTestRegistry()}.New()
+	defer w.Close()
+
+	var graph RedstoneGraph
+	var dirty, sinkPower int
+	var before, after uint64
+	runWorld(w, func(tx *Tx) {
+		tx.SetBlock(sourcePos, redstoneLossSource{Power: 15}, nil)
+		tx.SetBlock(relayerPos, redstoneLossRelayer{}, nil)
+		tx.SetBlock(sinkPos, redstoneLossConsumer{}, nil)
+
+		dirty = len(tx.World().redstone.dirty)
+		graph = tx.Redstone().Graph(relayerPos, 2)
+		if len(tx.World().redstone.dirty) != dirty {
+			t.Errorf("dirty positions after snapshot = %d, want %d", len(tx.World().redstone.dirty), dirty)
+		}
+		if sink, ok := tx.Block(sinkPos).(redstoneLossConsumer); ok {
+			sinkPower = sink.Power
+		}
+
+		before = tx.Redstone().Revision()
+		tx.World().redstone.tick(tx, 1)
+		after = tx.Redstone().Revision()
+	})
+	if sinkPower != 0 {
+		t.Fatalf("sink power after snapshot = %d, want 0", sinkPower)
+	}
+	if after <= before {
+		t.Fatalf("revision after tick = %d, want greater than %d", after, before)
+	}
+	if graph.Revision != before {
+		t.Fatalf("snapshot revision = %d, want %d", graph.Revision, before)
+	}
+
+	want := []RedstoneNode{
+		{Pos: sourcePos, Source: true, Power: 15, Dirty: true},
+		{Pos: relayerPos, Relayer: true, Power: 15, Dirty: true},
+		{Pos: sinkPos, Sink: true, Power: 15, Dirty: true},
+	}
+	if !slices.Equal(graph.Nodes, want) {
+		t.Fatalf("snapshot nodes = %+v, want %+v", graph.Nodes, want)
+	}
+	for _, edge := range graph.Edges {
+		if edge.From != relayerPos {
+			t.Fatalf("snapshot edge from %v, want edges only from relayer %v", edge.From, relayerPos)
+		}
+		if edge.SignalLoss != 0 {
+			t.Fatalf("snapshot edge to %v loses %d power, want 0", edge.To, edge.SignalLoss)
+		}
+	}
+	if !slices.ContainsFunc(graph.Edges, func(edge RedstoneEdge) bool { return edge.To == sinkPos }) {
+		t.Fatalf("snapshot edges %+v do not connect relayer to sink", graph.Edges)
+	}
+
+	var nodes []RedstoneNode
+	runWorld(w, func(tx *Tx) {
+		nodes = tx.Redstone().Graph(sourcePos, 0).Nodes
+	})
+	if len(nodes) != 1 || nodes[0].Pos != sourcePos {
+		t.Fatalf("snapshot with radius 0 = %+v, want only the node at %v", nodes, sourcePos)
+	}
+}
+
+func TestRedstoneNetworksGroupNearbyComponents(t *testing.T) {
+	graph := redstoneGraph{
+		nodes: []redstoneNode{
+			{pos: cube.Pos{0, 64, 0}},
+			{pos: cube.Pos{1, 65, 0}},
+			{pos: cube.Pos{5, 64, 0}},
+			{pos: cube.Pos{9, 64, 0}},
+		},
+		edges: []redstoneEdge{{from: 2, to: 3}},
+	}
+	networks := redstoneNetworks(graph)
+	want := [][]int{{0, 1}, {2, 3}}
+	if !slices.EqualFunc(networks, want, slices.Equal) {
+		t.Fatalf("redstoneNetworks() = %v, want %v", networks, want)
+	}
+}
+
+func TestRedstoneBudgetFreezesNetworkExceedingUpdates(t *testing.T) {
+	sourcePos, relayerPos, sinkPos := cube.Pos{0, 64, 0}, cube.Pos{1, 64, 0}, cube.Pos{2, 64, 0}
+	w := Config{Synchronous: true, Blocks: redstoneSignalLossTestRegistry(), RedstoneBudget: RedstoneBudget{
+		MaxNetworkUpdates: 1,
+		Action:            RedstoneBudgetActionFreeze,
+		Penalty:           time.Second,
+	}}.New()
+	defer w.Close()
+
+	handler := &redstoneBudgetRecordingHandler{}
+	w.Handle(handler)
+
+	sinkPower := func(tx *Tx) int {
+		sink, _ := tx.Block(sinkPos).(redstoneLossConsumer)
+		return sink.Power
+	}
+	runWorld(w, func(tx *Tx) {
+		tx.SetBlock(sourcePos, redstoneLossSource{Power: 15}, nil)
+		tx.SetBlock(relayerPos, redstoneLossRelayer{}, nil)
+		tx.SetBlock(sinkPos, redstoneLossConsumer{}, nil)
+		tx.World().redstone.tick(tx, 1)
+
+		if stats := tx.Redstone().Stats(); stats.Updates < 2 || len(stats.Networks) != 1 {
+			t.Errorf("stats after first tick = %+v, want at least 2 updates in one network", stats)
+		}
+		if !tx.Redstone().Limited(sinkPos) {
+			t.Errorf("sink not limited after network exceeded the budget")
+		}
+
+		tx.SetBlock(sourcePos, redstoneLossConsumer{}, nil)
+		tx.World().redstone.tick(tx, 2)
+		if power := sinkPower(tx); power != 15 {
+			t.Errorf("frozen sink power = %d, want 15", power)
+		}
+		if stats := tx.Redstone().Stats(); stats.Deferred == 0 {
+			t.Errorf("stats of frozen network = %+v, want deferred positions", stats)
+		}
+
+		tx.World().redstone.tick(tx, 21)
+		if power := sinkPower(tx); power != 0 {
+			t.Errorf("sink power after penalty = %d, want 0", power)
+		}
+	})
+	if len(handler.exceeded) == 0 {
+		t.Fatal("no budget exceeded event recorded")
+	}
+	exceeded := handler.exceeded[0]
+	if exceeded.Reason != RedstoneBudgetReasonUpdates || exceeded.Network.Pos != sourcePos {
+		t.Fatalf("exceeded = %+v, want update reason for network at %v", exceeded, sourcePos)
+	}
+}
+
+func TestRedstoneStatsWithoutBudget(t *testing.T) {
+	sourcePos, relayerPos, sinkPos := cube.Pos{0, 64, 0}, cube.Pos{1, 64, 0}, cube.Pos{2, 64, 0}
+	w := Config{Synchronous: true, Blocks: redstoneSignalLossTestRegistry()}.New()
+	defer w.Close()
+
+	runWorld(w, func(tx *Tx) {
+		tx.SetBlock(sourcePos, redstoneLossSource{Power: 15}, nil)
+		tx.SetBlock(relayerPos, redstoneLossRelayer{}, nil)
+		tx.SetBlock(sinkPos, redstoneLossConsumer{}, nil)
+		tx.World().redstone.tick(tx, 1)
+
+		if e := tx.World().redstone; e.stats.Networks != nil || e.stats.Chunks != nil {
+			t.Errorf("networks grouped without a budget before stats were requested")
+		}
+		stats := tx.Redstone().Stats()
+		if stats.Updates < 2 || len(stats.Networks) != 1 || stats.Chunks[ChunkPos{}].Nodes != stats.Nodes {
+			t.Errorf("stats = %+v, want at least 2 updates in one network within one chunk", stats)
+		}
+	})
+}
+
 func TestRedstoneVerticalRelayerPropagation(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -752,6 +910,15 @@ func (h *redstoneRecordingHandler) HandleRedstoneUpdate(_ *Context, update Redst
 	}
 }
 
+type redstoneBudgetRecordingHandler struct {
+	NopHandler
+	exceeded []RedstoneBudgetExceeded
+}
+
+func (h *redstoneBudgetRecordingHandler) HandleRedstoneBudgetExceeded(_ *Context, exceeded RedstoneBudgetExceeded) {
+	h.exceeded = append(h.exceeded, exceeded)
+}
+
 var redstoneCancellationActions *int
 
 func redstoneCancellationTestRegistry() BlockRegistry {
@@ -0,0 +1,194 @@
+package schematic
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/javablock"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// Schematic is a world.Structure read from a Sponge schematic (.schem) file, the schematic format used by Java
+// Edition tools such as WorldEdit. Versions 1, 2 and 3 of the format are supported.
+//
+// The Java Edition block states of the schematic are translated to Bedrock Edition blocks when it is read. States
+// that could not be translated are reported by Schematic.Unmapped and are left untouched when the Schematic is
+// built using world.Tx.BuildStructure. Block entity data and entities are not imported, as their Java Edition NBT
+// is not compatible with Bedrock Edition.
+type Schematic struct {
+	dim    [3]int
+	offset cube.Pos
+
+	palette []paletteEntry
+	// blocks holds the indices of the blocks in the palette, ordered by y, then z and then x.
+	blocks   []int32
+	unmapped []string
+}
+
+// maxVolume is the maximum number of blocks that a schematic read may hold. Larger schematics are rejected before
+// any memory is allocated for them.
+const maxVolume = 1 << 24
+
+// paletteEntry is a Java Edition block state translated to a block and a liquid.
+type paletteEntry struct {
+	b   world.Block
+	liq world.Liquid
+}
+
+// ReadFile reads a Schematic from the .schem file at the path passed. Blocks are looked up in the
+// world.DefaultBlockRegistry.
+func ReadFile(path string) (*Schematic, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read schematic: %w", err)
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read reads a Schematic from the io.Reader passed. The data read may either be gzip compressed, as .schem files
+// are, or uncompressed. Blocks are looked up in the world.DefaultBlockRegistry.
+func Read(r io.Reader) (*Schematic, error) {
+	return ReadWithRegistry(r, world.DefaultBlockRegistry)
+}
+
+// ReadWithRegistry reads a Schematic from the io.Reader passed, translating its block states to blocks registered
+// in the world.BlockRegistry passed. An error is returned only if the data is not a valid schematic: block states
+// that could not be translated are reported by Schematic.Unmapped.
+func ReadWithRegistry(r io.Reader, br world.BlockRegistry) (*Schematic, error) {
+	buf := bufio.NewReader(r)
+	if magic, _ := buf.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(buf)
+		if err != nil {
+			return nil, fmt.Errorf("read schematic: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = buf
+	}
+
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(r, nbt.BigEndian).Decode(&m); err != nil {
+		return nil, fmt.Errorf("read schematic: decode nbt: %w", err)
+	}
+	if inner, ok := m["Schematic"].(map[string]any); ok {
+		// Version 3 schematics wrap the schematic in a compound tag named Schematic.
+		m = inner
+	}
+
+	s := &Schematic{dim: [3]int{dimension(m, "Width"), dimension(m, "Height"), dimension(m, "Length")}}
+	// Every dimension is at most math.MaxUint16, so the volume cannot overflow.
+	if volume := s.dim[0] * s.dim[1] * s.dim[2]; volume > maxVolume {
+		return nil, fmt.Errorf("read schematic: dimensions %v exceed the maximum of %v blocks", s.dim, maxVolume)
+	}
+	if offset, ok := m["Offset"].([3]int32); ok {
+		s.offset = cube.Pos{int(offset[0]), int(offset[1]), int(offset[2])}
+	}
+
+	palette, data := m["Palette"], m["BlockData"]
+	if version, _ := m["Version"].(int32); version >= 3 {
+		blocks, ok := m["Blocks"].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("read schematic: version %v schematic without blocks", version)
+		}
+		palette, data = blocks["Palette"], blocks["Data"]
+	}
+	if err := s.readPalette(palette, javablock.NewTranslator(br)); err != nil {
+		return nil, err
+	}
+	if err := s.readBlocks(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// readPalette reads the palette of the schematic, translating every block state in it.
+func (s *Schematic) readPalette(v any, t *javablock.Translator) error {
+	palette, ok := v.(map[string]any)
+	if !ok {
+		return fmt.Errorf("read schematic: missing palette")
+	}
+	s.palette = make([]paletteEntry, len(palette))
+	for javaState, index := range palette {
+		i, ok := index.(int32)
+		if !ok || i < 0 || int(i) >= len(palette) {
+			return fmt.Errorf("read schematic: invalid palette index %v for %v", index, javaState)
+		}
+		b, liq, ok := t.TranslateState(javaState)
+		if !ok {
+			s.unmapped = append(s.unmapped, javaState)
+		}
+		s.palette[i] = paletteEntry{b: b, liq: liq}
+	}
+	slices.Sort(s.unmapped)
+	return nil
+}
+
+// readBlocks reads the block data of the schematic: a byte array holding a palette index encoded as a varint for
+// every block.
+func (s *Schematic) readBlocks(v any) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Array || val.Type().Elem().Kind() != reflect.Uint8 {
+		return fmt.Errorf("read schematic: missing block data")
+	}
+	volume := s.dim[0] * s.dim[1] * s.dim[2]
+	if val.Len() < volume {
+		// Every block takes up at least one byte, so the block data cannot possibly hold all blocks.
+		return fmt.Errorf("read schematic: block data of %v bytes cannot hold %v blocks", val.Len(), volume)
+	}
+	data := make([]byte, val.Len())
+	reflect.Copy(reflect.ValueOf(data), val)
+
+	r := bytes.NewReader(data)
+	s.blocks = make([]int32, volume)
+	for i := range s.blocks {
+		index, err := binary.ReadUvarint(r)
+		if err != nil {
+			return fmt.Errorf("read schematic: block data holds %v blocks, expected %v", i, len(s.blocks))
+		}
+		if index >= uint64(len(s.palette)) {
+			return fmt.Errorf("read schematic: palette index %v out of range", index)
+		}
+		s.blocks[i] = int32(index)
+	}
+	return nil
+}
+
+// dimension reads a dimension of the schematic. Dimensions are stored as unsigned shorts.
+func dimension(m map[string]any, k string) int {
+	v, _ := m[k].(int16)
+	return int(uint16(v))
+}
+
+// Dimensions returns the width, height and length of the Schematic.
+func (s *Schematic) Dimensions() [3]int {
+	return s.dim
+}
+
+// Offset returns the offset of the Schematic relative to the position that it was copied from, as stored by the
+// tool that created it.
+func (s *Schematic) Offset() cube.Pos {
+	return s.offset
+}
+
+// Unmapped returns the sorted Java Edition block states in the Schematic that could not be translated to a
+// Bedrock Edition block. Positions holding these states are left untouched when the Schematic is built.
+func (s *Schematic) Unmapped() []string {
+	return slices.Clone(s.unmapped)
+}
+
+// At returns the block at a position in the Schematic, and the liquid that it is waterlogged with, if any.
+func (s *Schematic) At(x, y, z int, _ func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	e := s.palette[s.blocks[(y*s.dim[2]+z)*s.dim[0]+x]]
+	return e.b, e.liq
+}
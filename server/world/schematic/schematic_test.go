@@ -0,0 +1,119 @@
+package schematic_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/schematic"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// testPalette is the palette of the schematics used in the tests. The block data of the schematics holds the
+// indices 0 to 5 in order, so that the schematic is 3 blocks wide, 1 block high and 2 blocks long.
+var testPalette = map[string]any{
+	"minecraft:stone": int32(0),
+	"minecraft:oak_stairs[facing=east,half=top,shape=straight,waterlogged=true]": int32(1),
+	"minecraft:furnace[facing=north,lit=true]":                                   int32(2),
+	"minecraft:oak_log[axis=x]":                                                  int32(3),
+	"minecraft:bricks":                                                           int32(4),
+	"minecraft:not_a_block[foo=bar]":                                             int32(5),
+}
+
+func TestReadV2(t *testing.T) {
+	testSchematic(t, map[string]any{
+		"Version":   int32(2),
+		"Width":     int16(3),
+		"Height":    int16(1),
+		"Length":    int16(2),
+		"Offset":    [3]int32{1, 2, 3},
+		"Palette":   testPalette,
+		"BlockData": [6]byte{0, 1, 2, 3, 4, 5},
+	})
+}
+
+func TestReadV3(t *testing.T) {
+	testSchematic(t, map[string]any{"Schematic": map[string]any{
+		"Version": int32(3),
+		"Width":   int16(3),
+		"Height":  int16(1),
+		"Length":  int16(2),
+		"Offset":  [3]int32{1, 2, 3},
+		"Blocks": map[string]any{
+			"Palette": testPalette,
+			"Data":    [6]byte{0, 1, 2, 3, 4, 5},
+		},
+	}})
+}
+
+func TestReadRejectsOversizedSchematic(t *testing.T) {
+	for name, dim := range map[string]int16{"oversized": -1, "truncated": 100} {
+		var buf bytes.Buffer
+		data := map[string]any{
+			"Version":   int32(2),
+			"Width":     dim,
+			"Height":    dim,
+			"Length":    dim,
+			"Palette":   map[string]any{"minecraft:stone": int32(0)},
+			"BlockData": [1]byte{0},
+		}
+		if err := nbt.NewEncoderWithEncoding(&buf, nbt.BigEndian).Encode(data); err != nil {
+			t.Fatalf("encode schematic: %v", err)
+		}
+		if _, err := schematic.Read(&buf); err == nil {
+			t.Errorf("expected %v schematic with dimensions %v to be rejected", name, uint16(dim))
+		}
+	}
+}
+
+func testSchematic(t *testing.T, data map[string]any) {
+	world.DefaultBlockRegistry.Finalize()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := nbt.NewEncoderWithEncoding(gz, nbt.BigEndian).Encode(data); err != nil {
+		t.Fatalf("encode schematic: %v", err)
+	}
+	_ = gz.Close()
+
+	s, err := schematic.Read(&buf)
+	if err != nil {
+		t.Fatalf("read schematic: %v", err)
+	}
+	if dim := s.Dimensions(); dim != [3]int{3, 1, 2} {
+		t.Errorf("expected dimensions [3 1 2], got %v", dim)
+	}
+	if off := s.Offset(); off != (cube.Pos{1, 2, 3}) {
+		t.Errorf("expected offset {1 2 3}, got %v", off)
+	}
+	if unmapped := s.Unmapped(); !slices.Equal(unmapped, []string{"minecraft:not_a_block[foo=bar]"}) {
+		t.Errorf("expected a single unmapped state, got %v", unmapped)
+	}
+
+	water := block.Water{Depth: 8, Still: true}
+	expected := []struct {
+		x, z int
+		b    world.Block
+		liq  world.Liquid
+	}{
+		{0, 0, block.Stone{}, nil},
+		{1, 0, block.Stairs{Block: block.Planks{Wood: block.OakWood()}, UpsideDown: true, Facing: cube.East}, water},
+		{2, 0, block.Furnace{Facing: cube.North, Lit: true}, nil},
+		{0, 1, block.Log{Wood: block.OakWood(), Axis: cube.X}, nil},
+		{1, 1, block.Bricks{}, nil},
+		{2, 1, nil, nil},
+	}
+	for _, e := range expected {
+		b, liq := s.At(e.x, 0, e.z, nil)
+		if b != e.b {
+			t.Errorf("expected %#v at %v 0 %v, got %#v", e.b, e.x, e.z, b)
+		}
+		if liq != e.liq {
+			t.Errorf("expected liquid %#v at %v 0 %v, got %#v", e.liq, e.x, e.z, liq)
+		}
+	}
+}
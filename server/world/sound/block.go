@@ -126,6 +126,9 @@ type DoorCrash struct{ sound }
 // Click is a clicking sound.
 type Click struct{ sound }
 
+// ClickFail is a clicking sound played when a dispenser or dropper fails to dispense an item.
+type ClickFail struct{ sound }
+
 // Ignite is a sound played when using a flint & steel.
 type Ignite struct{ sound }
 
@@ -197,6 +200,12 @@ type PowerOn struct{ sound }
 // PowerOff is a sound played when a redstone component is powered off.
 type PowerOff struct{ sound }
 
+// PistonExtend is a sound played when a piston extends its arm.
+type PistonExtend struct{ sound }
+
+// PistonRetract is a sound played when a piston retracts its arm.
+type PistonRetract struct{ sound }
+
 // LecternBookPlace is a sound played when a book is placed in a lectern.
 type LecternBookPlace struct{ sound }
 
@@ -29,6 +29,9 @@ type Burp struct{ sound }
 // Pop is a sound played when a chicken lays an egg.
 type Pop struct{ sound }
 
+// Shear is a sound played when a sheep is sheared.
+type Shear struct{ sound }
+
 // Explosion is a sound played when an explosion happens, such as from a creeper or TNT.
 type Explosion struct{ sound }
 
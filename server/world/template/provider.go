@@ -0,0 +1,98 @@
+package template
+
+import (
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/google/uuid"
+)
+
+// Compile time check to make sure Provider implements world.Provider.
+var _ world.Provider = (*Provider)(nil)
+
+// Provider is a world.Provider that serves the columns of a Template. The blocks of a column loaded are shared with
+// the Template and are copied only when a world first changes them, so that the Template itself is never changed.
+// Columns stored by a world are kept in the Provider only, until it is closed. A Provider may be created using
+// Template.Provider.
+type Provider struct {
+	t *Template
+
+	mu      sync.Mutex
+	set     *world.Settings
+	columns map[columnKey]*chunk.Column
+	spawns  map[uuid.UUID]cube.Pos
+}
+
+// Settings returns the world.Settings of the Provider. These start out as a copy of the settings of the Template.
+func (p *Provider) Settings() *world.Settings {
+	return p.set
+}
+
+// SaveSettings does nothing: The settings of a Provider are not saved.
+func (p *Provider) SaveSettings(*world.Settings) {}
+
+// LoadPlayerSpawnPosition returns the spawn position of a player previously stored using
+// Provider.SavePlayerSpawnPosition.
+func (p *Provider) LoadPlayerSpawnPosition(id uuid.UUID) (cube.Pos, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pos, ok := p.spawns[id]
+	return pos, ok, nil
+}
+
+// SavePlayerSpawnPosition stores the spawn position of a player in memory until the Provider is closed.
+func (p *Provider) SavePlayerSpawnPosition(id uuid.UUID, pos cube.Pos) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.spawns == nil {
+		p.spawns = make(map[uuid.UUID]cube.Pos)
+	}
+	p.spawns[id] = pos
+	return nil
+}
+
+// LoadColumn returns the column at a position in a dimension. If the column was previously stored to the Provider,
+// that column is returned. Otherwise, a copy of the column in the Template is returned, which shares its blocks with
+// the Template until they are first changed. If neither holds a column
+// at the position, an error for which errors.Is(err, leveldb.ErrNotFound) is true is returned.
+func (p *Provider) LoadColumn(pos world.ChunkPos, dim world.Dimension) (*chunk.Column, error) {
+	k := columnKey{pos: pos, dim: dim}
+	p.mu.Lock()
+	col, ok := p.columns[k]
+	p.mu.Unlock()
+	if ok {
+		return col, nil
+	}
+	if col, ok := p.t.columns[k]; ok {
+		return cloneColumn(col), nil
+	}
+	return nil, leveldb.ErrNotFound
+}
+
+// StoreColumn stores a column in memory until the Provider is closed. The column in the Template is not changed.
+func (p *Provider) StoreColumn(pos world.ChunkPos, dim world.Dimension, col *chunk.Column) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.columns[columnKey{pos: pos, dim: dim}] = col
+	return nil
+}
+
+// Modified returns the number of columns that were stored to the Provider, and thus differ from the Template.
+func (p *Provider) Modified() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.columns)
+}
+
+// Close throws away all columns and player spawn positions stored to the Provider. Columns loaded after Close are
+// again those of the Template.
+func (p *Provider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	clear(p.columns)
+	clear(p.spawns)
+	return nil
+}
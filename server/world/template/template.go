@@ -0,0 +1,128 @@
+// Package template implements world templates: worlds that are loaded into memory once and from which any number
+// of worlds may be created without disk I/O. Worlds created from a Template share the blocks of its chunks
+// copy-on-write: a world copies the blocks of a sub chunk only once it first changes them. Light is computed
+// by every world separately. Changes are thrown away when the world is closed, so the Template itself never changes.
+//
+// Templates are typically used for minigames, where many instances of the same map are played at the same time:
+//
+//	db, err := mcdb.Open("worlds/arena")
+//	if err != nil {
+//		panic(err)
+//	}
+//	t, err := template.Load(db)
+//	_ = db.Close()
+//	if err != nil {
+//		panic(err)
+//	}
+//	w := world.Config{Provider: t.Provider()}.New()
+package template
+
+import (
+	"fmt"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+)
+
+// Template is a world loaded into memory, from which worlds may be created using Template.Provider. A Template is
+// never changed after it is loaded and is safe for concurrent use.
+type Template struct {
+	set     *world.Settings
+	columns map[columnKey]*chunk.Column
+}
+
+// columnKey is the key of a column in a Template or Provider.
+type columnKey struct {
+	pos world.ChunkPos
+	dim world.Dimension
+}
+
+// Load loads all columns and the settings of the mcdb.DB passed into a new Template. The mcdb.DB is no longer used
+// after Load returns, so it may be closed directly after.
+func Load(db *mcdb.DB) (*Template, error) {
+	t := &Template{set: cloneSettings(db.Settings()), columns: make(map[columnKey]*chunk.Column)}
+
+	iter := db.NewColumnIterator(nil)
+	defer iter.Release()
+	for iter.Next() {
+		t.columns[columnKey{pos: iter.Position(), dim: iter.Dimension()}] = iter.Column()
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("load template: %w", err)
+	}
+	return t, nil
+}
+
+// Len returns the number of columns held by the Template, across all dimensions.
+func (t *Template) Len() int {
+	return len(t.columns)
+}
+
+// Provider returns a new world.Provider that serves the columns of the Template. The blocks of these columns are
+// shared with the Template until they are first changed. Columns stored to the Provider are kept in memory by that
+// Provider only and are discarded when it is closed. Every world created should use its own Provider.
+func (t *Template) Provider() *Provider {
+	return &Provider{t: t, set: cloneSettings(t.set), columns: make(map[columnKey]*chunk.Column)}
+}
+
+// cloneColumn returns a copy of a chunk.Column that may be changed without changing the original. The blocks of the
+// chunk are shared with the original until they are first changed.
+func cloneColumn(col *chunk.Column) *chunk.Column {
+	clone := &chunk.Column{
+		Chunk:           col.Chunk.Share(),
+		Entities:        make([]chunk.Entity, len(col.Entities)),
+		BlockEntities:   make([]chunk.BlockEntity, len(col.BlockEntities)),
+		Tick:            col.Tick,
+		ScheduledBlocks: append([]chunk.ScheduledBlockUpdate(nil), col.ScheduledBlocks...),
+	}
+	for i, e := range col.Entities {
+		clone.Entities[i] = chunk.Entity{ID: e.ID, Data: cloneNBT(e.Data).(map[string]any)}
+	}
+	for i, be := range col.BlockEntities {
+		clone.BlockEntities[i] = chunk.BlockEntity{Pos: be.Pos, Data: cloneNBT(be.Data).(map[string]any)}
+	}
+	return clone
+}
+
+// cloneNBT returns a deep copy of a decoded NBT value. Only compounds and lists are copied, as all other values are
+// immutable once decoded.
+func cloneNBT(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, val := range v {
+			m[k] = cloneNBT(val)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, val := range v {
+			s[i] = cloneNBT(val)
+		}
+		return s
+	}
+	return v
+}
+
+// cloneSettings returns a copy of the world.Settings passed that does not share its state.
+func cloneSettings(s *world.Settings) *world.Settings {
+	s.Lock()
+	defer s.Unlock()
+	return &world.Settings{
+		Name:               s.Name,
+		Spawn:              s.Spawn,
+		Time:               s.Time,
+		TimeCycle:          s.TimeCycle,
+		RainTime:           s.RainTime,
+		Raining:            s.Raining,
+		ThunderTime:        s.ThunderTime,
+		Thundering:         s.Thundering,
+		WeatherCycle:       s.WeatherCycle,
+		RequiredSleepTicks: s.RequiredSleepTicks,
+		CurrentTick:        s.CurrentTick,
+		DefaultGameMode:    s.DefaultGameMode,
+		Difficulty:         s.Difficulty,
+		TickRange:          s.TickRange,
+	}
+}
@@ -0,0 +1,115 @@
+package template_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"github.com/df-mc/dragonfly/server/world/template"
+)
+
+func TestTemplateCopyOnWrite(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	stone := world.BlockRuntimeID(block.Stone{})
+
+	db, err := mcdb.Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	c := chunk.New(world.DefaultBlockRegistry, world.Overworld.Range())
+	c.SetBlock(0, 0, 0, 0, stone)
+	if err := db.StoreColumn(world.ChunkPos{}, world.Overworld, &chunk.Column{Chunk: c}); err != nil {
+		t.Fatalf("store column: %v", err)
+	}
+	tmpl, err := template.Load(db)
+	_ = db.Close()
+	if err != nil {
+		t.Fatalf("load template: %v", err)
+	}
+	if tmpl.Len() != 1 {
+		t.Fatalf("expected template to hold 1 column, got %v", tmpl.Len())
+	}
+
+	p1, p2 := tmpl.Provider(), tmpl.Provider()
+	w1 := world.Config{Provider: p1, Synchronous: true}.New()
+	w2 := world.Config{Provider: p2, Synchronous: true}.New()
+	defer w2.Close()
+
+	w1.Do(func(tx *world.Tx) {
+		tx.SetBlock(cube.Pos{}, block.Dirt{}, nil)
+	})
+	w2.Do(func(tx *world.Tx) {
+		if b := tx.Block(cube.Pos{}); b != (block.Stone{}) {
+			t.Errorf("expected stone in second world after changing first world, got %#v", b)
+		}
+	})
+	w1.Save()
+	w2.Save()
+	if p1.Modified() != 1 {
+		t.Errorf("expected changed column to be stored to the provider, got %v modified columns", p1.Modified())
+	}
+	if p2.Modified() != 0 {
+		t.Errorf("expected no columns stored to the provider of an unchanged world, got %v", p2.Modified())
+	}
+	if err := w1.Close(); err != nil {
+		t.Fatalf("close world: %v", err)
+	}
+	if p1.Modified() != 0 {
+		t.Errorf("expected changes to be thrown away on close, got %v modified columns", p1.Modified())
+	}
+
+	w3 := world.Config{Provider: tmpl.Provider(), Synchronous: true}.New()
+	defer w3.Close()
+	w3.Do(func(tx *world.Tx) {
+		if b := tx.Block(cube.Pos{}); b != (block.Stone{}) {
+			t.Errorf("expected stone in new world after closing first world, got %#v", b)
+		}
+	})
+}
+
+func TestTemplateSharesBlocksUntilChanged(t *testing.T) {
+	world.DefaultBlockRegistry.Finalize()
+	stone, dirt := world.BlockRuntimeID(block.Stone{}), world.BlockRuntimeID(block.Dirt{})
+
+	db, err := mcdb.Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	c := chunk.New(world.DefaultBlockRegistry, world.Overworld.Range())
+	c.SetBlock(0, 0, 0, 0, stone)
+	if err := db.StoreColumn(world.ChunkPos{}, world.Overworld, &chunk.Column{Chunk: c}); err != nil {
+		t.Fatalf("store column: %v", err)
+	}
+	tmpl, err := template.Load(db)
+	_ = db.Close()
+	if err != nil {
+		t.Fatalf("load template: %v", err)
+	}
+
+	col1, err := tmpl.Provider().LoadColumn(world.ChunkPos{}, world.Overworld)
+	if err != nil {
+		t.Fatalf("load column: %v", err)
+	}
+	col2, err := tmpl.Provider().LoadColumn(world.ChunkPos{}, world.Overworld)
+	if err != nil {
+		t.Fatalf("load column: %v", err)
+	}
+	sub1, sub2 := col1.Chunk.SubChunk(0), col2.Chunk.SubChunk(0)
+	if sub1.Layers()[0] != sub2.Layers()[0] {
+		t.Fatalf("expected unchanged columns loaded from a template to share their blocks")
+	}
+
+	col1.Chunk.SetBlock(0, 0, 0, 0, dirt)
+	if sub1.Layers()[0] == sub2.Layers()[0] {
+		t.Fatalf("expected blocks to be copied when first changed")
+	}
+	if rid := col2.Chunk.Block(0, 0, 0, 0); rid != stone {
+		t.Errorf("expected stone in unchanged column after changing another column, got runtime ID %v", rid)
+	}
+	if rid := col1.Chunk.Block(0, 0, 0, 0); rid != dirt {
+		t.Errorf("expected dirt in changed column, got runtime ID %v", rid)
+	}
+}
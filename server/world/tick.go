@@ -100,6 +100,9 @@ func (t ticker) tick(tx *Tx) {
 	t.tickEntities(tx, tick)
 	w.scheduledUpdates.tick(tx, tick)
 	t.tickBlocksRandomly(tx, loaders, tick)
+	positions := loaderPositions(loaders)
+	t.despawnMobs(tx, positions)
+	t.spawnMobs(tx, loaders, positions, tick)
 	t.performNeighbourUpdates(tx)
 	w.redstone.tick(tx, tick)
 }
@@ -120,6 +123,11 @@ func (t ticker) performNeighbourUpdates(tx *Tx) {
 				ticker.NeighbourUpdateTick(pos, changedNeighbour, tx)
 			}
 		}
+		if update.stateChanged {
+			if observer, ok := tx.Block(pos).(NeighbourStateObserver); ok {
+				observer.NeighbourStateChange(pos, changedNeighbour, tx)
+			}
+		}
 	}
 }
 
@@ -347,6 +355,18 @@ func (queue *scheduledTickQueue) schedule(br BlockRegistry, pos cube.Pos, b Bloc
 	queue.ticks = append(queue.ticks, scheduledTick{pos: pos, t: resTick, b: b, bhash: index.hash})
 }
 
+// pending returns the positions of all scheduled ticks that have not yet been
+// processed and for which f returns true.
+func (queue *scheduledTickQueue) pending(f func(pos cube.Pos) bool) map[cube.Pos]struct{} {
+	m := make(map[cube.Pos]struct{})
+	for _, t := range queue.ticks {
+		if t.t > queue.currentTick && f(t.pos) {
+			m[t.pos] = struct{}{}
+		}
+	}
+	return m
+}
+
 // fromChunk returns all scheduled ticks positioned within a ChunkPos.
 func (queue *scheduledTickQueue) fromChunk(pos ChunkPos) []scheduledTick {
 	m := make([]scheduledTick, 0, 8)
@@ -17,6 +17,12 @@ func (r RedstoneTransaction) ScheduleUpdate(pos cube.Pos) {
 	r.tx.World().redstone.invalidateAround(pos, pos, RedstoneUpdateCauseScheduledTick, r.tx.Range())
 }
 
+// LastPower returns the last redstone power observed by the engine at pos. Consumers without a powered block state
+// may compare it against the power passed to RedstonePowerUpdate to only react to the signal turning on or off.
+func (r RedstoneTransaction) LastPower(pos cube.Pos) int {
+	return r.tx.World().redstone.lastPower(pos)
+}
+
 // Torch returns a transaction-scoped handle for transient redstone torch state at pos.
 func (r RedstoneTransaction) Torch(pos cube.Pos) RedstoneTorchTransaction {
 	return RedstoneTorchTransaction{tx: r.tx, pos: pos}
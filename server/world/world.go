@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"iter"
-	"maps"
 	"math/rand/v2"
 	"slices"
 	"sync"
@@ -363,7 +362,7 @@ func (tx *Tx) setBlock(pos cube.Pos, b Block, opts *SetOpts) {
 	rid := w.conf.Blocks.BlockRuntimeID(b)
 	redstoneAfterRelevant := isRedstoneRelevant(b)
 	needOldBlock := !opts.DisableRedstoneUpdates || !redstoneAfterRelevant
-	needOldRID := needOldBlock || (rid != w.conf.Blocks.AirRuntimeID() && !opts.DisableLiquidDisplacement)
+	needOldRID := needOldBlock || !opts.DisableBlockUpdates || (rid != w.conf.Blocks.AirRuntimeID() && !opts.DisableLiquidDisplacement)
 
 	var oldRID uint32
 	if needOldRID {
@@ -434,7 +433,7 @@ func (tx *Tx) setBlock(pos cube.Pos, b Block, opts *SetOpts) {
 	}
 
 	if !opts.DisableBlockUpdates {
-		w.doBlockUpdatesAround(pos)
+		w.doBlockUpdatesAround(pos, oldRID != rid)
 	}
 	if !opts.DisableRedstoneUpdates {
 		w.redstone.invalidateAroundBlockChange(pos, oldBlock, b, RedstoneUpdateCauseBlockUpdate, w.Range())
@@ -607,7 +606,7 @@ func (tx *Tx) setLiquid(pos cube.Pos, b Liquid) {
 	c := tx.chunk(chunkPos)
 	if b == nil {
 		w.removeLiquids(c, pos)
-		w.doBlockUpdatesAround(pos)
+		w.doBlockUpdatesAround(pos, true)
 		w.redstone.invalidateAround(pos, pos, RedstoneUpdateCauseBlockUpdate, w.Range())
 		return
 	}
@@ -631,7 +630,7 @@ func (tx *Tx) setLiquid(pos cube.Pos, b Liquid) {
 	}
 	c.modified = true
 
-	w.doBlockUpdatesAround(pos)
+	w.doBlockUpdatesAround(pos, true)
 	w.redstone.invalidateAround(pos, pos, RedstoneUpdateCauseBlockUpdate, w.Range())
 }
 
@@ -718,6 +717,22 @@ func (tx *Tx) light(pos cube.Pos) uint8 {
 	return c.Light(uint8(pos[0]), int16(pos[1]), uint8(pos[2]))
 }
 
+// blockLight returns the block light level at the position passed. This light
+// level is only influenced by blocks that emit light, such as torches, and not
+// by the sky. 0 is returned for positions in chunks that are not currently
+// loaded.
+func (tx *Tx) blockLight(pos cube.Pos) uint8 {
+	w := tx.World()
+	if pos[1] < w.ra[0] || pos[1] > w.ra[1] {
+		return 0
+	}
+	c, ok := w.loadedChunk(chunkPosFromBlockPos(pos))
+	if !ok {
+		return 0
+	}
+	return c.SubChunk(int16(pos[1])).BlockLight(uint8(pos[0]&0xf), uint8(pos[1]&0xf), uint8(pos[2]&0xf))
+}
+
 // skyLight returns the skylight level at the position passed. This light level
 // is not influenced by blocks that emit light, such as torches. The light
 // value, similarly to light, is a value in the range 0-15, where 0 means no
@@ -1108,16 +1123,17 @@ func (w *World) scheduleBlockUpdate(pos cube.Pos, b Block, delay time.Duration)
 }
 
 // doBlockUpdatesAround schedules block updates directly around and on the
-// position passed.
-func (w *World) doBlockUpdatesAround(pos cube.Pos) {
+// position passed. stateChanged specifies if the block state at the position
+// changed as a result of the update.
+func (w *World) doBlockUpdatesAround(pos cube.Pos, stateChanged bool) {
 	if w == nil || pos.OutOfBounds(w.Range()) {
 		return
 	}
 	changed := pos
 
-	w.updateNeighbour(pos, changed)
+	w.updateNeighbour(pos, changed, stateChanged)
 	pos.Neighbours(func(pos cube.Pos) {
-		w.updateNeighbour(pos, changed)
+		w.updateNeighbour(pos, changed, stateChanged)
 	}, w.Range())
 }
 
@@ -1125,12 +1141,14 @@ func (w *World) doBlockUpdatesAround(pos cube.Pos) {
 // neighbour that changed.
 type neighbourUpdate struct {
 	pos, neighbour cube.Pos
+	// stateChanged specifies if the block state of the neighbour changed.
+	stateChanged bool
 }
 
 // updateNeighbour ticks the position passed as a result of the neighbour
 // passed being updated.
-func (w *World) updateNeighbour(pos, changedNeighbour cube.Pos) {
-	w.neighbourUpdates = append(w.neighbourUpdates, neighbourUpdate{pos: pos, neighbour: changedNeighbour})
+func (w *World) updateNeighbour(pos, changedNeighbour cube.Pos, stateChanged bool) {
+	w.neighbourUpdates = append(w.neighbourUpdates, neighbourUpdate{pos: pos, neighbour: changedNeighbour, stateChanged: stateChanged})
 }
 
 // Handle changes the current Handler of the world. As a result, events called
@@ -1540,10 +1558,7 @@ func (w *World) columnTo(col *Column, pos ChunkPos) *chunk.Column {
 		Tick:            w.scheduledUpdates.currentTick,
 	}
 	for _, e := range col.Entities {
-		data := e.encodeNBT()
-		maps.Copy(data, e.t.EncodeNBT(&e.data))
-		data["identifier"] = e.t.EncodeEntity()
-		c.Entities = append(c.Entities, chunk.Entity{ID: int64(binary.LittleEndian.Uint64(e.id[8:])), Data: data})
+		c.Entities = append(c.Entities, chunk.Entity{ID: int64(binary.LittleEndian.Uint64(e.id[8:])), Data: e.EncodeNBT()})
 	}
 	for pos, be := range col.BlockEntities {
 		c.BlockEntities = append(c.BlockEntities, chunk.BlockEntity{Pos: pos, Data: be.(NBTer).EncodeNBT()})